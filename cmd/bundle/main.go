@@ -0,0 +1,155 @@
+// Command bundle exports and imports a tenant as a portable site archive
+//
+// Usage:
+//
+//	bundle export [-tenant id] [-o site.zip] [-all-media]
+//	bundle import [-tenant id] [-dry-run] [-author user-id] site.zip
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"gohac/internal/adapter/bundle"
+	"gohac/internal/adapter/database"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "export":
+		runExport(os.Args[2:])
+	case "import":
+		runImport(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	fmt.Fprintln(os.Stderr, "  bundle export [-tenant id] [-o site.zip] [-all-media]")
+	fmt.Fprintln(os.Stderr, "  bundle import [-tenant id] [-dry-run] [-author user-id] site.zip")
+	os.Exit(2)
+}
+
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	tenantID := fs.String("tenant", "", "tenant to export (empty for community edition)")
+	output := fs.String("o", "site.zip", "output archive")
+	allMedia := fs.Bool("all-media", false, "export every uploaded file, not only files referenced by content")
+	fs.Parse(args)
+
+	mediaDir, mediaBaseURL := storageConfig()
+
+	f, err := os.Create(*output)
+	if err != nil {
+		log.Fatalf("Failed to create %s: %v", *output, err)
+	}
+
+	manifest, err := bundle.Export(context.Background(), connect(), f, bundle.ExportOptions{
+		TenantID:     *tenantID,
+		MediaDir:     mediaDir,
+		MediaBaseURL: mediaBaseURL,
+		AllMedia:     *allMedia,
+	})
+	if err != nil {
+		f.Close()
+		os.Remove(*output)
+		log.Fatalf("Export failed: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("Failed to write %s: %v", *output, err)
+	}
+
+	log.Printf("Exported tenant %q to %s (%s)", manifest.TenantID, *output, manifest.ExportedAt.Format("2006-01-02 15:04:05"))
+}
+
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	tenantID := fs.String("tenant", "", "tenant to import into (empty for community edition)")
+	dryRun := fs.Bool("dry-run", false, "report what would be imported without writing anything")
+	author := fs.String("author", "", "user ID assigned to posts whose author is not in the archive")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage()
+	}
+	path := fs.Arg(0)
+
+	opts := bundle.ImportOptions{
+		TenantID: *tenantID,
+		DryRun:   *dryRun,
+	}
+	opts.MediaDir, opts.MediaBaseURL = storageConfig()
+	if *author != "" {
+		id, err := uuid.Parse(*author)
+		if err != nil {
+			log.Fatalf("Invalid author ID: %v", err)
+		}
+		opts.DefaultAuthorID = id
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", path, err)
+	}
+
+	b, err := bundle.Read(f, info.Size())
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", path, err)
+	}
+
+	report, err := bundle.Import(context.Background(), connect(), b, opts)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatalf("Failed to print report: %v", err)
+	}
+}
+
+// connect opens and migrates the database configured by the environment
+func connect() *gorm.DB {
+	db, err := database.Connect()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := database.Migrate(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	return db
+}
+
+// storageConfig returns the media directory and URL prefix, matching the server
+func storageConfig() (string, string) {
+	storagePath := os.Getenv("STORAGE_PATH")
+	if storagePath == "" {
+		storagePath = "./storage"
+	}
+	baseURL := os.Getenv("STORAGE_BASE_URL")
+	if baseURL == "" {
+		baseURL = "/uploads"
+	}
+	return filepath.Join(storagePath, "uploads"), baseURL
+}
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"gohac/config"
 	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/eventbus"
 	"gohac/internal/adapter/handler"
+	"gohac/internal/adapter/mailer"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/adapter/webhook"
 	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,6 +24,19 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// webhookPollInterval is how often queued webhook deliveries are checked
+	webhookPollInterval = 5 * time.Second
+	// outboxPollInterval is how often the outbox is checked for undispatched domain events
+	outboxPollInterval = 2 * time.Second
+	// commentRateLimit is how many comments one IP may submit per commentRateWindow
+	commentRateLimit  = 5
+	commentRateWindow = 10 * time.Minute
+	// formRateLimit is how many form submissions one IP may make per formRateWindow
+	formRateLimit  = 10
+	formRateWindow = 10 * time.Minute
+)
+
 func main() {
 	// Initialize database connection
 	db, err := database.Connect()
@@ -28,6 +49,26 @@ func main() {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
+	// Background jobs stop, and the server shuts down, on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Empty the trash of content deleted before the retention period
+	go runTrashRetention(ctx, db)
+
+	// Send queued webhook deliveries in the background
+	go webhook.NewDispatcher(db).Run(ctx, webhookPollInterval)
+
+	// Dispatch domain events recorded in the outbox to their subscribers
+	bus := eventbus.NewBus()
+	eventbus.RegisterAuditLog(bus, db)
+	eventbus.RegisterFormNotifications(bus, mailer.FromEnv())
+	eventbus.RegisterSearchIndex(bus, db)
+	eventbus.RegisterWebhooks(bus, db)
+	// Admin sessions see changes once the outbox is next polled
+	eventbus.RegisterRealtime(bus)
+	go eventbus.NewDispatcher(db, bus).Run(ctx, outboxPollInterval)
+
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		AppName:      "Gohac CMS",
@@ -114,6 +155,13 @@ func main() {
 	log.Printf("💾 Database: %s", config.GetDatabaseDriver())
 	log.Printf("🏢 Multi-tenancy: %v", config.SupportsMultiTenancy())
 
+	go func() {
+		<-ctx.Done()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("Error shutting down server: %v", err)
+		}
+	}()
+
 	if err := app.Listen(":" + port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
@@ -134,8 +182,9 @@ func setupAPIRoutes(app *fiber.App, db *gorm.DB) {
 	authProtected := api.Group("/auth")
 	authProtected.Use(middleware.Protected())
 	authProtected.Get("/me", authHandler.Me)
-	authProtected.Put("/profile", authHandler.UpdateProfile)
+	authProtected.Put("/profile", handler.AuditImpersonation(db), authHandler.UpdateProfile)
 	authProtected.Post("/logout", authHandler.Logout)
+	authProtected.Post("/impersonate/stop", authHandler.StopImpersonation)
 
 	// Protected routes (require authentication)
 	v1 := api.Group("/v1")
@@ -145,6 +194,7 @@ func setupAPIRoutes(app *fiber.App, db *gorm.DB) {
 	if !config.SupportsMultiTenancy() {
 		v1.Use(middleware.DBMiddleware(db))
 	}
+	v1.Use(handler.AuditImpersonation(db))
 
 	// Create page handler
 	pageHandler := handler.NewPageHandler(db)
@@ -152,17 +202,22 @@ func setupAPIRoutes(app *fiber.App, db *gorm.DB) {
 	// Page routes
 	v1.Post("/pages", pageHandler.CreatePage)
 	v1.Get("/pages", pageHandler.ListPages)
+	v1.Get("/pages/tree", pageHandler.GetPageTree)
+	v1.Post("/pages/bulk", pageHandler.BulkPages)
 	v1.Get("/pages/:id", pageHandler.GetPage)
 	v1.Put("/pages/:id", pageHandler.UpdatePage)
 	v1.Delete("/pages/:id", pageHandler.DeletePage)
+	v1.Get("/pages/:id/translations", pageHandler.ListPageTranslations)
+	v1.Post("/pages/:id/translations", pageHandler.CreatePageTranslation)
 
 	// Upload handler
-	uploadHandler := handler.NewUploadHandler()
+	uploadHandler := handler.NewUploadHandler(db)
 	v1.Post("/upload", uploadHandler.UploadFile)
 	v1.Post("/upload/from-url", uploadHandler.DownloadFromURL)
 
 	// Settings handler
 	settingsHandler := handler.NewSettingsHandler(db)
+	v1.Get("/settings", settingsHandler.GetAdminSettings)
 	v1.Put("/settings", settingsHandler.UpdateSettings)
 
 	// Menu handler
@@ -182,30 +237,164 @@ func setupAPIRoutes(app *fiber.App, db *gorm.DB) {
 	v1.Delete("/users/:id", userHandler.DeleteUser)
 
 	// Media handler
-	mediaHandler := handler.NewMediaHandler()
+	mediaHandler := handler.NewMediaHandler(db)
 	v1.Get("/media", mediaHandler.ListMedia)
 	v1.Get("/media/:filename", mediaHandler.GetMediaInfo)
+	v1.Delete("/media/:filename", mediaHandler.DeleteMedia)
 
 	// Dashboard handler
 	dashboardHandler := handler.NewDashboardHandler(db)
 	v1.Get("/dashboard/stats", dashboardHandler.GetStats)
+	v1.Get("/dashboard/usage", dashboardHandler.GetUsage)
+
+	// Create bundle handler (site export/import)
+	bundleHandler := handler.NewBundleHandler(db)
+	v1.Get("/export", bundleHandler.Export)
+	v1.Post("/import", bundleHandler.Import)
 
 	// Post handler
 	postHandler := handler.NewPostHandler(db)
 	v1.Post("/posts", postHandler.CreatePost)
 	v1.Get("/posts", postHandler.ListPosts)
+	v1.Post("/posts/bulk", postHandler.BulkPosts)
 	v1.Get("/posts/:id", postHandler.GetPost)
 	v1.Put("/posts/:id", postHandler.UpdatePost)
 	v1.Delete("/posts/:id", postHandler.DeletePost)
+	v1.Get("/posts/:id/translations", postHandler.ListPostTranslations)
+	v1.Post("/posts/:id/translations", postHandler.CreatePostTranslation)
 
 	// Category handler
 	categoryHandler := handler.NewCategoryHandler(db)
 	v1.Post("/categories", categoryHandler.CreateCategory)
 	v1.Get("/categories", categoryHandler.ListCategories)
+	v1.Get("/categories/tree", categoryHandler.GetCategoryTree)
 	v1.Get("/categories/:id", categoryHandler.GetCategory)
 	v1.Put("/categories/:id", categoryHandler.UpdateCategory)
 	v1.Delete("/categories/:id", categoryHandler.DeleteCategory)
 
+	// Tag handler
+	tagHandler := handler.NewTagHandler(db)
+	v1.Get("/tags", tagHandler.ListTags)
+	v1.Post("/tags", tagHandler.CreateTag)
+	v1.Get("/tags/:id", tagHandler.GetTag)
+	v1.Put("/tags/:id", tagHandler.UpdateTag)
+	v1.Delete("/tags/:id", tagHandler.DeleteTag)
+	v1.Post("/tags/:id/merge", tagHandler.MergeTags)
+
+	// Search handler
+	searchHandler := handler.NewSearchHandler(db)
+	v1.Get("/search", searchHandler.Search)
+
+	// Redirect routes (import is registered before /:id)
+	redirectHandler := handler.NewRedirectHandler(db)
+	v1.Get("/redirects", redirectHandler.ListRedirects)
+	v1.Post("/redirects", redirectHandler.CreateRedirect)
+	v1.Post("/redirects/import", redirectHandler.ImportRedirects)
+	v1.Get("/redirects/:id", redirectHandler.GetRedirect)
+	v1.Put("/redirects/:id", redirectHandler.UpdateRedirect)
+	v1.Delete("/redirects/:id", redirectHandler.DeleteRedirect)
+
+	// Collection routes (custom content types and their items)
+	collectionHandler := handler.NewCollectionHandler(db)
+	v1.Get("/collections", collectionHandler.ListCollections)
+	v1.Post("/collections", collectionHandler.CreateCollection)
+	v1.Get("/collections/:type", collectionHandler.GetCollection)
+	v1.Put("/collections/:type", collectionHandler.UpdateCollection)
+	v1.Delete("/collections/:type", collectionHandler.DeleteCollection)
+	v1.Get("/collections/:type/items", collectionHandler.ListItems)
+	v1.Post("/collections/:type/items", collectionHandler.CreateItem)
+	v1.Get("/collections/:type/items/:id", collectionHandler.GetItem)
+	v1.Put("/collections/:type/items/:id", collectionHandler.UpdateItem)
+	v1.Delete("/collections/:type/items/:id", collectionHandler.DeleteItem)
+
+	// Global block routes (shared sections referenced from pages by ref blocks)
+	globalBlockHandler := handler.NewGlobalBlockHandler(db)
+	v1.Get("/global-blocks", globalBlockHandler.ListGlobalBlocks)
+	v1.Post("/global-blocks", globalBlockHandler.CreateGlobalBlock)
+	v1.Get("/global-blocks/:id", globalBlockHandler.GetGlobalBlock)
+	v1.Put("/global-blocks/:id", globalBlockHandler.UpdateGlobalBlock)
+	v1.Delete("/global-blocks/:id", globalBlockHandler.DeleteGlobalBlock)
+	v1.Get("/global-blocks/:id/usage", globalBlockHandler.ListGlobalBlockUsage)
+
+	// Page template routes (managing templates requires the admin role)
+	pageTemplateHandler := handler.NewPageTemplateHandler(db)
+	v1.Get("/page-templates", pageTemplateHandler.ListPageTemplates)
+	v1.Post("/page-templates", pageTemplateHandler.CreatePageTemplate)
+	v1.Get("/page-templates/:id", pageTemplateHandler.GetPageTemplate)
+	v1.Put("/page-templates/:id", pageTemplateHandler.UpdatePageTemplate)
+	v1.Delete("/page-templates/:id", pageTemplateHandler.DeletePageTemplate)
+
+	// Trash routes (purging requires the admin role)
+	trashHandler := handler.NewTrashHandler(db)
+	v1.Get("/trash", trashHandler.ListTrash)
+	v1.Delete("/trash", trashHandler.EmptyTrash)
+	v1.Post("/trash/:type/:id/restore", trashHandler.RestoreTrashItem)
+	v1.Delete("/trash/:type/:id", trashHandler.PurgeTrashItem)
+
+	// Editorial workflow routes (allowed status changes depend on the tenant's workflow and the user's role)
+	workflowHandler := handler.NewWorkflowHandler(db)
+	v1.Get("/reviews", workflowHandler.ListReviewQueue)
+	v1.Post("/reviews/:type/:id/transition", workflowHandler.TransitionContent)
+	v1.Get("/reviews/:type/:id/comments", workflowHandler.ListReviewComments)
+	v1.Post("/reviews/:type/:id/comments", workflowHandler.CreateReviewComment)
+
+	// Notification routes (always for the current user)
+	notificationHandler := handler.NewNotificationHandler(db)
+	v1.Get("/notifications", notificationHandler.ListNotifications)
+	v1.Post("/notifications/read", notificationHandler.MarkAllNotificationsRead)
+	v1.Post("/notifications/:id/read", notificationHandler.MarkNotificationRead)
+
+	// Edit lock routes (advisory; POST again as a heartbeat, admins can take locks over)
+	editLockHandler := handler.NewEditLockHandler(db)
+	v1.Get("/locks", editLockHandler.ListEditLocks)
+	v1.Get("/locks/:type/:id", editLockHandler.GetEditLock)
+	v1.Post("/locks/:type/:id", editLockHandler.AcquireEditLock)
+	v1.Delete("/locks/:type/:id", editLockHandler.ReleaseEditLock)
+
+	// Real-time event stream for the admin panel (server-sent events)
+	eventHandler := handler.NewEventHandler()
+	v1.Get("/events", eventHandler.StreamEvents)
+
+	// Webhook routes (admin only; deliveries are signed, retried and logged)
+	webhookHandler := handler.NewWebhookHandler(db)
+	v1.Get("/webhooks", webhookHandler.ListWebhooks)
+	v1.Post("/webhooks", webhookHandler.CreateWebhook)
+	v1.Get("/webhooks/:id", webhookHandler.GetWebhook)
+	v1.Put("/webhooks/:id", webhookHandler.UpdateWebhook)
+	v1.Delete("/webhooks/:id", webhookHandler.DeleteWebhook)
+	v1.Get("/webhooks/:id/deliveries", webhookHandler.ListWebhookDeliveries)
+	v1.Post("/webhooks/:id/deliveries/:delivery_id/replay", webhookHandler.ReplayWebhookDelivery)
+
+	// Comment moderation routes (the queue lists pending comments unless ?status= says otherwise)
+	commentHandler := handler.NewCommentHandler(db)
+	v1.Get("/comments", commentHandler.ListComments)
+	v1.Get("/comments/:id", commentHandler.GetComment)
+	v1.Put("/comments/:id", commentHandler.ModerateComment)
+	v1.Delete("/comments/:id", commentHandler.DeleteComment)
+
+	// Form submission routes (per form block of a page)
+	formHandler := handler.NewFormHandler(db)
+	v1.Get("/forms/:page_id/:block_id/submissions", formHandler.ListSubmissions)
+	v1.Get("/forms/:page_id/:block_id/submissions/export", formHandler.ExportSubmissions)
+	v1.Delete("/forms/:page_id/:block_id/submissions/:id", formHandler.DeleteSubmission)
+
+	// Platform routes (super-admin only, act across tenants)
+	platformHandler := handler.NewPlatformHandler(db)
+	platform := v1.Group("/platform", middleware.RequireSuperAdmin())
+	platform.Post("/impersonate", platformHandler.Impersonate)
+	platform.Get("/audit-logs", platformHandler.ListAuditLogs)
+
+	// Tenant provisioning, plans and usage metering
+	tenantHandler := handler.NewTenantHandler(db)
+	platform.Get("/plans", tenantHandler.ListPlans)
+	platform.Post("/plans", tenantHandler.CreatePlan)
+	platform.Put("/plans/:id", tenantHandler.UpdatePlan)
+	platform.Delete("/plans/:id", tenantHandler.DeletePlan)
+	platform.Get("/tenants", tenantHandler.ListTenants)
+	platform.Post("/tenants", tenantHandler.CreateTenant)
+	platform.Put("/tenants/:id", tenantHandler.UpdateTenant)
+	platform.Get("/usage", tenantHandler.GetUsageReport)
+
 	// Public API routes (no authentication required)
 	public := app.Group("/api/public")
 	// Set DB in context for public routes (community edition)
@@ -214,10 +403,21 @@ func setupAPIRoutes(app *fiber.App, db *gorm.DB) {
 	}
 	// Register specific routes BEFORE wildcard routes to avoid route conflicts
 	public.Get("/settings", settingsHandler.GetSettings)
-	public.Get("/menus/:id", menuHandler.GetMenu) // Public endpoint to get menu by ID
+	public.Get("/menus/:id", menuHandler.GetMenuPublic) // Public endpoint to get menu by ID
 	public.Get("/pages/*", pageHandler.GetPageBySlugPublic)
 	public.Get("/posts", postHandler.ListPostsPublic)
 	public.Get("/posts/:slug", postHandler.GetPostBySlugPublic)
+	public.Get("/posts/:slug/comments", commentHandler.ListCommentsPublic)
+	public.Get("/categories", categoryHandler.GetCategoryTree)
+	public.Get("/categories/*", postHandler.ListCategoryPostsPublic)
+	public.Get("/tags", tagHandler.ListTagsPublic)
+	public.Get("/tags/:slug", postHandler.ListTagPostsPublic)
+	public.Post("/posts/:slug/comments", middleware.RateLimit(commentRateLimit, commentRateWindow), commentHandler.SubmitComment)
+	public.Get("/search", searchHandler.SearchPublic)
+	public.Get("/redirects/resolve", redirectHandler.ResolveRedirect)
+	public.Get("/collections/:type", collectionHandler.ListItemsPublic)
+	public.Get("/collections/:type/:slug", collectionHandler.GetItemPublic)
+	public.Post("/forms/:page_id/:block_id", middleware.RateLimit(formRateLimit, formRateWindow), formHandler.SubmitForm)
 }
 
 // errorHandler is the global error handler
@@ -236,6 +436,33 @@ func errorHandler(c *fiber.Ctx, err error) error {
 	})
 }
 
+// runTrashRetention purges content that has been in the trash longer than TRASH_RETENTION_DAYS
+// Runs at startup and then hourly until ctx is done; disabled when the retention period is 0
+func runTrashRetention(ctx context.Context, db *gorm.DB) {
+	days := config.TrashRetentionDays()
+	if days == 0 {
+		log.Println("🗑️  Trash retention disabled")
+		return
+	}
+
+	repo := repository.NewTrashRepository(db)
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		purged, err := repo.PurgeExpired(ctx, time.Now().AddDate(0, 0, -days))
+		if err != nil {
+			log.Printf("Error emptying trash: %v", err)
+		} else if purged > 0 {
+			log.Printf("🗑️  Purged %d items trashed more than %d days ago", purged, days)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // getEdition returns the current edition name
 func getEdition() string {
 	if config.IsEnterprise() {
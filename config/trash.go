@@ -0,0 +1,21 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"gohac/internal/core/domain"
+)
+
+// TrashRetentionDays returns how many days deleted content stays in the trash
+// Set with TRASH_RETENTION_DAYS; 0 keeps trashed content until it is purged by hand
+func TrashRetentionDays() int {
+	if value := os.Getenv("TRASH_RETENTION_DAYS"); value != "" {
+		if days, err := strconv.Atoi(value); err == nil && days >= 0 {
+			return days
+		}
+		log.Printf("Invalid TRASH_RETENTION_DAYS %q, using %d", value, domain.DefaultTrashRetentionDays)
+	}
+	return domain.DefaultTrashRetentionDays
+}
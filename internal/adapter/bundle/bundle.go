@@ -0,0 +1,213 @@
+// Package bundle exports and imports a whole tenant as a portable site archive
+// The archive is a zip of JSON documents plus media files and does not depend on
+// the database dialect, so sites can move between environments and between the
+// SQLite and PostgreSQL editions. Clone reuses the same machinery to create a
+// tenant from a template tenant.
+package bundle
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// FormatVersion is the archive format written by Export and accepted by Read
+const FormatVersion = 1
+
+// Archive entry names
+const (
+	manifestEntry     = "manifest.json"
+	pagesEntry        = "pages.json"
+	postsEntry        = "posts.json"
+	categoriesEntry   = "categories.json"
+	menusEntry        = "menus.json"
+	globalBlocksEntry = "global_blocks.json"
+	templatesEntry    = "page_templates.json"
+	settingsEntry     = "settings.json"
+	usersEntry        = "users.json"
+	mediaEntry        = "media.json"
+	mediaPrefix       = "media/"
+)
+
+// Manifest describes an archive
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	TenantID      string    `json:"tenant_id"`
+	ExportedAt    time.Time `json:"exported_at"`
+	SourceDialect string    `json:"source_dialect"` // e.g. "sqlite", "postgres"
+	MediaBaseURL  string    `json:"media_base_url"` // URL prefix media files were served from
+}
+
+// Post is the portable form of a post
+// Relations are reduced to IDs so they can be remapped on import
+type Post struct {
+	ID              uuid.UUID         `json:"id"`
+	Locale          string            `json:"locale"`
+	TranslationOfID *uuid.UUID        `json:"translation_of_id,omitempty"`
+	SourceHash      string            `json:"source_hash,omitempty"`
+	Title           string            `json:"title"`
+	Slug            string            `json:"slug"`
+	Excerpt         string            `json:"excerpt"`
+	Blocks          datatypes.JSON    `json:"blocks,omitempty"`
+	Content         string            `json:"content"` // Legacy Markdown/HTML body; older bundles also carry JSON blocks here
+	FeaturedImage   string            `json:"featured_image"`
+	Status          domain.PostStatus `json:"status"`
+	PublishedAt     *time.Time        `json:"published_at"`
+	AuthorID        uuid.UUID         `json:"author_id"`
+	CategoryIDs     []uuid.UUID       `json:"category_ids"`
+	Tags            []string          `json:"tags,omitempty"` // Tag names
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// contentHash returns the hash domain.Post.ContentHash computes for the same content
+func (p *Post) contentHash() string {
+	return (&domain.Post{Title: p.Title, Excerpt: p.Excerpt, Blocks: p.Blocks, Content: p.Content}).ContentHash()
+}
+
+// User is the portable form of a user
+// Password hashes are never exported
+type User struct {
+	ID        uuid.UUID       `json:"id"`
+	Name      string          `json:"name"`
+	Email     string          `json:"email"`
+	Role      domain.UserRole `json:"role"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// MediaFile describes a media file stored in the archive under media/
+type MediaFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Bundle is the in-memory form of an archive
+type Bundle struct {
+	Manifest     Manifest
+	Pages        []*domain.Page
+	Posts        []*Post
+	Categories   []*domain.Category
+	Menus        []*domain.Menu
+	GlobalBlocks []*domain.GlobalBlock
+	Templates    []*domain.PageTemplate
+	Settings     *domain.GlobalSettings
+	Users        []*User
+	Media        []*MediaFile
+
+	mediaFiles map[string]*zip.File // Media contents by name, set by Read
+	mediaDir   string               // Directory holding media contents, set when cloning
+}
+
+// openMedia opens the contents of a media file listed in Media
+func (b *Bundle) openMedia(name string) (io.ReadCloser, error) {
+	if f, ok := b.mediaFiles[name]; ok {
+		return f.Open()
+	}
+	if b.mediaDir != "" {
+		return os.Open(filepath.Join(b.mediaDir, name))
+	}
+	return nil, fmt.Errorf("media file %s is not available", name)
+}
+
+// Read parses an archive
+// Media contents stay in the archive and are read on demand during import
+func Read(r io.ReaderAt, size int64) (*Bundle, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		entries[f.Name] = f
+	}
+
+	b := &Bundle{mediaFiles: make(map[string]*zip.File)}
+	if err := readJSON(entries, manifestEntry, &b.Manifest, true); err != nil {
+		return nil, err
+	}
+	if b.Manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %d (expected %d)", b.Manifest.FormatVersion, FormatVersion)
+	}
+
+	targets := []struct {
+		name string
+		v    interface{}
+	}{
+		{pagesEntry, &b.Pages},
+		{postsEntry, &b.Posts},
+		{categoriesEntry, &b.Categories},
+		{menusEntry, &b.Menus},
+		{globalBlocksEntry, &b.GlobalBlocks},
+		{templatesEntry, &b.Templates},
+		{settingsEntry, &b.Settings},
+		{usersEntry, &b.Users},
+		{mediaEntry, &b.Media},
+	}
+	for _, target := range targets {
+		if err := readJSON(entries, target.name, target.v, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, media := range b.Media {
+		// Names are plain file names; anything else could escape the media directory
+		if media.Name == "" || path.Base(media.Name) != media.Name || media.Name == "." || media.Name == ".." {
+			return nil, fmt.Errorf("invalid media file name %q", media.Name)
+		}
+		f, ok := entries[mediaPrefix+media.Name]
+		if !ok {
+			return nil, fmt.Errorf("media file %s is missing from the archive", media.Name)
+		}
+		b.mediaFiles[media.Name] = f
+	}
+
+	return b, nil
+}
+
+// readJSON decodes an archive entry into v
+func readJSON(entries map[string]*zip.File, name string, v interface{}, required bool) error {
+	f, ok := entries[name]
+	if !ok {
+		if required {
+			return fmt.Errorf("archive is missing %s", name)
+		}
+		return nil
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	if err := json.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeJSON encodes v as an archive entry
+func writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,347 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestDB creates an isolated in-memory SQLite database for testing
+func setupTestDB(t *testing.T) *gorm.DB {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.New().String())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(
+		&domain.User{},
+		&domain.Page{},
+		&domain.Post{},
+		&domain.Category{},
+		&domain.Menu{},
+		&domain.GlobalBlock{},
+		&domain.PageTemplate{},
+		&domain.SystemConfig{},
+		&domain.UsageCounter{},
+		&domain.OutboxEvent{},
+	)
+	require.NoError(t, err)
+	require.NoError(t, repository.CreateSearchSchema(db))
+
+	return db
+}
+
+// seedSource creates a tenant with one of everything and returns its menu
+func seedSource(t *testing.T, db *gorm.DB, mediaDir string) *domain.Menu {
+	ctx := context.Background()
+
+	author := &domain.User{TenantID: "source", Email: "editor@example.com", Password: "hash", Name: "Editor", Role: domain.UserRoleEditor}
+	require.NoError(t, db.Create(author).Error)
+
+	menu := &domain.Menu{TenantID: "source", Name: "Main", Items: datatypes.JSON(`[]`)}
+	require.NoError(t, db.Create(menu).Error)
+
+	blocks := fmt.Sprintf(`[{"id":"b1","type":"menu","data":{"menu_id":"%s"}},{"id":"b2","type":"image","data":{"url":"/uploads/logo.png"}}]`, menu.ID)
+	page := &domain.Page{TenantID: "source", Slug: "about", Title: "About", Status: domain.PageStatusPublished, Blocks: datatypes.JSON(blocks)}
+	require.NoError(t, db.Create(page).Error)
+
+	category := &domain.Category{TenantID: "source", Name: "News", Slug: "news"}
+	require.NoError(t, db.Create(category).Error)
+
+	post := &domain.Post{TenantID: "source", Slug: "hello", Title: "Hello", Content: "Hi", Status: domain.PostStatusPublished, AuthorID: author.ID, Categories: []domain.Category{*category}}
+	require.NoError(t, db.Create(post).Error)
+
+	err := repository.NewSettingsRepository(db).UpdateGlobalSettings(ctx, "source", &domain.GlobalSettings{SiteName: "Source", HeaderMenuID: menu.ID.String()})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(mediaDir, "logo.png"), []byte("logo"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(mediaDir, "unused.png"), []byte("unused"), 0644))
+
+	return menu
+}
+
+func exportSource(t *testing.T, db *gorm.DB, mediaDir string) *Bundle {
+	var buf bytes.Buffer
+	_, err := Export(context.Background(), db, &buf, ExportOptions{TenantID: "source", MediaDir: mediaDir, MediaBaseURL: "/uploads"})
+	require.NoError(t, err)
+
+	b, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return b
+}
+
+func TestExport_ReferencedMediaOnly(t *testing.T) {
+	db := setupTestDB(t)
+	mediaDir := t.TempDir()
+	seedSource(t, db, mediaDir)
+
+	b := exportSource(t, db, mediaDir)
+
+	assert.Equal(t, "source", b.Manifest.TenantID)
+	assert.Len(t, b.Pages, 1)
+	require.Len(t, b.Posts, 1)
+	assert.Len(t, b.Posts[0].CategoryIDs, 1)
+	require.Len(t, b.Users, 1)
+	assert.Equal(t, "editor@example.com", b.Users[0].Email)
+	require.Len(t, b.Media, 1)
+	assert.Equal(t, "logo.png", b.Media[0].Name)
+}
+
+func TestImport_RemapsIDsAndResolvesConflicts(t *testing.T) {
+	db := setupTestDB(t)
+	sourceMedia := t.TempDir()
+	menu := seedSource(t, db, sourceMedia)
+	b := exportSource(t, db, sourceMedia)
+
+	// Target already has a page with the same slug and a different logo.png
+	targetMedia := t.TempDir()
+	require.NoError(t, db.Create(&domain.Page{TenantID: "target", Slug: "about", Title: "Existing", Status: domain.PageStatusDraft}).Error)
+	require.NoError(t, os.WriteFile(filepath.Join(targetMedia, "logo.png"), []byte("other"), 0644))
+
+	report, err := Import(context.Background(), db, b, ImportOptions{TenantID: "target", MediaDir: targetMedia, MediaBaseURL: "/media"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Created[KindPages])
+	assert.Equal(t, 1, report.Created[KindPosts])
+	assert.Equal(t, 1, report.Created[KindUsers])
+	assert.Equal(t, 1, report.Created[KindMedia])
+	assert.Equal(t, []string{"editor@example.com"}, report.PasswordResetRequired)
+	assert.Contains(t, report.Renamed, Change{Type: "page", From: "about", To: "about-2"})
+	assert.Contains(t, report.Renamed, Change{Type: "media", From: "logo.png", To: "logo-2.png"})
+
+	var page domain.Page
+	require.NoError(t, db.Where("tenant_id = ? AND slug = ?", "target", "about-2").First(&page).Error)
+
+	var targetMenu domain.Menu
+	require.NoError(t, db.Where("tenant_id = ?", "target").First(&targetMenu).Error)
+	assert.NotEqual(t, menu.ID, targetMenu.ID)
+
+	var blocks []domain.Block
+	require.NoError(t, json.Unmarshal(page.Blocks, &blocks))
+	require.Len(t, blocks, 2)
+	assert.JSONEq(t, fmt.Sprintf(`{"menu_id":"%s"}`, targetMenu.ID), string(blocks[0].Data))
+	assert.JSONEq(t, `{"url":"/media/logo-2.png"}`, string(blocks[1].Data))
+
+	settings, err := repository.NewSettingsRepository(db).GetGlobalSettings(context.Background(), "target")
+	require.NoError(t, err)
+	assert.Equal(t, targetMenu.ID.String(), settings.HeaderMenuID)
+
+	var post domain.Post
+	require.NoError(t, db.Preload("Categories").Where("tenant_id = ?", "target").First(&post).Error)
+	require.Len(t, post.Categories, 1)
+	assert.Equal(t, "target", post.Categories[0].TenantID)
+
+	var author domain.User
+	require.NoError(t, db.First(&author, "id = ?", post.AuthorID).Error)
+	assert.Equal(t, "target", author.TenantID)
+
+	data, err := os.ReadFile(filepath.Join(targetMedia, "logo-2.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "logo", string(data))
+
+	// The staging directory is gone once the files are in place
+	entries, err := os.ReadDir(targetMedia)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"logo-2.png", "logo.png"}, names)
+}
+
+func TestImport_RemapsGlobalBlocksAndTemplates(t *testing.T) {
+	db := setupTestDB(t)
+	mediaDir := t.TempDir()
+
+	global := &domain.GlobalBlock{TenantID: "source", Name: "Signup CTA", Type: "cta", Data: datatypes.JSON(`{"title":"Join","image_url":"/uploads/cta.png"}`)}
+	require.NoError(t, db.Create(global).Error)
+	blocks := fmt.Sprintf(`[{"id":"b1","type":"ref","data":{"global_block_id":"%s"}}]`, global.ID)
+	template := &domain.PageTemplate{TenantID: "source", Name: "Landing"}
+	require.NoError(t, template.SetBlocks([]domain.TemplateBlock{{Block: global.RefBlock("t1"), Locked: true}}))
+	require.NoError(t, db.Create(template).Error)
+	require.NoError(t, db.Create(&domain.Page{TenantID: "source", Slug: "pricing", Title: "Pricing", Status: domain.PageStatusPublished, Blocks: datatypes.JSON(blocks), TemplateID: &template.ID, LockedBlocks: datatypes.JSON(`["b1"]`)}).Error)
+	require.NoError(t, os.WriteFile(filepath.Join(mediaDir, "cta.png"), []byte("cta"), 0644))
+
+	b := exportSource(t, db, mediaDir)
+	require.Len(t, b.GlobalBlocks, 1)
+	require.Len(t, b.Media, 1)
+
+	report, err := Import(context.Background(), db, b, ImportOptions{TenantID: "target", MediaDir: t.TempDir(), MediaBaseURL: "/media"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created[KindGlobalBlocks])
+	assert.Equal(t, 1, report.Created[KindTemplates])
+
+	var targetGlobal domain.GlobalBlock
+	require.NoError(t, db.Where("tenant_id = ?", "target").First(&targetGlobal).Error)
+	assert.NotEqual(t, global.ID, targetGlobal.ID)
+	assert.JSONEq(t, `{"title":"Join","image_url":"/media/cta.png"}`, string(targetGlobal.Data))
+
+	var page domain.Page
+	require.NoError(t, db.Where("tenant_id = ?", "target").First(&page).Error)
+	assert.Equal(t, []uuid.UUID{targetGlobal.ID}, domain.BlockRefIDs(page.Blocks))
+	assert.Equal(t, []string{"b1"}, page.LockedBlockIDs())
+
+	var targetTemplate domain.PageTemplate
+	require.NoError(t, db.Where("tenant_id = ?", "target").First(&targetTemplate).Error)
+	require.NotNil(t, page.TemplateID)
+	assert.Equal(t, targetTemplate.ID, *page.TemplateID)
+	templateBlocks, err := targetTemplate.TemplateBlocks()
+	require.NoError(t, err)
+	require.Len(t, templateBlocks, 1)
+	assert.True(t, templateBlocks[0].Locked)
+	ref, ok := domain.BlockRefID(templateBlocks[0].Block)
+	require.True(t, ok)
+	assert.Equal(t, targetGlobal.ID, ref)
+}
+
+func TestImport_DryRunLeavesNothingBehind(t *testing.T) {
+	db := setupTestDB(t)
+	sourceMedia := t.TempDir()
+	seedSource(t, db, sourceMedia)
+	b := exportSource(t, db, sourceMedia)
+
+	targetMedia := t.TempDir()
+	report, err := Import(context.Background(), db, b, ImportOptions{TenantID: "target", MediaDir: targetMedia, MediaBaseURL: "/uploads", DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Created[KindPages])
+
+	for _, model := range []interface{}{&domain.Page{}, &domain.Post{}, &domain.Category{}, &domain.Menu{}, &domain.User{}, &domain.SystemConfig{}} {
+		var count int64
+		require.NoError(t, db.Model(model).Where("tenant_id = ?", "target").Count(&count).Error)
+		assert.Zero(t, count, "%T", model)
+	}
+
+	entries, err := os.ReadDir(targetMedia)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestImport_QuotaExceededLeavesNothingBehind(t *testing.T) {
+	db := setupTestDB(t)
+	sourceMedia := t.TempDir()
+	seedSource(t, db, sourceMedia)
+	b := exportSource(t, db, sourceMedia)
+
+	targetMedia := t.TempDir()
+	_, err := Import(context.Background(), db, b, ImportOptions{
+		TenantID:     "target",
+		MediaDir:     targetMedia,
+		MediaBaseURL: "/uploads",
+		Limits:       map[domain.UsageMetric]int64{domain.UsageMetricStorageBytes: 2},
+	})
+	var quotaErr *domain.QuotaExceededError
+	require.True(t, errors.As(err, &quotaErr))
+	assert.Equal(t, domain.UsageMetricStorageBytes, quotaErr.Metric)
+
+	var pages int64
+	require.NoError(t, db.Model(&domain.Page{}).Where("tenant_id = ?", "target").Count(&pages).Error)
+	assert.Zero(t, pages)
+	entries, err := os.ReadDir(targetMedia)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestClone_CopiesSiteWithoutUsersAndPosts(t *testing.T) {
+	db := setupTestDB(t)
+	mediaDir := t.TempDir()
+	menu := seedSource(t, db, mediaDir)
+
+	report, err := Clone(context.Background(), db, CloneOptions{
+		SourceTenantID: "source",
+		TargetTenantID: "clone",
+		MediaDir:       mediaDir,
+		MediaBaseURL:   "/uploads",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Created[KindPages])
+	assert.Equal(t, 1, report.Created[KindMenus])
+	assert.Equal(t, 1, report.Created[KindCategories])
+	assert.Equal(t, 0, report.Created[KindPosts])
+	assert.Equal(t, 0, report.Created[KindUsers])
+	assert.Equal(t, 1, report.Created[KindMedia])
+
+	var users, posts int64
+	require.NoError(t, db.Model(&domain.User{}).Where("tenant_id = ?", "clone").Count(&users).Error)
+	require.NoError(t, db.Model(&domain.Post{}).Where("tenant_id = ?", "clone").Count(&posts).Error)
+	assert.Zero(t, users)
+	assert.Zero(t, posts)
+
+	var cloneMenu domain.Menu
+	require.NoError(t, db.Where("tenant_id = ?", "clone").First(&cloneMenu).Error)
+	assert.NotEqual(t, menu.ID, cloneMenu.ID)
+
+	var page domain.Page
+	require.NoError(t, db.Where("tenant_id = ? AND slug = ?", "clone", "about").First(&page).Error)
+	var blocks []domain.Block
+	require.NoError(t, json.Unmarshal(page.Blocks, &blocks))
+	require.Len(t, blocks, 2)
+	assert.JSONEq(t, fmt.Sprintf(`{"menu_id":"%s"}`, cloneMenu.ID), string(blocks[0].Data))
+	assert.JSONEq(t, `{"url":"/uploads/logo-2.png"}`, string(blocks[1].Data))
+
+	settings, err := repository.NewSettingsRepository(db).GetGlobalSettings(context.Background(), "clone")
+	require.NoError(t, err)
+	assert.Equal(t, cloneMenu.ID.String(), settings.HeaderMenuID)
+
+	// The template keeps its own media and references
+	data, err := os.ReadFile(filepath.Join(mediaDir, "logo-2.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "logo", string(data))
+	var source domain.Page
+	require.NoError(t, db.Where("tenant_id = ? AND slug = ?", "source", "about").First(&source).Error)
+	assert.Contains(t, string(source.Blocks), "/uploads/logo.png")
+
+	var storage int64
+	require.NoError(t, db.Model(&domain.UsageCounter{}).Select("value").
+		Where("tenant_id = ? AND metric = ? AND period = ?", "clone", domain.UsageMetricStorageBytes, "").Scan(&storage).Error)
+	assert.Equal(t, int64(len("logo")), storage)
+}
+
+func TestClone_PostsBringTheirAuthors(t *testing.T) {
+	db := setupTestDB(t)
+	mediaDir := t.TempDir()
+	seedSource(t, db, mediaDir)
+	other := &domain.User{TenantID: "source", Email: "other@example.com", Password: "hash", Name: "Other", Role: domain.UserRoleEditor}
+	require.NoError(t, db.Create(other).Error)
+
+	report, err := Clone(context.Background(), db, CloneOptions{
+		SourceTenantID: "source",
+		TargetTenantID: "clone",
+		MediaDir:       mediaDir,
+		MediaBaseURL:   "/uploads",
+		IncludePosts:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created[KindPosts])
+	assert.Equal(t, 1, report.Created[KindUsers])
+
+	var post domain.Post
+	require.NoError(t, db.Where("tenant_id = ?", "clone").First(&post).Error)
+	var author domain.User
+	require.NoError(t, db.First(&author, "id = ?", post.AuthorID).Error)
+	assert.Equal(t, "clone", author.TenantID)
+	assert.Equal(t, "editor@example.com", author.Email)
+
+	// Removing the copy's media leaves the template's files alone
+	require.Len(t, report.MediaFiles, 1)
+	require.NoError(t, RemoveMedia(report))
+	_, err = os.Stat(filepath.Join(mediaDir, "logo-2.png"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(mediaDir, "logo.png"))
+	assert.NoError(t, err)
+}
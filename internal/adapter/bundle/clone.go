@@ -0,0 +1,75 @@
+package bundle
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CloneOptions configures copying one tenant's site into another
+type CloneOptions struct {
+	SourceTenantID string
+	TargetTenantID string
+	MediaDir       string // Directory holding uploaded files, shared by both tenants
+	MediaBaseURL   string // Public URL prefix of MediaDir (e.g. "/uploads")
+	IncludeUsers   bool
+	IncludePosts   bool // Posts bring their authors along, even without IncludeUsers
+	DryRun         bool
+	Limits         map[domain.UsageMetric]int64 // Plan limits of the target tenant, see ImportOptions
+}
+
+// Clone deep-copies a template tenant into another tenant
+// Pages, menus, categories, settings and the media they reference are always
+// copied; users and posts only when requested, though copied posts always
+// bring their authors so they stay written by users of their own tenant.
+// It is an export and import without the archive, so IDs, menu references
+// and media URLs are remapped the same way. Media files are copied under new
+// names so the two sites do not share uploads.
+func Clone(ctx context.Context, db *gorm.DB, opts CloneOptions) (*Report, error) {
+	b, err := load(ctx, db, opts.SourceTenantID)
+	if err != nil {
+		return nil, err
+	}
+	b.Manifest.MediaBaseURL = opts.MediaBaseURL
+	if !opts.IncludePosts {
+		b.Posts = nil
+	}
+	if !opts.IncludeUsers {
+		b.Users = postAuthors(b)
+	}
+
+	media, err := collectMedia(b, ExportOptions{MediaDir: opts.MediaDir, MediaBaseURL: opts.MediaBaseURL})
+	if err != nil {
+		return nil, err
+	}
+	b.Media = media
+	b.mediaDir = opts.MediaDir
+
+	return Import(ctx, db, b, ImportOptions{
+		TenantID:     opts.TargetTenantID,
+		MediaDir:     opts.MediaDir,
+		MediaBaseURL: opts.MediaBaseURL,
+		DryRun:       opts.DryRun,
+		CopyMedia:    true,
+		Limits:       opts.Limits,
+	})
+}
+
+// postAuthors returns the bundle's users that wrote one of its posts
+func postAuthors(b *Bundle) []*User {
+	authors := map[uuid.UUID]bool{}
+	for _, p := range b.Posts {
+		authors[p.AuthorID] = true
+	}
+
+	var users []*User
+	for _, u := range b.Users {
+		if authors[u.ID] {
+			users = append(users, u)
+		}
+	}
+	return users
+}
@@ -0,0 +1,248 @@
+package bundle
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+// ExportOptions configures an export
+type ExportOptions struct {
+	TenantID     string
+	MediaDir     string // Directory holding uploaded files
+	MediaBaseURL string // Public URL prefix of MediaDir (e.g. "/uploads")
+	AllMedia     bool   // Export every file in MediaDir, not only files referenced by content
+}
+
+// Export writes a tenant's pages, posts, categories, menus, global blocks, page templates,
+// settings, users and media to w
+func Export(ctx context.Context, db *gorm.DB, w io.Writer, opts ExportOptions) (*Manifest, error) {
+	b, err := load(ctx, db, opts.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	b.Manifest.MediaBaseURL = opts.MediaBaseURL
+
+	media, err := collectMedia(b, opts)
+	if err != nil {
+		return nil, err
+	}
+	b.Media = media
+
+	zw := zip.NewWriter(w)
+	entries := []struct {
+		name string
+		v    interface{}
+	}{
+		{manifestEntry, b.Manifest},
+		{pagesEntry, b.Pages},
+		{postsEntry, b.Posts},
+		{categoriesEntry, b.Categories},
+		{menusEntry, b.Menus},
+		{globalBlocksEntry, b.GlobalBlocks},
+		{templatesEntry, b.Templates},
+		{settingsEntry, b.Settings},
+		{usersEntry, b.Users},
+		{mediaEntry, b.Media},
+	}
+	for _, entry := range entries {
+		if err := writeJSON(zw, entry.name, entry.v); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range b.Media {
+		if err := copyMediaToArchive(zw, filepath.Join(opts.MediaDir, file.Name), file.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	return &b.Manifest, nil
+}
+
+// load reads all of a tenant's content into a bundle
+func load(ctx context.Context, db *gorm.DB, tenantID string) (*Bundle, error) {
+	db = db.WithContext(ctx)
+	b := &Bundle{
+		Manifest: Manifest{
+			FormatVersion: FormatVersion,
+			TenantID:      tenantID,
+			ExportedAt:    time.Now().UTC(),
+			SourceDialect: db.Dialector.Name(),
+		},
+	}
+
+	if err := db.Where("tenant_id = ?", tenantID).Order("created_at ASC").Find(&b.Pages).Error; err != nil {
+		return nil, fmt.Errorf("failed to load pages: %w", err)
+	}
+	if err := db.Where("tenant_id = ?", tenantID).Order("created_at ASC").Find(&b.Categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to load categories: %w", err)
+	}
+	if err := db.Where("tenant_id = ?", tenantID).Order("created_at ASC").Find(&b.Menus).Error; err != nil {
+		return nil, fmt.Errorf("failed to load menus: %w", err)
+	}
+	if err := db.Where("tenant_id = ?", tenantID).Order("created_at ASC").Find(&b.GlobalBlocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to load global blocks: %w", err)
+	}
+	if err := db.Where("tenant_id = ?", tenantID).Order("created_at ASC").Find(&b.Templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to load page templates: %w", err)
+	}
+
+	var posts []*domain.Post
+	if err := db.Preload("Categories").Preload("Tags").Where("tenant_id = ?", tenantID).Order("created_at ASC").Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("failed to load posts: %w", err)
+	}
+	for _, p := range posts {
+		post := &Post{
+			ID:              p.ID,
+			Locale:          p.Locale,
+			TranslationOfID: p.TranslationOfID,
+			SourceHash:      p.SourceHash,
+			Title:           p.Title,
+			Slug:            p.Slug,
+			Excerpt:         p.Excerpt,
+			Blocks:          p.Blocks,
+			Content:         p.Content,
+			FeaturedImage:   p.FeaturedImage,
+			Status:          p.Status,
+			PublishedAt:     p.PublishedAt,
+			AuthorID:        p.AuthorID,
+			CreatedAt:       p.CreatedAt,
+			UpdatedAt:       p.UpdatedAt,
+		}
+		for _, category := range p.Categories {
+			post.CategoryIDs = append(post.CategoryIDs, category.ID)
+		}
+		for _, tag := range p.Tags {
+			post.Tags = append(post.Tags, tag.Name)
+		}
+		b.Posts = append(b.Posts, post)
+	}
+
+	var users []*domain.User
+	if err := db.Where("tenant_id = ?", tenantID).Order("created_at ASC").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
+	for _, u := range users {
+		b.Users = append(b.Users, &User{
+			ID:        u.ID,
+			Name:      u.Name,
+			Email:     u.Email,
+			Role:      u.Role,
+			CreatedAt: u.CreatedAt,
+		})
+	}
+
+	settings, err := repository.NewSettingsRepository(db).GetGlobalSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	b.Settings = settings
+
+	return b, nil
+}
+
+// collectMedia lists the media files to export
+// Unless AllMedia is set, only files whose URL appears in the tenant's content are included
+func collectMedia(b *Bundle, opts ExportOptions) ([]*MediaFile, error) {
+	media := []*MediaFile{}
+	if opts.MediaDir == "" {
+		return media, nil
+	}
+
+	entries, err := os.ReadDir(opts.MediaDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return media, nil
+		}
+		return nil, fmt.Errorf("failed to read media directory: %w", err)
+	}
+
+	var content string
+	if !opts.AllMedia {
+		content, err = contentText(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if !opts.AllMedia && !strings.Contains(content, mediaURL(opts.MediaBaseURL, entry.Name())) {
+			continue
+		}
+
+		size, sum, err := hashFile(filepath.Join(opts.MediaDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		media = append(media, &MediaFile{Name: entry.Name(), Size: size, SHA256: sum})
+	}
+	return media, nil
+}
+
+// contentText serializes all content that may reference media URLs
+func contentText(b *Bundle) (string, error) {
+	data, err := json.Marshal([]interface{}{b.Pages, b.Posts, b.Menus, b.GlobalBlocks, b.Templates, b.Settings})
+	if err != nil {
+		return "", fmt.Errorf("failed to scan content for media: %w", err)
+	}
+	return string(data), nil
+}
+
+// copyMediaToArchive adds a media file to the archive
+func copyMediaToArchive(zw *zip.Writer, src, name string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open media file %s: %w", name, err)
+	}
+	defer f.Close()
+
+	w, err := zw.Create(mediaPrefix + name)
+	if err != nil {
+		return fmt.Errorf("failed to add media file %s: %w", name, err)
+	}
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("failed to add media file %s: %w", name, err)
+	}
+	return nil
+}
+
+// hashFile returns a file's size and SHA-256 checksum
+func hashFile(p string) (int64, string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to open media file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read media file: %w", err)
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// mediaURL joins a media base URL and a file name
+func mediaURL(baseURL, name string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + name
+}
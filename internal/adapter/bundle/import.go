@@ -0,0 +1,899 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ImportOptions configures an import
+type ImportOptions struct {
+	TenantID        string
+	MediaDir        string    // Directory to write media files to
+	MediaBaseURL    string    // Public URL prefix of MediaDir (e.g. "/uploads")
+	DryRun          bool      // Validate and report without changing anything
+	DefaultAuthorID uuid.UUID // Author for posts whose author could not be mapped (defaults to a tenant admin)
+	CopyMedia       bool      // Write every media file under a new name instead of reusing identical files
+	ActorID         string    // User the recorded content events are attributed to
+
+	// Limits of the tenant's plan (0 or missing = unlimited). The imported content is reserved against
+	// them in the import transaction; exceeding one fails with a *domain.QuotaExceededError
+	Limits map[domain.UsageMetric]int64
+}
+
+// Change records an identifier that had to change during import
+type Change struct {
+	Type string `json:"type"` // page, post, category, media
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Report summarises an import
+type Report struct {
+	DryRun                bool           `json:"dry_run"`
+	Created               map[string]int `json:"created"`
+	Reused                map[string]int `json:"reused"` // Users matched by email, page templates matched by name, identical media files
+	Renamed               []Change       `json:"renamed"`
+	MediaBytes            int64          `json:"media_bytes"`             // Size of media files written
+	PasswordResetRequired []string       `json:"password_reset_required"` // Created users have no usable password
+	Warnings              []string       `json:"warnings"`
+
+	MediaFiles []string `json:"-"` // Paths of the media files written, for RemoveMedia
+}
+
+// RemoveMedia deletes the media files an import wrote
+// For imports run inside a caller's transaction, to clean up when that transaction fails
+func RemoveMedia(report *Report) error {
+	var errs []error
+	for _, path := range report.MediaFiles {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	report.MediaFiles = nil
+	return errors.Join(errs...)
+}
+
+// Report keys
+const (
+	KindPages        = "pages"
+	KindPosts        = "posts"
+	KindCategories   = "categories"
+	KindMenus        = "menus"
+	KindGlobalBlocks = "global_blocks"
+	KindTemplates    = "page_templates"
+	KindUsers        = "users"
+	KindMedia        = "media"
+)
+
+// errDryRun rolls back the import transaction after a successful dry run
+var errDryRun = errors.New("dry run")
+
+// Import loads a bundle into a tenant
+// All rows get new IDs and references between them are remapped. Conflicting
+// slugs and media file names get a numeric suffix, and media URLs are rewritten
+// to the target's media base URL. Everything runs in one transaction, and a dry
+// run performs the same work before rolling it back.
+func Import(ctx context.Context, db *gorm.DB, b *Bundle, opts ImportOptions) (*Report, error) {
+	report := &Report{
+		DryRun:                opts.DryRun,
+		Created:               map[string]int{},
+		Reused:                map[string]int{},
+		Renamed:               []Change{},
+		PasswordResetRequired: []string{},
+		Warnings:              []string{},
+	}
+	for _, kind := range []string{KindPages, KindPosts, KindCategories, KindMenus, KindGlobalBlocks, KindTemplates, KindUsers, KindMedia} {
+		report.Created[kind] = 0
+	}
+
+	im := &importer{
+		ctx:         ctx,
+		bundle:      b,
+		opts:        opts,
+		report:      report,
+		userIDs:     map[uuid.UUID]uuid.UUID{},
+		menuIDs:     map[uuid.UUID]uuid.UUID{},
+		globalIDs:   map[uuid.UUID]uuid.UUID{},
+		templateIDs: map[uuid.UUID]uuid.UUID{},
+		pages:       map[uuid.UUID]*domain.Page{},
+		posts:       map[uuid.UUID]*domain.Post{},
+		categories:  map[uuid.UUID]*domain.Category{},
+		usedSlugs:   map[string]map[string]bool{},
+		urlReplacer: strings.NewReplacer(),
+	}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		im.tx = tx
+		if err := im.run(); err != nil {
+			return err
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil {
+		im.discardMedia()
+		if !errors.Is(err, errDryRun) {
+			return nil, err
+		}
+		return report, nil
+	}
+	im.publishMedia()
+	return report, nil
+}
+
+// importer holds the state of a single import
+type importer struct {
+	ctx    context.Context
+	tx     *gorm.DB
+	bundle *Bundle
+	opts   ImportOptions
+	report *Report
+
+	userIDs     map[uuid.UUID]uuid.UUID        // Bundle user ID -> target user ID
+	menuIDs     map[uuid.UUID]uuid.UUID        // Bundle menu ID -> new menu ID
+	globalIDs   map[uuid.UUID]uuid.UUID        // Bundle global block ID -> new global block ID
+	templateIDs map[uuid.UUID]uuid.UUID        // Bundle page template ID -> new page template ID
+	pages       map[uuid.UUID]*domain.Page     // Bundle page ID -> created page
+	posts       map[uuid.UUID]*domain.Post     // Bundle post ID -> created post
+	categories  map[uuid.UUID]*domain.Category // Bundle category ID -> created category
+	usedSlugs   map[string]map[string]bool     // Table and locale -> slugs claimed by this import
+	urlReplacer *strings.Replacer              // Rewrites media URLs
+	mediaWrites []mediaWrite                   // Media files to write once the rows are in place
+	stageDir    string                         // Directory inside MediaDir holding the written files until commit
+}
+
+// mediaWrite is a media file to copy from the bundle into the media directory
+type mediaWrite struct {
+	source string // Name in the bundle
+	name   string // Name in the media directory
+}
+
+// run imports everything in dependency order
+func (im *importer) run() error {
+	steps := []func() error{
+		im.importUsers,
+		im.planMedia,
+		im.importCategories,
+		im.importMenus,
+		im.importGlobalBlocks,
+		im.importTemplates,
+		im.importPages,
+		im.importPosts,
+		im.importSettings,
+		im.meterUsage,
+		im.writeMedia,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importUsers matches users by email and creates the missing ones without a usable password
+func (im *importer) importUsers() error {
+	for _, u := range im.bundle.Users {
+		var existing domain.User
+		err := im.tx.Where("tenant_id = ? AND email = ?", im.opts.TenantID, u.Email).First(&existing).Error
+		if err == nil {
+			im.userIDs[u.ID] = existing.ID
+			im.report.Reused[KindUsers]++
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to look up user %s: %w", u.Email, err)
+		}
+
+		// Platform roles never travel with a site
+		role := u.Role
+		if role != domain.UserRoleAdmin && role != domain.UserRoleEditor {
+			role = domain.UserRoleAdmin
+		}
+
+		user := &domain.User{
+			TenantID:  im.opts.TenantID,
+			Name:      u.Name,
+			Email:     u.Email,
+			Password:  uuid.NewString(), // Random; the user must reset it
+			Role:      role,
+			CreatedAt: u.CreatedAt,
+		}
+		if err := user.HashPassword(); err != nil {
+			return fmt.Errorf("failed to create user %s: %w", u.Email, err)
+		}
+		if err := repository.NewUserRepository(im.tx).Create(im.ctx, user); err != nil {
+			return err
+		}
+
+		im.userIDs[u.ID] = user.ID
+		im.report.Created[KindUsers]++
+		im.report.PasswordResetRequired = append(im.report.PasswordResetRequired, user.Email)
+	}
+	return nil
+}
+
+// planMedia picks a target name for every media file and builds the URL rewriter
+// Identical files already in the media directory are reused
+func (im *importer) planMedia() error {
+	if len(im.bundle.Media) == 0 {
+		return nil
+	}
+	if im.opts.MediaDir == "" {
+		im.report.Warnings = append(im.report.Warnings, "No media directory configured; media files were skipped")
+		return nil
+	}
+
+	planned := map[string]bool{}
+	var pairs []string
+	for _, media := range im.bundle.Media {
+		ext := filepath.Ext(media.Name)
+		base := strings.TrimSuffix(media.Name, ext)
+
+		name := media.Name
+		reuse := false
+		for n := 2; ; n++ {
+			if !planned[name] {
+				_, sum, err := hashFile(filepath.Join(im.opts.MediaDir, name))
+				if errors.Is(err, os.ErrNotExist) {
+					break
+				}
+				if err == nil && sum == media.SHA256 && !im.opts.CopyMedia {
+					reuse = true
+					break
+				}
+			}
+			name = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+		planned[name] = true
+
+		if reuse {
+			im.report.Reused[KindMedia]++
+		} else {
+			im.mediaWrites = append(im.mediaWrites, mediaWrite{source: media.Name, name: name})
+			im.report.Created[KindMedia]++
+			im.report.MediaBytes += media.Size
+		}
+		if name != media.Name {
+			im.report.Renamed = append(im.report.Renamed, Change{Type: "media", From: media.Name, To: name})
+		}
+
+		oldURL := mediaURL(im.bundle.Manifest.MediaBaseURL, media.Name)
+		newURL := mediaURL(im.opts.MediaBaseURL, name)
+		if oldURL != newURL {
+			pairs = append(pairs, oldURL, newURL)
+		}
+	}
+
+	im.urlReplacer = strings.NewReplacer(pairs...)
+	return nil
+}
+
+// importCategories creates categories with new IDs and unique slugs
+// Parents are created before their subcategories so the links can be remapped
+func (im *importer) importCategories() error {
+	categories := append([]*domain.Category(nil), im.bundle.Categories...)
+	bundleCategories := make(map[uuid.UUID]*domain.Category, len(categories))
+	for _, c := range categories {
+		bundleCategories[c.ID] = c
+	}
+	depth := func(c *domain.Category) int {
+		d := 0
+		for c.ParentID != nil && d < domain.MaxCategoryDepth {
+			parent, ok := bundleCategories[*c.ParentID]
+			if !ok {
+				break
+			}
+			c = parent
+			d++
+		}
+		return d
+	}
+	sort.SliceStable(categories, func(i, j int) bool {
+		return depth(categories[i]) < depth(categories[j])
+	})
+
+	for _, c := range categories {
+		// Subcategories follow their parent's path, which may have been renamed
+		path := c.Slug
+		var parentID *uuid.UUID
+		if c.ParentID != nil {
+			if parent, ok := im.categories[*c.ParentID]; ok {
+				parentID = &parent.ID
+				path = domain.PagePath(parent.Slug, domain.PageSegment(c.Slug))
+			}
+		}
+
+		slug, err := im.uniqueSlug("categories", "category", "", path)
+		if err != nil {
+			return err
+		}
+
+		category := &domain.Category{
+			TenantID:    im.opts.TenantID,
+			ParentID:    parentID,
+			Name:        c.Name,
+			Slug:        slug,
+			Description: c.Description,
+			CreatedAt:   c.CreatedAt,
+		}
+		if err := repository.NewCategoryRepository(im.tx).Create(im.ctx, category); err != nil {
+			return err
+		}
+
+		im.categories[c.ID] = category
+		im.report.Created[KindCategories]++
+	}
+	return nil
+}
+
+// importMenus creates menus with new IDs, linking translations to their new source
+func (im *importer) importMenus() error {
+	menus := append([]*domain.Menu(nil), im.bundle.Menus...)
+	sort.SliceStable(menus, func(i, j int) bool {
+		return menus[i].TranslationOfID == nil && menus[j].TranslationOfID != nil
+	})
+
+	for _, m := range menus {
+		menu := &domain.Menu{
+			TenantID:        im.opts.TenantID,
+			Locale:          m.Locale,
+			TranslationOfID: remapID(m.TranslationOfID, im.menuIDs),
+			Name:            m.Name,
+			Description:     m.Description,
+			Items:           im.rewriteJSON(m.Items),
+			CreatedAt:       m.CreatedAt,
+		}
+		if err := repository.NewMenuRepository(im.tx).As(im.opts.ActorID).Create(im.ctx, menu); err != nil {
+			return err
+		}
+
+		im.menuIDs[m.ID] = menu.ID
+		im.report.Created[KindMenus]++
+	}
+	return nil
+}
+
+// importGlobalBlocks creates global blocks with new IDs, remapping menu references
+func (im *importer) importGlobalBlocks() error {
+	for _, g := range im.bundle.GlobalBlocks {
+		// A global menu block is remapped like a menu block on a page
+		block := []domain.Block{{Type: g.Type, Data: json.RawMessage(im.rewriteJSON(g.Data))}}
+		if _, err := remapBlockRefs(block, domain.BlockTypeMenu, "menu_id", im.menuIDs); err != nil {
+			im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("Global block %s: data could not be parsed; menu references were not remapped", g.Name))
+		}
+		data := datatypes.JSON(block[0].Data)
+
+		global := &domain.GlobalBlock{
+			TenantID:  im.opts.TenantID,
+			Name:      g.Name,
+			Type:      g.Type,
+			Data:      data,
+			CreatedAt: g.CreatedAt,
+		}
+		if err := repository.NewGlobalBlockRepository(im.tx).Create(im.ctx, global); err != nil {
+			return err
+		}
+
+		im.globalIDs[g.ID] = global.ID
+		im.report.Created[KindGlobalBlocks]++
+	}
+	return nil
+}
+
+// importTemplates creates page templates with new IDs, remapping menu and ref blocks
+// Templates are matched by name, so an existing template with the same name is reused
+func (im *importer) importTemplates() error {
+	for _, t := range im.bundle.Templates {
+		var existing domain.PageTemplate
+		err := im.tx.Where("tenant_id = ? AND name = ?", im.opts.TenantID, t.Name).First(&existing).Error
+		if err == nil {
+			im.templateIDs[t.ID] = existing.ID
+			im.report.Reused[KindTemplates]++
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to look up page template %s: %w", t.Name, err)
+		}
+
+		template := &domain.PageTemplate{
+			TenantID:    im.opts.TenantID,
+			Name:        t.Name,
+			Description: t.Description,
+			Blocks:      im.rewriteJSON(t.Blocks),
+			Meta:        im.rewriteJSON(t.Meta),
+			CreatedAt:   t.CreatedAt,
+		}
+		if blocks, err := template.TemplateBlocks(); err != nil {
+			im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("Page template %s: blocks could not be parsed; menu and global block references were not remapped", t.Name))
+		} else {
+			plain := make([]domain.Block, len(blocks))
+			for j := range blocks {
+				plain[j] = blocks[j].Block
+			}
+			_, menuErr := remapBlockRefs(plain, domain.BlockTypeMenu, "menu_id", im.menuIDs)
+			_, refErr := remapBlockRefs(plain, domain.BlockTypeRef, "global_block_id", im.globalIDs)
+			if menuErr != nil || refErr != nil {
+				im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("Page template %s: block data could not be parsed; some references were not remapped", t.Name))
+			}
+			for j := range blocks {
+				blocks[j].Block = plain[j]
+			}
+			if err := template.SetBlocks(blocks); err != nil {
+				return fmt.Errorf("page template %s: %w", t.Name, err)
+			}
+		}
+		if err := repository.NewPageTemplateRepository(im.tx).Create(im.ctx, template); err != nil {
+			return err
+		}
+
+		im.templateIDs[t.ID] = template.ID
+		im.report.Created[KindTemplates]++
+	}
+	return nil
+}
+
+// importPages creates pages with new IDs and unique slugs, remapping menu and ref blocks
+// Sources are created before their translations and parents before their children
+// so the links can be remapped
+func (im *importer) importPages() error {
+	pages := append([]*domain.Page(nil), im.bundle.Pages...)
+	bundlePages := make(map[uuid.UUID]*domain.Page, len(pages))
+	for _, p := range pages {
+		bundlePages[p.ID] = p
+	}
+	depth := func(p *domain.Page) int {
+		d := 0
+		for p.ParentID != nil && d < domain.MaxPageDepth {
+			parent, ok := bundlePages[*p.ParentID]
+			if !ok {
+				break
+			}
+			p = parent
+			d++
+		}
+		return d
+	}
+	sort.SliceStable(pages, func(i, j int) bool {
+		iTranslation, jTranslation := pages[i].TranslationOfID != nil, pages[j].TranslationOfID != nil
+		if iTranslation != jTranslation {
+			return !iTranslation
+		}
+		return depth(pages[i]) < depth(pages[j])
+	})
+
+	for _, p := range pages {
+		// Child pages follow their parent's path, which may have been renamed
+		path := p.Slug
+		var parentID *uuid.UUID
+		if p.ParentID != nil {
+			if parent, ok := im.pages[*p.ParentID]; ok {
+				parentID = &parent.ID
+				path = domain.PagePath(parent.Slug, domain.PageSegment(p.Slug))
+			}
+		}
+
+		slug, err := im.uniqueSlug("pages", "page", p.Locale, path)
+		if err != nil {
+			return err
+		}
+
+		blocks, err := RemapBlockMenus(im.rewriteJSON(p.Blocks), im.menuIDs)
+		if err == nil {
+			blocks, err = RemapBlockGlobalBlocks(blocks, im.globalIDs)
+		}
+		if err != nil {
+			im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("Page %s: blocks could not be parsed; menu and global block references were not remapped", p.Slug))
+			blocks = im.rewriteJSON(p.Blocks)
+		}
+
+		page := &domain.Page{
+			TenantID:     im.opts.TenantID,
+			Locale:       p.Locale,
+			Slug:         slug,
+			ParentID:     parentID,
+			SortOrder:    p.SortOrder,
+			Title:        p.Title,
+			Blocks:       blocks,
+			Status:       p.Status,
+			Meta:         im.rewriteJSON(p.Meta),
+			TemplateID:   remapID(p.TemplateID, im.templateIDs),
+			LockedBlocks: p.LockedBlocks,
+			CreatedAt:    p.CreatedAt,
+			PublishedAt:  p.PublishedAt,
+		}
+		if p.TranslationOfID != nil {
+			if source, ok := im.pages[*p.TranslationOfID]; ok {
+				page.TranslationOfID = &source.ID
+				// Media URLs may have been rewritten, so translations that were up to date are rehashed
+				page.SourceHash = p.SourceHash
+				if bundleSource := bundlePages[*p.TranslationOfID]; p.SourceHash == bundleSource.ContentHash() {
+					page.SourceHash = source.ContentHash()
+				}
+			}
+		}
+		if err := repository.NewPageRepository(im.tx).As(im.opts.ActorID).Create(im.ctx, page); err != nil {
+			return err
+		}
+		if err := repository.NewSearchRepository(im.tx).Index(im.ctx, domain.NewPageSearchDocument(page)); err != nil {
+			return err
+		}
+
+		im.pages[p.ID] = page
+		im.report.Created[KindPages]++
+	}
+	return nil
+}
+
+// importPosts creates posts with new IDs and unique slugs, remapping authors and categories
+func (im *importer) importPosts() error {
+	if len(im.bundle.Posts) == 0 {
+		return nil
+	}
+
+	defaultAuthorID, err := im.defaultAuthorID()
+	if err != nil {
+		return err
+	}
+
+	posts := append([]*Post(nil), im.bundle.Posts...)
+	sort.SliceStable(posts, func(i, j int) bool {
+		return posts[i].TranslationOfID == nil && posts[j].TranslationOfID != nil
+	})
+	bundlePosts := make(map[uuid.UUID]*Post, len(posts))
+	for _, p := range posts {
+		bundlePosts[p.ID] = p
+	}
+
+	for _, p := range posts {
+		slug, err := im.uniqueSlug("posts", "post", p.Locale, p.Slug)
+		if err != nil {
+			return err
+		}
+
+		authorID, ok := im.userIDs[p.AuthorID]
+		if !ok {
+			if defaultAuthorID == uuid.Nil {
+				return fmt.Errorf("post %s: author is not in the bundle and the tenant has no admin to assign it to", p.Slug)
+			}
+			authorID = defaultAuthorID
+		}
+
+		// Older bundles carry post blocks as JSON content
+		rawBlocks, content := p.Blocks, p.Content
+		if len(rawBlocks) == 0 {
+			if _, ok := domain.ParseBlockContent(content); ok {
+				rawBlocks, content = datatypes.JSON(content), ""
+			}
+		}
+		var blocks datatypes.JSON
+		if len(rawBlocks) > 0 {
+			blocks, err = RemapBlockMenus(im.rewriteJSON(rawBlocks), im.menuIDs)
+			if err == nil {
+				blocks, err = RemapBlockGlobalBlocks(blocks, im.globalIDs)
+			}
+			if err != nil {
+				im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("Post %s: blocks could not be parsed; menu and global block references were not remapped", p.Slug))
+				blocks = im.rewriteJSON(rawBlocks)
+			}
+		}
+
+		post := &domain.Post{
+			TenantID:      im.opts.TenantID,
+			Locale:        p.Locale,
+			Title:         p.Title,
+			Slug:          slug,
+			Excerpt:       p.Excerpt,
+			Blocks:        blocks,
+			Content:       im.urlReplacer.Replace(content),
+			FeaturedImage: im.urlReplacer.Replace(p.FeaturedImage),
+			Status:        p.Status,
+			PublishedAt:   p.PublishedAt,
+			AuthorID:      authorID,
+			CreatedAt:     p.CreatedAt,
+		}
+		if p.TranslationOfID != nil {
+			if source, ok := im.posts[*p.TranslationOfID]; ok {
+				post.TranslationOfID = &source.ID
+				post.SourceHash = p.SourceHash
+				if bundleSource := bundlePosts[*p.TranslationOfID]; p.SourceHash == bundleSource.contentHash() {
+					post.SourceHash = source.ContentHash()
+				}
+			}
+		}
+		if err := im.tx.Omit("Author", "Categories", "Tags").Create(post).Error; err != nil {
+			return fmt.Errorf("failed to create post %s: %w", slug, err)
+		}
+
+		var categories []domain.Category
+		for _, categoryID := range p.CategoryIDs {
+			if category, ok := im.categories[categoryID]; ok {
+				categories = append(categories, *category)
+			}
+		}
+		if len(categories) > 0 {
+			if err := im.tx.Model(post).Association("Categories").Append(&categories); err != nil {
+				return fmt.Errorf("failed to link categories to post %s: %w", slug, err)
+			}
+		}
+		if len(p.Tags) > 0 {
+			tags, err := repository.NewTagRepository(im.tx).Resolve(im.ctx, im.opts.TenantID, p.Tags)
+			if err != nil {
+				return fmt.Errorf("failed to resolve tags of post %s: %w", slug, err)
+			}
+			if err := im.tx.Model(post).Association("Tags").Append(&tags); err != nil {
+				return fmt.Errorf("failed to link tags to post %s: %w", slug, err)
+			}
+		}
+		if err := repository.NewSearchRepository(im.tx).Index(im.ctx, domain.NewPostSearchDocument(post)); err != nil {
+			return err
+		}
+
+		im.posts[p.ID] = post
+		im.report.Created[KindPosts]++
+	}
+	return nil
+}
+
+// importSettings replaces the tenant's settings, remapping menu IDs and media URLs
+func (im *importer) importSettings() error {
+	if im.bundle.Settings == nil {
+		return nil
+	}
+
+	settings := *im.bundle.Settings
+	settings.HeaderMenuID = RemapMenuID(settings.HeaderMenuID, im.menuIDs)
+	settings.FooterMenuID = RemapMenuID(settings.FooterMenuID, im.menuIDs)
+	settings.Logo = im.urlReplacer.Replace(settings.Logo)
+	settings.Favicon = im.urlReplacer.Replace(settings.Favicon)
+
+	return repository.NewSettingsRepository(im.tx).UpdateGlobalSettings(im.ctx, im.opts.TenantID, &settings)
+}
+
+// meterUsage adds the imported content to the tenant's usage counters, within the plan's limits
+func (im *importer) meterUsage() error {
+	usage := map[domain.UsageMetric]int64{
+		domain.UsageMetricPages:        int64(im.report.Created[KindPages]),
+		domain.UsageMetricPosts:        int64(im.report.Created[KindPosts]),
+		domain.UsageMetricUsers:        int64(im.report.Created[KindUsers]),
+		domain.UsageMetricStorageBytes: im.report.MediaBytes,
+	}
+
+	usageRepo := repository.NewUsageRepository(im.tx)
+	for _, metric := range domain.UsageMetrics {
+		if err := usageRepo.Reserve(im.ctx, im.opts.TenantID, metric, usage[metric], im.opts.Limits[metric]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMedia copies the planned media files into a staging directory inside the media directory
+// Runs last so a failure still rolls back the database changes. The files only become visible
+// under their planned names once the transaction has committed (see publishMedia)
+func (im *importer) writeMedia() error {
+	if im.opts.DryRun || len(im.mediaWrites) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(im.opts.MediaDir, 0755); err != nil {
+		return fmt.Errorf("failed to create media directory: %w", err)
+	}
+	stageDir, err := os.MkdirTemp(im.opts.MediaDir, ".import-")
+	if err != nil {
+		return fmt.Errorf("failed to create media staging directory: %w", err)
+	}
+	im.stageDir = stageDir
+
+	for _, write := range im.mediaWrites {
+		if err := copyMedia(im.bundle, write.source, filepath.Join(stageDir, write.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishMedia moves the staged media files to their planned names after the import committed
+// The rows already reference them, so a file that cannot be moved is reported as a warning
+func (im *importer) publishMedia() {
+	if im.stageDir == "" {
+		return
+	}
+	for _, write := range im.mediaWrites {
+		path := filepath.Join(im.opts.MediaDir, write.name)
+		if err := os.Rename(filepath.Join(im.stageDir, write.name), path); err != nil {
+			im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("Media file %s could not be stored: %v", write.name, err))
+			continue
+		}
+		im.report.MediaFiles = append(im.report.MediaFiles, path)
+	}
+	im.discardMedia()
+}
+
+// discardMedia removes the staging directory and any files still in it
+func (im *importer) discardMedia() {
+	if im.stageDir == "" {
+		return
+	}
+	if err := os.RemoveAll(im.stageDir); err != nil {
+		im.report.Warnings = append(im.report.Warnings, fmt.Sprintf("Failed to remove media staging directory: %v", err))
+	}
+	im.stageDir = ""
+}
+
+// uniqueSlug returns slug, or slug-N if it is taken in the target tenant or by this import
+// Slugs of localized content only need to be unique within their locale; pass "" for other tables
+func (im *importer) uniqueSlug(table, kind, locale, slug string) (string, error) {
+	scope := table + "/" + locale
+	if im.usedSlugs[scope] == nil {
+		im.usedSlugs[scope] = map[string]bool{}
+	}
+
+	candidate := slug
+	for n := 2; ; n++ {
+		if !im.usedSlugs[scope][candidate] {
+			query := im.tx.Table(table).Where("tenant_id = ? AND slug = ? AND deleted_at IS NULL", im.opts.TenantID, candidate)
+			if locale != "" {
+				query = query.Where("locale = ?", locale)
+			}
+			var count int64
+			if err := query.Count(&count).Error; err != nil {
+				return "", fmt.Errorf("failed to check %s slug: %w", kind, err)
+			}
+			if count == 0 {
+				break
+			}
+		}
+		candidate = fmt.Sprintf("%s-%d", slug, n)
+	}
+
+	im.usedSlugs[scope][candidate] = true
+	if candidate != slug {
+		im.report.Renamed = append(im.report.Renamed, Change{Type: kind, From: slug, To: candidate})
+	}
+	return candidate, nil
+}
+
+// defaultAuthorID returns the configured fallback author, or the tenant's first admin
+func (im *importer) defaultAuthorID() (uuid.UUID, error) {
+	if im.opts.DefaultAuthorID != uuid.Nil {
+		return im.opts.DefaultAuthorID, nil
+	}
+
+	var admin domain.User
+	err := im.tx.Where("tenant_id = ? AND role = ?", im.opts.TenantID, domain.UserRoleAdmin).Order("created_at ASC").First(&admin).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, fmt.Errorf("failed to find default author: %w", err)
+	}
+	return admin.ID, nil
+}
+
+// rewriteJSON rewrites media URLs inside a JSON document
+func (im *importer) rewriteJSON(data datatypes.JSON) datatypes.JSON {
+	if len(data) == 0 {
+		return data
+	}
+	return datatypes.JSON(im.urlReplacer.Replace(string(data)))
+}
+
+// RemapMenuID maps a menu ID string through ids, leaving unknown or invalid IDs unchanged
+func RemapMenuID(id string, ids map[uuid.UUID]uuid.UUID) string {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return id
+	}
+	if mapped, ok := ids[parsed]; ok {
+		return mapped.String()
+	}
+	return id
+}
+
+// remapID maps a bundle ID to the ID of the row created for it
+// Returns nil if id is nil or its row was not imported
+func remapID(id *uuid.UUID, ids map[uuid.UUID]uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	if mapped, ok := ids[*id]; ok {
+		return &mapped
+	}
+	return nil
+}
+
+// RemapBlockMenus rewrites the menu_id of menu blocks through ids
+func RemapBlockMenus(blocksJSON datatypes.JSON, ids map[uuid.UUID]uuid.UUID) (datatypes.JSON, error) {
+	return remapBlocksJSON(blocksJSON, domain.BlockTypeMenu, "menu_id", ids)
+}
+
+// RemapBlockGlobalBlocks rewrites the global_block_id of ref blocks through ids
+func RemapBlockGlobalBlocks(blocksJSON datatypes.JSON, ids map[uuid.UUID]uuid.UUID) (datatypes.JSON, error) {
+	return remapBlocksJSON(blocksJSON, domain.BlockTypeRef, "global_block_id", ids)
+}
+
+// remapBlocksJSON rewrites an ID field of one block type in a blocks array through ids
+func remapBlocksJSON(blocksJSON datatypes.JSON, blockType domain.BlockType, key string, ids map[uuid.UUID]uuid.UUID) (datatypes.JSON, error) {
+	if len(blocksJSON) == 0 || len(ids) == 0 {
+		return blocksJSON, nil
+	}
+
+	var blocks []domain.Block
+	if err := json.Unmarshal(blocksJSON, &blocks); err != nil {
+		return nil, err
+	}
+
+	changed, err := remapBlockRefs(blocks, blockType, key, ids)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return blocksJSON, nil
+	}
+	remapped, err := json.Marshal(blocks)
+	if err != nil {
+		return nil, err
+	}
+	return remapped, nil
+}
+
+// remapBlockRefs rewrites an ID field of one block type in place and reports whether any changed
+func remapBlockRefs(blocks []domain.Block, blockType domain.BlockType, key string, ids map[uuid.UUID]uuid.UUID) (bool, error) {
+	changed := false
+	for i, block := range blocks {
+		if block.Type != string(blockType) || len(block.Data) == 0 {
+			continue
+		}
+
+		var data map[string]interface{}
+		if err := json.Unmarshal(block.Data, &data); err != nil {
+			return false, err
+		}
+		id, _ := data[key].(string)
+		if remapped := RemapMenuID(id, ids); remapped != id {
+			data[key] = remapped
+			raw, err := json.Marshal(data)
+			if err != nil {
+				return false, err
+			}
+			blocks[i].Data = raw
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// copyMedia writes a bundle's media file to dst, refusing to overwrite
+func copyMedia(b *Bundle, name, dst string) error {
+	src, err := b.openMedia(name)
+	if err != nil {
+		return fmt.Errorf("failed to open media %s: %w", name, err)
+	}
+	defer src.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create media file %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write media file %s: %w", dst, err)
+	}
+	return out.Close()
+}
@@ -3,13 +3,42 @@ package database
 import (
 	"fmt"
 	"log"
+	"strings"
 
+	"gohac/config"
+	"gohac/internal/adapter/repository"
 	"gohac/internal/core/domain"
 
 	"github.com/go-gormigrate/gormigrate/v2"
 	"gorm.io/gorm"
 )
 
+// tenantUniqueIndexes are the composite (tenant_id, [locale,] slug/email) unique indexes
+var tenantUniqueIndexes = []struct {
+	model interface{}
+	name  string
+}{
+	{&domain.Page{}, "idx_pages_tenant_slug"},
+	{&domain.Post{}, "idx_posts_tenant_slug"},
+	{&domain.Category{}, "idx_categories_tenant_slug"},
+	{&domain.User{}, "idx_users_tenant_email"},
+}
+
+// trashUniqueIndexes are the unique indexes of soft-deleted content, which ignore trashed rows
+var trashUniqueIndexes = []struct {
+	model   interface{}
+	name    string
+	table   string
+	columns string
+}{
+	{&domain.Page{}, "idx_pages_tenant_slug", "pages", "tenant_id, locale, slug"},
+	{&domain.Page{}, "idx_pages_translation_locale", "pages", "translation_of_id, locale"},
+	{&domain.Post{}, "idx_posts_tenant_slug", "posts", "tenant_id, locale, slug"},
+	{&domain.Post{}, "idx_posts_translation_locale", "posts", "translation_of_id, locale"},
+	{&domain.Category{}, "idx_categories_tenant_slug", "categories", "tenant_id, slug"},
+	{&domain.Menu{}, "idx_menus_translation_locale", "menus", "translation_of_id, locale"},
+}
+
 // Migrate runs all database migrations using gormigrate
 func Migrate(db *gorm.DB) error {
 	m := gormigrate.New(db, gormigrate.DefaultOptions, []*gormigrate.Migration{
@@ -186,6 +215,520 @@ func Migrate(db *gorm.DB) error {
 				return tx.Migrator().DropTable("post_categories", &domain.Post{}, &domain.Category{})
 			},
 		},
+		{
+			ID: "20240106_tenant_unique_constraints",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240106_tenant_unique_constraints: Scoping slug and email uniqueness per tenant")
+
+				// Drop the old global indexes (replaced by composite tenant indexes)
+				legacyIndexes := []struct {
+					model interface{}
+					name  string
+				}{
+					{&domain.Page{}, "idx_pages_slug"},
+					{&domain.Post{}, "idx_posts_slug"},
+					{&domain.Category{}, "idx_categories_slug"},
+					{&domain.User{}, "idx_users_email"},
+				}
+				for _, idx := range legacyIndexes {
+					if tx.Migrator().HasIndex(idx.model, idx.name) {
+						if err := tx.Migrator().DropIndex(idx.model, idx.name); err != nil {
+							return fmt.Errorf("failed to drop index %s: %w", idx.name, err)
+						}
+					}
+				}
+
+				// Users gain a tenant association
+				if !tx.Migrator().HasColumn(&domain.User{}, "TenantID") {
+					if err := tx.Migrator().AddColumn(&domain.User{}, "TenantID"); err != nil {
+						return fmt.Errorf("failed to add users.tenant_id: %w", err)
+					}
+				}
+
+				// NULL tenant IDs would bypass composite unique indexes
+				for _, table := range []string{"posts", "categories"} {
+					if err := tx.Exec("UPDATE " + table + " SET tenant_id = '' WHERE tenant_id IS NULL").Error; err != nil {
+						return fmt.Errorf("failed to normalize %s.tenant_id: %w", table, err)
+					}
+				}
+
+				// Pages never had a unique slug, so duplicates may exist. Posts and
+				// categories were globally unique and cannot conflict, but are checked
+				// anyway in case the old index was removed by hand.
+				for _, table := range []string{"pages", "posts", "categories"} {
+					if err := dedupeSlugs(tx, table); err != nil {
+						return err
+					}
+				}
+
+				for _, idx := range tenantUniqueIndexes {
+					if !tx.Migrator().HasIndex(idx.model, idx.name) {
+						if err := tx.Migrator().CreateIndex(idx.model, idx.name); err != nil {
+							return fmt.Errorf("failed to create index %s: %w", idx.name, err)
+						}
+					}
+				}
+
+				log.Println("✅ Tenant unique constraints created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240106_tenant_unique_constraints")
+				for _, idx := range tenantUniqueIndexes {
+					if tx.Migrator().HasIndex(idx.model, idx.name) {
+						if err := tx.Migrator().DropIndex(idx.model, idx.name); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			},
+		},
+		{
+			ID: "20240107_super_admin_audit",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240107_super_admin_audit: Creating audit_logs table")
+
+				if err := tx.AutoMigrate(&domain.AuditLog{}); err != nil {
+					return fmt.Errorf("failed to create audit_logs table: %w", err)
+				}
+
+				// In enterprise, admins outside any tenant (such as the seeded admin)
+				// operate the platform and become super-admins
+				if config.SupportsMultiTenancy() {
+					if err := tx.Model(&domain.User{}).
+						Where("tenant_id = ? AND role = ?", "", domain.UserRoleAdmin).
+						Update("role", domain.UserRoleSuperAdmin).Error; err != nil {
+						return fmt.Errorf("failed to promote platform admins: %w", err)
+					}
+				}
+
+				log.Println("✅ Audit logs table created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240107_super_admin_audit")
+				if err := tx.Model(&domain.User{}).
+					Where("role = ?", domain.UserRoleSuperAdmin).
+					Update("role", domain.UserRoleAdmin).Error; err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable(&domain.AuditLog{})
+			},
+		},
+		{
+			ID: "20240108_quotas",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240108_quotas: Creating plans, tenants and usage_counters tables")
+
+				if err := tx.AutoMigrate(&domain.Plan{}, &domain.Tenant{}, &domain.UsageCounter{}); err != nil {
+					return fmt.Errorf("failed to create quota tables: %w", err)
+				}
+
+				// Usage is counted incrementally from here on, so seed the running
+				// totals from existing content. Storage is not attributable to a
+				// tenant for files uploaded before metering and starts at zero.
+				backfills := map[domain.UsageMetric]string{
+					domain.UsageMetricPages: "pages",
+					domain.UsageMetricPosts: "posts",
+					domain.UsageMetricUsers: "users",
+				}
+				for metric, table := range backfills {
+					err := tx.Exec(
+						"INSERT INTO usage_counters (tenant_id, metric, period, value, updated_at) "+
+							"SELECT tenant_id, ?, '', COUNT(*), CURRENT_TIMESTAMP FROM "+table+" GROUP BY tenant_id",
+						string(metric),
+					).Error
+					if err != nil {
+						return fmt.Errorf("failed to backfill %s usage: %w", metric, err)
+					}
+				}
+
+				log.Println("✅ Quota tables created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240108_quotas")
+				return tx.Migrator().DropTable(&domain.UsageCounter{}, &domain.Tenant{}, &domain.Plan{})
+			},
+		},
+		{
+			ID: "20240109_tenant_settings",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240109_tenant_settings: Scoping system config keys per tenant")
+
+				// idx_tenant_key only covered the key, so tenants could not have their own settings
+				if tx.Migrator().HasIndex(&domain.SystemConfig{}, "idx_tenant_key") {
+					if err := tx.Migrator().DropIndex(&domain.SystemConfig{}, "idx_tenant_key"); err != nil {
+						return fmt.Errorf("failed to drop index idx_tenant_key: %w", err)
+					}
+				}
+				if err := tx.Migrator().CreateIndex(&domain.SystemConfig{}, "idx_tenant_key"); err != nil {
+					return fmt.Errorf("failed to create index idx_tenant_key: %w", err)
+				}
+
+				log.Println("✅ System config keys scoped per tenant")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240109_tenant_settings")
+
+				// Keys become global again, so only the community settings can stay
+				if err := tx.Where("tenant_id <> ?", "").Delete(&domain.SystemConfig{}).Error; err != nil {
+					return fmt.Errorf("failed to delete tenant settings: %w", err)
+				}
+				if tx.Migrator().HasIndex(&domain.SystemConfig{}, "idx_tenant_key") {
+					if err := tx.Migrator().DropIndex(&domain.SystemConfig{}, "idx_tenant_key"); err != nil {
+						return fmt.Errorf("failed to drop index idx_tenant_key: %w", err)
+					}
+				}
+				if err := tx.Exec("CREATE UNIQUE INDEX idx_tenant_key ON system_configs (key)").Error; err != nil {
+					return fmt.Errorf("failed to create index idx_tenant_key: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			ID: "20240110_search",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240110_search: Creating full-text search index")
+
+				if err := repository.CreateSearchSchema(tx); err != nil {
+					return fmt.Errorf("failed to create search index: %w", err)
+				}
+				if err := rebuildSearchIndex(tx); err != nil {
+					return err
+				}
+
+				log.Println("✅ Search index created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240110_search")
+				return tx.Migrator().DropTable(&domain.SearchDocument{})
+			},
+		},
+		{
+			ID: "20240111_localization",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240111_localization: Adding locales and translation links to content")
+
+				// Slugs become unique per locale, so the same slug can be used by each translation
+				for _, idx := range []struct {
+					model interface{}
+					name  string
+				}{
+					{&domain.Page{}, "idx_pages_tenant_slug"},
+					{&domain.Post{}, "idx_posts_tenant_slug"},
+				} {
+					if tx.Migrator().HasIndex(idx.model, idx.name) {
+						if err := tx.Migrator().DropIndex(idx.model, idx.name); err != nil {
+							return fmt.Errorf("failed to drop index %s: %w", idx.name, err)
+						}
+					}
+				}
+
+				// Existing content gets the default locale from the column default
+				if err := tx.AutoMigrate(&domain.Page{}, &domain.Post{}, &domain.Menu{}); err != nil {
+					return fmt.Errorf("failed to add locale columns: %w", err)
+				}
+
+				log.Println("✅ Localization columns created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240111_localization")
+				return removeLocalization(tx)
+			},
+		},
+		{
+			ID: "20240112_page_hierarchy",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240112_page_hierarchy: Adding parent pages and sort order")
+
+				if err := tx.AutoMigrate(&domain.Page{}); err != nil {
+					return fmt.Errorf("failed to add page hierarchy columns: %w", err)
+				}
+				if err := linkNestedPages(tx); err != nil {
+					return err
+				}
+
+				log.Println("✅ Page hierarchy created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240112_page_hierarchy")
+				if err := tx.Migrator().DropColumn(&domain.Page{}, "ParentID"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&domain.Page{}, "SortOrder")
+			},
+		},
+		{
+			ID: "20240113_redirects",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240113_redirects: Creating redirects table")
+
+				if err := tx.AutoMigrate(&domain.Redirect{}); err != nil {
+					return fmt.Errorf("failed to create redirects table: %w", err)
+				}
+
+				log.Println("✅ Redirects table created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240113_redirects")
+				return tx.Migrator().DropTable(&domain.Redirect{})
+			},
+		},
+		{
+			ID: "20240114_collections",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240114_collections: Creating collections and collection_items tables")
+
+				if err := tx.AutoMigrate(&domain.Collection{}, &domain.CollectionItem{}); err != nil {
+					return fmt.Errorf("failed to create collection tables: %w", err)
+				}
+
+				log.Println("✅ Collection tables created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240114_collections")
+				return tx.Migrator().DropTable(&domain.CollectionItem{}, &domain.Collection{})
+			},
+		},
+		{
+			ID: "20240115_global_blocks",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240115_global_blocks: Creating global_blocks table")
+
+				if err := tx.AutoMigrate(&domain.GlobalBlock{}); err != nil {
+					return fmt.Errorf("failed to create global_blocks table: %w", err)
+				}
+
+				log.Println("✅ Global blocks table created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240115_global_blocks")
+				return tx.Migrator().DropTable(&domain.GlobalBlock{})
+			},
+		},
+		{
+			ID: "20240116_page_templates",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240116_page_templates: Creating page_templates table and page template columns")
+
+				if err := tx.AutoMigrate(&domain.PageTemplate{}, &domain.Page{}); err != nil {
+					return fmt.Errorf("failed to create page templates: %w", err)
+				}
+
+				log.Println("✅ Page templates created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240116_page_templates")
+				if err := tx.Migrator().DropColumn(&domain.Page{}, "TemplateID"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropColumn(&domain.Page{}, "LockedBlocks"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable(&domain.PageTemplate{})
+			},
+		},
+		{
+			ID: "20240117_post_blocks",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240117_post_blocks: Moving JSON post content to blocks")
+
+				if err := tx.AutoMigrate(&domain.Post{}); err != nil {
+					return fmt.Errorf("failed to add post blocks: %w", err)
+				}
+				if err := convertPostContentToBlocks(tx); err != nil {
+					return err
+				}
+
+				log.Println("✅ Post blocks added successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240117_post_blocks")
+				if err := tx.Model(&domain.Post{}).
+					Where("blocks IS NOT NULL AND (content IS NULL OR content = '')").
+					Update("content", gorm.Expr("CAST(blocks AS TEXT)")).Error; err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&domain.Post{}, "Blocks")
+			},
+		},
+		{
+			ID: "20240118_trash",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240118_trash: Adding soft deletes to content")
+
+				if err := tx.AutoMigrate(&domain.Page{}, &domain.Post{}, &domain.Category{}, &domain.Menu{}); err != nil {
+					return fmt.Errorf("failed to add deleted_at columns: %w", err)
+				}
+
+				// Trashed rows must not block their slug, so unique indexes only cover live rows
+				for _, idx := range trashUniqueIndexes {
+					if tx.Migrator().HasIndex(idx.model, idx.name) {
+						if err := tx.Migrator().DropIndex(idx.model, idx.name); err != nil {
+							return fmt.Errorf("failed to drop index %s: %w", idx.name, err)
+						}
+					}
+					if err := tx.Migrator().CreateIndex(idx.model, idx.name); err != nil {
+						return fmt.Errorf("failed to create index %s: %w", idx.name, err)
+					}
+				}
+
+				log.Println("✅ Soft deletes added successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240118_trash")
+				return removeSoftDeletes(tx)
+			},
+		},
+		{
+			ID: "20240119_workflow",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240119_workflow: Creating review_comments and notifications tables")
+
+				if err := tx.AutoMigrate(&domain.ReviewComment{}, &domain.Notification{}); err != nil {
+					return fmt.Errorf("failed to create workflow tables: %w", err)
+				}
+
+				log.Println("✅ Workflow tables created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240119_workflow")
+				if err := tx.Migrator().DropTable(&domain.Notification{}); err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable(&domain.ReviewComment{})
+			},
+		},
+		{
+			ID: "20240120_edit_locks",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240120_edit_locks: Adding content versions and edit_locks table")
+
+				// Existing pages and posts start at version 1 through the column default
+				if err := tx.AutoMigrate(&domain.Page{}, &domain.Post{}, &domain.EditLock{}); err != nil {
+					return fmt.Errorf("failed to add content versions and edit locks: %w", err)
+				}
+
+				log.Println("✅ Content versions and edit locks added successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240120_edit_locks")
+				if err := tx.Migrator().DropColumn(&domain.Page{}, "Version"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropColumn(&domain.Post{}, "Version"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropTable(&domain.EditLock{})
+			},
+		},
+		{
+			ID: "20240121_webhooks",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240121_webhooks: Creating webhooks and webhook_deliveries tables")
+
+				if err := tx.AutoMigrate(&domain.Webhook{}, &domain.WebhookDelivery{}); err != nil {
+					return fmt.Errorf("failed to create webhook tables: %w", err)
+				}
+
+				log.Println("✅ Webhook tables created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240121_webhooks")
+				return tx.Migrator().DropTable(&domain.WebhookDelivery{}, &domain.Webhook{})
+			},
+		},
+		{
+			ID: "20240122_outbox",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240122_outbox: Creating outbox_events table")
+
+				if err := tx.AutoMigrate(&domain.OutboxEvent{}); err != nil {
+					return fmt.Errorf("failed to create outbox_events table: %w", err)
+				}
+
+				log.Println("✅ Outbox table created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240122_outbox")
+				return tx.Migrator().DropTable(&domain.OutboxEvent{})
+			},
+		},
+		{
+			ID: "20240123_comments",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240123_comments: Creating comments table")
+
+				if err := tx.AutoMigrate(&domain.Comment{}); err != nil {
+					return fmt.Errorf("failed to create comments table: %w", err)
+				}
+
+				log.Println("✅ Comments table created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240123_comments")
+				return tx.Migrator().DropTable(&domain.Comment{})
+			},
+		},
+		{
+			ID: "20240124_form_submissions",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240124_form_submissions: Creating form_submissions table")
+
+				if err := tx.AutoMigrate(&domain.FormSubmission{}); err != nil {
+					return fmt.Errorf("failed to create form_submissions table: %w", err)
+				}
+
+				log.Println("✅ Form submissions table created successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240124_form_submissions")
+				return tx.Migrator().DropTable(&domain.FormSubmission{})
+			},
+		},
+		{
+			ID: "20240125_taxonomies",
+			Migrate: func(tx *gorm.DB) error {
+				log.Println("Running migration 20240125_taxonomies: Adding nested categories and tags")
+
+				// Existing categories stay top-level; their slugs already are their paths
+				if err := tx.AutoMigrate(&domain.Category{}, &domain.Tag{}); err != nil {
+					return fmt.Errorf("failed to migrate taxonomy tables: %w", err)
+				}
+				// Creates the post_tags join table
+				if err := tx.AutoMigrate(&domain.Post{}); err != nil {
+					return fmt.Errorf("failed to create post_tags table: %w", err)
+				}
+
+				log.Println("✅ Taxonomies migrated successfully")
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				log.Println("Rolling back migration 20240125_taxonomies")
+				if err := tx.Migrator().DropTable("post_tags", &domain.Tag{}); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&domain.Category{}, "ParentID")
+			},
+		},
 	})
 
 	if err := m.Migrate(); err != nil {
@@ -195,3 +738,310 @@ func Migrate(db *gorm.DB) error {
 	log.Println("✅ All migrations completed successfully")
 	return nil
 }
+
+// dedupeSlugs renames rows that share a slug within the same tenant
+// The oldest row keeps its slug, later ones get a numeric suffix (about, about-2, ...)
+func dedupeSlugs(tx *gorm.DB, table string) error {
+	var duplicates []struct {
+		TenantID string
+		Slug     string
+	}
+	if err := tx.Table(table).
+		Select("tenant_id, slug").
+		Group("tenant_id, slug").
+		Having("COUNT(*) > 1").
+		Scan(&duplicates).Error; err != nil {
+		return fmt.Errorf("failed to find duplicate slugs in %s: %w", table, err)
+	}
+
+	for _, dup := range duplicates {
+		var ids []string
+		if err := tx.Table(table).
+			Where("tenant_id = ? AND slug = ?", dup.TenantID, dup.Slug).
+			Order("created_at ASC").
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to load duplicate rows in %s: %w", table, err)
+		}
+
+		suffix := 2
+		for _, id := range ids[1:] {
+			var candidate string
+			for {
+				candidate = fmt.Sprintf("%s-%d", dup.Slug, suffix)
+				suffix++
+
+				var count int64
+				if err := tx.Table(table).
+					Where("tenant_id = ? AND slug = ?", dup.TenantID, candidate).
+					Count(&count).Error; err != nil {
+					return fmt.Errorf("failed to check slug %s in %s: %w", candidate, table, err)
+				}
+				if count == 0 {
+					break
+				}
+			}
+
+			if err := tx.Table(table).Where("id = ?", id).Update("slug", candidate).Error; err != nil {
+				return fmt.Errorf("failed to rename duplicate slug in %s: %w", table, err)
+			}
+			log.Printf("Renamed duplicate %s slug %q to %q (tenant %q)", table, dup.Slug, candidate, dup.TenantID)
+		}
+	}
+
+	return nil
+}
+
+// removeLocalization deletes translations and the locale columns, restoring per-tenant slugs
+// Content keeps its original (source) rows; translations cannot exist without locales
+func removeLocalization(tx *gorm.DB) error {
+	translations := "SELECT id FROM posts WHERE translation_of_id IS NOT NULL"
+	for _, table := range []string{"post_categories", "post_tags"} {
+		if !tx.Migrator().HasTable(table) {
+			continue
+		}
+		if err := tx.Exec("DELETE FROM " + table + " WHERE post_id IN (" + translations + ")").Error; err != nil {
+			return fmt.Errorf("failed to unlink translated posts from %s: %w", table, err)
+		}
+	}
+	for _, table := range []string{"pages", "posts", "menus"} {
+		if err := tx.Exec("DELETE FROM " + table + " WHERE translation_of_id IS NOT NULL").Error; err != nil {
+			return fmt.Errorf("failed to delete %s translations: %w", table, err)
+		}
+	}
+
+	localized := []struct {
+		model   interface{}
+		table   string
+		indexes []string
+		columns []string
+	}{
+		{&domain.Page{}, "pages", []string{"idx_pages_tenant_slug", "idx_pages_translation_locale", "idx_pages_translation_of_id"}, []string{"locale", "translation_of_id", "source_hash"}},
+		{&domain.Post{}, "posts", []string{"idx_posts_tenant_slug", "idx_posts_translation_locale", "idx_posts_translation_of_id"}, []string{"locale", "translation_of_id", "source_hash"}},
+		{&domain.Menu{}, "menus", []string{"idx_menus_translation_locale", "idx_menus_translation_of_id"}, []string{"locale", "translation_of_id"}},
+	}
+	for _, l := range localized {
+		for _, name := range l.indexes {
+			if tx.Migrator().HasIndex(l.model, name) {
+				if err := tx.Migrator().DropIndex(l.model, name); err != nil {
+					return fmt.Errorf("failed to drop index %s: %w", name, err)
+				}
+			}
+		}
+		for _, column := range l.columns {
+			if err := dropColumn(tx, l.table, column); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, table := range []string{"pages", "posts"} {
+		if err := tx.Exec("CREATE UNIQUE INDEX idx_" + table + "_tenant_slug ON " + table + " (tenant_id, slug)").Error; err != nil {
+			return fmt.Errorf("failed to create index idx_%s_tenant_slug: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// removeSoftDeletes purges trashed content and restores the unique indexes and tables
+// as they were before the trash existed
+func removeSoftDeletes(tx *gorm.DB) error {
+	trashedPages := "SELECT id FROM pages WHERE deleted_at IS NOT NULL"
+	trashedPosts := "SELECT id FROM posts WHERE deleted_at IS NOT NULL"
+	trashedCategories := "SELECT id FROM categories WHERE deleted_at IS NOT NULL"
+	trashedMenus := "SELECT id FROM menus WHERE deleted_at IS NOT NULL"
+	cleanup := []struct {
+		table string
+		query string
+	}{
+		{"form_submissions", "DELETE FROM form_submissions WHERE page_id IN (" + trashedPages + ")"},
+		{"comments", "DELETE FROM comments WHERE post_id IN (" + trashedPosts + ")"},
+		{"post_categories", "DELETE FROM post_categories WHERE post_id IN (" + trashedPosts + ") OR category_id IN (" + trashedCategories + ")"},
+		{"post_tags", "DELETE FROM post_tags WHERE post_id IN (" + trashedPosts + ")"},
+		{"pages", "UPDATE pages SET translation_of_id = NULL, source_hash = '' WHERE translation_of_id IN (" + trashedPages + ")"},
+		{"posts", "UPDATE posts SET translation_of_id = NULL, source_hash = '' WHERE translation_of_id IN (" + trashedPosts + ")"},
+		{"menus", "UPDATE menus SET translation_of_id = NULL WHERE translation_of_id IN (" + trashedMenus + ")"},
+	}
+	for _, step := range cleanup {
+		if !tx.Migrator().HasTable(step.table) {
+			continue
+		}
+		if err := tx.Exec(step.query).Error; err != nil {
+			return fmt.Errorf("failed to clean up %s: %w", step.table, err)
+		}
+	}
+
+	// Trashed children are purged with their parents, and live rows never have a trashed parent
+	softDeleted := []struct {
+		model interface{}
+		table string
+	}{
+		{&domain.Page{}, "pages"},
+		{&domain.Post{}, "posts"},
+		{&domain.Category{}, "categories"},
+		{&domain.Menu{}, "menus"},
+	}
+	for _, s := range softDeleted {
+		if err := tx.Exec("DELETE FROM " + s.table + " WHERE deleted_at IS NOT NULL").Error; err != nil {
+			return fmt.Errorf("failed to purge trashed %s: %w", s.table, err)
+		}
+	}
+
+	// The partial indexes refer to deleted_at, so they go before the column does
+	for _, idx := range trashUniqueIndexes {
+		if tx.Migrator().HasIndex(idx.model, idx.name) {
+			if err := tx.Migrator().DropIndex(idx.model, idx.name); err != nil {
+				return fmt.Errorf("failed to drop index %s: %w", idx.name, err)
+			}
+		}
+	}
+	for _, s := range softDeleted {
+		index := "idx_" + s.table + "_deleted_at"
+		if tx.Migrator().HasIndex(s.model, index) {
+			if err := tx.Migrator().DropIndex(s.model, index); err != nil {
+				return fmt.Errorf("failed to drop index %s: %w", index, err)
+			}
+		}
+		if err := dropColumn(tx, s.table, "deleted_at"); err != nil {
+			return err
+		}
+	}
+	for _, idx := range trashUniqueIndexes {
+		if err := tx.Exec("CREATE UNIQUE INDEX " + idx.name + " ON " + idx.table + " (" + idx.columns + ")").Error; err != nil {
+			return fmt.Errorf("failed to create index %s: %w", idx.name, err)
+		}
+	}
+	return nil
+}
+
+// dropColumn drops a column if it exists; indexes on the column must be dropped first
+// Unlike the SQLite migrator, which rebuilds the table, this keeps the table's other indexes
+func dropColumn(tx *gorm.DB, table, column string) error {
+	if !tx.Migrator().HasColumn(table, column) {
+		return nil
+	}
+	if err := tx.Exec("ALTER TABLE " + table + " DROP COLUMN " + column).Error; err != nil {
+		return fmt.Errorf("failed to drop column %s of %s: %w", column, table, err)
+	}
+	return nil
+}
+
+// rebuildSearchIndex indexes all pages and posts
+func rebuildSearchIndex(tx *gorm.DB) error {
+	searchRepo := repository.NewSearchRepository(tx)
+
+	var pages []*domain.Page
+	err := tx.FindInBatches(&pages, 100, func(batch *gorm.DB, _ int) error {
+		for _, page := range pages {
+			if err := searchRepo.Index(batch.Statement.Context, domain.NewPageSearchDocument(page)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to index pages: %w", err)
+	}
+
+	var posts []*domain.Post
+	err = tx.FindInBatches(&posts, 100, func(batch *gorm.DB, _ int) error {
+		for _, post := range posts {
+			if err := searchRepo.Index(batch.Statement.Context, domain.NewPostSearchDocument(post)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to index posts: %w", err)
+	}
+
+	return nil
+}
+
+// linkNestedPages sets the parent of pages whose slug was typed as a path (e.g. "services/consulting")
+// to the page at the parent path in the same tenant and locale, if there is one
+func linkNestedPages(tx *gorm.DB) error {
+	var pages []struct {
+		ID       string
+		TenantID string
+		Locale   string
+		Slug     string
+	}
+	if err := tx.Table("pages").Select("id, tenant_id, locale, slug").Scan(&pages).Error; err != nil {
+		return fmt.Errorf("failed to load pages: %w", err)
+	}
+
+	paths := make(map[string]string, len(pages))
+	for _, p := range pages {
+		paths[p.TenantID+"\x00"+p.Locale+"\x00"+p.Slug] = p.ID
+	}
+
+	for _, p := range pages {
+		i := strings.LastIndex(p.Slug, "/")
+		if i <= 0 {
+			continue
+		}
+		parentID, ok := paths[p.TenantID+"\x00"+p.Locale+"\x00"+p.Slug[:i]]
+		if !ok {
+			continue
+		}
+		if err := tx.Table("pages").Where("id = ?", p.ID).Update("parent_id", parentID).Error; err != nil {
+			return fmt.Errorf("failed to link page %s to its parent: %w", p.Slug, err)
+		}
+	}
+	return nil
+}
+
+// convertPostContentToBlocks moves post content holding a JSON blocks array to the blocks column
+// Markdown and HTML content is left in place. Translations that were up to date with their
+// source keep a matching source hash.
+func convertPostContentToBlocks(tx *gorm.DB) error {
+	var posts []*domain.Post
+	if err := tx.Select("id", "translation_of_id", "title", "excerpt", "content", "source_hash").
+		Find(&posts).Error; err != nil {
+		return fmt.Errorf("failed to load posts: %w", err)
+	}
+
+	oldHashes := make(map[string]string, len(posts))
+	for _, post := range posts {
+		oldHashes[post.ID.String()] = post.ContentHash()
+	}
+
+	for _, post := range posts {
+		if _, ok := domain.ParseBlockContent(post.Content); !ok {
+			continue
+		}
+		post.Blocks = []byte(post.Content)
+		post.Content = ""
+		if err := tx.Model(&domain.Post{}).Where("id = ?", post.ID).
+			Updates(map[string]interface{}{"blocks": post.Blocks, "content": ""}).Error; err != nil {
+			return fmt.Errorf("failed to convert content of post %s: %w", post.ID, err)
+		}
+	}
+
+	// Reload sources so hashes reflect the stored form of the blocks
+	newHashes := make(map[string]string, len(posts))
+	var stored []*domain.Post
+	if err := tx.Select("id", "title", "excerpt", "content", "blocks").
+		Where("translation_of_id IS NULL").Find(&stored).Error; err != nil {
+		return fmt.Errorf("failed to reload posts: %w", err)
+	}
+	for _, post := range stored {
+		newHashes[post.ID.String()] = post.ContentHash()
+	}
+
+	for _, post := range posts {
+		if post.TranslationOfID == nil {
+			continue
+		}
+		source := post.TranslationOfID.String()
+		if post.SourceHash == "" || post.SourceHash != oldHashes[source] || newHashes[source] == oldHashes[source] {
+			continue
+		}
+		if err := tx.Model(&domain.Post{}).Where("id = ?", post.ID).
+			Update("source_hash", newHashes[source]).Error; err != nil {
+			return fmt.Errorf("failed to update source hash of post %s: %w", post.ID, err)
+		}
+	}
+	return nil
+}
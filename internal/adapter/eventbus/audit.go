@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"context"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+// RegisterAuditLog subscribes the audit log to publishing and deletion of pages and posts, and to menu changes
+// The event type is used as the audit action and the event payload as its details;
+// an event dispatched twice is logged twice. Events of bulk actions are left out, as the
+// bulk action logs every item it changes itself
+func RegisterAuditLog(bus *Bus, db *gorm.DB) {
+	bus.Subscribe("content.*", "audit-log", func(ctx context.Context, event *domain.OutboxEvent) error {
+		if !audited(event) {
+			return nil
+		}
+		return repository.NewAuditLogRepository(db).Create(ctx, &domain.AuditLog{
+			TenantID:   event.TenantID,
+			ActorID:    event.ActorID,
+			Action:     string(event.Type),
+			EntityType: event.AggregateType,
+			EntityID:   event.AggregateID.String(),
+			Details:    event.Payload,
+		})
+	})
+}
+
+// audited reports whether a content event is kept in the audit log
+func audited(event *domain.OutboxEvent) bool {
+	if event.BatchID != "" {
+		return false
+	}
+	switch event.AggregateType {
+	case "menu":
+		return true
+	case "page", "post":
+		return event.Type == domain.EventContentPublished || event.Type == domain.EventContentDeleted
+	}
+	return false
+}
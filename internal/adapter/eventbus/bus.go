@@ -0,0 +1,63 @@
+// Package eventbus dispatches domain events from the transactional outbox to in-process subscribers
+// Repositories record events in the outbox_events table in the same transaction as
+// the change, so an event exists if and only if the change was committed. The
+// Dispatcher polls the table and hands each event to every matching subscriber,
+// retrying until all of them succeed; subscribers must therefore tolerate seeing
+// an event more than once.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"gohac/internal/core/domain"
+)
+
+// Handler handles one outbox event; returning an error retries the event later
+type Handler func(ctx context.Context, event *domain.OutboxEvent) error
+
+type subscriber struct {
+	name    string
+	pattern string
+	handle  Handler
+}
+
+// Bus routes outbox events to the subscribers whose pattern matches the event name
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []subscriber
+}
+
+// NewBus creates a bus without subscribers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for events matching pattern, e.g. "content.published", "content.*" or "*"
+// The name identifies the subscriber in dispatch errors
+func (b *Bus) Subscribe(pattern, name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, subscriber{name: name, pattern: pattern, handle: handler})
+}
+
+// Dispatch calls every matching subscriber and joins their errors
+// All subscribers run even if one fails, so a retry also reaches those that succeeded
+func (b *Bus) Dispatch(ctx context.Context, event *domain.OutboxEvent) error {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subscribers {
+		if !domain.MatchesEventName(sub.pattern, string(event.Type)) {
+			continue
+		}
+		if err := sub.handle(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
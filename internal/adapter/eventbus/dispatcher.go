@@ -0,0 +1,86 @@
+package eventbus
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gohac/internal/adapter/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// batchSize is how many due events one poll claims
+	batchSize = 50
+	// claimLease is how long a claimed event is hidden from other dispatchers
+	claimLease = time.Minute
+	// retention is how long dispatched events are kept before they are purged
+	retention = 7 * 24 * time.Hour
+	// purgeInterval is how often dispatched events are purged
+	purgeInterval = time.Hour
+)
+
+// Dispatcher delivers due outbox events to a bus
+// Events are claimed before dispatch, so several instances can share one outbox
+type Dispatcher struct {
+	db  *gorm.DB
+	bus *Bus
+	now func() time.Time
+}
+
+// NewDispatcher creates a dispatcher delivering outbox events to bus
+func NewDispatcher(db *gorm.DB, bus *Bus) *Dispatcher {
+	return &Dispatcher{db: db, bus: bus, now: time.Now}
+}
+
+// Run polls for due events until ctx is cancelled, purging old dispatched events along the way
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var lastPurge time.Time
+	for {
+		if _, err := d.DispatchDue(ctx); err != nil {
+			log.Printf("Error dispatching outbox events: %v", err)
+		}
+		if now := d.now(); now.Sub(lastPurge) >= purgeInterval {
+			if _, err := repository.NewOutboxRepository(d.db).PurgeDispatched(ctx, now.Add(-retention)); err != nil {
+				log.Printf("Error purging outbox events: %v", err)
+			}
+			lastPurge = now
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue dispatches the events that are due and returns how many were attempted
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	repo := repository.NewOutboxRepository(d.db)
+	attempted := 0
+	for {
+		events, err := repo.ClaimDue(ctx, d.now(), batchSize, claimLease)
+		if err != nil {
+			return attempted, err
+		}
+		for _, event := range events {
+			if err := d.bus.Dispatch(ctx, event); err != nil {
+				log.Printf("Error dispatching %s event %s: %v", event.Type, event.ID, err)
+				event.RecordFailure(d.now(), err)
+			} else {
+				event.MarkDispatched(d.now())
+			}
+			if err := repo.Save(ctx, event); err != nil {
+				// The claim runs out and the event is dispatched again
+				log.Printf("Error saving outbox event %s: %v", event.ID, err)
+			}
+			attempted++
+		}
+		if len(events) < batchSize {
+			return attempted, nil
+		}
+	}
+}
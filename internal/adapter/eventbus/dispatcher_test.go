@@ -0,0 +1,80 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDispatcher_DispatchDue(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Page{}, &domain.OutboxEvent{}, &domain.AuditLog{}))
+
+	page := &domain.Page{TenantID: "acme", Slug: "launch", Title: "Launch", Status: domain.PageStatusPublished}
+	require.NoError(t, repository.NewPageRepository(db).As("user-1").Create(context.Background(), page))
+
+	// The search indexer fails once; the audit log succeeds every time
+	bus := NewBus()
+	RegisterAuditLog(bus, db)
+	var indexed []domain.EventType
+	failures := 1
+	bus.Subscribe("content.*", "search-index", func(ctx context.Context, event *domain.OutboxEvent) error {
+		if failures > 0 {
+			failures--
+			return errors.New("index unavailable")
+		}
+		indexed = append(indexed, event.Type)
+		return nil
+	})
+
+	now := time.Now()
+	dispatcher := NewDispatcher(db, bus)
+	dispatcher.now = func() time.Time { return now }
+
+	attempted, err := dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempted, "content.created and content.published")
+
+	var pending domain.OutboxEvent
+	require.NoError(t, db.Where("dispatched_at IS NULL").First(&pending).Error)
+	assert.Equal(t, domain.EventContentCreated, pending.Type)
+	assert.Equal(t, 1, pending.Attempts)
+	assert.Contains(t, pending.LastError, "search-index: index unavailable")
+	require.NotNil(t, pending.NextAttemptAt)
+	assert.True(t, pending.NextAttemptAt.After(now))
+
+	// Not due yet
+	attempted, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+
+	now = now.Add(time.Minute)
+	attempted, err = dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.ElementsMatch(t, []domain.EventType{domain.EventContentPublished, domain.EventContentCreated}, indexed)
+
+	var undispatched int64
+	require.NoError(t, db.Model(&domain.OutboxEvent{}).Where("dispatched_at IS NULL").Count(&undispatched).Error)
+	assert.Zero(t, undispatched)
+
+	// Only publishing is audited, attributed to the user who made the change
+	var entries []domain.AuditLog
+	require.NoError(t, db.Find(&entries).Error)
+	require.Len(t, entries, 1)
+	assert.Equal(t, string(domain.EventContentPublished), entries[0].Action)
+	assert.Equal(t, "page", entries[0].EntityType)
+	assert.Equal(t, page.ID.String(), entries[0].EntityID)
+	assert.Equal(t, "user-1", entries[0].ActorID)
+	assert.Equal(t, "acme", entries[0].TenantID)
+}
@@ -0,0 +1,58 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gohac/internal/adapter/mailer"
+	"gohac/internal/core/domain"
+)
+
+// RegisterFormNotifications emails each form submission to the recipients named in its event
+// A failed send is retried with the event, so recipients may occasionally get a message twice
+func RegisterFormNotifications(bus *Bus, m mailer.Mailer) {
+	bus.Subscribe(string(domain.EventFormSubmitted), "form-notifications", func(ctx context.Context, event *domain.OutboxEvent) error {
+		var submitted domain.FormSubmitted
+		if err := event.Decode(&submitted); err != nil {
+			return fmt.Errorf("failed to decode form submission: %w", err)
+		}
+		if len(submitted.Notify) == 0 {
+			return nil
+		}
+		return m.Send(ctx, formNotification(&submitted))
+	})
+}
+
+// formNotification lists the submitted values in the order of the form's fields
+// Replies go to the first email address that was submitted, if any
+func formNotification(submitted *domain.FormSubmitted) mailer.Message {
+	name := submitted.FormTitle
+	if name == "" {
+		name = submitted.PageTitle
+	}
+
+	msg := mailer.Message{
+		To:      submitted.Notify,
+		Subject: "New submission: " + name,
+	}
+	var body strings.Builder
+	fmt.Fprintf(&body, "A visitor submitted %q on the page %q.\n\n", name, submitted.PageTitle)
+	for _, field := range submitted.Fields {
+		value, ok := submitted.Values[field.Name]
+		if !ok {
+			continue
+		}
+		label := field.Label
+		if label == "" {
+			label = field.Name
+		}
+		fmt.Fprintf(&body, "%s:\n%s\n\n", label, value)
+		if field.Type == domain.FormFieldEmail && msg.ReplyTo == "" {
+			msg.ReplyTo = value
+		}
+	}
+	fmt.Fprintf(&body, "Submission ID: %s\n", submitted.SubmissionID)
+	msg.Body = body.String()
+	return msg
+}
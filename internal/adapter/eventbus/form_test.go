@@ -0,0 +1,59 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gohac/internal/adapter/mailer"
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMailer struct {
+	sent []mailer.Message
+	err  error
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg mailer.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestRegisterFormNotifications(t *testing.T) {
+	m := &recordingMailer{err: errors.New("smtp down")}
+	bus := NewBus()
+	RegisterFormNotifications(bus, m)
+
+	event, err := domain.NewOutboxEvent("acme", "", domain.FormSubmitted{
+		SubmissionID: uuid.New(),
+		PageTitle:    "Contact",
+		FormTitle:    "Contact us",
+		Fields: []domain.FormField{
+			{Name: "name", Label: "Name", Type: domain.FormFieldText},
+			{Name: "email", Label: "Email", Type: domain.FormFieldEmail},
+			{Name: "message", Type: domain.FormFieldTextarea},
+		},
+		Values: map[string]string{"name": "Ann", "email": "ann@example.com", "message": "Hello"},
+		Notify: []string{"sales@example.com"},
+	})
+	require.NoError(t, err)
+
+	// A failed send fails the dispatch, so the event is retried
+	assert.Error(t, bus.Dispatch(context.Background(), event))
+
+	m.err = nil
+	require.NoError(t, bus.Dispatch(context.Background(), event))
+	require.Len(t, m.sent, 1)
+	msg := m.sent[0]
+	assert.Equal(t, []string{"sales@example.com"}, msg.To)
+	assert.Equal(t, "ann@example.com", msg.ReplyTo)
+	assert.Equal(t, "New submission: Contact us", msg.Subject)
+	assert.Contains(t, msg.Body, "Name:\nAnn\n\nEmail:\nann@example.com\n\nmessage:\nHello\n")
+}
@@ -0,0 +1,23 @@
+package eventbus
+
+import (
+	"context"
+	"log"
+
+	"gohac/internal/adapter/realtime"
+	"gohac/internal/core/domain"
+)
+
+// RegisterRealtime streams the events webhooks can subscribe to to the tenant's open admin sessions
+// Streaming is best effort: a failed publish is logged rather than retried with the event
+func RegisterRealtime(bus *Bus) {
+	bus.Subscribe("*", "realtime", func(ctx context.Context, event *domain.OutboxEvent) error {
+		if !domain.IsWebhookEventType(event.Type) {
+			return nil
+		}
+		if err := realtime.Default().Publish(ctx, event.Event()); err != nil {
+			log.Printf("Error streaming %s event %s: %v", event.Type, event.ID, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+// RegisterSearchIndex keeps the search index in step with changes to pages and posts
+// The page or post is reloaded for every event, so an event dispatched late or twice still
+// indexes its current state; content that is gone, such as trashed content, is removed
+func RegisterSearchIndex(bus *Bus, db *gorm.DB) {
+	bus.Subscribe("content.*", "search-index", func(ctx context.Context, event *domain.OutboxEvent) error {
+		search := repository.NewSearchRepository(db)
+		var doc *domain.SearchDocument
+		switch domain.SearchEntityType(event.AggregateType) {
+		case domain.SearchEntityPage:
+			page, err := repository.NewPageRepository(db).GetByID(ctx, event.AggregateID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return search.Remove(ctx, domain.SearchEntityPage, event.AggregateID)
+			} else if err != nil {
+				return err
+			}
+			doc = domain.NewPageSearchDocument(page)
+		case domain.SearchEntityPost:
+			post, err := repository.NewPostRepository(db).GetByID(ctx, event.AggregateID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return search.Remove(ctx, domain.SearchEntityPost, event.AggregateID)
+			} else if err != nil {
+				return err
+			}
+			doc = domain.NewPostSearchDocument(post)
+		default:
+			return nil
+		}
+		return search.Index(ctx, doc)
+	})
+}
@@ -0,0 +1,49 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RegisterWebhooks queues a delivery of each event to the active webhooks of its tenant that subscribe to it
+// The webhook dispatcher sends them. Webhooks that already have a delivery of the event are skipped,
+// so an event dispatched twice is not sent twice
+func RegisterWebhooks(bus *Bus, db *gorm.DB) {
+	bus.Subscribe("*", "webhooks", func(ctx context.Context, event *domain.OutboxEvent) error {
+		if !domain.IsWebhookEventType(event.Type) {
+			return nil
+		}
+		repo := repository.NewWebhookRepository(db)
+		webhooks, err := repo.ListActive(ctx, event.TenantID)
+		if err != nil || len(webhooks) == 0 {
+			return err
+		}
+		queued, err := repo.ListDeliveredWebhookIDs(ctx, event.TenantID, event.ID.String())
+		if err != nil {
+			return err
+		}
+		skip := make(map[uuid.UUID]bool, len(queued))
+		for _, id := range queued {
+			skip[id] = true
+		}
+
+		payload, err := json.Marshal(event.Event())
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+		}
+		var deliveries []*domain.WebhookDelivery
+		for _, webhook := range webhooks {
+			if webhook.Matches(event.Type) && !skip[webhook.ID] {
+				deliveries = append(deliveries, domain.NewWebhookDelivery(webhook, event.ID.String(), event.Type, payload))
+			}
+		}
+		return repo.CreateDeliveries(ctx, deliveries)
+	})
+}
@@ -0,0 +1,62 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRegisterWebhooks(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Webhook{}, &domain.WebhookDelivery{}, &domain.OutboxEvent{}))
+
+	build := &domain.Webhook{TenantID: "acme", Name: "Build", URL: "https://example.com/build", Secret: "s", Active: true,
+		Events: datatypes.JSON(`["content.*"]`)}
+	locks := &domain.Webhook{TenantID: "acme", Name: "Locks", URL: "https://example.com/locks", Secret: "s", Active: true,
+		Events: datatypes.JSON(`["lock.acquired"]`)}
+	other := &domain.Webhook{TenantID: "globex", Name: "Other", URL: "https://example.com/other", Secret: "s", Active: true}
+	for _, hook := range []*domain.Webhook{build, locks, other} {
+		require.NoError(t, db.Create(hook).Error)
+	}
+
+	bus := NewBus()
+	RegisterWebhooks(bus, db)
+	event, err := domain.NewOutboxEvent("acme", "user-1", domain.PageCreated{PageEvent: domain.NewPageEvent(&domain.Page{Slug: "about", Title: "About"})})
+	require.NoError(t, err)
+	require.NoError(t, db.Create(event).Error)
+
+	// Only the tenant's webhooks that subscribe to the event get a delivery, and only once
+	for i := 0; i < 2; i++ {
+		require.NoError(t, bus.Dispatch(context.Background(), event))
+	}
+	var deliveries []domain.WebhookDelivery
+	require.NoError(t, db.Find(&deliveries).Error)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, build.ID, deliveries[0].WebhookID)
+	assert.Equal(t, event.ID.String(), deliveries[0].EventID)
+	assert.Equal(t, domain.EventContentCreated, deliveries[0].EventType)
+
+	var payload domain.Event
+	require.NoError(t, json.Unmarshal(deliveries[0].Payload, &payload))
+	assert.Equal(t, "page", payload.EntityType)
+	assert.Equal(t, "user-1", payload.ActorID)
+
+	// Events webhooks cannot subscribe to are not delivered
+	submitted, err := domain.NewOutboxEvent("acme", "", domain.FormSubmitted{})
+	require.NoError(t, err)
+	require.NoError(t, db.Create(submitted).Error)
+	require.NoError(t, bus.Dispatch(context.Background(), submitted))
+	ids, err := repository.NewWebhookRepository(db).ListDeliveredWebhookIDs(context.Background(), "acme", submitted.ID.String())
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
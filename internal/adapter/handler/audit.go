@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// recordAudit writes an audit log entry for the current request
+// The actor and impersonator are taken from the authenticated session
+// Failures are logged but never fail the request
+func recordAudit(c *fiber.Ctx, db *gorm.DB, tenantID, action, entityType, entityID string, details fiber.Map) {
+	actorID, _ := c.Locals("user_id").(string)
+	impersonatorID, _ := c.Locals("impersonator_id").(string)
+
+	entry := &domain.AuditLog{
+		TenantID:       tenantID,
+		ActorID:        actorID,
+		ImpersonatorID: impersonatorID,
+		Action:         action,
+		EntityType:     entityType,
+		EntityID:       entityID,
+		IPAddress:      c.IP(),
+	}
+	if details != nil {
+		if detailsJSON, err := json.Marshal(details); err == nil {
+			entry.Details = detailsJSON
+		}
+	}
+
+	if err := repository.NewAuditLogRepository(db).Create(c.Context(), entry); err != nil {
+		log.Printf("Error recording audit log %s: %v", action, err)
+	}
+}
+
+// AuditImpersonation records every change made with an impersonation token, so the super-admin
+// behind it shows up in the audit log next to the impersonated user. Reads are not recorded
+// Must be registered after Protected
+func AuditImpersonation(fallback *gorm.DB) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		impersonatorID, _ := c.Locals("impersonator_id").(string)
+		if impersonatorID == "" {
+			return c.Next()
+		}
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+
+		err := c.Next()
+
+		db, dbErr := database.GetDBFromContext(c.Context())
+		if dbErr != nil {
+			db = fallback
+		}
+		tenantID, _ := c.Locals("user_tenant_id").(string)
+		recordAudit(c, db, tenantID, domain.AuditActionImpersonateWrite, "", "", fiber.Map{
+			"method": c.Method(),
+			"path":   c.Path(),
+			"status": c.Response().StatusCode(),
+		})
+		return err
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditImpersonation_RecordsWritesOnly(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	app := newTestApp(t)
+	app.Use(func(c *fiber.Ctx) error {
+		if c.Get("X-Impersonator") != "" {
+			c.Locals("impersonator_id", c.Get("X-Impersonator"))
+		}
+		return c.Next()
+	})
+	app.Use(AuditImpersonation(db))
+	app.All("/pages", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	send := func(method, impersonator string) {
+		app.send(method, "/pages", nil, map[string]string{"X-User": "editor-1", "X-Tenant": "acme", "X-Impersonator": impersonator})
+	}
+	send(http.MethodGet, "root-1")
+	send(http.MethodPost, "")
+	send(http.MethodPost, "root-1")
+
+	var entries []domain.AuditLog
+	require.NoError(t, db.Find(&entries).Error)
+	require.Len(t, entries, 1)
+	assert.Equal(t, domain.AuditActionImpersonateWrite, entries[0].Action)
+	assert.Equal(t, "editor-1", entries[0].ActorID)
+	assert.Equal(t, "root-1", entries[0].ImpersonatorID)
+	assert.Equal(t, "acme", entries[0].TenantID)
+	assert.Contains(t, string(entries[0].Details), `"path":"/pages"`)
+}
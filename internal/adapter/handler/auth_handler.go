@@ -3,6 +3,7 @@ package handler
 import (
 	"gohac/internal/adapter/database"
 	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
 	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
@@ -33,10 +34,11 @@ type LoginResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 	User    struct {
-		ID    string `json:"id"`
-		Name  string `json:"name"`
-		Email string `json:"email"`
-		Role  string `json:"role"`
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Role     string `json:"role"`
+		TenantID string `json:"tenant_id"`
 	} `json:"user"`
 }
 
@@ -67,9 +69,16 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		db = h.db
 	}
 
-	// Get user by email
+	// Get user by email within the current tenant
 	userRepo := repository.NewUserRepository(db)
-	user, err := userRepo.GetByEmail(c.Context(), req.Email)
+	tenantID := middleware.GetTenantID(c)
+	user, err := userRepo.GetByEmail(c.Context(), tenantID, req.Email)
+	if err != nil && tenantID != "" {
+		// Platform super-admins can sign in from any tenant
+		if platformUser, platformErr := userRepo.GetByEmail(c.Context(), "", req.Email); platformErr == nil && platformUser.IsSuperAdmin() {
+			user, err = platformUser, nil
+		}
+	}
 	if err != nil {
 		// Don't reveal if user exists or not (security best practice)
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
@@ -86,8 +95,13 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	// Generate JWT token (valid for 24 hours)
-	token, err := middleware.GenerateToken(user.ID.String(), user.Email, 24)
+	// Generate JWT token bound to the user's tenant (valid for 24 hours)
+	token, err := middleware.IssueToken(&middleware.Claims{
+		UserID:   user.ID.String(),
+		Email:    user.Email,
+		TenantID: user.TenantID,
+		Role:     string(user.Role),
+	}, 24)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to generate token",
@@ -95,19 +109,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	// Create HTTP-only cookie (Secure: false for localhost dev, SameSite: Lax)
-	cookie := &fiber.Cookie{
-		Name:     middleware.AuthTokenCookieName,
-		Value:    token,
-		Path:     "/",
-		MaxAge:   24 * 60 * 60, // 24 hours in seconds
-		HTTPOnly: true,
-		Secure:   false, // Set to false for localhost development
-		SameSite: "Lax", // Changed to Lax for better compatibility
-	}
-
-	// Set cookie
-	c.Cookie(cookie)
+	setAuthCookie(c, token, 24*60*60) // 24 hours in seconds
 
 	// Return success response
 	response := LoginResponse{
@@ -118,10 +120,30 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	response.User.Name = user.Name
 	response.User.Email = user.Email
 	response.User.Role = string(user.Role)
+	response.User.TenantID = user.TenantID
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
+// hasAdminRole reports whether the session's token carries an admin or super-admin role
+func hasAdminRole(c *fiber.Ctx) bool {
+	role, _ := c.Locals("user_role").(string)
+	return (&domain.User{Role: domain.UserRole(role)}).IsAdmin()
+}
+
+// setAuthCookie stores the JWT in an HTTP-only cookie (Secure: false for localhost dev, SameSite: Lax)
+func setAuthCookie(c *fiber.Ctx, token string, maxAge int) {
+	c.Cookie(&fiber.Cookie{
+		Name:     middleware.AuthTokenCookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HTTPOnly: true,
+		Secure:   false, // Set to false for localhost development
+		SameSite: "Lax", // Changed to Lax for better compatibility
+	})
+}
+
 // Me returns the current authenticated user's information
 // This endpoint is protected and requires valid JWT token
 func (h *AuthHandler) Me(c *fiber.Ctx) error {
@@ -167,14 +189,18 @@ func (h *AuthHandler) Me(c *fiber.Ctx) error {
 	}
 
 	// Return user info (excluding password)
+	// impersonator_id lets the admin panel show an impersonation banner
+	impersonatorID, _ := c.Locals("impersonator_id").(string)
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
 		"user": fiber.Map{
-			"id":    user.ID.String(),
-			"name":  user.Name,
-			"email": user.Email,
-			"role":  user.Role,
+			"id":        user.ID.String(),
+			"name":      user.Name,
+			"email":     user.Email,
+			"role":      user.Role,
+			"tenant_id": user.TenantID,
 		},
+		"impersonator_id": impersonatorID,
 	})
 }
 
@@ -293,3 +319,60 @@ func (h *AuthHandler) UpdateProfile(c *fiber.Ctx) error {
 		},
 	})
 }
+
+// StopImpersonation handles POST /api/auth/impersonate/stop (protected endpoint)
+// Swaps an impersonation token back for the super-admin's own token
+func (h *AuthHandler) StopImpersonation(c *fiber.Ctx) error {
+	impersonatorID, _ := c.Locals("impersonator_id").(string)
+	if impersonatorID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Not impersonating",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	userRepo := repository.NewUserRepository(db)
+	impersonator, err := userRepo.GetByID(c.Context(), impersonatorID)
+	if err != nil || !impersonator.IsSuperAdmin() {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Impersonator is no longer a super-admin",
+			"code":  fiber.StatusForbidden,
+		})
+	}
+
+	token, err := middleware.IssueToken(&middleware.Claims{
+		UserID:   impersonator.ID.String(),
+		Email:    impersonator.Email,
+		TenantID: impersonator.TenantID,
+		Role:     string(impersonator.Role),
+	}, 24)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to generate token",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	targetID, _ := c.Locals("user_id").(string)
+	targetTenantID, _ := c.Locals("user_tenant_id").(string)
+	recordAudit(c, db, targetTenantID, domain.AuditActionImpersonateStop, "user", targetID, nil)
+
+	setAuthCookie(c, token, 24*60*60)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Impersonation ended",
+		"user": fiber.Map{
+			"id":        impersonator.ID.String(),
+			"name":      impersonator.Name,
+			"email":     impersonator.Email,
+			"role":      impersonator.Role,
+			"tenant_id": impersonator.TenantID,
+		},
+	})
+}
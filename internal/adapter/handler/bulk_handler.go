@@ -0,0 +1,471 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BulkRequest represents the request body for changing many pages or posts at once
+// Items are selected by IDs or by a filter, not both
+type BulkRequest struct {
+	Action      string            `json:"action" validate:"required"` // publish, unpublish, archive, delete, set_category or set_author
+	IDs         []string          `json:"ids,omitempty"`
+	Filter      map[string]string `json:"filter,omitempty"`       // List endpoint filters without the filter[] wrapper, e.g. {"status": "draft", "created_at[lt]": "2024-01-01"}
+	Atomic      bool              `json:"atomic,omitempty"`       // Change every item or none; otherwise each item succeeds or fails on its own
+	CategoryIDs []string          `json:"category_ids,omitempty"` // set_category: the posts' new categories
+	AuthorID    string            `json:"author_id,omitempty"`    // set_author: the posts' new author
+}
+
+// BulkItemResult reports what a bulk call did to one item
+type BulkItemResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"` // "ok" or "failed"
+	Error  string `json:"error,omitempty"`
+}
+
+// bulkRefusal is an item the action cannot be applied to, reported to the client as is
+type bulkRefusal string
+
+func (r bulkRefusal) Error() string { return string(r) }
+
+// bulkApply changes one item through tx as part of the bulk action batchID and returns what to do
+// once the change is committed, such as tracking usage or notifying reviewers, or nil if there is nothing to do
+type bulkApply func(tx *gorm.DB, batchID string, id uuid.UUID) (func(), error)
+
+// BulkPages handles POST /api/v1/pages/bulk (protected endpoint)
+// Publishes, unpublishes, archives or deletes many pages; nested pages are deleted before their parents
+func (h *PageHandler) BulkPages(c *fiber.Ctx) error {
+	req, action, ok, err := parseBulkRequest(c)
+	if !ok {
+		return err
+	}
+	if !action.AppliesToPages() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Pages do not support the %s action", action),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+	tenantID := middleware.GetTenantID(c)
+	repo := repository.NewPageRepository(db)
+
+	ids, ok, err := bulkSelection(c, req, repoInterface.PageListSpec, func(filters []repoInterface.Filter) ([]uuid.UUID, int64, error) {
+		return repo.ListIDs(c.Context(), tenantID, filters, domain.BulkMaxItems)
+	})
+	if !ok {
+		return err
+	}
+
+	loaded, err := repo.ListByIDs(c.Context(), tenantID, ids)
+	if err != nil {
+		log.Printf("Error loading pages for bulk %s: %v", action, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to load pages",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	pages := make(map[uuid.UUID]*domain.Page, len(loaded))
+	for _, page := range loaded {
+		pages[page.ID] = page
+	}
+	if action == domain.BulkActionDelete {
+		// Children first, so a page and its nested pages can be deleted together
+		sort.SliceStable(ids, func(i, j int) bool {
+			return pageDepth(pages[ids[i]]) > pageDepth(pages[ids[j]])
+		})
+	}
+
+	settings := tenantSettings(c, db)
+	role, _ := c.Locals("user_role").(string)
+
+	return runBulk(c, db, "page", action, ids, req.Atomic, nil, func(tx *gorm.DB, batchID string, id uuid.UUID) (func(), error) {
+		page, found := pages[id]
+		if !found {
+			return nil, bulkRefusal("Page not found")
+		}
+		txRepo := repository.NewPageRepository(tx).As(actorID(c)).InBatch(batchID)
+
+		if action == domain.BulkActionDelete {
+			if err := txRepo.Delete(c.Context(), id); err != nil {
+				return nil, err
+			}
+			return func() {
+				trackUsage(c, db, page.TenantID, domain.UsageMetricPages, -1)
+			}, nil
+		}
+
+		from, to := string(page.Status), action.TargetStatus()
+		if !settings.Workflow.Allows(from, to, domain.UserRole(role)) {
+			return nil, bulkRefusal(fmt.Sprintf("Your role cannot move content from '%s' to '%s'", from, to))
+		}
+		page.Status = domain.PageStatus(to)
+		switch {
+		case page.Status == domain.PageStatusDraft:
+			page.PublishedAt = nil
+		case page.Status == domain.PageStatusPublished && from != to:
+			now := time.Now()
+			page.PublishedAt = &now
+		}
+		if err := txRepo.Update(c.Context(), page); err != nil {
+			return nil, err
+		}
+		if !settings.Workflow.IsEnabled() || from == to {
+			return nil, nil
+		}
+		return func() {
+			recordTransition(c, db, settings, domain.ReviewEntityPage, page.ID, page.Title, from, to, "")
+		}, nil
+	})
+}
+
+// BulkPosts handles POST /api/v1/posts/bulk (protected endpoint)
+// Publishes, unpublishes, archives or deletes many posts, or sets their categories or author
+func (h *PostHandler) BulkPosts(c *fiber.Ctx) error {
+	req, action, ok, err := parseBulkRequest(c)
+	if !ok {
+		return err
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+	tenantID := middleware.GetTenantID(c)
+	repo := repository.NewPostRepository(db)
+
+	// The new categories or author must exist before any post is touched
+	var categories []domain.Category
+	var author *domain.User
+	details := fiber.Map{}
+	switch action {
+	case domain.BulkActionSetCategory:
+		categories, ok, err = bulkCategories(c, db, tenantID, req.CategoryIDs)
+		if !ok {
+			return err
+		}
+		details["category_ids"] = req.CategoryIDs
+	case domain.BulkActionSetAuthor:
+		author, err = repository.NewUserRepository(db).GetByID(c.Context(), req.AuthorID)
+		if err != nil || author.TenantID != tenantID {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "author_id must name a user of this site",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		details["author_id"] = author.ID.String()
+	}
+
+	ids, ok, err := bulkSelection(c, req, repoInterface.PostListSpec, func(filters []repoInterface.Filter) ([]uuid.UUID, int64, error) {
+		return repo.ListIDs(c.Context(), tenantID, filters, domain.BulkMaxItems)
+	})
+	if !ok {
+		return err
+	}
+
+	loaded, err := repo.ListByIDs(c.Context(), tenantID, ids)
+	if err != nil {
+		log.Printf("Error loading posts for bulk %s: %v", action, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to load posts",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	posts := make(map[uuid.UUID]*domain.Post, len(loaded))
+	for _, post := range loaded {
+		posts[post.ID] = post
+	}
+
+	settings := tenantSettings(c, db)
+	role, _ := c.Locals("user_role").(string)
+
+	return runBulk(c, db, "post", action, ids, req.Atomic, details, func(tx *gorm.DB, batchID string, id uuid.UUID) (func(), error) {
+		post, found := posts[id]
+		if !found {
+			return nil, bulkRefusal("Post not found")
+		}
+		txRepo := repository.NewPostRepository(tx).As(actorID(c)).InBatch(batchID)
+
+		if action == domain.BulkActionDelete {
+			if err := txRepo.Delete(c.Context(), id); err != nil {
+				return nil, err
+			}
+			return func() {
+				trackUsage(c, db, post.TenantID, domain.UsageMetricPosts, -1)
+			}, nil
+		}
+
+		from, to := string(post.Status), string(post.Status)
+		switch action {
+		case domain.BulkActionSetCategory, domain.BulkActionSetAuthor:
+			// Edits like any other: published posts stay out of reach and approved ones go back to review
+			edited, ok := reviewedEditStatus(c, settings, from)
+			if !ok {
+				return nil, bulkRefusal(reviewedEditRefusal(from))
+			}
+			to = edited
+			post.Status = domain.PostStatus(to)
+			if action == domain.BulkActionSetCategory {
+				post.Categories = categories
+			} else {
+				post.AuthorID = author.ID
+				post.Author = *author
+			}
+		default:
+			to = action.TargetStatus()
+			if !settings.Workflow.Allows(from, to, domain.UserRole(role)) {
+				return nil, bulkRefusal(fmt.Sprintf("Your role cannot move content from '%s' to '%s'", from, to))
+			}
+			post.Status = domain.PostStatus(to)
+			switch {
+			case post.Status == domain.PostStatusDraft:
+				post.PublishedAt = nil
+			case post.Status == domain.PostStatusPublished && from != to:
+				now := time.Now()
+				post.PublishedAt = &now
+			}
+		}
+		if err := txRepo.Update(c.Context(), post); err != nil {
+			return nil, err
+		}
+		if !settings.Workflow.IsEnabled() || from == to {
+			return nil, nil
+		}
+		return func() {
+			recordTransition(c, db, settings, domain.ReviewEntityPost, post.ID, post.Title, from, to, "")
+		}, nil
+	})
+}
+
+// parseBulkRequest parses and checks the body of a bulk call
+// ok is false if the response has already been written
+func parseBulkRequest(c *fiber.Ctx) (*BulkRequest, domain.BulkAction, bool, error) {
+	var req BulkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, "", false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	action := domain.BulkAction(strings.ToLower(req.Action))
+	message := ""
+	switch {
+	case !action.IsValid():
+		message = "action must be one of publish, unpublish, archive, delete, set_category or set_author"
+	case (len(req.IDs) == 0) == (len(req.Filter) == 0):
+		message = "Select items with either ids or filter"
+	case len(req.IDs) > domain.BulkMaxItems:
+		message = fmt.Sprintf("At most %d items can be changed at once", domain.BulkMaxItems)
+	case action == domain.BulkActionSetCategory && req.CategoryIDs == nil:
+		message = "category_ids is required for set_category"
+	case action == domain.BulkActionSetAuthor && req.AuthorID == "":
+		message = "author_id is required for set_author"
+	}
+	if message != "" {
+		return nil, "", false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": message,
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	return &req, action, true, nil
+}
+
+// bulkSelection returns the IDs a bulk call applies to, from its ids or by running its filter
+// Filters matching more than domain.BulkMaxItems items are refused rather than applied in part
+func bulkSelection(c *fiber.Ctx, req *BulkRequest, spec repoInterface.ListSpec, match func([]repoInterface.Filter) ([]uuid.UUID, int64, error)) ([]uuid.UUID, bool, error) {
+	if len(req.IDs) > 0 {
+		ids := make([]uuid.UUID, 0, len(req.IDs))
+		seen := make(map[uuid.UUID]bool, len(req.IDs))
+		for _, idStr := range req.IDs {
+			id, err := uuid.Parse(idStr)
+			if err != nil {
+				return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": fmt.Sprintf("Invalid ID %q", idStr),
+					"code":  fiber.StatusBadRequest,
+				})
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids, true, nil
+	}
+
+	params := make(map[string]string, len(req.Filter))
+	for key, value := range req.Filter {
+		if name, op, hasOp := strings.Cut(key, "["); hasOp {
+			params["filter["+name+"]["+op] = value
+		} else {
+			params["filter["+key+"]"] = value
+		}
+	}
+	q, ok, err := parseListQuery(c, params, spec)
+	if !ok {
+		return nil, false, err
+	}
+
+	ids, total, err := match(q.Filters)
+	if err != nil {
+		log.Printf("Error selecting items for bulk action: %v", err)
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to select items",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	if total > domain.BulkMaxItems {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("The filter matches %d items; at most %d can be changed at once", total, domain.BulkMaxItems),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	return ids, true, nil
+}
+
+// bulkCategories loads the categories of a set_category call, which must all belong to the tenant
+// ok is false if the response has already been written
+func bulkCategories(c *fiber.Ctx, db *gorm.DB, tenantID string, idStrs []string) ([]domain.Category, bool, error) {
+	repo := repository.NewCategoryRepository(db)
+	categories := make([]domain.Category, 0, len(idStrs))
+	for _, idStr := range idStrs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid category ID format",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		category, err := repo.GetByID(c.Context(), id)
+		if err != nil || category.TenantID != tenantID {
+			return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Category %s not found", id),
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		categories = append(categories, *category)
+	}
+	return categories, true, nil
+}
+
+// runBulk applies a bulk action to every item and writes the response
+// An atomic call runs in one transaction and changes nothing if any item fails; otherwise every item
+// is applied and reported on its own. Each changed item gets an audit entry, tied together by a batch ID;
+// the domain events of the changes carry the batch ID too, so the audit log does not record them again
+func runBulk(c *fiber.Ctx, db *gorm.DB, entityType string, action domain.BulkAction, ids []uuid.UUID, atomic bool, details fiber.Map, apply bulkApply) error {
+	batchID := uuid.New().String()
+	results := make([]BulkItemResult, 0, len(ids))
+	var committed []func()
+	succeeded, failed := 0, 0
+
+	if atomic {
+		var failedID uuid.UUID
+		var failure error
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for _, id := range ids {
+				done, err := apply(tx, batchID, id)
+				if err != nil {
+					failedID, failure = id, err
+					return err
+				}
+				committed = append(committed, done)
+			}
+			return nil
+		})
+		if err != nil {
+			if failure == nil {
+				failure = err
+			}
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "No items were changed because one of them failed",
+				"code":    fiber.StatusConflict,
+				"results": []BulkItemResult{bulkFailure(failedID, entityType, action, failure)},
+			})
+		}
+		for _, id := range ids {
+			results = append(results, BulkItemResult{ID: id.String(), Status: "ok"})
+		}
+		succeeded = len(ids)
+	} else {
+		for _, id := range ids {
+			done, err := apply(db, batchID, id)
+			if err != nil {
+				results = append(results, bulkFailure(id, entityType, action, err))
+				failed++
+				continue
+			}
+			committed = append(committed, done)
+			results = append(results, BulkItemResult{ID: id.String(), Status: "ok"})
+			succeeded++
+		}
+	}
+
+	for _, done := range committed {
+		if done != nil {
+			done()
+		}
+	}
+
+	tenantID := middleware.GetTenantID(c)
+	for _, result := range results {
+		if result.Status != "ok" {
+			continue
+		}
+		entry := fiber.Map{"batch_id": batchID}
+		for key, value := range details {
+			entry[key] = value
+		}
+		recordAudit(c, db, tenantID, action.AuditAction(), entityType, result.ID, entry)
+	}
+
+	return c.JSON(fiber.Map{
+		"action":    action,
+		"atomic":    atomic,
+		"batch_id":  batchID,
+		"succeeded": succeeded,
+		"failed":    failed,
+		"results":   results,
+	})
+}
+
+// bulkFailure reports an item a bulk action failed on
+func bulkFailure(id uuid.UUID, entityType string, action domain.BulkAction, err error) BulkItemResult {
+	var refusal bulkRefusal
+	message := "Failed to " + strings.ReplaceAll(string(action), "_", " ") + " " + entityType
+	switch {
+	case errors.As(err, &refusal):
+		message = string(refusal)
+	case errors.Is(err, domain.ErrPageHasChildren):
+		message = "Page has child pages. Move or delete them first"
+	case errors.Is(err, domain.ErrVersionConflict):
+		message = "Changed by someone else in the meantime"
+	default:
+		log.Printf("Error applying bulk %s to %s %s: %v", action, entityType, id, err)
+	}
+	return BulkItemResult{ID: id.String(), Status: "failed", Error: message}
+}
+
+// pageDepth is the nesting level of a page, 0 for top-level pages and unknown ones
+func pageDepth(page *domain.Page) int {
+	if page == nil {
+		return 0
+	}
+	return strings.Count(page.Slug, "/")
+}
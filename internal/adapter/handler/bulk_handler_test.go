@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkHandler_PostsAndPages(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	author := &domain.User{Name: "Author", Email: "author@test.com", Password: "x", Role: domain.UserRoleEditor}
+	require.NoError(t, db.Create(author).Error)
+	news := &domain.Category{Name: "News", Slug: "news"}
+	require.NoError(t, db.Create(news).Error)
+
+	draftA := &domain.Post{Title: "A", Slug: "a", Status: domain.PostStatusDraft}
+	draftB := &domain.Post{Title: "B", Slug: "b", Status: domain.PostStatusDraft}
+	archived := &domain.Post{Title: "C", Slug: "c", Status: domain.PostStatusArchived}
+	for _, post := range []*domain.Post{draftA, draftB, archived} {
+		require.NoError(t, db.Omit("Author").Create(post).Error)
+	}
+
+	parent := &domain.Page{Title: "Parent", Slug: "parent"}
+	child := &domain.Page{Title: "Child", Slug: "parent/child"}
+	other := &domain.Page{Title: "Other", Slug: "other"}
+	require.NoError(t, db.Create(parent).Error)
+	child.ParentID = &parent.ID
+	require.NoError(t, db.Create(child).Error)
+	require.NoError(t, db.Create(other).Error)
+
+	posts := NewPostHandler(db)
+	pages := NewPageHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/posts/bulk", posts.BulkPosts)
+	app.Post("/api/v1/pages/bulk", pages.BulkPages)
+
+	type response struct {
+		Succeeded int              `json:"succeeded"`
+		Failed    int              `json:"failed"`
+		BatchID   string           `json:"batch_id"`
+		Results   []BulkItemResult `json:"results"`
+	}
+	send := func(path string, body interface{}) (int, response) {
+		resp := app.send(http.MethodPost, path, body)
+		var result response
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result
+	}
+
+	// Posts selected by a filter are published, each with an audit entry from the same batch
+	status, result := send("/api/v1/posts/bulk", BulkRequest{Action: "publish", Filter: map[string]string{"status": "draft"}})
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, 2, result.Succeeded)
+	var published []domain.Post
+	require.NoError(t, db.Where("status = ?", domain.PostStatusPublished).Find(&published).Error)
+	require.Len(t, published, 2)
+	assert.NotNil(t, published[0].PublishedAt)
+	var batched int64
+	require.NoError(t, db.Model(&domain.OutboxEvent{}).Where("batch_id = ?", result.BatchID).Count(&batched).Error)
+	assert.NotZero(t, batched)
+
+	// The events of the batch do not log the publishing a second time
+	dispatchEvents(t, db)
+	var audits []domain.AuditLog
+	require.NoError(t, db.Find(&audits).Error)
+	require.Len(t, audits, 2)
+	for _, audit := range audits {
+		assert.Equal(t, "bulk.publish", audit.Action)
+		assert.Contains(t, string(audit.Details), result.BatchID)
+	}
+
+	// Unknown items fail on their own; the others are recategorized
+	missing := uuid.New()
+	status, result = send("/api/v1/posts/bulk", BulkRequest{
+		Action:      "set_category",
+		IDs:         []string{draftA.ID.String(), missing.String()},
+		CategoryIDs: []string{news.ID.String()},
+	})
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, []BulkItemResult{
+		{ID: draftA.ID.String(), Status: "ok"},
+		{ID: missing.String(), Status: "failed", Error: "Post not found"},
+	}, result.Results)
+	var reloaded domain.Post
+	require.NoError(t, db.Preload("Categories").First(&reloaded, "id = ?", draftA.ID).Error)
+	require.Len(t, reloaded.Categories, 1)
+	assert.Equal(t, news.ID, reloaded.Categories[0].ID)
+
+	status, _ = send("/api/v1/posts/bulk", BulkRequest{Action: "set_author", IDs: []string{archived.ID.String()}, AuthorID: author.ID.String()})
+	require.Equal(t, fiber.StatusOK, status)
+	var reassigned domain.Post
+	require.NoError(t, db.First(&reassigned, "id = ?", archived.ID).Error)
+	assert.Equal(t, author.ID, reassigned.AuthorID)
+
+	// An atomic call changes nothing when one item fails: the parent still has a child
+	status, result = send("/api/v1/pages/bulk", BulkRequest{Action: "delete", IDs: []string{other.ID.String(), parent.ID.String()}, Atomic: true})
+	require.Equal(t, fiber.StatusConflict, status)
+	require.Len(t, result.Results, 1)
+	assert.Equal(t, parent.ID.String(), result.Results[0].ID)
+	var remaining int64
+	require.NoError(t, db.Model(&domain.Page{}).Count(&remaining).Error)
+	assert.Equal(t, int64(3), remaining)
+
+	// Nested pages are deleted before their parents
+	status, result = send("/api/v1/pages/bulk", BulkRequest{Action: "delete", IDs: []string{parent.ID.String(), child.ID.String()}, Atomic: true})
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, 2, result.Succeeded)
+	require.NoError(t, db.Model(&domain.Page{}).Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining)
+
+	for _, req := range []BulkRequest{
+		{Action: "set_author", IDs: []string{other.ID.String()}, AuthorID: author.ID.String()},
+		{Action: "explode", IDs: []string{other.ID.String()}},
+		{Action: "publish"},
+		{Action: "publish", IDs: []string{other.ID.String()}, Filter: map[string]string{"status": "draft"}},
+		{Action: "publish", Filter: map[string]string{"status": "gone"}},
+	} {
+		status, _ := send("/api/v1/pages/bulk", req)
+		assert.Equal(t, fiber.StatusBadRequest, status, req)
+	}
+}
+
+func TestBulkHandler_PostEditsFollowTheWorkflow(t *testing.T) {
+	db := setupHandlerDB(t)
+	require.NoError(t, repository.NewSettingsRepository(db).UpdateGlobalSettings(context.Background(), "",
+		&domain.GlobalSettings{Workflow: &domain.WorkflowSettings{Enabled: true}}))
+
+	editor := &domain.User{Name: "Ed", Email: "ed@example.com", Password: "x", Role: domain.UserRoleEditor}
+	require.NoError(t, db.Create(editor).Error)
+	news := &domain.Category{Name: "News", Slug: "news"}
+	require.NoError(t, db.Create(news).Error)
+	published := &domain.Post{Title: "Live", Slug: "live", Status: domain.PostStatusPublished}
+	approved := &domain.Post{Title: "Approved", Slug: "approved", Status: domain.PostStatusApproved}
+	draft := &domain.Post{Title: "Draft", Slug: "draft", Status: domain.PostStatusDraft}
+	for _, post := range []*domain.Post{published, approved, draft} {
+		require.NoError(t, db.Omit("Author").Create(post).Error)
+	}
+
+	posts := NewPostHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/posts/bulk", posts.BulkPosts)
+	ids := []string{published.ID.String(), approved.ID.String(), draft.ID.String()}
+	status := func(post *domain.Post) domain.PostStatus {
+		var stored domain.Post
+		require.NoError(t, db.First(&stored, "id = ?", post.ID).Error)
+		return stored.Status
+	}
+
+	for _, req := range []BulkRequest{
+		{Action: "set_category", IDs: ids, CategoryIDs: []string{news.ID.String()}},
+		{Action: "set_author", IDs: ids, AuthorID: editor.ID.String()},
+	} {
+		// Editors cannot change published posts, and their changes send approved posts back to review
+		resp := app.send(http.MethodPost, "/api/v1/posts/bulk", req, asUser(editor))
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var result struct {
+			Succeeded int              `json:"succeeded"`
+			Results   []BulkItemResult `json:"results"`
+		}
+		decodeJSON(t, resp, &result)
+		assert.Equal(t, 2, result.Succeeded, req.Action)
+		assert.Equal(t, BulkItemResult{ID: published.ID.String(), Status: "failed",
+			Error: "Your role cannot change content that is 'published'; move it back to draft first"}, result.Results[0])
+		assert.Equal(t, domain.PostStatusPublished, status(published))
+		assert.Equal(t, domain.PostStatusInReview, status(approved))
+		assert.Equal(t, domain.PostStatusDraft, status(draft))
+
+		require.NoError(t, db.Model(&domain.Post{}).Where("id = ?", approved.ID).Update("status", domain.PostStatusApproved).Error)
+	}
+
+	var categorized int64
+	require.NoError(t, db.Table("post_categories").Where("post_id = ?", published.ID).Count(&categorized).Error)
+	assert.Zero(t, categorized)
+	var reviews int64
+	require.NoError(t, db.Model(&domain.ReviewComment{}).Where("entity_id = ?", approved.ID).Count(&reviews).Error)
+	assert.Equal(t, int64(2), reviews)
+
+	// Reviewers edit published posts as before
+	resp := app.send(http.MethodPost, "/api/v1/posts/bulk", BulkRequest{Action: "set_category", IDs: ids, CategoryIDs: []string{news.ID.String()}},
+		asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, domain.PostStatusPublished, status(published))
+	assert.Equal(t, domain.PostStatusApproved, status(approved))
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gohac/config"
+	"gohac/internal/adapter/bundle"
+	"gohac/internal/adapter/database"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BundleHandler handles tenant export and import
+type BundleHandler struct {
+	db           *gorm.DB
+	mediaDir     string
+	mediaBaseURL string
+}
+
+// NewBundleHandler creates a new bundle handler instance
+func NewBundleHandler(db *gorm.DB) *BundleHandler {
+	mediaDir, mediaBaseURL := mediaStorage()
+	return &BundleHandler{
+		db:           db,
+		mediaDir:     mediaDir,
+		mediaBaseURL: mediaBaseURL,
+	}
+}
+
+// mediaStorage returns the upload directory and its public URL prefix
+// Same storage layout as UploadHandler
+func mediaStorage() (string, string) {
+	storagePath := os.Getenv("STORAGE_PATH")
+	if storagePath == "" {
+		storagePath = "./storage"
+	}
+	baseURL := os.Getenv("STORAGE_BASE_URL")
+	if baseURL == "" {
+		baseURL = "/uploads"
+	}
+	return filepath.Join(storagePath, "uploads"), baseURL
+}
+
+// Export handles GET /api/v1/export (protected endpoint, admin only)
+// Streams the current tenant as a zip archive
+func (h *BundleHandler) Export(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Admin role required",
+			"code":  fiber.StatusForbidden,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tenantID := middleware.GetTenantID(c)
+
+	// Media is not partitioned by tenant, so enterprise exports only what the tenant references
+	var buf bytes.Buffer
+	_, err = bundle.Export(c.Context(), db, &buf, bundle.ExportOptions{
+		TenantID:     tenantID,
+		MediaDir:     h.mediaDir,
+		MediaBaseURL: h.mediaBaseURL,
+		AllMedia:     !config.SupportsMultiTenancy(),
+	})
+	if err != nil {
+		log.Printf("Error exporting tenant %q: %v", tenantID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to export site",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	recordAudit(c, db, tenantID, domain.AuditActionSiteExport, "tenant", tenantID, nil)
+
+	name := tenantID
+	if name == "" {
+		name = "site"
+	}
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Attachment(fmt.Sprintf("%s-%s.zip", name, time.Now().UTC().Format("20060102-150405")))
+	return c.Send(buf.Bytes())
+}
+
+// Import handles POST /api/v1/import?dry_run=true (protected endpoint, admin only)
+// Expects the archive in the multipart "file" field and returns the import report
+func (h *BundleHandler) Import(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Admin role required",
+			"code":  fiber.StatusForbidden,
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "No archive provided",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to open archive",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	defer file.Close()
+
+	b, err := bundle.Read(file, fileHeader.Size)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	opts := bundle.ImportOptions{
+		TenantID:     middleware.GetTenantID(c),
+		MediaDir:     h.mediaDir,
+		MediaBaseURL: h.mediaBaseURL,
+		DryRun:       true,
+	}
+	// Posts whose author is not in the bundle are assigned to the importing user
+	if userID, ok := c.Locals("user_id").(string); ok {
+		opts.ActorID = userID
+		if id, err := uuid.Parse(userID); err == nil {
+			opts.DefaultAuthorID = id
+		}
+	}
+
+	// Always dry-run first: it validates the archive before anything is written
+	report, err := bundle.Import(c.Context(), db, b, opts)
+	if err != nil {
+		log.Printf("Error importing archive (dry run): %v", err)
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error": "Import failed: " + err.Error(),
+			"code":  fiber.StatusUnprocessableEntity,
+		})
+	}
+	if c.QueryBool("dry_run") {
+		return c.JSON(report)
+	}
+
+	// The import reserves what it creates against the plan in its own transaction
+	opts.Limits, err = planLimits(c.Context(), db, opts.TenantID)
+	if err != nil {
+		log.Printf("Error checking quota for import: %v", err)
+	}
+
+	opts.DryRun = false
+	report, err = bundle.Import(c.Context(), db, b, opts)
+	if err != nil {
+		var quotaErr *domain.QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			return quotaExceeded(c, quotaErr)
+		}
+		log.Printf("Error importing archive: %v", err)
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error": "Import failed: " + err.Error(),
+			"code":  fiber.StatusUnprocessableEntity,
+		})
+	}
+
+	recordAudit(c, db, opts.TenantID, domain.AuditActionSiteImport, "tenant", opts.TenantID, fiber.Map{
+		"source_tenant_id": b.Manifest.TenantID,
+		"exported_at":      b.Manifest.ExportedAt,
+		"created":          report.Created,
+	})
+
+	return c.JSON(report)
+}
@@ -1,13 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"log"
-	"strconv"
 	"strings"
 
 	"gohac/internal/adapter/database"
 	"gohac/internal/adapter/repository"
 	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -31,13 +33,15 @@ type CreateCategoryRequest struct {
 	Name        string `json:"name" validate:"required"`
 	Slug        string `json:"slug" validate:"required"`
 	Description string `json:"description"`
+	ParentID    string `json:"parent_id,omitempty"` // UUID of the parent category; the slug becomes a segment under its path
 }
 
 // UpdateCategoryRequest represents the request body for updating a category
 type UpdateCategoryRequest struct {
-	Name        string `json:"name,omitempty"`
-	Slug        string `json:"slug,omitempty"`
-	Description string `json:"description,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Slug        string  `json:"slug,omitempty"`
+	Description string  `json:"description,omitempty"`
+	ParentID    *string `json:"parent_id,omitempty"` // UUID of the new parent category, or "" to make the category top-level
 }
 
 // CreateCategory handles POST /api/v1/categories (protected endpoint)
@@ -64,9 +68,25 @@ func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
 	}
 
 	categoryRepo := repository.NewCategoryRepository(db)
+	tenantID := middleware.GetTenantID(c)
+
+	category := &domain.Category{
+		TenantID:    tenantID,
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+	}
+	if req.ParentID != "" {
+		parent, err := resolveParentCategory(c, categoryRepo, category, req.ParentID)
+		if err != nil {
+			return invalidParentCategory(c, err)
+		}
+		category.ParentID = &parent.ID
+		category.Slug = domain.PagePath(parent.Slug, domain.PageSegment(req.Slug))
+	}
 
 	// Check if slug already exists
-	_, err = categoryRepo.GetBySlug(c.Context(), req.Slug)
+	_, err = categoryRepo.GetBySlug(c.Context(), tenantID, category.Slug)
 	if err == nil {
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 			"error": "Category with this slug already exists",
@@ -74,13 +94,13 @@ func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	category := &domain.Category{
-		Name:        req.Name,
-		Slug:        req.Slug,
-		Description: req.Description,
-	}
-
 	if err := categoryRepo.Create(c.Context(), category); err != nil {
+		if errors.Is(err, domain.ErrCategoryAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Category with this slug already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		log.Printf("Error creating category: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to create category",
@@ -92,7 +112,13 @@ func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
 }
 
 // ListCategories handles GET /api/v1/categories (protected endpoint)
+// Accepts the filters, sorts and paging of repository.CategoryListSpec
 func (h *CategoryHandler) ListCategories(c *fiber.Ctx) error {
+	q, ok, err := parseListQuery(c, c.Queries(), repoInterface.CategoryListSpec)
+	if !ok {
+		return err
+	}
+
 	db, err := database.GetDBFromContext(c.Context())
 	if err != nil {
 		db = h.db
@@ -100,33 +126,17 @@ func (h *CategoryHandler) ListCategories(c *fiber.Ctx) error {
 
 	categoryRepo := repository.NewCategoryRepository(db)
 
-	limit, _ := strconv.Atoi(c.Query("limit", "100"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
-
-	categories, total, err := categoryRepo.List(c.Context(), limit, offset)
+	categories, page, err := categoryRepo.List(c.Context(), middleware.GetTenantID(c), q)
 	if err != nil {
 		log.Printf("Error listing categories: %v", err)
 		// If table doesn't exist, return empty list instead of error
 		if strings.Contains(err.Error(), "no such table") || strings.Contains(err.Error(), "doesn't exist") {
-			return c.JSON(fiber.Map{
-				"data":   []*domain.Category{},
-				"total":  0,
-				"limit":  limit,
-				"offset": offset,
-			})
+			return respondList(c, []*domain.Category{}, q, page)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to list categories",
-			"code":  fiber.StatusInternalServerError,
-		})
+		return listFailed(c, err, "Failed to list categories")
 	}
 
-	return c.JSON(fiber.Map{
-		"data":   categories,
-		"total":  total,
-		"limit":  limit,
-		"offset": offset,
-	})
+	return respondList(c, categories, q, page)
 }
 
 // GetCategory handles GET /api/v1/categories/:id (protected endpoint)
@@ -204,26 +214,67 @@ func (h *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
 		})
 	}
 
+	oldSlug := category.Slug
+
+	// Moving a category or renaming a subcategory recomputes its path from the parent's
+	var parent *domain.Category
+	if req.ParentID != nil && *req.ParentID != "" {
+		if parent, err = resolveParentCategory(c, categoryRepo, category, *req.ParentID); err != nil {
+			return invalidParentCategory(c, err)
+		}
+		category.ParentID = &parent.ID
+	} else if req.ParentID != nil {
+		category.ParentID = nil
+	} else if category.ParentID != nil && req.Slug != "" {
+		if parent, err = categoryRepo.GetByID(c.Context(), *category.ParentID); err != nil {
+			log.Printf("Error getting parent category: %v", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to get parent category",
+				"code":  fiber.StatusInternalServerError,
+			})
+		}
+	}
+
 	// Update fields
 	if req.Name != "" {
 		category.Name = req.Name
 	}
-	if req.Slug != "" {
+	if req.Slug != "" || req.ParentID != nil {
+		slug := category.Slug
+		if req.Slug != "" {
+			slug = req.Slug
+		}
+		switch {
+		case parent != nil:
+			category.Slug = domain.PagePath(parent.Slug, domain.PageSegment(slug))
+		case req.ParentID != nil:
+			category.Slug = domain.PageSegment(slug)
+		default:
+			category.Slug = slug
+		}
+
 		// Check if new slug already exists for another category
-		existingCategory, err := categoryRepo.GetBySlug(c.Context(), req.Slug)
+		existingCategory, err := categoryRepo.GetBySlug(c.Context(), category.TenantID, category.Slug)
 		if err == nil && existingCategory.ID != category.ID {
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 				"error": "Category with this slug already exists",
 				"code":  fiber.StatusConflict,
 			})
 		}
-		category.Slug = req.Slug
 	}
 	if req.Description != "" || req.Description == "" {
 		category.Description = req.Description
 	}
 
-	if err := categoryRepo.Update(c.Context(), category); err != nil {
+	// Subcategories follow the category to its new path
+	moved, err := categoryRepo.UpdateWithDescendants(c.Context(), category, oldSlug)
+	if err != nil {
+		if errors.Is(err, domain.ErrCategoryAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Category with this slug already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		log.Printf("Error updating category: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update category",
@@ -231,10 +282,20 @@ func (h *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
 		})
 	}
 
+	// Old archive URLs keep working through permanent redirects
+	if category.Slug != oldSlug {
+		addSlugRedirect(c, db, category.TenantID, domain.CategoryPublicPath(oldSlug), domain.CategoryPublicPath(category.Slug))
+		for _, descendant := range moved {
+			oldPath := oldSlug + strings.TrimPrefix(descendant.Slug, category.Slug)
+			addSlugRedirect(c, db, descendant.TenantID, domain.CategoryPublicPath(oldPath), domain.CategoryPublicPath(descendant.Slug))
+		}
+	}
+
 	return c.JSON(category)
 }
 
 // DeleteCategory handles DELETE /api/v1/categories/:id (protected endpoint)
+// The category goes to the trash; categories with subcategories cannot be deleted
 func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := uuid.Parse(idParam)
@@ -252,6 +313,12 @@ func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
 
 	categoryRepo := repository.NewCategoryRepository(db)
 	if err := categoryRepo.Delete(c.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrCategoryHasChildren) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Category has subcategories. Move or delete them first",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		log.Printf("Error deleting category: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to delete category",
@@ -261,3 +328,82 @@ func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
+
+// GetCategoryTree handles GET /api/v1/categories/tree and GET /api/public/categories
+// Returns all categories nested under their parents, with the public path of each archive
+func (h *CategoryHandler) GetCategoryTree(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	categories, err := repository.NewCategoryRepository(db).ListAll(c.Context(), middleware.GetTenantID(c))
+	if err != nil {
+		log.Printf("Error listing categories: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list categories",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": domain.BuildCategoryTree(categories),
+	})
+}
+
+// resolveParentCategory loads the category that will become category's parent
+// The parent must share the category's tenant and must not be the category or one of its subcategories,
+// and the category and its subcategories must stay within domain.MaxCategoryDepth levels below it
+func resolveParentCategory(c *fiber.Ctx, repo repoInterface.CategoryRepository, category *domain.Category, parentIDStr string) (*domain.Category, error) {
+	parentID, err := uuid.Parse(parentIDStr)
+	if err != nil {
+		return nil, domain.ErrInvalidParentCategory
+	}
+	parent, err := repo.GetByID(c.Context(), parentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "category not found") {
+			return nil, domain.ErrInvalidParentCategory
+		}
+		return nil, err
+	}
+	if parent.TenantID != category.TenantID {
+		return nil, domain.ErrInvalidParentCategory
+	}
+
+	// A new category is a single level; a moved one brings its subcategories along
+	height := 1
+	if category.ID != uuid.Nil {
+		if height, err = repo.SubtreeHeight(c.Context(), category.TenantID, category.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Walk up from the parent; reaching the category itself would create a cycle
+	ancestor := parent
+	for depth := 1; ; depth++ {
+		if ancestor.ID == category.ID || depth+height > domain.MaxCategoryDepth {
+			return nil, domain.ErrInvalidParentCategory
+		}
+		if ancestor.ParentID == nil {
+			return parent, nil
+		}
+		if ancestor, err = repo.GetByID(c.Context(), *ancestor.ParentID); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// invalidParentCategory writes the response for a parent category that cannot be used
+func invalidParentCategory(c *fiber.Ctx, err error) error {
+	if errors.Is(err, domain.ErrInvalidParentCategory) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid parent category. It must exist and cannot be the category itself or one of its subcategories",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	log.Printf("Error resolving parent category: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to get parent category",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
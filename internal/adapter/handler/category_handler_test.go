@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryHandler_HierarchyAndPublicListing(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	categories := NewCategoryHandler(db)
+	posts := NewPostHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/categories", categories.CreateCategory)
+	app.Get("/api/v1/categories/tree", categories.GetCategoryTree)
+	app.Put("/api/v1/categories/:id", categories.UpdateCategory)
+	app.Delete("/api/v1/categories/:id", categories.DeleteCategory)
+	app.Get("/api/public/categories/*", posts.ListCategoryPostsPublic)
+
+	create := func(req CreateCategoryRequest) *domain.Category {
+		resp := app.send("POST", "/api/v1/categories", req)
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var category domain.Category
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&category))
+		return &category
+	}
+	listed := func(path string) (int, []string) {
+		resp := app.send("GET", "/api/public/categories/"+path, nil)
+		if resp.StatusCode != fiber.StatusOK {
+			return resp.StatusCode, nil
+		}
+		var body struct {
+			Data []domain.Post `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		var slugs []string
+		for _, post := range body.Data {
+			slugs = append(slugs, post.Slug)
+		}
+		return resp.StatusCode, slugs
+	}
+
+	// A subcategory's slug is a segment under its parent's path
+	tech := create(CreateCategoryRequest{Name: "Tech", Slug: "tech"})
+	golang := create(CreateCategoryRequest{Name: "Go", Slug: "go", ParentID: tech.ID.String()})
+	generics := create(CreateCategoryRequest{Name: "Generics", Slug: "generics", ParentID: golang.ID.String()})
+	travel := create(CreateCategoryRequest{Name: "Travel", Slug: "travel"})
+	assert.Equal(t, "tech/go", golang.Slug)
+	assert.Equal(t, "tech/go/generics", generics.Slug)
+
+	resp := app.send("GET", "/api/v1/categories/tree", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var tree struct {
+		Data []*domain.CategoryTreeNode `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&tree))
+	require.Len(t, tree.Data, 2)
+	require.Len(t, tree.Data[0].Children, 1)
+	assert.Equal(t, "/blog/category/tech/go", tree.Data[0].Children[0].Path)
+
+	// Published posts of a category and its subcategories are listed once each
+	post := func(slug string, status domain.PostStatus, in ...*domain.Category) {
+		p := &domain.Post{Title: slug, Slug: slug, Status: status}
+		for _, category := range in {
+			p.Categories = append(p.Categories, *category)
+		}
+		require.NoError(t, db.Omit("Author").Create(p).Error)
+	}
+	post("intro", domain.PostStatusPublished, tech)
+	post("modules", domain.PostStatusPublished, golang, tech)
+	post("constraints", domain.PostStatusPublished, generics)
+	post("unfinished", domain.PostStatusDraft, golang)
+	post("lisbon", domain.PostStatusPublished, travel)
+
+	status, slugs := listed("tech")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.ElementsMatch(t, []string{"intro", "modules", "constraints"}, slugs)
+	_, slugs = listed("tech/go")
+	assert.ElementsMatch(t, []string{"modules", "constraints"}, slugs)
+	status, _ = listed("nope")
+	assert.Equal(t, fiber.StatusNotFound, status)
+
+	// A category cannot move under its own subcategory, and one with subcategories cannot be deleted
+	resp = app.send("PUT", "/api/v1/categories/"+tech.ID.String(), fiber.Map{"parent_id": generics.ID.String()})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp = app.send("DELETE", "/api/v1/categories/"+tech.ID.String(), nil)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// Moving a category takes its subcategories along and redirects the old archive paths
+	resp = app.send("PUT", "/api/v1/categories/"+golang.ID.String(), fiber.Map{"name": "Go", "parent_id": travel.ID.String()})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var moved domain.Category
+	require.NoError(t, db.First(&moved, "id = ?", generics.ID).Error)
+	assert.Equal(t, "travel/go/generics", moved.Slug)
+	_, slugs = listed("travel")
+	assert.ElementsMatch(t, []string{"lisbon", "modules", "constraints"}, slugs)
+
+	resp = app.send("GET", "/api/public/categories/tech/go/generics", nil)
+	require.Equal(t, fiber.StatusMovedPermanently, resp.StatusCode)
+	var redirect struct {
+		Redirect string `json:"redirect"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&redirect))
+	assert.Equal(t, "/blog/category/travel/go/generics", redirect.Redirect)
+
+	// Top-level again, keeping its own segment
+	resp = app.send("PUT", "/api/v1/categories/"+golang.ID.String(), fiber.Map{"name": "Go", "parent_id": ""})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var topLevel domain.Category
+	require.NoError(t, db.First(&topLevel, "id = ?", golang.ID).Error)
+	assert.Equal(t, "go", topLevel.Slug)
+	assert.Nil(t, topLevel.ParentID)
+
+	// A move may not push the category's subcategories past the depth limit
+	parent := create(CreateCategoryRequest{Name: "Level 1", Slug: "level-1"})
+	for depth := 2; depth < domain.MaxCategoryDepth; depth++ {
+		parent = create(CreateCategoryRequest{Name: "Level", Slug: "level", ParentID: parent.ID.String()})
+	}
+	resp = app.send("PUT", "/api/v1/categories/"+golang.ID.String(), fiber.Map{"name": "Go", "parent_id": parent.ID.String()})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	leaf := create(CreateCategoryRequest{Name: "Leaf", Slug: "leaf"})
+	resp = app.send("PUT", "/api/v1/categories/"+leaf.ID.String(), fiber.Map{"name": "Leaf", "parent_id": parent.ID.String()})
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
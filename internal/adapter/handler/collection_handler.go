@@ -0,0 +1,680 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// itemFilterPattern matches item filter query keys: filter[field] or filter[field][op]
+var itemFilterPattern = regexp.MustCompile(`^filter\[([a-z0-9_]+)\](?:\[([a-z]+)\])?$`)
+
+// CollectionHandler handles collection and collection item HTTP requests
+type CollectionHandler struct {
+	db *gorm.DB
+}
+
+// NewCollectionHandler creates a new collection handler instance
+func NewCollectionHandler(db *gorm.DB) *CollectionHandler {
+	return &CollectionHandler{
+		db: db,
+	}
+}
+
+// CollectionRequest represents the request body for creating or updating a collection
+type CollectionRequest struct {
+	Slug        string                   `json:"slug"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Fields      []domain.CollectionField `json:"fields"`
+}
+
+// CollectionItemRequest represents the request body for creating or updating a collection item
+type CollectionItemRequest struct {
+	Slug   string                 `json:"slug"`
+	Status string                 `json:"status"`
+	Data   map[string]interface{} `json:"data"`
+}
+
+// ListCollections handles GET /api/v1/collections (protected endpoint)
+func (h *CollectionHandler) ListCollections(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	collections, err := repository.NewCollectionRepository(db).List(c.Context(), middleware.GetTenantID(c))
+	if err != nil {
+		log.Printf("Error listing collections: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list collections",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  collections,
+		"total": len(collections),
+	})
+}
+
+// CreateCollection handles POST /api/v1/collections (protected endpoint)
+func (h *CollectionHandler) CreateCollection(c *fiber.Ctx) error {
+	var req CollectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	if req.Name == "" || !domain.IsValidCollectionSlug(req.Slug) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Name and a slug of lowercase letters, digits and hyphens are required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	collection := &domain.Collection{
+		TenantID:    middleware.GetTenantID(c),
+		Slug:        req.Slug,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err := setCollectionFields(collection, req.Fields); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if err := repository.NewCollectionRepository(db).Create(c.Context(), collection); err != nil {
+		return collectionSaveFailed(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(collection)
+}
+
+// GetCollection handles GET /api/v1/collections/:type (protected endpoint)
+func (h *CollectionHandler) GetCollection(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	collection, err := repository.NewCollectionRepository(db).GetBySlug(c.Context(), middleware.GetTenantID(c), c.Params("type"))
+	if err != nil {
+		return collectionLookupFailed(c, err)
+	}
+
+	return c.JSON(collection)
+}
+
+// UpdateCollection handles PUT /api/v1/collections/:type (protected endpoint)
+// Existing items are not rewritten when the schema changes; they are validated again on their next update
+func (h *CollectionHandler) UpdateCollection(c *fiber.Ctx) error {
+	var req CollectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	repo := repository.NewCollectionRepository(db)
+	collection, err := repo.GetBySlug(c.Context(), middleware.GetTenantID(c), c.Params("type"))
+	if err != nil {
+		return collectionLookupFailed(c, err)
+	}
+
+	if req.Slug != "" {
+		if !domain.IsValidCollectionSlug(req.Slug) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Slug must contain only lowercase letters, digits and hyphens",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		collection.Slug = req.Slug
+	}
+	if req.Name != "" {
+		collection.Name = req.Name
+	}
+	if req.Description != "" {
+		collection.Description = req.Description
+	}
+	if req.Fields != nil {
+		if err := setCollectionFields(collection, req.Fields); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+
+	if err := repo.Update(c.Context(), collection); err != nil {
+		return collectionSaveFailed(c, err)
+	}
+
+	return c.JSON(collection)
+}
+
+// DeleteCollection handles DELETE /api/v1/collections/:type (protected endpoint)
+// Deletes the collection together with its items
+func (h *CollectionHandler) DeleteCollection(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	repo := repository.NewCollectionRepository(db)
+	collection, err := repo.GetBySlug(c.Context(), middleware.GetTenantID(c), c.Params("type"))
+	if err != nil {
+		return collectionLookupFailed(c, err)
+	}
+
+	if err := repo.Delete(c.Context(), collection.ID); err != nil {
+		log.Printf("Error deleting collection: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete collection",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// ListItems handles GET /api/v1/collections/:type/items (protected endpoint)
+// Supports ?status=, ?filter[field]=value, ?filter[field][op]=value, ?sort=field or -field, ?limit= and ?offset=
+func (h *CollectionHandler) ListItems(c *fiber.Ctx) error {
+	return h.listItems(c, c.Query("status"))
+}
+
+// ListItemsPublic handles GET /api/public/collections/:type (public endpoint)
+// Same query parameters as ListItems, restricted to published items
+func (h *CollectionHandler) ListItemsPublic(c *fiber.Ctx) error {
+	return h.listItems(c, string(domain.CollectionItemStatusPublished))
+}
+
+// listItems lists a collection's items filtered and sorted by the query parameters
+func (h *CollectionHandler) listItems(c *fiber.Ctx, status string) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	repo := repository.NewCollectionRepository(db)
+	collection, err := repo.GetBySlug(c.Context(), middleware.GetTenantID(c), c.Params("type"))
+	if err != nil {
+		return collectionLookupFailed(c, err)
+	}
+
+	opts, err := parseItemQuery(c, collection)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	opts.Status = status
+
+	items, total, err := repo.ListItems(c.Context(), opts)
+	if err != nil {
+		log.Printf("Error listing items of collection %s: %v", collection.Slug, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list items",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   items,
+		"total":  total,
+		"limit":  opts.Limit,
+		"offset": opts.Offset,
+	})
+}
+
+// CreateItem handles POST /api/v1/collections/:type/items (protected endpoint)
+func (h *CollectionHandler) CreateItem(c *fiber.Ctx) error {
+	var req CollectionItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	if req.Slug == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Slug is required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	repo := repository.NewCollectionRepository(db)
+	tenantID := middleware.GetTenantID(c)
+	collection, err := repo.GetBySlug(c.Context(), tenantID, c.Params("type"))
+	if err != nil {
+		return collectionLookupFailed(c, err)
+	}
+
+	item := &domain.CollectionItem{
+		TenantID:     tenantID,
+		CollectionID: collection.ID,
+		Slug:         req.Slug,
+		Status:       domain.CollectionItemStatusDraft,
+	}
+	if req.Status != "" {
+		if item.Status, err = parseItemStatus(req.Status); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+	if item.Status == domain.CollectionItemStatusPublished {
+		now := time.Now()
+		item.PublishedAt = &now
+	}
+	if err := setItemData(c, db, collection, item, req.Data); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	if err := repo.CreateItem(c.Context(), item); err != nil {
+		return itemSaveFailed(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(item)
+}
+
+// GetItem handles GET /api/v1/collections/:type/items/:id (protected endpoint)
+func (h *CollectionHandler) GetItem(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	_, item, err := h.getItem(c, db)
+	if err != nil {
+		return itemLookupFailed(c, err)
+	}
+
+	return c.JSON(item)
+}
+
+// UpdateItem handles PUT /api/v1/collections/:type/items/:id (protected endpoint)
+// Data, when given, replaces all field values and is validated against the current schema
+func (h *CollectionHandler) UpdateItem(c *fiber.Ctx) error {
+	var req CollectionItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	collection, item, err := h.getItem(c, db)
+	if err != nil {
+		return itemLookupFailed(c, err)
+	}
+
+	if req.Slug != "" {
+		item.Slug = req.Slug
+	}
+	if req.Status != "" {
+		status, err := parseItemStatus(req.Status)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		// Set published_at if transitioning to published
+		if status == domain.CollectionItemStatusPublished && item.Status != domain.CollectionItemStatusPublished {
+			now := time.Now()
+			item.PublishedAt = &now
+		}
+		item.Status = status
+	}
+	if req.Data != nil {
+		if err := setItemData(c, db, collection, item, req.Data); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+
+	if err := repository.NewCollectionRepository(db).UpdateItem(c.Context(), item); err != nil {
+		return itemSaveFailed(c, err)
+	}
+
+	return c.JSON(item)
+}
+
+// DeleteItem handles DELETE /api/v1/collections/:type/items/:id (protected endpoint)
+func (h *CollectionHandler) DeleteItem(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	_, item, err := h.getItem(c, db)
+	if err != nil {
+		return itemLookupFailed(c, err)
+	}
+
+	if err := repository.NewCollectionRepository(db).DeleteItem(c.Context(), item.ID); err != nil {
+		log.Printf("Error deleting collection item: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete item",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// GetItemPublic handles GET /api/public/collections/:type/:slug (public endpoint)
+// Only published items are returned
+func (h *CollectionHandler) GetItemPublic(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	repo := repository.NewCollectionRepository(db)
+	collection, err := repo.GetBySlug(c.Context(), middleware.GetTenantID(c), c.Params("type"))
+	if err != nil {
+		return collectionLookupFailed(c, err)
+	}
+
+	item, err := repo.GetItemBySlug(c.Context(), collection.ID, c.Params("slug"))
+	if err == nil && item.Status != domain.CollectionItemStatusPublished {
+		err = fmt.Errorf("collection item not found: %w", gorm.ErrRecordNotFound)
+	}
+	if err != nil {
+		return itemLookupFailed(c, err)
+	}
+
+	return c.JSON(item)
+}
+
+// getItem loads the collection and item named by the :type and :id route parameters
+// Items of other collections or tenants are reported as not found
+func (h *CollectionHandler) getItem(c *fiber.Ctx, db *gorm.DB) (*domain.Collection, *domain.CollectionItem, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("collection item not found: %w", err)
+	}
+
+	repo := repository.NewCollectionRepository(db)
+	collection, err := repo.GetBySlug(c.Context(), middleware.GetTenantID(c), c.Params("type"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	item, err := repo.GetItem(c.Context(), id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if item.CollectionID != collection.ID {
+		return nil, nil, fmt.Errorf("collection item not found: %w", gorm.ErrRecordNotFound)
+	}
+	return collection, item, nil
+}
+
+// setCollectionFields validates a schema and stores it on the collection
+func setCollectionFields(collection *domain.Collection, fields []domain.CollectionField) error {
+	if fields == nil {
+		fields = []domain.CollectionField{}
+	}
+	if err := domain.ValidateCollectionFields(fields); err != nil {
+		return err
+	}
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidCollectionSchema, err)
+	}
+	collection.Fields = datatypes.JSON(fieldsJSON)
+	return nil
+}
+
+// setItemData validates item values against the collection schema and stores them on the item
+// Reference fields must point to an item of the referenced collection in the same tenant
+func setItemData(c *fiber.Ctx, db *gorm.DB, collection *domain.Collection, item *domain.CollectionItem, data map[string]interface{}) error {
+	values, err := collection.ValidateItemData(data)
+	if err != nil {
+		return err
+	}
+
+	fields, _ := collection.Schema()
+	repo := repository.NewCollectionRepository(db)
+	for _, field := range fields {
+		ref, ok := values[field.Name].(string)
+		if field.Type != domain.FieldTypeReference || !ok {
+			continue
+		}
+		target, err := repo.GetBySlug(c.Context(), collection.TenantID, field.Collection)
+		if err != nil {
+			return fmt.Errorf("%w: %s references unknown collection %q", domain.ErrInvalidCollectionItem, field.Name, field.Collection)
+		}
+		refID, _ := uuid.Parse(ref)
+		referenced, err := repo.GetItem(c.Context(), refID)
+		if err != nil || referenced.CollectionID != target.ID {
+			return fmt.Errorf("%w: %s references an unknown %s item", domain.ErrInvalidCollectionItem, field.Name, field.Collection)
+		}
+	}
+
+	dataJSON, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidCollectionItem, err)
+	}
+	item.Data = datatypes.JSON(dataJSON)
+	return nil
+}
+
+// parseItemStatus validates a collection item status
+func parseItemStatus(value string) (domain.CollectionItemStatus, error) {
+	status := domain.CollectionItemStatus(strings.ToLower(value))
+	switch status {
+	case domain.CollectionItemStatusDraft, domain.CollectionItemStatusPublished, domain.CollectionItemStatusArchived:
+		return status, nil
+	}
+	return "", errors.New("invalid status. Must be 'draft', 'published', or 'archived'")
+}
+
+// parseItemQuery builds list options from the query string, checking filters and sorting against the schema
+func parseItemQuery(c *fiber.Ctx, collection *domain.Collection) (repoInterface.ListItemsOptions, error) {
+	opts := repoInterface.ListItemsOptions{CollectionID: collection.ID}
+	paging, err := repoInterface.ParseListQuery(pagingParams(c), repoInterface.ListSpec{DefaultLimit: 20})
+	if err != nil {
+		return opts, err
+	}
+	opts.Limit, opts.Offset = paging.Limit, paging.Offset
+
+	var parseErr error
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		match := itemFilterPattern.FindStringSubmatch(string(key))
+		if match == nil || parseErr != nil {
+			return
+		}
+		field, ok := collection.Field(match[1])
+		if !ok || !field.Filterable() {
+			parseErr = fmt.Errorf("cannot filter by %q", match[1])
+			return
+		}
+		filter, err := parseItemFilter(field, match[2], string(value))
+		if err != nil {
+			parseErr = err
+			return
+		}
+		opts.Filters = append(opts.Filters, filter)
+	})
+	if parseErr != nil {
+		return opts, parseErr
+	}
+
+	if sort := c.Query("sort"); sort != "" {
+		name := strings.TrimPrefix(sort, "-")
+		opts.SortDesc = name != sort
+		switch name {
+		case "created_at", "updated_at", "published_at", "slug":
+			opts.SortColumn = name
+		default:
+			field, ok := collection.Field(name)
+			if !ok || !field.Sortable() {
+				return opts, fmt.Errorf("cannot sort by %q", name)
+			}
+			opts.SortField = &field
+		}
+	}
+
+	return opts, nil
+}
+
+// parseItemFilter converts a query filter value to the field type
+func parseItemFilter(field domain.CollectionField, op, value string) (repoInterface.ItemFilter, error) {
+	filter := repoInterface.ItemFilter{Field: field, Op: repoInterface.ItemFilterOp(op)}
+	if op == "" {
+		filter.Op = repoInterface.ItemFilterEq
+	}
+
+	switch filter.Op {
+	case repoInterface.ItemFilterEq, repoInterface.ItemFilterNe:
+	case repoInterface.ItemFilterGt, repoInterface.ItemFilterGte, repoInterface.ItemFilterLt, repoInterface.ItemFilterLte:
+		if field.Type != domain.FieldTypeNumber && field.Type != domain.FieldTypeDate && field.Type != domain.FieldTypeText {
+			return filter, fmt.Errorf("cannot compare %q with %s", field.Name, op)
+		}
+	case repoInterface.ItemFilterContains:
+		if field.Type != domain.FieldTypeText && field.Type != domain.FieldTypeRichText {
+			return filter, fmt.Errorf("cannot search %q with contains", field.Name)
+		}
+	default:
+		return filter, fmt.Errorf("unknown filter operator %q", op)
+	}
+
+	switch field.Type {
+	case domain.FieldTypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return filter, fmt.Errorf("filter on %q must be a number", field.Name)
+		}
+		filter.Value = n
+	case domain.FieldTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("filter on %q must be true or false", field.Name)
+		}
+		filter.Value = b
+	case domain.FieldTypeDate:
+		date, err := domain.ParseFieldDate(value)
+		if err != nil {
+			return filter, fmt.Errorf("filter on %q must be a date", field.Name)
+		}
+		filter.Value = date
+	default:
+		filter.Value = value
+	}
+	return filter, nil
+}
+
+// collectionLookupFailed writes the response for a failed collection lookup
+func collectionLookupFailed(c *fiber.Ctx, err error) error {
+	if strings.Contains(err.Error(), "collection not found") {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Collection not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+	log.Printf("Error getting collection: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to get collection",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
+
+// itemLookupFailed writes the response for a failed collection item lookup
+func itemLookupFailed(c *fiber.Ctx, err error) error {
+	if strings.Contains(err.Error(), "collection not found") {
+		return collectionLookupFailed(c, err)
+	}
+	if strings.Contains(err.Error(), "collection item not found") {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Item not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+	log.Printf("Error getting collection item: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to get item",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
+
+// collectionSaveFailed writes the response for a failed collection create or update
+func collectionSaveFailed(c *fiber.Ctx, err error) error {
+	if errors.Is(err, domain.ErrCollectionAlreadyExists) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Collection with this slug already exists",
+			"code":  fiber.StatusConflict,
+		})
+	}
+	log.Printf("Error saving collection: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to save collection",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
+
+// itemSaveFailed writes the response for a failed collection item create or update
+func itemSaveFailed(c *fiber.Ctx, err error) error {
+	if errors.Is(err, domain.ErrCollectionItemAlreadyExists) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Item with this slug already exists",
+			"code":  fiber.StatusConflict,
+		})
+	}
+	log.Printf("Error saving collection item: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to save item",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionHandler_ItemsFilteredBySchema(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	h := NewCollectionHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/collections", h.CreateCollection)
+	app.Post("/api/v1/collections/:type/items", h.CreateItem)
+	app.Get("/api/v1/collections/:type/items", h.ListItems)
+	app.Get("/api/public/collections/:type", h.ListItemsPublic)
+	app.Get("/api/public/collections/:type/:slug", h.GetItemPublic)
+
+	list := func(path string) []domain.CollectionItem {
+		resp := app.send("GET", path, nil)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode, path)
+		var result struct {
+			Data []domain.CollectionItem `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result.Data
+	}
+	slugs := func(items []domain.CollectionItem) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.Slug)
+		}
+		return out
+	}
+
+	resp := app.send("POST", "/api/v1/collections", CollectionRequest{Slug: "teams", Name: "Teams", Fields: []domain.CollectionField{
+		{Name: "name", Type: domain.FieldTypeText, Required: true},
+	}})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	resp = app.send("POST", "/api/v1/collections", CollectionRequest{Slug: "team-members", Name: "Team members", Fields: []domain.CollectionField{
+		{Name: "name", Type: domain.FieldTypeText, Required: true},
+		{Name: "years", Type: domain.FieldTypeNumber},
+		{Name: "remote", Type: domain.FieldTypeBoolean},
+		{Name: "team", Type: domain.FieldTypeReference, Collection: "teams"},
+	}})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	resp = app.send("POST", "/api/v1/collections/teams/items", CollectionItemRequest{Slug: "platform", Data: map[string]interface{}{"name": "Platform"}})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var team domain.CollectionItem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&team))
+
+	members := []CollectionItemRequest{
+		{Slug: "ada", Status: "published", Data: map[string]interface{}{"name": "Ada", "years": 12, "remote": true, "team": team.ID.String()}},
+		{Slug: "grace", Status: "published", Data: map[string]interface{}{"name": "Grace", "years": 30, "remote": false}},
+		{Slug: "linus", Status: "draft", Data: map[string]interface{}{"name": "Linus", "years": 3, "remote": true}},
+	}
+	for _, member := range members {
+		resp = app.send("POST", "/api/v1/collections/team-members/items", member)
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode, member.Slug)
+	}
+
+	// Values are validated against the schema, including references
+	resp = app.send("POST", "/api/v1/collections/team-members/items", CollectionItemRequest{Slug: "bad", Data: map[string]interface{}{"name": "Bad", "years": "many"}})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp = app.send("POST", "/api/v1/collections/team-members/items", CollectionItemRequest{Slug: "bad", Data: map[string]interface{}{"name": "Bad", "team": team.CollectionID.String()}})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	assert.Equal(t, []string{"linus", "ada", "grace"}, slugs(list("/api/v1/collections/team-members/items?sort=years")))
+	assert.Equal(t, []string{"ada", "linus"}, slugs(list("/api/v1/collections/team-members/items?filter[remote]=true&sort=-years")))
+	assert.Equal(t, []string{"grace"}, slugs(list("/api/v1/collections/team-members/items?filter[years][gte]=10&filter[remote]=false")))
+	assert.Equal(t, []string{"grace"}, slugs(list("/api/v1/collections/team-members/items?filter[name][contains]=RAC")))
+
+	// Public endpoints only serve published items
+	assert.Equal(t, []string{"ada", "grace"}, slugs(list("/api/public/collections/team-members?sort=name")))
+	resp = app.send("GET", "/api/public/collections/team-members/linus", nil)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	resp = app.send("GET", "/api/public/collections/team-members/ada", nil)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// Filters and sorting are limited to schema fields
+	resp = app.send("GET", "/api/v1/collections/team-members/items?filter[salary]=1", nil)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp = app.send("GET", "/api/v1/collections/team-members/items?sort=team", nil)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp = app.send("GET", "/api/v1/collections/team-members/items?limit=500", nil)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp = app.send("GET", "/api/public/collections/unknown", nil)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,362 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/adapter/spam"
+	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CommentHandler handles reader comments on posts and their moderation
+type CommentHandler struct {
+	db *gorm.DB
+}
+
+// NewCommentHandler creates a new comment handler instance
+func NewCommentHandler(db *gorm.DB) *CommentHandler {
+	return &CommentHandler{
+		db: db,
+	}
+}
+
+// SubmitCommentRequest represents the request body for submitting a comment
+type SubmitCommentRequest struct {
+	AuthorName  string     `json:"author_name"`
+	AuthorEmail string     `json:"author_email"`
+	AuthorURL   string     `json:"author_url"`
+	Body        string     `json:"body"`
+	ParentID    *uuid.UUID `json:"parent_id"` // Approved comment on the same post being replied to
+	Website     string     `json:"website"`   // Honeypot: hidden from readers, so only bots fill it in
+}
+
+// ModerateCommentRequest represents the request body for moderating a comment
+type ModerateCommentRequest struct {
+	Status domain.CommentStatus `json:"status"`
+}
+
+// commentAccepted is the response to every plausible submission, so bots learn nothing from it
+var commentAccepted = fiber.Map{
+	"status":  domain.CommentStatusPending,
+	"message": "Thanks! Your comment will appear once it has been approved.",
+}
+
+// ListCommentsPublic handles GET /api/public/posts/:slug/comments (public endpoint)
+// Returns a page of approved top-level comments, oldest first, each with its approved replies
+func (h *CommentHandler) ListCommentsPublic(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	post, ok, err := commentedPost(c, db)
+	if !ok {
+		return err
+	}
+
+	limit, offset, ok, err := parsePaging(c, 20)
+	if !ok {
+		return err
+	}
+
+	roots, replies, total, err := repository.NewCommentRepository(db).ListApprovedThreads(c.Context(), post.TenantID, post.ID, limit, offset)
+	if err != nil {
+		log.Printf("Error listing comments: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list comments",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   domain.BuildCommentThreads(roots, replies),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// SubmitComment handles POST /api/public/posts/:slug/comments (public endpoint)
+// Comments wait in the moderation queue; those the spam classifier flags go straight to spam
+func (h *CommentHandler) SubmitComment(c *fiber.Ctx) error {
+	var req SubmitCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	// Pretend to accept comments that filled in the honeypot
+	if strings.TrimSpace(req.Website) != "" {
+		return c.Status(fiber.StatusAccepted).JSON(commentAccepted)
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	post, ok, err := commentedPost(c, db)
+	if !ok {
+		return err
+	}
+
+	comment := &domain.Comment{
+		TenantID:    post.TenantID,
+		PostID:      post.ID,
+		ParentID:    req.ParentID,
+		AuthorName:  req.AuthorName,
+		AuthorEmail: req.AuthorEmail,
+		AuthorURL:   req.AuthorURL,
+		Body:        req.Body,
+		Status:      domain.CommentStatusPending,
+		IPAddress:   c.IP(),
+		UserAgent:   truncate(c.Get(fiber.HeaderUserAgent), 500),
+	}
+	if err := domain.ValidateComment(comment); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	repo := repository.NewCommentRepository(db)
+	if comment.ParentID != nil {
+		parent, err := repo.GetByID(c.Context(), post.TenantID, *comment.ParentID)
+		if err != nil && !errors.Is(err, domain.ErrCommentNotFound) {
+			log.Printf("Error getting parent comment: %v", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to submit comment",
+				"code":  fiber.StatusInternalServerError,
+			})
+		}
+		if err != nil || parent.PostID != post.ID || parent.Status != domain.CommentStatusApproved {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "parent_id must be an approved comment on this post",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+
+	// A classifier failure leaves the comment for a moderator to judge
+	if verdict, err := spam.Default().Classify(c.Context(), comment); err != nil {
+		log.Printf("Error classifying comment: %v", err)
+	} else {
+		comment.SpamScore = verdict.Score
+		comment.SpamReasons = strings.Join(verdict.Reasons, "; ")
+		if verdict.Spam {
+			comment.Status = domain.CommentStatusSpam
+		}
+	}
+
+	if err := repo.Create(c.Context(), comment); err != nil {
+		log.Printf("Error creating comment: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to submit comment",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(commentAccepted)
+}
+
+// ListComments handles GET /api/v1/comments
+// Lists the moderation queue by default; ?status= picks another status or "all", ?post_id= narrows to a post
+func (h *CommentHandler) ListComments(c *fiber.Ctx) error {
+	opts := repoInterface.ListCommentOptions{
+		Limit:    20,
+		TenantID: middleware.GetTenantID(c),
+		Status:   domain.CommentStatusPending,
+	}
+
+	switch status := c.Query("status"); status {
+	case "":
+	case "all":
+		opts.Status = ""
+	default:
+		opts.Status = domain.CommentStatus(status)
+		if !opts.Status.IsValid() {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "status must be 'pending', 'approved', 'spam', 'deleted' or 'all'",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+	if postID := c.Query("post_id"); postID != "" {
+		id, err := uuid.Parse(postID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid post ID format",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		opts.PostID = &id
+	}
+	var ok bool
+	var err error
+	if opts.Limit, opts.Offset, ok, err = parsePaging(c, opts.Limit); !ok {
+		return err
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	comments, total, err := repository.NewCommentRepository(db).List(c.Context(), opts)
+	if err != nil {
+		log.Printf("Error listing comments: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list comments",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   comments,
+		"total":  total,
+		"limit":  opts.Limit,
+		"offset": opts.Offset,
+	})
+}
+
+// GetComment handles GET /api/v1/comments/:id
+func (h *CommentHandler) GetComment(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	comment, ok, err := h.loadComment(c, db)
+	if !ok {
+		return err
+	}
+	return c.JSON(comment)
+}
+
+// ModerateComment handles PUT /api/v1/comments/:id
+// Moves a comment to any status, e.g. approving it or marking it as spam
+func (h *CommentHandler) ModerateComment(c *fiber.Ctx) error {
+	var req ModerateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	if !req.Status.IsValid() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "status must be 'pending', 'approved', 'spam' or 'deleted'",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	return h.moderate(c, req.Status)
+}
+
+// DeleteComment handles DELETE /api/v1/comments/:id
+// Comments are hidden rather than removed, so they can still be restored from the queue
+func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
+	return h.moderate(c, domain.CommentStatusDeleted)
+}
+
+// moderate moves the comment in the route to a status and responds with it
+func (h *CommentHandler) moderate(c *fiber.Ctx, status domain.CommentStatus) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	comment, ok, err := h.loadComment(c, db)
+	if !ok {
+		return err
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	comment.Moderate(status, userID, time.Now())
+	if err := repository.NewCommentRepository(db).Update(c.Context(), comment); err != nil {
+		log.Printf("Error moderating comment: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to moderate comment",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	return c.JSON(comment)
+}
+
+// loadComment loads the tenant's comment named by the :id route parameter
+// When ok is false the error response has already been written
+func (h *CommentHandler) loadComment(c *fiber.Ctx, db *gorm.DB) (*domain.Comment, bool, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid comment ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	comment, err := repository.NewCommentRepository(db).GetByID(c.Context(), middleware.GetTenantID(c), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrCommentNotFound) {
+			return nil, false, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Comment not found",
+				"code":  fiber.StatusNotFound,
+			})
+		}
+		log.Printf("Error getting comment: %v", err)
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get comment",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	return comment, true, nil
+}
+
+// commentedPost loads the published post named by the :slug route parameter
+// Translations may share a slug, so the post in the negotiated locale is preferred, as when reading posts
+// When ok is false the error response has already been written
+func commentedPost(c *fiber.Ctx, db *gorm.DB) (*domain.Post, bool, error) {
+	postRepo := repository.NewPostRepository(db)
+	tenantID := middleware.GetTenantID(c)
+	slug := c.Params("slug")
+	post, err := postRepo.GetBySlugInLocale(c.Context(), tenantID, negotiateLocale(c, tenantSettings(c, db)), slug)
+	if err != nil && strings.Contains(err.Error(), "post not found") {
+		post, err = postRepo.GetBySlug(c.Context(), tenantID, slug)
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "post not found") {
+			return nil, false, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Post not found",
+				"code":  fiber.StatusNotFound,
+			})
+		}
+		log.Printf("Error getting post by slug: %v", err)
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get post",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	return post, true, nil
+}
+
+// truncate cuts s to at most n bytes without splitting a character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
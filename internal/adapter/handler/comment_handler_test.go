@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentHandler_SubmitModerateAndList(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	published := &domain.Post{Title: "Hello", Slug: "hello", Status: domain.PostStatusPublished}
+	draft := &domain.Post{Title: "Soon", Slug: "soon", Status: domain.PostStatusDraft}
+	require.NoError(t, db.Create(published).Error)
+	require.NoError(t, db.Create(draft).Error)
+
+	comments := NewCommentHandler(db)
+	app := newTestApp(t)
+	app.Get("/api/public/posts/:slug/comments", comments.ListCommentsPublic)
+	app.Post("/api/public/posts/:slug/comments", middleware.RateLimit(7, time.Minute), comments.SubmitComment)
+	app.Get("/api/v1/comments", comments.ListComments)
+	app.Put("/api/v1/comments/:id", comments.ModerateComment)
+	app.Delete("/api/v1/comments/:id", comments.DeleteComment)
+
+	moderator := map[string]string{"X-User": "moderator-1"}
+	queue := func(status string) []domain.Comment {
+		resp := app.send("GET", "/api/v1/comments?status="+status, nil, moderator)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var body struct {
+			Data []domain.Comment `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body.Data
+	}
+
+	// Drafts take no comments, and invalid comments are rejected
+	resp := app.send("POST", "/api/public/posts/soon/comments", SubmitCommentRequest{AuthorName: "Ann", Body: "Hi"})
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	resp = app.send("POST", "/api/public/posts/hello/comments", SubmitCommentRequest{AuthorName: "Ann"})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	// A genuine comment waits for moderation, a spammy one is filed as spam, and a bot
+	// that fills in the honeypot is told the same thing but nothing is stored
+	resp = app.send("POST", "/api/public/posts/hello/comments", SubmitCommentRequest{AuthorName: "Ann", AuthorEmail: "ann@example.com", Body: "Lovely post, thanks for writing it."})
+	assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	resp = app.send("POST", "/api/public/posts/hello/comments", SubmitCommentRequest{AuthorName: "Cheap pills", Body: "Buy viagra at http://a.example http://b.example http://c.example"})
+	assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	resp = app.send("POST", "/api/public/posts/hello/comments", SubmitCommentRequest{AuthorName: "Bot", Body: "Nice", Website: "http://bot.example"})
+	assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+	pending := queue("")
+	require.Len(t, pending, 1)
+	assert.Equal(t, "Ann", pending[0].AuthorName)
+	assert.Equal(t, "ann@example.com", pending[0].AuthorEmail)
+	spammed := queue("spam")
+	require.Len(t, spammed, 1)
+	assert.Contains(t, spammed[0].SpamReasons, "viagra")
+	assert.Len(t, queue("all"), 2)
+
+	// Nothing is public until approved; replies must answer an approved comment
+	resp = app.send("POST", "/api/public/posts/hello/comments", SubmitCommentRequest{AuthorName: "Bob", Body: "Agreed!", ParentID: &pending[0].ID})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	resp = app.send("PUT", "/api/v1/comments/"+pending[0].ID.String(), ModerateCommentRequest{Status: "bogus"}, moderator)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp = app.send("PUT", "/api/v1/comments/"+pending[0].ID.String(), ModerateCommentRequest{Status: domain.CommentStatusApproved}, moderator)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var approved domain.Comment
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&approved))
+	assert.Equal(t, "moderator-1", approved.ModeratedBy)
+
+	resp = app.send("POST", "/api/public/posts/hello/comments", SubmitCommentRequest{AuthorName: "Bob", Body: "Agreed, great read!", ParentID: &approved.ID})
+	assert.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	reply := queue("")
+	require.Len(t, reply, 1)
+	resp = app.send("PUT", "/api/v1/comments/"+reply[0].ID.String(), ModerateCommentRequest{Status: domain.CommentStatusApproved}, moderator)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp = app.send("GET", "/api/public/posts/hello/comments", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var threads struct {
+		Data  []map[string]interface{} `json:"data"`
+		Total int64                    `json:"total"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&threads))
+	assert.Equal(t, int64(1), threads.Total)
+	require.Len(t, threads.Data, 1)
+	assert.Equal(t, "Ann", threads.Data[0]["author_name"])
+	assert.NotContains(t, threads.Data[0], "author_email")
+	replies := threads.Data[0]["replies"].([]interface{})
+	require.Len(t, replies, 1)
+	assert.Equal(t, "Bob", replies[0].(map[string]interface{})["author_name"])
+
+	// Deleting hides the comment and its replies
+	resp = app.send("DELETE", "/api/v1/comments/"+approved.ID.String(), nil, moderator)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	resp = app.send("GET", "/api/public/posts/hello/comments", nil)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&threads))
+	assert.Empty(t, threads.Data)
+
+	resp = app.send("PUT", "/api/v1/comments/"+uuid.New().String(), ModerateCommentRequest{Status: domain.CommentStatusApproved}, moderator)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	// Seven submissions per minute are allowed; this test has made seven
+	resp = app.send("POST", "/api/public/posts/hello/comments", SubmitCommentRequest{AuthorName: "Ann", Body: "One more thing"})
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func TestCommentHandler_CommentsFollowTheLocale(t *testing.T) {
+	db := setupHandlerDB(t)
+	require.NoError(t, repository.NewSettingsRepository(db).UpdateGlobalSettings(context.Background(), "", &domain.GlobalSettings{
+		SiteName: "Acme",
+		Locales:  []string{"de"},
+	}))
+
+	// The translation keeps the slug of its source
+	english := &domain.Post{Title: "News", Slug: "news", Locale: "en", Status: domain.PostStatusPublished}
+	require.NoError(t, db.Create(english).Error)
+	german := &domain.Post{Title: "Neuigkeiten", Slug: "news", Locale: "de", Status: domain.PostStatusPublished, TranslationOfID: &english.ID}
+	require.NoError(t, db.Create(german).Error)
+
+	app := newTestApp(t)
+	app.Post("/api/public/posts/:slug/comments", NewCommentHandler(db).SubmitComment)
+
+	resp := app.send("POST", "/api/public/posts/news/comments", SubmitCommentRequest{AuthorName: "Jan", Body: "Danke für den Beitrag."},
+		map[string]string{"Accept-Language": "de"})
+	require.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	resp = app.send("POST", "/api/public/posts/news/comments", SubmitCommentRequest{AuthorName: "Ann", Body: "Thanks for the post."},
+		map[string]string{"Accept-Language": "fr"})
+	require.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+	var comments []domain.Comment
+	require.NoError(t, db.Order("created_at").Find(&comments).Error)
+	require.Len(t, comments, 2)
+	assert.Equal(t, german.ID, comments[0].PostID)
+	assert.Equal(t, english.ID, comments[1].PostID, "unknown locales fall back to the default")
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// versionETag formats a content version as an ETag
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// setVersionETag exposes the version of the returned content, for clients to send back in If-Match
+func setVersionETag(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, versionETag(version))
+}
+
+// checkVersion rejects a write that is based on an outdated version of the content
+// The client names the version it edited in an If-Match header or in the request body;
+// writes that name neither are not checked, so older clients keep working
+func checkVersion(c *fiber.Ctx, current int, requested *int) (bool, error) {
+	if header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch)); header != "" && header != "*" {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.Trim(strings.TrimPrefix(strings.TrimSpace(tag), "W/"), `"`)
+			if version, err := strconv.Atoi(tag); err == nil && version == current {
+				return true, nil
+			}
+		}
+		return false, versionConflict(c, current)
+	}
+	if requested != nil && *requested != current {
+		return false, versionConflict(c, current)
+	}
+	return true, nil
+}
+
+// versionConflict writes the response for a write based on an outdated version
+// The current version is included so the client can reload and retry
+func versionConflict(c *fiber.Ctx, current int) error {
+	setVersionETag(c, current)
+	return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+		"error":   "This content was changed by someone else since you loaded it; reload it and try again",
+		"code":    fiber.StatusConflict,
+		"version": current,
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
 	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -95,3 +98,45 @@ func (h *DashboardHandler) GetStats(c *fiber.Ctx) error {
 		},
 	})
 }
+
+// GetUsage handles GET /api/v1/dashboard/usage (protected endpoint)
+// Returns the current tenant's usage against its plan limits (0 = unlimited)
+func (h *DashboardHandler) GetUsage(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tenantID := middleware.GetTenantID(c)
+	now := time.Now()
+
+	var plan *domain.Plan
+	if tenant, err := repository.NewTenantRepository(db).GetByID(c.Context(), tenantID); err == nil {
+		plan = tenant.Plan
+	}
+
+	usageRepo := repository.NewUsageRepository(db)
+	usage := fiber.Map{}
+	for _, metric := range domain.UsageMetrics {
+		used, err := usageRepo.Get(c.Context(), tenantID, metric, metric.LimitPeriod(now))
+		if err != nil {
+			log.Printf("Error getting usage %s: %v", metric, err)
+		}
+
+		var limit int64
+		if plan != nil {
+			limit = plan.Limit(metric)
+		}
+		usage[string(metric)] = fiber.Map{
+			"used":  used,
+			"limit": limit,
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"period":  domain.UsagePeriod(now),
+		"plan":    plan,
+		"usage":   usage,
+	})
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EditLockHandler handles advisory edit locks on pages and posts
+type EditLockHandler struct {
+	db *gorm.DB
+}
+
+// NewEditLockHandler creates a new edit lock handler instance
+func NewEditLockHandler(db *gorm.DB) *EditLockHandler {
+	return &EditLockHandler{
+		db: db,
+	}
+}
+
+// AcquireEditLockRequest represents the request body for taking an edit lock
+type AcquireEditLockRequest struct {
+	TakeOver bool `json:"take_over,omitempty"` // Replace another user's lock; requires the admin role
+}
+
+// ListEditLocks handles GET /api/v1/locks (protected endpoint)
+// Lists live locks so content lists can show what is being edited; supports ?type=page|post
+func (h *EditLockHandler) ListEditLocks(c *fiber.Ctx) error {
+	entityType := domain.EditLockEntityType(c.Query("type"))
+	if entityType != "" && entityType != domain.EditLockPage && entityType != domain.EditLockPost {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errInvalidLockType.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	locks, err := repository.NewEditLockRepository(db).ListActive(c.Context(), middleware.GetTenantID(c), entityType)
+	if err != nil {
+		log.Printf("Error listing edit locks: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list edit locks",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  locks,
+		"total": len(locks),
+	})
+}
+
+// GetEditLock handles GET /api/v1/locks/:type/:id (protected endpoint)
+// Returns 404 when nobody is editing the content
+func (h *EditLockHandler) GetEditLock(c *fiber.Ctx) error {
+	entityType, id, err := parseLockTarget(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	lock, err := repository.NewEditLockRepository(db).Get(c.Context(), middleware.GetTenantID(c), entityType, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrEditLockNotFound) {
+			return editLockNotFound(c)
+		}
+		log.Printf("Error getting edit lock: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get edit lock",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(lock)
+}
+
+// AcquireEditLock handles POST /api/v1/locks/:type/:id (protected endpoint)
+// Takes the lock, or renews it when called again by its holder (the heartbeat).
+// Returns 409 with the current lock while someone else is editing, unless an admin takes it over.
+func (h *EditLockHandler) AcquireEditLock(c *fiber.Ctx) error {
+	entityType, id, err := parseLockTarget(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var req AcquireEditLockRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+	if req.TakeOver && !hasAdminRole(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Only admins can take over an edit lock",
+			"code":  fiber.StatusForbidden,
+		})
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	if userID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+			"code":  fiber.StatusUnauthorized,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tenantID := middleware.GetTenantID(c)
+	if exists, err := contentExists(db, tenantID, string(entityType), id); err != nil || !exists {
+		if err != nil {
+			log.Printf("Error checking %s %s: %v", entityType, id, err)
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Content not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+
+	lock := &domain.EditLock{
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   id,
+		UserID:     userID,
+	}
+	if user, err := repository.NewUserRepository(db).GetByID(c.Context(), userID); err == nil {
+		lock.UserName = user.Name
+	}
+
+	previous, acquired, err := repository.NewEditLockRepository(db).Acquire(c.Context(), lock, req.TakeOver)
+	if err != nil {
+		if errors.Is(err, domain.ErrEditLocked) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Being edited by " + lockHolderName(previous),
+				"code":  fiber.StatusConflict,
+				"lock":  previous,
+			})
+		}
+		log.Printf("Error acquiring edit lock: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to acquire edit lock",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	if previous != nil {
+		recordAudit(c, db, tenantID, domain.AuditActionEditLockTakeOver, string(entityType), id.String(), fiber.Map{
+			"previous_user_id": previous.UserID,
+		})
+		notify(c, db, &domain.Notification{
+			TenantID:   tenantID,
+			UserID:     previous.UserID,
+			Type:       domain.NotificationEditLockTakenOver,
+			Title:      fmt.Sprintf("%s took over the %s you were editing", lockHolderName(lock), entityType),
+			Message:    "Unsaved changes may conflict with theirs; reload before saving",
+			EntityType: string(entityType),
+			EntityID:   id.String(),
+		})
+	}
+	// Heartbeats renew the lock without telling everyone again
+	if acquired {
+		recordEvent(c, db, domain.LockAcquired{EditLock: *lock})
+	}
+
+	return c.JSON(lock)
+}
+
+// ReleaseEditLock handles DELETE /api/v1/locks/:type/:id (protected endpoint)
+// Editors release their own lock; admins can release anyone's
+func (h *EditLockHandler) ReleaseEditLock(c *fiber.Ctx) error {
+	entityType, id, err := parseLockTarget(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	if hasAdminRole(c) {
+		userID = ""
+	}
+	if err := repository.NewEditLockRepository(db).Release(c.Context(), middleware.GetTenantID(c), entityType, id, userID); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEditLockNotFound):
+			return editLockNotFound(c)
+		case errors.Is(err, domain.ErrEditLocked):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Only admins can release another user's edit lock",
+				"code":  fiber.StatusForbidden,
+			})
+		}
+		log.Printf("Error releasing edit lock: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to release edit lock",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	recordEvent(c, db, domain.LockReleased{ContentType: entityType, ContentID: id})
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// errInvalidLockType is returned for content that cannot be locked
+var errInvalidLockType = errors.New("type must be 'page' or 'post'")
+
+// parseLockTarget parses the :type and :id route parameters
+func parseLockTarget(c *fiber.Ctx) (domain.EditLockEntityType, uuid.UUID, error) {
+	entityType := domain.EditLockEntityType(c.Params("type"))
+	if entityType != domain.EditLockPage && entityType != domain.EditLockPost {
+		return "", uuid.Nil, errInvalidLockType
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return "", uuid.Nil, errors.New("invalid ID format")
+	}
+	return entityType, id, nil
+}
+
+// lockHolderName names the holder of a lock for messages
+func lockHolderName(lock *domain.EditLock) string {
+	if lock == nil || lock.UserName == "" {
+		return "another user"
+	}
+	return lock.UserName
+}
+
+// editLockNotFound writes the response for content nobody is editing
+func editLockNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		"error": "Not locked",
+		"code":  fiber.StatusNotFound,
+	})
+}
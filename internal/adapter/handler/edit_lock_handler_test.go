@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"gohac/internal/adapter/realtime"
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditLockHandler_LockLifecycle(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	alice := &domain.User{Name: "Alice", Email: "alice@example.com", Password: "x", Role: domain.UserRoleEditor}
+	bob := &domain.User{Name: "Bob", Email: "bob@example.com", Password: "x", Role: domain.UserRoleEditor}
+	admin := &domain.User{Name: "Ada", Email: "ada@example.com", Password: "x", Role: domain.UserRoleAdmin}
+	for _, user := range []*domain.User{alice, bob, admin} {
+		require.NoError(t, db.Create(user).Error)
+	}
+	page := &domain.Page{Slug: "about", Title: "About"}
+	require.NoError(t, db.Create(page).Error)
+
+	broker := realtime.NewMemoryBroker()
+	previous := realtime.SetBroker(broker)
+	defer realtime.SetBroker(previous)
+	events := broker.Subscribe("")
+	defer events.Close()
+	// Counts the lock.acquired events recorded since the last call
+	acquisitions := func() int {
+		dispatchEvents(t, db)
+		count := 0
+		for {
+			select {
+			case event := <-events.Events():
+				if event.Type == domain.EventLockAcquired {
+					count++
+				}
+			default:
+				return count
+			}
+		}
+	}
+
+	locks := NewEditLockHandler(db)
+	app := newTestApp(t)
+	app.Get("/api/v1/locks", locks.ListEditLocks)
+	app.Get("/api/v1/locks/:type/:id", locks.GetEditLock)
+	app.Post("/api/v1/locks/:type/:id", locks.AcquireEditLock)
+	app.Delete("/api/v1/locks/:type/:id", locks.ReleaseEditLock)
+
+	decodeLock := func(resp *http.Response) domain.EditLock {
+		var lock domain.EditLock
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&lock))
+		return lock
+	}
+	path := "/api/v1/locks/page/" + page.ID.String()
+
+	resp := app.send("GET", path, nil, asUser(alice))
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	resp = app.send("POST", path, nil, asUser(alice))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	first := decodeLock(resp)
+	assert.Equal(t, "Alice", first.UserName)
+
+	// The heartbeat keeps the lock and pushes back its expiry
+	time.Sleep(10 * time.Millisecond)
+	resp = app.send("POST", path, nil, asUser(alice))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	renewed := decodeLock(resp)
+	assert.True(t, renewed.ExpiresAt.After(first.ExpiresAt))
+	assert.True(t, renewed.AcquiredAt.Equal(first.AcquiredAt))
+	assert.Equal(t, 1, acquisitions())
+
+	// Others see who is editing and cannot take the lock
+	resp = app.send("POST", path, nil, asUser(bob))
+	require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	var conflict struct {
+		Error string          `json:"error"`
+		Lock  domain.EditLock `json:"lock"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&conflict))
+	assert.Equal(t, "Being edited by Alice", conflict.Error)
+	assert.Equal(t, alice.ID.String(), conflict.Lock.UserID)
+	assert.Equal(t, fiber.StatusForbidden, app.send("POST", path, AcquireEditLockRequest{TakeOver: true}, asUser(bob)).StatusCode)
+	assert.Equal(t, fiber.StatusForbidden, app.send("DELETE", path, nil, asUser(bob)).StatusCode)
+
+	// An admin takes over and the previous holder is told
+	resp = app.send("POST", path, AcquireEditLockRequest{TakeOver: true}, asUser(admin))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, admin.ID.String(), decodeLock(resp).UserID)
+	assert.Equal(t, 1, acquisitions())
+	var notifications []domain.Notification
+	require.NoError(t, db.Where("user_id = ?", alice.ID.String()).Find(&notifications).Error)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, domain.NotificationEditLockTakenOver, notifications[0].Type)
+
+	resp = app.send("GET", "/api/v1/locks?type=page", nil, asUser(alice))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var list struct {
+		Data []domain.EditLock `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, "Ada", list.Data[0].UserName)
+
+	// Expired locks are free for the next editor
+	require.NoError(t, db.Model(&domain.EditLock{}).Where("entity_id = ?", page.ID).Update("expires_at", time.Now().Add(-time.Second)).Error)
+	resp = app.send("POST", path, nil, asUser(bob))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, acquisitions())
+	assert.Equal(t, fiber.StatusNoContent, app.send("DELETE", path, nil, asUser(bob)).StatusCode)
+	assert.Equal(t, fiber.StatusNotFound, app.send("DELETE", path, nil, asUser(bob)).StatusCode)
+
+	assert.Equal(t, fiber.StatusBadRequest, app.send("POST", "/api/v1/locks/menu/"+page.ID.String(), nil, asUser(bob)).StatusCode)
+}
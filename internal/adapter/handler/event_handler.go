@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gohac/internal/adapter/realtime"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// eventKeepAlive is how often an idle stream sends a comment so proxies keep it open
+var eventKeepAlive = 15 * time.Second
+
+// EventHandler streams the tenant's changes to the admin panel
+type EventHandler struct{}
+
+// NewEventHandler creates a new event handler instance
+func NewEventHandler() *EventHandler {
+	return &EventHandler{}
+}
+
+// StreamEvents handles GET /api/v1/events (protected endpoint)
+// Streams the tenant's events as server-sent events until the client disconnects
+// Supports ?types=content.updated,lock.acquired to receive only some kinds of events
+func (h *EventHandler) StreamEvents(c *fiber.Ctx) error {
+	var types map[domain.EventType]bool
+	if raw := c.Query("types"); raw != "" {
+		types = make(map[domain.EventType]bool)
+		for _, t := range strings.Split(raw, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				types[domain.EventType(t)] = true
+			}
+		}
+	}
+
+	// The request context is recycled once the handler returns, so subscribe now
+	sub := realtime.Default().Subscribe(middleware.GetTenantID(c))
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		// Tell the client how long to wait before reconnecting, and that the stream is open
+		fmt.Fprintf(w, "retry: 3000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		ticker := time.NewTicker(eventKeepAlive)
+		defer ticker.Stop()
+		for {
+			select {
+			case event, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+				if types != nil && !types[event.Type] {
+					continue
+				}
+				payload, err := json.Marshal(event)
+				if err != nil {
+					log.Printf("Error encoding event %s: %v", event.Type, err)
+					continue
+				}
+				fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, payload)
+			case <-ticker.C:
+				fmt.Fprintf(w, ": keep-alive\n\n")
+			}
+			// A failed flush means the client went away
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// actorID returns the user making the request, recorded with the events of the changes it makes
+func actorID(c *fiber.Ctx) string {
+	userID, _ := c.Locals("user_id").(string)
+	return userID
+}
+
+// recordEvent records an event for a change no repository records events for
+// Outbox subscribers stream it to admin sessions and queue it for webhooks;
+// failures are logged but never fail the request
+func recordEvent(c *fiber.Ctx, db *gorm.DB, event domain.DomainEvent) {
+	if err := repository.NewOutboxRepository(db).Record(c.Context(), middleware.GetTenantID(c), actorID(c), event); err != nil {
+		log.Printf("Error recording %s event: %v", event.EventType(), err)
+	}
+}
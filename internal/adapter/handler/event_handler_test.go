@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gohac/internal/adapter/realtime"
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHandler_StreamEvents(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	broker := realtime.NewMemoryBroker()
+	previous := realtime.SetBroker(broker)
+	defer realtime.SetBroker(previous)
+
+	pages := NewPageHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/pages", pages.CreatePage)
+	app.Delete("/api/v1/pages/:id", pages.DeletePage)
+	app.Get("/api/v1/events", NewEventHandler().StreamEvents)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go app.Listener(ln)
+	defer ln.Close()
+
+	req, err := http.NewRequest("GET", "http://"+ln.Addr().String()+"/api/v1/events?types=content.created,content.deleted", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Tenant", "acme")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Reads one event, skipping the retry preamble and keep-alive comments
+	lines := bufio.NewScanner(resp.Body)
+	next := func() (string, domain.Event) {
+		var name string
+		var event domain.Event
+		for lines.Scan() {
+			line := lines.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event))
+			case line == "" && name != "":
+				return name, event
+			}
+		}
+		t.Fatal("stream ended before the next event")
+		return "", event
+	}
+
+	createPage := func(tenantID, slug string) domain.Page {
+		resp := app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: slug, Title: slug},
+			map[string]string{"X-User": "editor-1", "X-Role": string(domain.UserRoleEditor), "X-Tenant": tenantID})
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var page domain.Page
+		decodeJSON(t, resp, &page)
+		return page
+	}
+
+	// Changes in other tenants never reach the stream
+	createPage("globex", "elsewhere")
+	about := createPage("acme", "about")
+	dispatchEvents(t, db)
+
+	name, event := next()
+	assert.Equal(t, string(domain.EventContentCreated), name)
+	assert.Equal(t, "page", event.EntityType)
+	assert.Equal(t, about.ID.String(), event.EntityID)
+	assert.Equal(t, "editor-1", event.ActorID)
+	var data struct {
+		Slug string `json:"slug"`
+	}
+	require.NoError(t, json.Unmarshal(event.Data, &data))
+	assert.Equal(t, "about", data.Slug)
+
+	// Filtered out by ?types=
+	require.NoError(t, broker.Publish(req.Context(), domain.NewEvent(domain.EventMediaUploaded, "acme", "media", "")))
+
+	delResp := app.send("DELETE", "/api/v1/pages/"+about.ID.String(), nil,
+		map[string]string{"X-Role": string(domain.UserRoleEditor), "X-Tenant": "acme"})
+	require.Equal(t, fiber.StatusNoContent, delResp.StatusCode)
+	dispatchEvents(t, db)
+
+	name, event = next()
+	assert.Equal(t, string(domain.EventContentDeleted), name)
+	assert.Equal(t, about.ID.String(), event.EntityID)
+
+	// Closing the broker ends open streams
+	require.NoError(t, broker.Close())
+	assert.False(t, lines.Scan())
+}
@@ -0,0 +1,392 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reference expansion embeds referenced entities in public responses, requested
+// with ?expand=menus,media (or ?include=). References are found by convention
+// anywhere in the response, including inside blocks and settings:
+//   - "<name>_id" and "<name>_ids" keys whose name ends in menu, post, page, category
+//     or author embed the entity at "<name>" or the plural of "<name>"
+//     (menu_id -> menu, header_menu_id -> header_menu, related_post_ids -> related_posts)
+//   - uploaded file URLs embed the file's media info at "<key>_media"
+//     (image_url -> image_media, logo -> logo_media, url -> media)
+//
+// Entities are loaded with one query per kind and level. Embedded entities are
+// expanded in turn, up to ?expand_depth= levels.
+
+const (
+	defaultExpandDepth = 1
+	maxExpandDepth     = 3
+
+	expandMedia = "media"
+)
+
+// referenceKinds maps the singular name used in reference keys to its expand kind
+var referenceKinds = map[string]string{
+	"menu":     "menus",
+	"post":     "posts",
+	"page":     "pages",
+	"category": "categories",
+	"author":   "authors",
+}
+
+// expandRef is a reference found in a response, to be replaced by the embedded entities
+type expandRef struct {
+	target map[string]interface{}
+	key    string // Key the entities are embedded at
+	kind   string
+	ids    []string // Entity IDs, or the URL for media
+	many   bool
+}
+
+// expander resolves references for one request
+type expander struct {
+	c        *fiber.Ctx
+	db       *gorm.DB
+	tenantID string
+	locale   string
+	kinds    map[string]bool
+	depth    int
+	loaded   map[string]map[string][]byte // Kind -> ID -> entity JSON; nil if missing or not public
+}
+
+// newExpander parses the expand query parameters
+// Returns nil when nothing should be expanded
+func newExpander(c *fiber.Ctx, db *gorm.DB, tenantID, locale string) (*expander, error) {
+	raw := c.Query("expand", c.Query("include"))
+	if raw == "" {
+		return nil, nil
+	}
+
+	e := &expander{
+		c:        c,
+		db:       db,
+		tenantID: tenantID,
+		locale:   locale,
+		kinds:    make(map[string]bool),
+		depth:    defaultExpandDepth,
+		loaded:   make(map[string]map[string][]byte),
+	}
+	for _, kind := range strings.Split(raw, ",") {
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		switch {
+		case kind == "":
+		case kind == "*" || kind == "all":
+			for _, k := range referenceKinds {
+				e.kinds[k] = true
+			}
+			e.kinds[expandMedia] = true
+		case kind == expandMedia || isReferenceKind(kind):
+			e.kinds[kind] = true
+		default:
+			return nil, fmt.Errorf("cannot expand %q", kind)
+		}
+	}
+
+	if value := c.Query("expand_depth"); value != "" {
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth < 1 || depth > maxExpandDepth {
+			return nil, fmt.Errorf("expand_depth must be between 1 and %d", maxExpandDepth)
+		}
+		e.depth = depth
+	}
+	return e, nil
+}
+
+// isReferenceKind reports whether kind names a kind of referenced entity
+func isReferenceKind(kind string) bool {
+	for _, k := range referenceKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// respondExpanded writes value as JSON with the references requested by ?expand= embedded
+func respondExpanded(c *fiber.Ctx, db *gorm.DB, tenantID, locale string, value interface{}) error {
+	e, err := newExpander(c, db, tenantID, locale)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	if e == nil {
+		return c.JSON(value)
+	}
+
+	// Work on the generic JSON form so references can be found anywhere
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return err
+	}
+
+	if err := e.expand(generic); err != nil {
+		log.Printf("Error expanding references: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to expand references",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	return c.JSON(generic)
+}
+
+// expand embeds references in root level by level
+// Every embedded entity is a fresh copy, so references between entities can never form cycles
+func (e *expander) expand(root interface{}) error {
+	current := []interface{}{root}
+	for level := 0; level < e.depth && len(current) > 0; level++ {
+		var refs []expandRef
+		for _, value := range current {
+			e.collect(value, &refs)
+		}
+		if err := e.load(refs); err != nil {
+			return err
+		}
+
+		var next []interface{}
+		for _, ref := range refs {
+			embedded := []interface{}{}
+			for _, id := range ref.ids {
+				raw := e.loaded[ref.kind][id]
+				if raw == nil {
+					continue
+				}
+				var value interface{}
+				if err := json.Unmarshal(raw, &value); err != nil {
+					return err
+				}
+				embedded = append(embedded, value)
+			}
+			switch {
+			case ref.many:
+				ref.target[ref.key] = embedded
+			case len(embedded) == 1:
+				ref.target[ref.key] = embedded[0]
+			}
+			next = append(next, embedded...)
+		}
+		current = next
+	}
+	return nil
+}
+
+// collect finds the references in value
+func (e *expander) collect(value interface{}, refs *[]expandRef) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			e.collect(item, refs)
+		}
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := e.reference(v, key, child); ok {
+				*refs = append(*refs, ref)
+				continue
+			}
+			e.collect(child, refs)
+		}
+	}
+}
+
+// reference reports whether key of m holds a reference that should be expanded
+// Keys that would overwrite an existing value are left alone
+func (e *expander) reference(m map[string]interface{}, key string, value interface{}) (expandRef, bool) {
+	if url, ok := value.(string); ok && e.kinds[expandMedia] &&
+		(strings.HasPrefix(url, "/uploads/") || strings.HasPrefix(url, "/static/uploads/")) {
+		embedKey := expandMedia
+		if key != "url" && key != "src" {
+			embedKey = strings.TrimSuffix(key, "_url") + "_media"
+		}
+		if _, exists := m[embedKey]; exists {
+			return expandRef{}, false
+		}
+		return expandRef{target: m, key: embedKey, kind: expandMedia, ids: []string{url}}, true
+	}
+
+	var name string
+	many := false
+	switch {
+	case strings.HasSuffix(key, "_ids"):
+		name, many = strings.TrimSuffix(key, "_ids"), true
+	case strings.HasSuffix(key, "_id"):
+		name = strings.TrimSuffix(key, "_id")
+	default:
+		return expandRef{}, false
+	}
+
+	for singular, kind := range referenceKinds {
+		if !e.kinds[kind] || (name != singular && !strings.HasSuffix(name, "_"+singular)) {
+			continue
+		}
+		embedKey := name
+		if many {
+			embedKey = strings.TrimSuffix(name, singular) + kind
+		}
+		if _, exists := m[embedKey]; exists {
+			return expandRef{}, false
+		}
+		ids := referenceIDs(value)
+		if len(ids) == 0 && !many {
+			return expandRef{}, false
+		}
+		return expandRef{target: m, key: embedKey, kind: kind, ids: ids, many: many}, true
+	}
+	return expandRef{}, false
+}
+
+// referenceIDs returns the valid UUIDs in a reference value (a string or an array of strings)
+func referenceIDs(value interface{}) []string {
+	var values []interface{}
+	switch v := value.(type) {
+	case string:
+		values = []interface{}{v}
+	case []interface{}:
+		values = v
+	}
+
+	var ids []string
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			if id, err := uuid.Parse(s); err == nil {
+				ids = append(ids, id.String())
+			}
+		}
+	}
+	return ids
+}
+
+// load fetches the referenced entities that are not loaded yet, one query per kind
+func (e *expander) load(refs []expandRef) error {
+	missing := make(map[string][]string)
+	for _, ref := range refs {
+		if e.loaded[ref.kind] == nil {
+			e.loaded[ref.kind] = make(map[string][]byte)
+		}
+		for _, id := range ref.ids {
+			if _, seen := e.loaded[ref.kind][id]; !seen {
+				e.loaded[ref.kind][id] = nil
+				missing[ref.kind] = append(missing[ref.kind], id)
+			}
+		}
+	}
+
+	for kind, ids := range missing {
+		entities, err := e.fetch(kind, ids)
+		if err != nil {
+			return err
+		}
+		for id, entity := range entities {
+			raw, err := json.Marshal(entity)
+			if err != nil {
+				return err
+			}
+			e.loaded[kind][id] = raw
+		}
+	}
+	return nil
+}
+
+// fetch loads the public entities of one kind by ID
+// Unpublished pages and posts are left out; menus are served in the request locale
+func (e *expander) fetch(kind string, ids []string) (map[string]interface{}, error) {
+	entities := make(map[string]interface{})
+	if kind == expandMedia {
+		uploadPath := mediaUploadPath()
+		for _, url := range ids {
+			if item, ok := mediaItemForURL(uploadPath, url); ok {
+				entities[url] = item
+			}
+		}
+		return entities, nil
+	}
+
+	uuids := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		uuids = append(uuids, uuid.MustParse(id))
+	}
+
+	ctx := e.c.Context()
+	switch kind {
+	case "menus":
+		repo := repository.NewMenuRepository(e.db)
+		menus, err := repo.ListByIDs(ctx, e.tenantID, uuids)
+		if err != nil {
+			return nil, err
+		}
+		for _, menu := range menus {
+			entities[menu.ID.String()] = menuResponse(localizeMenu(e.c, repo, menu, e.locale))
+		}
+	case "posts":
+		posts, err := repository.NewPostRepository(e.db).ListByIDs(ctx, e.tenantID, uuids)
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range posts {
+			if post.Status == domain.PostStatusPublished {
+				post.Blocks = domain.StripFormNotify(post.Blocks)
+				entities[post.ID.String()] = expandedPost{Post: post, Author: publicAuthor(&post.Author)}
+			}
+		}
+	case "pages":
+		pages, err := repository.NewPageRepository(e.db).ListByIDs(ctx, e.tenantID, uuids)
+		if err != nil {
+			return nil, err
+		}
+		for _, page := range pages {
+			if page.Status == domain.PageStatusPublished {
+				page.Blocks = domain.StripFormNotify(page.Blocks)
+				entities[page.ID.String()] = page
+			}
+		}
+	case "categories":
+		categories, err := repository.NewCategoryRepository(e.db).ListByIDs(ctx, e.tenantID, uuids)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			entities[category.ID.String()] = category
+		}
+	case "authors":
+		users, err := repository.NewUserRepository(e.db).ListByIDs(ctx, e.tenantID, uuids)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			entities[user.ID.String()] = publicAuthor(user)
+		}
+	}
+	return entities, nil
+}
+
+// expandedPost is an embedded post, whose author is shown with their public profile only
+type expandedPost struct {
+	*domain.Post
+	Author fiber.Map `json:"author"`
+}
+
+// publicAuthor is the public profile of an author, without their email, role or tenant
+func publicAuthor(user *domain.User) fiber.Map {
+	return fiber.Map{
+		"id":   user.ID,
+		"name": user.Name,
+	}
+}
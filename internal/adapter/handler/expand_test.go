@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandReferences(t *testing.T) {
+	uploads := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(uploads, "team.png"), []byte("png"), 0o644))
+	t.Setenv("STORAGE_PATH", uploads)
+
+	db := setupHandlerDB(t)
+
+	author := &domain.User{Name: "Ada", Email: "ada@example.com", Password: "x"}
+	require.NoError(t, db.Create(author).Error)
+	menu := &domain.Menu{Name: "Main", Items: []byte(`[{"label":"Home","url":"/"}]`)}
+	require.NoError(t, db.Create(menu).Error)
+	post := &domain.Post{
+		Title:    "Launch",
+		Slug:     "launch",
+		Status:   domain.PostStatusPublished,
+		AuthorID: author.ID,
+		Blocks:   []byte(`[{"id":"p1","type":"menu","data":{"menu_id":"` + menu.ID.String() + `"}}]`),
+	}
+	require.NoError(t, db.Create(post).Error)
+	draft := &domain.Post{Title: "Draft", Slug: "draft", Status: domain.PostStatusDraft, AuthorID: author.ID}
+	require.NoError(t, db.Create(draft).Error)
+
+	blocks := `[
+		{"id":"b1","type":"menu","data":{"menu_id":"` + menu.ID.String() + `"}},
+		{"id":"b2","type":"posts","data":{"related_post_ids":["` + post.ID.String() + `","` + draft.ID.String() + `"]}},
+		{"id":"b3","type":"image","data":{"url":"/uploads/team.png","alt":"Team"}}
+	]`
+	page := &domain.Page{Slug: "home", Title: "Home", Status: domain.PageStatusPublished, Blocks: []byte(blocks)}
+	require.NoError(t, db.Create(page).Error)
+
+	app := newTestApp(t)
+	app.Get("/api/public/pages/*", NewPageHandler(db).GetPageBySlugPublic)
+
+	get := func(path string) (int, map[string]interface{}) {
+		resp := app.send("GET", path, nil)
+		var body map[string]interface{}
+		decodeJSON(t, resp, &body)
+		return resp.StatusCode, body
+	}
+	blockData := func(body map[string]interface{}, i int) map[string]interface{} {
+		return body["blocks"].([]interface{})[i].(map[string]interface{})["data"].(map[string]interface{})
+	}
+
+	// Without expand references are left as IDs
+	status, body := get("/api/public/pages/home")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.NotContains(t, blockData(body, 0), "menu")
+
+	status, body = get("/api/public/pages/home?expand=menus,posts,media")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "Main", blockData(body, 0)["menu"].(map[string]interface{})["name"])
+
+	// Unpublished posts are never embedded
+	related := blockData(body, 1)["related_posts"].([]interface{})
+	require.Len(t, related, 1)
+	embedded := related[0].(map[string]interface{})
+	assert.Equal(t, "Launch", embedded["title"])
+	assert.NotContains(t, embedded["blocks"].([]interface{})[0].(map[string]interface{})["data"], "menu")
+
+	// The author of an embedded post shows only their public profile
+	assert.Equal(t, map[string]interface{}{"id": author.ID.String(), "name": "Ada"}, embedded["author"])
+
+	media := blockData(body, 2)["media"].(map[string]interface{})
+	assert.Equal(t, "team.png", media["name"])
+	assert.Equal(t, float64(3), media["size"])
+
+	// A second level expands the references of embedded posts
+	status, body = get("/api/public/pages/home?include=posts,menus&expand_depth=2")
+	require.Equal(t, fiber.StatusOK, status)
+	embedded = blockData(body, 1)["related_posts"].([]interface{})[0].(map[string]interface{})
+	postBlocks := embedded["blocks"].([]interface{})
+	require.Len(t, postBlocks, 1)
+	postMenu := postBlocks[0].(map[string]interface{})["data"].(map[string]interface{})["menu"]
+	assert.Equal(t, "Main", postMenu.(map[string]interface{})["name"])
+
+	status, _ = get("/api/public/pages/home?expand=widgets")
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	status, _ = get("/api/public/pages/home?expand=menus&expand_depth=9")
+	assert.Equal(t, fiber.StatusBadRequest, status)
+}
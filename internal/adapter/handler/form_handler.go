@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FormHandler handles submissions of form blocks
+type FormHandler struct {
+	db *gorm.DB
+}
+
+// NewFormHandler creates a new form handler instance
+func NewFormHandler(db *gorm.DB) *FormHandler {
+	return &FormHandler{
+		db: db,
+	}
+}
+
+// SubmitFormRequest represents the request body for submitting a form block
+type SubmitFormRequest struct {
+	Values  map[string]interface{} `json:"values"`  // Field name -> value
+	Website string                 `json:"website"` // Honeypot: hidden from visitors, so only bots fill it in
+}
+
+// defaultFormSuccessMessage is shown when the form block does not set its own
+const defaultFormSuccessMessage = "Thanks! Your submission has been received."
+
+// SubmitForm handles POST /api/public/forms/:page_id/:block_id (public endpoint)
+// Values are validated against the form block as it is stored on the published page
+func (h *FormHandler) SubmitForm(c *fiber.Ctx) error {
+	var req SubmitFormRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	page, ok, err := formPage(c, db, true)
+	if !ok {
+		return err
+	}
+	form, ok, err := formBlock(c, db, page)
+	if !ok {
+		return err
+	}
+
+	successMessage := form.SuccessMessage
+	if successMessage == "" {
+		successMessage = defaultFormSuccessMessage
+	}
+	// Pretend to accept submissions that filled in the honeypot
+	if strings.TrimSpace(req.Website) != "" {
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": successMessage})
+	}
+
+	values, problems := form.ValidateSubmission(req.Values)
+	if problems != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":  "Some fields are invalid",
+			"code":   fiber.StatusBadRequest,
+			"fields": problems,
+		})
+	}
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		log.Printf("Error encoding form submission: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to submit form",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	notify := form.Notify
+	if len(notify) == 0 {
+		if contact := tenantSettings(c, db).ContactEmail; contact != "" {
+			notify = []string{contact}
+		}
+	}
+
+	submission := &domain.FormSubmission{
+		TenantID:  page.TenantID,
+		PageID:    page.ID,
+		BlockID:   c.Params("block_id"),
+		Values:    valuesJSON,
+		IPAddress: c.IP(),
+		UserAgent: truncate(c.Get(fiber.HeaderUserAgent), 500),
+	}
+	event := domain.FormSubmitted{
+		PageID:    page.ID,
+		PageTitle: page.Title,
+		BlockID:   submission.BlockID,
+		FormTitle: form.Title,
+		Fields:    form.Fields,
+		Values:    values,
+		Notify:    notify,
+	}
+	if err := repository.NewFormRepository(db).CreateSubmission(c.Context(), submission, event); err != nil {
+		log.Printf("Error storing form submission: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to submit form",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": successMessage})
+}
+
+// ListSubmissions handles GET /api/v1/forms/:page_id/:block_id/submissions
+// The response includes the form's current fields, or none if the block was removed from the page
+func (h *FormHandler) ListSubmissions(c *fiber.Ctx) error {
+	limit, offset, ok, err := parsePaging(c, 20)
+	if !ok {
+		return err
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	page, ok, err := formPage(c, db, false)
+	if !ok {
+		return err
+	}
+
+	submissions, total, err := repository.NewFormRepository(db).ListSubmissions(c.Context(), page.TenantID, page.ID, c.Params("block_id"), limit, offset)
+	if err != nil {
+		log.Printf("Error listing form submissions: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list form submissions",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	fields := []domain.FormField{}
+	if form := findFormBlock(c, db, page); form != nil {
+		fields = form.Fields
+	}
+	return c.JSON(fiber.Map{
+		"data":   submissions,
+		"fields": fields,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// ExportSubmissions handles GET /api/v1/forms/:page_id/:block_id/submissions/export
+// Writes all submissions as CSV, oldest first: one column per field of the current form,
+// then columns for values of fields that have since been removed
+func (h *FormHandler) ExportSubmissions(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	page, ok, err := formPage(c, db, false)
+	if !ok {
+		return err
+	}
+	blockID := c.Params("block_id")
+
+	submissions, _, err := repository.NewFormRepository(db).ListSubmissions(c.Context(), page.TenantID, page.ID, blockID, 0, 0)
+	if err != nil {
+		log.Printf("Error listing form submissions: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to export form submissions",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	var fields []domain.FormField
+	if form := findFormBlock(c, db, page); form != nil {
+		fields = form.Fields
+	}
+	// Listed newest first; exported oldest first
+	rows := make([]map[string]string, len(submissions))
+	for i, submission := range submissions {
+		rows[len(submissions)-1-i] = submission.DecodeValues()
+	}
+	names, header := submissionColumns(fields, rows)
+
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+	_ = w.Write(append([]string{"id", "submitted_at"}, header...))
+	for i, values := range rows {
+		submission := submissions[len(submissions)-1-i]
+		record := []string{submission.ID.String(), submission.CreatedAt.UTC().Format(time.RFC3339)}
+		for _, name := range names {
+			record = append(record, csvSafe(values[name]))
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Printf("Error writing form submissions CSV: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to export form submissions",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	filename := strings.NewReplacer("/", "-", `"`, "", "\\", "").Replace(page.Slug + "-" + blockID)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+	return c.SendString(buf.String())
+}
+
+// DeleteSubmission handles DELETE /api/v1/forms/:page_id/:block_id/submissions/:id
+func (h *FormHandler) DeleteSubmission(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid submission ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	page, ok, err := formPage(c, db, false)
+	if !ok {
+		return err
+	}
+
+	if err := repository.NewFormRepository(db).DeleteSubmission(c.Context(), page.TenantID, page.ID, c.Params("block_id"), id); err != nil {
+		if errors.Is(err, domain.ErrFormSubmissionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Submission not found",
+				"code":  fiber.StatusNotFound,
+			})
+		}
+		log.Printf("Error deleting form submission: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete form submission",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// formPage loads the tenant's page named by the :page_id route parameter
+// With published set, pages that are not live are treated as missing
+// When ok is false the error response has already been written
+func formPage(c *fiber.Ctx, db *gorm.DB, published bool) (*domain.Page, bool, error) {
+	id, err := uuid.Parse(c.Params("page_id"))
+	if err != nil {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid page ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	page, err := repository.NewPageRepository(db).GetByID(c.Context(), id)
+	if err == nil && (page.TenantID != middleware.GetTenantID(c) || (published && page.Status != domain.PageStatusPublished)) {
+		err = fmt.Errorf("page not found: %w", gorm.ErrRecordNotFound)
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "page not found") {
+			return nil, false, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Page not found",
+				"code":  fiber.StatusNotFound,
+			})
+		}
+		log.Printf("Error getting page: %v", err)
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get page",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	return page, true, nil
+}
+
+// formBlock finds the form block named by the :block_id route parameter on the page
+// When ok is false the error response has already been written
+func formBlock(c *fiber.Ctx, db *gorm.DB, page *domain.Page) (*domain.FormBlockData, bool, error) {
+	form := findFormBlock(c, db, page)
+	if form == nil {
+		return nil, false, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Form not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+	return form, true, nil
+}
+
+// findFormBlock returns the valid form block with the :block_id route parameter, including
+// forms placed through global blocks, or nil if the page has none
+func findFormBlock(c *fiber.Ctx, db *gorm.DB, page *domain.Page) *domain.FormBlockData {
+	blocksJSON := page.Blocks
+	resolveGlobalBlocks(c, db, page.TenantID, &blocksJSON)
+
+	var blocks []domain.Block
+	if len(blocksJSON) == 0 || json.Unmarshal(blocksJSON, &blocks) != nil {
+		return nil
+	}
+	blockID := c.Params("block_id")
+	for _, block := range blocks {
+		if block.ID != blockID || block.Type != string(domain.BlockTypeForm) {
+			continue
+		}
+		form, err := domain.ParseFormBlock(block.Data)
+		if err != nil {
+			log.Printf("Error parsing form block %s of page %s: %v", block.ID, page.ID, err)
+			return nil
+		}
+		return form
+	}
+	return nil
+}
+
+// submissionColumns returns the value keys and header labels of a submissions export
+// Fields of the form come first in form order; keys no longer on the form follow alphabetically
+func submissionColumns(fields []domain.FormField, rows []map[string]string) ([]string, []string) {
+	var names, header []string
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.Name] = true
+		names = append(names, field.Name)
+		label := field.Label
+		if label == "" {
+			label = field.Name
+		}
+		header = append(header, label)
+	}
+
+	var removed []string
+	for _, row := range rows {
+		for name := range row {
+			if !known[name] {
+				known[name] = true
+				removed = append(removed, name)
+			}
+		}
+	}
+	sort.Strings(removed)
+	return append(names, removed...), append(header, removed...)
+}
+
+// csvSafe keeps spreadsheet applications from running submitted values as formulas
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
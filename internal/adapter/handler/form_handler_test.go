@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormHandler_SubmitAndExport(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	pages := NewPageHandler(db)
+	forms := NewFormHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/pages", pages.CreatePage)
+	app.Post("/api/public/forms/:page_id/:block_id", forms.SubmitForm)
+	app.Get("/api/v1/forms/:page_id/:block_id/submissions", forms.ListSubmissions)
+	app.Get("/api/v1/forms/:page_id/:block_id/submissions/export", forms.ExportSubmissions)
+
+	contact := json.RawMessage(`{
+		"title": "Contact us",
+		"notify": ["sales@example.com"],
+		"fields": [
+			{"name": "name", "label": "Name", "type": "text", "required": true, "max_length": 50},
+			{"name": "email", "label": "Email", "type": "email", "required": true},
+			{"name": "topic", "label": "Topic", "type": "select", "options": ["sales", "support"]},
+			{"name": "message", "label": "Message", "type": "textarea"}
+		]
+	}`)
+
+	// Pages with an invalid form definition are rejected
+	resp := app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "broken", Title: "Broken", Blocks: []domain.Block{
+		{ID: "form-1", Type: "form", Data: json.RawMessage(`{"fields": [{"name": "Bad Name", "type": "text"}]}`)},
+	}})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "contact", Title: "Contact", Status: "published", Blocks: []domain.Block{
+		{ID: "intro", Type: "text", Data: json.RawMessage(`{"content": "Say hi"}`)},
+		{ID: "form-1", Type: "form", Data: contact},
+	}})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var page domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "draft", Title: "Draft", Blocks: []domain.Block{
+		{ID: "form-1", Type: "form", Data: contact},
+	}})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var draft domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&draft))
+
+	formPath := "/api/public/forms/" + page.ID.String() + "/form-1"
+
+	// Unknown forms, unpublished pages and blocks that are not forms cannot be submitted
+	resp = app.send("POST", "/api/public/forms/"+draft.ID.String()+"/form-1", SubmitFormRequest{})
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	resp = app.send("POST", "/api/public/forms/"+page.ID.String()+"/intro", SubmitFormRequest{})
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	resp = app.send("POST", formPath, SubmitFormRequest{Values: map[string]interface{}{"name": "Ann", "email": "not-an-email", "topic": "billing"}})
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	var invalid struct {
+		Fields map[string]string `json:"fields"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&invalid))
+	assert.Equal(t, map[string]string{"email": "must be an email address", "topic": "must be one of the options"}, invalid.Fields)
+
+	// Bots filling in the honeypot are thanked but not stored
+	resp = app.send("POST", formPath, SubmitFormRequest{Values: map[string]interface{}{"name": "Bot", "email": "bot@example.com"}, Website: "x"})
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	resp = app.send("POST", formPath, SubmitFormRequest{Values: map[string]interface{}{
+		"name": "Ann", "email": "ann@example.com", "topic": "sales", "message": "=HYPERLINK(\"x\")", "extra": "dropped",
+	}})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	resp = app.send("POST", formPath, SubmitFormRequest{Values: map[string]interface{}{"name": "Bob", "email": "bob@example.com"}})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	resp = app.send("GET", "/api/v1/forms/"+page.ID.String()+"/form-1/submissions", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var listed struct {
+		Data   []domain.FormSubmission `json:"data"`
+		Fields []domain.FormField      `json:"fields"`
+		Total  int64                   `json:"total"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listed))
+	assert.Equal(t, int64(2), listed.Total)
+	assert.Len(t, listed.Fields, 4)
+	assert.Equal(t, map[string]string{"name": "Bob", "email": "bob@example.com"}, listed.Data[0].DecodeValues())
+
+	// Each stored submission queues a notification for the form's recipients
+	var events []domain.OutboxEvent
+	require.NoError(t, db.Where("type = ?", domain.EventFormSubmitted).Find(&events).Error)
+	require.Len(t, events, 2)
+	var submitted domain.FormSubmitted
+	require.NoError(t, events[0].Decode(&submitted))
+	assert.Equal(t, []string{"sales@example.com"}, submitted.Notify)
+	assert.Equal(t, "Contact us", submitted.FormTitle)
+
+	resp = app.send("GET", "/api/v1/forms/"+page.ID.String()+"/form-1/submissions/export", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), `filename="contact-form-1.csv"`)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "submitted_at", "Name", "Email", "Topic", "Message"}, records[0])
+	assert.Equal(t, []string{"Ann", "ann@example.com", "sales", "'=HYPERLINK(\"x\")"}, records[1][2:])
+	assert.Equal(t, []string{"Bob", "bob@example.com", "", ""}, records[2][2:])
+}
+
+func TestFormHandler_PublicPagesHideNotify(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	newsletter := &domain.GlobalBlock{Name: "Newsletter", Type: "form",
+		Data: []byte(`{"title": "Newsletter", "notify": ["list@example.com"], "fields": [{"name": "email", "type": "email"}]}`)}
+	require.NoError(t, db.Create(newsletter).Error)
+	page := &domain.Page{Slug: "contact", Title: "Contact", Status: domain.PageStatusPublished}
+	page.Blocks, _ = json.Marshal([]domain.Block{
+		{ID: "form-1", Type: "form", Data: json.RawMessage(`{"title": "Contact us", "notify": ["sales@example.com"], "fields": [{"name": "name", "type": "text"}]}`)},
+		newsletter.RefBlock("form-2"),
+	})
+	require.NoError(t, db.Create(page).Error)
+
+	app := newTestApp(t)
+	app.Get("/api/public/pages/*", NewPageHandler(db).GetPageBySlugPublic)
+	resp := app.send("GET", "/api/public/pages/contact", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Contact us")
+	assert.Contains(t, string(body), "Newsletter")
+	assert.NotContains(t, string(body), "notify")
+	assert.NotContains(t, string(body), "@example.com")
+}
@@ -0,0 +1,503 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// GlobalBlockHandler handles global block-related HTTP requests
+type GlobalBlockHandler struct {
+	db *gorm.DB
+}
+
+// NewGlobalBlockHandler creates a new global block handler instance
+func NewGlobalBlockHandler(db *gorm.DB) *GlobalBlockHandler {
+	return &GlobalBlockHandler{
+		db: db,
+	}
+}
+
+// CreateGlobalBlockRequest represents the request body for creating a global block
+// The block is given by type and data, or copied from a page block with page_id and block_id
+type CreateGlobalBlockRequest struct {
+	Name    string          `json:"name"`
+	Type    string          `json:"type,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	PageID  string          `json:"page_id,omitempty"`
+	BlockID string          `json:"block_id,omitempty"`
+	Replace bool            `json:"replace,omitempty"` // Replace the page block with a ref block to the new global block
+}
+
+// UpdateGlobalBlockRequest represents the request body for updating a global block
+type UpdateGlobalBlockRequest struct {
+	Name string          `json:"name,omitempty"`
+	Type string          `json:"type,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// ListGlobalBlocks handles GET /api/v1/global-blocks (protected endpoint)
+func (h *GlobalBlockHandler) ListGlobalBlocks(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	blocks, err := repository.NewGlobalBlockRepository(db).List(c.Context(), middleware.GetTenantID(c))
+	if err != nil {
+		log.Printf("Error listing global blocks: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list global blocks",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  blocks,
+		"total": len(blocks),
+	})
+}
+
+// CreateGlobalBlock handles POST /api/v1/global-blocks (protected endpoint)
+// With page_id and block_id the page block is saved as a global block, and with
+// replace the page block is swapped for a ref block in the same transaction
+func (h *GlobalBlockHandler) CreateGlobalBlock(c *fiber.Ctx) error {
+	var req CreateGlobalBlockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tenantID := middleware.GetTenantID(c)
+	global := &domain.GlobalBlock{
+		TenantID: tenantID,
+		Name:     req.Name,
+		Type:     req.Type,
+		Data:     datatypes.JSON(req.Data),
+	}
+
+	var page *domain.Page
+	var blocks []domain.Block
+	blockIndex := -1
+	if req.PageID != "" {
+		pageID, err := uuid.Parse(req.PageID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid page ID format",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		page, err = repository.NewPageRepository(db).GetByID(c.Context(), pageID)
+		if err == nil && page.TenantID != tenantID {
+			err = fmt.Errorf("page not found: %w", gorm.ErrRecordNotFound)
+		}
+		if err != nil {
+			return pageLookupFailed(c, err)
+		}
+
+		if len(page.Blocks) > 0 {
+			if err := json.Unmarshal(page.Blocks, &blocks); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Page blocks could not be parsed",
+					"code":  fiber.StatusBadRequest,
+				})
+			}
+		}
+		for i, block := range blocks {
+			if block.ID == req.BlockID {
+				blockIndex = i
+				break
+			}
+		}
+		if blockIndex < 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Block not found on page",
+				"code":  fiber.StatusNotFound,
+			})
+		}
+		global.Type = blocks[blockIndex].Type
+		global.Data = datatypes.JSON(blocks[blockIndex].Data)
+	}
+
+	if err := global.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	// Swapping in a ref block changes the block's type, which template locks may forbid
+	var replaced []domain.Block
+	if page != nil && req.Replace {
+		global.ID = uuid.New()
+		replaced = append(replaced, blocks...)
+		replaced[blockIndex] = global.RefBlock(blocks[blockIndex].ID)
+		if ok, err := enforceBlockLocks(c, page, replaced); !ok {
+			return err
+		}
+	}
+
+	err = db.WithContext(c.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := repository.NewGlobalBlockRepository(tx).Create(c.Context(), global); err != nil {
+			return err
+		}
+		if replaced == nil {
+			return nil
+		}
+
+		blocksJSON, err := json.Marshal(replaced)
+		if err != nil {
+			return err
+		}
+		page.Blocks = blocksJSON
+		return repository.NewPageRepository(tx).As(actorID(c)).Update(c.Context(), page)
+	})
+	if err != nil {
+		log.Printf("Error creating global block: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create global block",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(global)
+}
+
+// GetGlobalBlock handles GET /api/v1/global-blocks/:id (protected endpoint)
+func (h *GlobalBlockHandler) GetGlobalBlock(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid global block ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	global, err := h.getTenantGlobalBlock(c, db, id)
+	if err != nil {
+		return globalBlockLookupFailed(c, err)
+	}
+
+	return c.JSON(global)
+}
+
+// UpdateGlobalBlock handles PUT /api/v1/global-blocks/:id (protected endpoint)
+// Omitted fields keep their current values; every page using the block shows the change
+func (h *GlobalBlockHandler) UpdateGlobalBlock(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid global block ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var req UpdateGlobalBlockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	global, err := h.getTenantGlobalBlock(c, db, id)
+	if err != nil {
+		return globalBlockLookupFailed(c, err)
+	}
+
+	if req.Name != "" {
+		global.Name = req.Name
+	}
+	if req.Type != "" {
+		global.Type = req.Type
+	}
+	if len(req.Data) > 0 {
+		global.Data = datatypes.JSON(req.Data)
+	}
+	if err := global.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	if err := repository.NewGlobalBlockRepository(db).Update(c.Context(), global); err != nil {
+		log.Printf("Error updating global block: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update global block",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(global)
+}
+
+// DeleteGlobalBlock handles DELETE /api/v1/global-blocks/:id (protected endpoint)
+// Blocks that are still referenced by pages cannot be deleted
+func (h *GlobalBlockHandler) DeleteGlobalBlock(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid global block ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if _, err := h.getTenantGlobalBlock(c, db, id); err != nil {
+		return globalBlockLookupFailed(c, err)
+	}
+
+	repo := repository.NewGlobalBlockRepository(db)
+	usage, err := listGlobalBlockUsage(c, db, id)
+	if err != nil {
+		log.Printf("Error listing global block usage: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete global block",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	if len(usage) > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": domain.ErrGlobalBlockInUse.Error(),
+			"code":  fiber.StatusConflict,
+			"pages": usage,
+		})
+	}
+
+	if err := repo.Delete(c.Context(), id); err != nil {
+		log.Printf("Error deleting global block: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete global block",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// ListGlobalBlockUsage handles GET /api/v1/global-blocks/:id/usage (protected endpoint)
+// Lists every page and post that references the block, including drafts and translations
+func (h *GlobalBlockHandler) ListGlobalBlockUsage(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid global block ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if _, err := h.getTenantGlobalBlock(c, db, id); err != nil {
+		return globalBlockLookupFailed(c, err)
+	}
+
+	usage, err := listGlobalBlockUsage(c, db, id)
+	if err != nil {
+		log.Printf("Error listing global block usage: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list global block usage",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  usage,
+		"total": len(usage),
+	})
+}
+
+// getTenantGlobalBlock loads a global block, hiding blocks of other tenants
+func (h *GlobalBlockHandler) getTenantGlobalBlock(c *fiber.Ctx, db *gorm.DB, id uuid.UUID) (*domain.GlobalBlock, error) {
+	global, err := repository.NewGlobalBlockRepository(db).GetByID(c.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if global.TenantID != middleware.GetTenantID(c) {
+		return nil, fmt.Errorf("global block not found: %w", gorm.ErrRecordNotFound)
+	}
+	return global, nil
+}
+
+// listGlobalBlockUsage summarises the pages and then the posts using a global block
+func listGlobalBlockUsage(c *fiber.Ctx, db *gorm.DB, id uuid.UUID) ([]fiber.Map, error) {
+	repo := repository.NewGlobalBlockRepository(db)
+	tenantID := middleware.GetTenantID(c)
+	pages, err := repo.ListUsage(c.Context(), tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := repo.ListPostUsage(c.Context(), tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]fiber.Map, 0, len(pages)+len(posts))
+	for _, page := range pages {
+		usage = append(usage, fiber.Map{
+			"type":   "page",
+			"id":     page.ID,
+			"title":  page.Title,
+			"slug":   page.Slug,
+			"locale": page.Locale,
+			"status": page.Status,
+		})
+	}
+	for _, post := range posts {
+		usage = append(usage, fiber.Map{
+			"type":   "post",
+			"id":     post.ID,
+			"title":  post.Title,
+			"slug":   post.Slug,
+			"locale": post.Locale,
+			"status": post.Status,
+		})
+	}
+	return usage, nil
+}
+
+// validateBlocks checks blocks of a page or post and verifies that their ref blocks
+// point to global blocks of the tenant. Writes a 400 response and returns false otherwise.
+func validateBlocks(c *fiber.Ctx, db *gorm.DB, tenantID string, blocks []domain.Block) (bool, error) {
+	if err := domain.ValidateBlocks(blocks); err != nil {
+		return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid blocks: " + err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var ids []uuid.UUID
+	for _, block := range blocks {
+		if block.Type != string(domain.BlockTypeRef) {
+			continue
+		}
+		id, ok := domain.BlockRefID(block)
+		if !ok {
+			return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Ref block " + block.ID + " needs a valid global_block_id",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return true, nil
+	}
+
+	globals, err := repository.NewGlobalBlockRepository(db).ListByIDs(c.Context(), tenantID, ids)
+	if err != nil {
+		log.Printf("Error checking block references: %v", err)
+		return false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to check block references",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	found := make(map[uuid.UUID]bool, len(globals))
+	for _, global := range globals {
+		found[global.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Global block " + id.String() + " does not exist",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+	return true, nil
+}
+
+// resolveGlobalBlocks replaces ref blocks with the global blocks they point to
+// The global blocks of all given block arrays are loaded with one query.
+// Failures are logged and leave the blocks unresolved.
+func resolveGlobalBlocks(c *fiber.Ctx, db *gorm.DB, tenantID string, blocks ...*datatypes.JSON) {
+	var ids []uuid.UUID
+	for _, b := range blocks {
+		ids = append(ids, domain.BlockRefIDs(*b)...)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	globals, err := repository.NewGlobalBlockRepository(db).ListByIDs(c.Context(), tenantID, ids)
+	if err != nil {
+		log.Printf("Error loading global blocks: %v", err)
+		return
+	}
+	byID := make(map[uuid.UUID]*domain.GlobalBlock, len(globals))
+	for _, global := range globals {
+		byID[global.ID] = global
+	}
+
+	for _, b := range blocks {
+		if len(domain.BlockRefIDs(*b)) == 0 {
+			continue
+		}
+		resolved, err := domain.ResolveBlockRefs(*b, byID)
+		if err != nil {
+			log.Printf("Error resolving global blocks: %v", err)
+			continue
+		}
+		*b = resolved
+	}
+}
+
+// resolvePublicBlocks prepares block arrays for a public response
+// Global blocks are resolved and the staff-only parts of blocks, such as form notification addresses, removed
+func resolvePublicBlocks(c *fiber.Ctx, db *gorm.DB, tenantID string, blocks ...*datatypes.JSON) {
+	resolveGlobalBlocks(c, db, tenantID, blocks...)
+	for _, b := range blocks {
+		*b = domain.StripFormNotify(*b)
+	}
+}
+
+// globalBlockLookupFailed writes the response for a failed global block lookup
+func globalBlockLookupFailed(c *fiber.Ctx, err error) error {
+	if strings.Contains(err.Error(), "global block not found") {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Global block not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+	log.Printf("Error getting global block: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to get global block",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalBlockHandler_SharedAcrossPages(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	pages := NewPageHandler(db)
+	globals := NewGlobalBlockHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/pages", pages.CreatePage)
+	app.Post("/api/v1/global-blocks", globals.CreateGlobalBlock)
+	app.Put("/api/v1/global-blocks/:id", globals.UpdateGlobalBlock)
+	app.Delete("/api/v1/global-blocks/:id", globals.DeleteGlobalBlock)
+	app.Get("/api/v1/global-blocks/:id/usage", globals.ListGlobalBlockUsage)
+	app.Get("/api/public/pages/*", pages.GetPageBySlugPublic)
+
+	publicBlocks := func(slug string) []domain.Block {
+		resp := app.send("GET", "/api/public/pages/"+slug, nil)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var page struct {
+			Blocks []domain.Block `json:"blocks"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		return page.Blocks
+	}
+
+	// Save a block of an existing page as a global block, replacing it with a ref
+	cta := json.RawMessage(`{"title":"Start today","button_text":"Sign up","button_url":"/signup"}`)
+	resp := app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "home", Title: "Home", Status: "published", Blocks: []domain.Block{
+		{ID: "b1", Type: "text", Data: json.RawMessage(`{"content":"Welcome"}`)},
+		{ID: "b2", Type: "cta", Data: cta},
+	}})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var home domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&home))
+
+	resp = app.send("POST", "/api/v1/global-blocks", CreateGlobalBlockRequest{Name: "Signup CTA", PageID: home.ID.String(), BlockID: "b2", Replace: true})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var global domain.GlobalBlock
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&global))
+	assert.Equal(t, "cta", global.Type)
+	assert.JSONEq(t, string(cta), string(global.Data))
+
+	// Another page references it; refs to unknown global blocks are rejected
+	ref := global.RefBlock("c1")
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "pricing", Title: "Pricing", Status: "published", Blocks: []domain.Block{ref}})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "broken", Title: "Broken", Blocks: []domain.Block{
+		{ID: "x", Type: "ref", Data: json.RawMessage(`{"global_block_id":"00000000-0000-0000-0000-000000000001"}`)},
+	}})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	// Editing the global block changes every page at read time
+	resp = app.send("PUT", "/api/v1/global-blocks/"+global.ID.String(), UpdateGlobalBlockRequest{Data: json.RawMessage(`{"title":"Start now","button_text":"Sign up","button_url":"/signup"}`)})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	for _, slug := range []string{"home", "pricing"} {
+		blocks := publicBlocks(slug)
+		last := blocks[len(blocks)-1]
+		assert.Equal(t, "cta", last.Type, slug)
+		assert.Equal(t, global.ID.String(), last.GlobalBlockID, slug)
+		assert.JSONEq(t, `{"title":"Start now","button_text":"Sign up","button_url":"/signup"}`, string(last.Data), slug)
+	}
+	assert.Equal(t, "b2", publicBlocks("home")[1].ID)
+
+	// Posts use the same block protocol
+	ref.ID = "p1"
+	refJSON, err := json.Marshal([]domain.Block{ref})
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&domain.Post{Title: "News", Slug: "news", Blocks: refJSON, AuthorID: uuid.New()}).Error)
+
+	// Usage lists the pages and the post, and the block cannot be deleted while in use
+	resp = app.send("GET", "/api/v1/global-blocks/"+global.ID.String()+"/usage", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var usage struct {
+		Data []struct {
+			Type string `json:"type"`
+			Slug string `json:"slug"`
+		} `json:"data"`
+		Total int `json:"total"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&usage))
+	require.Equal(t, 3, usage.Total)
+	assert.Equal(t, "home", usage.Data[0].Slug)
+	assert.Equal(t, "pricing", usage.Data[1].Slug)
+	assert.Equal(t, "post", usage.Data[2].Type)
+	assert.Equal(t, "news", usage.Data[2].Slug)
+
+	resp = app.send("DELETE", "/api/v1/global-blocks/"+global.ID.String(), nil)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
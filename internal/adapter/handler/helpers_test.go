@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gohac/internal/adapter/eventbus"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// testModels are the tables of a test database, as created by the migrations
+var testModels = []interface{}{
+	&domain.User{},
+	&domain.Page{},
+	&domain.Post{},
+	&domain.Category{},
+	&domain.Tag{},
+	&domain.Menu{},
+	&domain.SystemConfig{},
+	&domain.Redirect{},
+	&domain.Collection{},
+	&domain.CollectionItem{},
+	&domain.GlobalBlock{},
+	&domain.PageTemplate{},
+	&domain.Comment{},
+	&domain.FormSubmission{},
+	&domain.ReviewComment{},
+	&domain.Notification{},
+	&domain.EditLock{},
+	&domain.Webhook{},
+	&domain.WebhookDelivery{},
+	&domain.OutboxEvent{},
+	&domain.AuditLog{},
+	&domain.Plan{},
+	&domain.Tenant{},
+	&domain.UsageCounter{},
+}
+
+// setupHandlerDB creates an isolated in-memory SQLite database with every table and the search index
+func setupHandlerDB(t *testing.T) *gorm.DB {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.New().String())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(testModels...))
+	require.NoError(t, repository.CreateSearchSchema(db))
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+// dispatchEvents hands the events recorded in the outbox to the subscribers the server registers,
+// as the outbox dispatcher would on its next poll
+func dispatchEvents(t *testing.T, db *gorm.DB) {
+	bus := eventbus.NewBus()
+	eventbus.RegisterAuditLog(bus, db)
+	eventbus.RegisterSearchIndex(bus, db)
+	eventbus.RegisterWebhooks(bus, db)
+	eventbus.RegisterRealtime(bus)
+	_, err := eventbus.NewDispatcher(db, bus).DispatchDue(context.Background())
+	require.NoError(t, err)
+}
+
+// testApp is a Fiber app for handler tests
+// Requests get the locals the auth middleware sets from the JWT, taken from
+// the X-User, X-Role and X-Tenant headers instead
+type testApp struct {
+	*fiber.App
+	t *testing.T
+}
+
+// newTestApp creates an app whose requests are authenticated from their headers
+func newTestApp(t *testing.T) *testApp {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		if userID := c.Get("X-User"); userID != "" {
+			c.Locals("user_id", userID)
+		}
+		if role := c.Get("X-Role"); role != "" {
+			c.Locals("user_role", role)
+		}
+		if tenantID := c.Get("X-Tenant"); tenantID != "" {
+			c.Locals("tenant_id", tenantID)
+			c.Locals("user_tenant_id", tenantID)
+		}
+		return c.Next()
+	})
+	return &testApp{App: app, t: t}
+}
+
+// send makes a request with body encoded as JSON (nil for none) and the given headers
+func (a *testApp) send(method, path string, body interface{}, headers ...map[string]string) *http.Response {
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(a.t, json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	for _, h := range headers {
+		for key, value := range h {
+			req.Header.Set(key, value)
+		}
+	}
+	resp, err := a.Test(req, -1)
+	require.NoError(a.t, err)
+	return resp
+}
+
+// asUser returns the headers authenticating a request as user
+func asUser(user *domain.User) map[string]string {
+	return map[string]string{
+		"X-User":   user.ID.String(),
+		"X-Role":   string(user.Role),
+		"X-Tenant": user.TenantID,
+	}
+}
+
+// asRole returns the headers authenticating a request as an unnamed user with a role
+func asRole(role domain.UserRole) map[string]string {
+	return map[string]string{"X-Role": string(role)}
+}
+
+// decodeJSON decodes a response body into v
+func decodeJSON(t *testing.T, resp *http.Response, v interface{}) {
+	t.Helper()
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+}
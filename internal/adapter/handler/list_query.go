@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+
+	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseListQuery parses the filter, sort, fields and paging parameters of a list endpoint
+// ok is false if the response has already been written
+func parseListQuery(c *fiber.Ctx, params map[string]string, spec repoInterface.ListSpec) (repoInterface.ListQuery, bool, error) {
+	q, err := repoInterface.ParseListQuery(params, spec)
+	if err != nil {
+		return q, false, invalidListQuery(c, err)
+	}
+	return q, true, nil
+}
+
+// parsePaging reads the limit and offset of a list endpoint that has its own filters
+// They are checked like those of every list query, so no list returns more than repoInterface.MaxPageSize rows
+// ok is false if the response has already been written
+func parsePaging(c *fiber.Ctx, defaultLimit int) (limit, offset int, ok bool, err error) {
+	q, ok, err := parseListQuery(c, pagingParams(c), repoInterface.ListSpec{DefaultLimit: defaultLimit})
+	return q.Limit, q.Offset, ok, err
+}
+
+// pagingParams picks the limit and offset parameters of a request
+func pagingParams(c *fiber.Ctx) map[string]string {
+	params := make(map[string]string, 2)
+	for key, value := range c.Queries() {
+		if key == "limit" || key == "offset" {
+			params[key] = value
+		}
+	}
+	return params
+}
+
+// listFailed writes the response for a list query the repository rejected or failed to run
+func listFailed(c *fiber.Ctx, err error, message string) error {
+	if errors.Is(err, domain.ErrInvalidListQuery) {
+		return invalidListQuery(c, err)
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": message,
+		"code":  fiber.StatusInternalServerError,
+	})
+}
+
+func invalidListQuery(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": err.Error(),
+		"code":  fiber.StatusBadRequest,
+	})
+}
+
+// respondList writes a page of a list, keeping only the fields the query selected
+// next_cursor is only present when there are more rows
+func respondList(c *fiber.Ctx, items interface{}, q repoInterface.ListQuery, page repoInterface.ListPage) error {
+	body := fiber.Map{
+		"data":   items,
+		"total":  page.Total,
+		"limit":  q.Limit,
+		"offset": q.Offset,
+	}
+	if page.NextCursor != "" {
+		body["next_cursor"] = page.NextCursor
+	}
+	if len(q.Fields) == 0 {
+		return c.JSON(body)
+	}
+
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	var rows []map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &rows); err != nil {
+		return err
+	}
+	selected := make([]map[string]json.RawMessage, len(rows))
+	for i, row := range rows {
+		selected[i] = make(map[string]json.RawMessage, len(q.Fields))
+		for _, name := range q.Fields {
+			if value, ok := row[name]; ok {
+				selected[i][name] = value
+			}
+		}
+	}
+	body["data"] = selected
+	return c.JSON(body)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePaging_ListsRejectBadPages(t *testing.T) {
+	db := setupHandlerDB(t)
+	post := &domain.Post{Title: "Hello", Slug: "hello", Status: domain.PostStatusPublished}
+	require.NoError(t, db.Omit("Author").Create(post).Error)
+	require.NoError(t, db.Create(&domain.Tag{Name: "Go", Slug: "go"}).Error)
+
+	posts := NewPostHandler(db)
+	tags := NewTagHandler(db)
+	comments := NewCommentHandler(db)
+	search := NewSearchHandler(db)
+	notifications := NewNotificationHandler(db)
+	app := newTestApp(t)
+	app.Get("/api/public/posts", posts.ListPostsPublic)
+	app.Get("/api/public/tags", tags.ListTagsPublic)
+	app.Get("/api/public/tags/:slug", posts.ListTagPostsPublic)
+	app.Get("/api/public/posts/:slug/comments", comments.ListCommentsPublic)
+	app.Get("/api/v1/comments", comments.ListComments)
+	app.Get("/api/public/search", search.SearchPublic)
+	app.Get("/api/v1/notifications", notifications.ListNotifications)
+	admin := asRole(domain.UserRoleAdmin)
+
+	for _, path := range []string{
+		"/api/public/posts",
+		"/api/public/tags",
+		"/api/public/tags/go",
+		"/api/public/posts/hello/comments",
+		"/api/v1/comments",
+		"/api/public/search?q=hello",
+		"/api/v1/notifications",
+	} {
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+		resp := app.send("GET", path, nil, admin)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode, path)
+
+		// Pages larger than any list allows, empty pages and negative offsets are refused, not clamped
+		for _, query := range []string{"limit=-1", "limit=1000000", "limit=0", "offset=-1", "limit=x"} {
+			resp := app.send("GET", path+sep+query, nil, admin)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, path+sep+query)
+		}
+	}
+
+	// Search keeps its own smaller page size
+	resp := app.send("GET", "/api/public/search?q=hello&limit=100", nil)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp = app.send("GET", "/api/public/search?q=hello&limit=50", nil)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
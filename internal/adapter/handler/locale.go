@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// tenantSettings loads the current tenant's settings
+// Falls back to empty settings (default locale only) so public content stays reachable
+func tenantSettings(c *fiber.Ctx, db *gorm.DB) *domain.GlobalSettings {
+	settings, err := repository.NewSettingsRepository(db).GetGlobalSettings(c.Context(), middleware.GetTenantID(c))
+	if err != nil {
+		log.Printf("Error loading settings for locale negotiation: %v", err)
+		return &domain.GlobalSettings{}
+	}
+	return settings
+}
+
+// negotiateLocale picks the locale to serve from ?locale= or the Accept-Language header
+// Only enabled locales are considered; the tenant's default locale is the fallback
+func negotiateLocale(c *fiber.Ctx, settings *domain.GlobalSettings) string {
+	if locale := domain.NormalizeLocale(c.Query("locale")); locale != "" && settings.IsLocaleEnabled(locale) {
+		return locale
+	}
+
+	enabled := settings.EnabledLocales()
+	for _, tag := range parseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage)) {
+		if locale := matchLocale(tag, enabled); locale != "" {
+			return locale
+		}
+	}
+
+	return settings.PrimaryLocale()
+}
+
+// matchLocale matches a requested locale against the enabled locales
+// An exact match wins, then a locale with the same language (e.g. "pt-PT" matches "pt-BR")
+func matchLocale(tag string, enabled []string) string {
+	locale := domain.NormalizeLocale(tag)
+	if locale == "" {
+		return ""
+	}
+	for _, candidate := range enabled {
+		if candidate == locale {
+			return candidate
+		}
+	}
+	language := domain.LocaleLanguage(locale)
+	for _, candidate := range enabled {
+		if domain.LocaleLanguage(candidate) == language {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header, most preferred first
+// Tags with q=0 and the "*" wildcard are dropped
+func parseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weightedTag{tag: tag, q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+// resolveContentLocale validates the locale requested for new content
+// An empty locale means the tenant's default locale
+func resolveContentLocale(settings *domain.GlobalSettings, requested string) (string, error) {
+	if requested == "" {
+		return settings.PrimaryLocale(), nil
+	}
+	locale := domain.NormalizeLocale(requested)
+	if locale == "" || !settings.IsLocaleEnabled(locale) {
+		return "", domain.ErrLocaleNotEnabled
+	}
+	return locale, nil
+}
+
+// localeNotEnabled writes the response for content in a locale the tenant has not enabled
+func localeNotEnabled(c *fiber.Ctx, settings *domain.GlobalSettings) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":   "Locale is not enabled",
+		"code":    fiber.StatusBadRequest,
+		"locales": settings.EnabledLocales(),
+	})
+}
+
+// setContentLanguage marks a response as localized
+func setContentLanguage(c *fiber.Ctx, locale string) {
+	c.Set(fiber.HeaderContentLanguage, locale)
+	c.Vary(fiber.HeaderAcceptLanguage)
+}
+
+// chooseVariantLocale picks which of the available locales to serve
+// Returns an empty string if neither the wanted nor the fallback locale is available
+func chooseVariantLocale(available map[string]bool, wanted, fallback string) string {
+	if available[wanted] {
+		return wanted
+	}
+	if available[fallback] {
+		return fallback
+	}
+	return ""
+}
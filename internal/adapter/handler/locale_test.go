@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, []string{"fr-CH", "fr", "en", "de"}, parseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5"))
+	assert.Equal(t, []string{"de", "en"}, parseAcceptLanguage("en;q=0.2, de, ja;q=0"))
+	assert.Empty(t, parseAcceptLanguage(""))
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	settings := &domain.GlobalSettings{DefaultLocale: "en", Locales: []string{"de", "pt-BR"}}
+
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		want           string
+	}{
+		{"query parameter", "?locale=de", "pt-BR", "de"},
+		{"query parameter is normalized", "?locale=pt_br", "", "pt-BR"},
+		{"disabled query locale is ignored", "?locale=fr", "de", "de"},
+		{"exact header match", "", "pt-BR, de;q=0.5", "pt-BR"},
+		{"language match", "", "pt-PT", "pt-BR"},
+		{"preference order", "", "fr, de;q=0.9, en;q=0.8", "de"},
+		{"fallback to default", "", "ja, fr", "en"},
+		{"no preference", "", "", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				return c.SendString(negotiateLocale(c, settings))
+			})
+
+			req := httptest.NewRequest("GET", "/"+tt.query, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			body := make([]byte, 16)
+			n, _ := resp.Body.Read(body)
+			assert.Equal(t, tt.want, string(body[:n]))
+		})
+	}
+}
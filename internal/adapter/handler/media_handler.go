@@ -7,23 +7,67 @@ import (
 	"path/filepath"
 	"strings"
 
+	"gohac/internal/adapter/database"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 // MediaHandler handles media-related HTTP requests
 type MediaHandler struct {
+	db         *gorm.DB
 	uploadPath string
 }
 
 // NewMediaHandler creates a new media handler instance
-func NewMediaHandler() *MediaHandler {
+func NewMediaHandler(db *gorm.DB) *MediaHandler {
+	return &MediaHandler{
+		db:         db,
+		uploadPath: mediaUploadPath(),
+	}
+}
+
+// mediaUploadPath returns the directory uploaded media files are stored in
+func mediaUploadPath() string {
 	uploadPath := os.Getenv("STORAGE_PATH")
 	if uploadPath == "" {
 		uploadPath = "./storage/uploads"
 	}
-	return &MediaHandler{
-		uploadPath: uploadPath,
+	return uploadPath
+}
+
+// mediaItemForURL describes the uploaded file a media URL points to
+// Returns false for URLs outside the uploads directory and for missing files
+func mediaItemForURL(uploadPath, url string) (*MediaItem, bool) {
+	var rel string
+	for _, prefix := range []string{"/uploads/", "/static/uploads/"} {
+		if strings.HasPrefix(url, prefix) {
+			rel = strings.TrimPrefix(url, prefix)
+			break
+		}
+	}
+	// Reject traversal outside the uploads directory
+	if rel == "" || strings.Contains(rel, "..") || strings.Contains(rel, "\\") {
+		return nil, false
 	}
+
+	fileInfo, err := os.Stat(filepath.Join(uploadPath, filepath.FromSlash(rel)))
+	if err != nil || fileInfo.IsDir() {
+		return nil, false
+	}
+
+	mimeType := mime.TypeByExtension(filepath.Ext(rel))
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	return &MediaItem{
+		Name: filepath.Base(rel),
+		URL:  url,
+		Size: fileInfo.Size(),
+		Type: mimeType,
+	}, true
 }
 
 // MediaItem represents a media file
@@ -93,21 +137,9 @@ func (h *MediaHandler) ListMedia(c *fiber.Ctx) error {
 
 // GetMediaInfo handles GET /api/v1/media/:filename (protected endpoint)
 func (h *MediaHandler) GetMediaInfo(c *fiber.Ctx) error {
-	filename := c.Params("filename")
-	if filename == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Filename is required",
-			"code":  fiber.StatusBadRequest,
-		})
-	}
-
-	// Sanitize filename to prevent directory traversal
-	filename = filepath.Base(filename)
-	if strings.Contains(filename, "..") || strings.Contains(filename, "/") || strings.Contains(filename, "\\") {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid filename",
-			"code":  fiber.StatusBadRequest,
-		})
+	filename, ok, err := mediaFilename(c)
+	if !ok {
+		return err
 	}
 
 	filePath := filepath.Join(h.uploadPath, filename)
@@ -142,3 +174,71 @@ func (h *MediaHandler) GetMediaInfo(c *fiber.Ctx) error {
 		Type: mimeType,
 	})
 }
+
+// DeleteMedia handles DELETE /api/v1/media/:filename (protected endpoint)
+// Removes an uploaded file and frees its size from the tenant's storage usage; requires the admin role
+func (h *MediaHandler) DeleteMedia(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Deleting media requires the admin role",
+			"code":  fiber.StatusForbidden,
+		})
+	}
+
+	filename, ok, err := mediaFilename(c)
+	if !ok {
+		return err
+	}
+
+	filePath := filepath.Join(h.uploadPath, filename)
+	fileInfo, err := os.Stat(filePath)
+	if err == nil && fileInfo.IsDir() {
+		err = os.ErrNotExist
+	}
+	if err == nil {
+		err = os.Remove(filePath)
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "File not found",
+				"code":  fiber.StatusNotFound,
+			})
+		}
+		log.Printf("Error deleting media file %s: %v", filename, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete file",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+	trackUsage(c, db, middleware.GetTenantID(c), domain.UsageMetricStorageBytes, -fileInfo.Size())
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// mediaFilename reads the :filename route parameter, writing the error response if it is missing
+// or would escape the uploads directory
+func mediaFilename(c *fiber.Ctx) (string, bool, error) {
+	filename := c.Params("filename")
+	if filename == "" {
+		return "", false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Filename is required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	// Sanitize filename to prevent directory traversal
+	filename = filepath.Base(filename)
+	if strings.Contains(filename, "..") || strings.Contains(filename, "/") || strings.Contains(filename, "\\") {
+		return "", false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid filename",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	return filename, true, nil
+}
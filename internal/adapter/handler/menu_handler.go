@@ -2,12 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
-	"strconv"
 
 	"gohac/internal/adapter/database"
 	"gohac/internal/adapter/repository"
 	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -29,9 +31,11 @@ func NewMenuHandler(db *gorm.DB) *MenuHandler {
 
 // CreateMenuRequest represents the request body for creating a menu
 type CreateMenuRequest struct {
-	Name        string            `json:"name" validate:"required"`
-	Description string            `json:"description,omitempty"`
-	Items       []domain.MenuItem `json:"items,omitempty"`
+	Name          string            `json:"name" validate:"required"`
+	Description   string            `json:"description,omitempty"`
+	Items         []domain.MenuItem `json:"items,omitempty"`
+	Locale        string            `json:"locale,omitempty"`         // Defaults to the tenant's default locale
+	TranslationOf string            `json:"translation_of,omitempty"` // UUID of the menu this menu translates
 }
 
 // UpdateMenuRequest represents the request body for updating a menu
@@ -67,6 +71,46 @@ func (h *MenuHandler) CreateMenu(c *fiber.Ctx) error {
 
 	repo := repository.NewMenuRepository(db)
 
+	settings := tenantSettings(c, db)
+	locale, err := resolveContentLocale(settings, req.Locale)
+	if err != nil {
+		return localeNotEnabled(c, settings)
+	}
+
+	// Translations are linked to the source menu, never to another translation
+	var translationOfID *uuid.UUID
+	if req.TranslationOf != "" {
+		sourceID, err := uuid.Parse(req.TranslationOf)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid translation_of menu ID",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		source, err := repo.GetByID(c.Context(), sourceID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Source menu not found",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		if source.TranslationOfID != nil {
+			if source, err = repo.GetByID(c.Context(), *source.TranslationOfID); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Source menu not found",
+					"code":  fiber.StatusBadRequest,
+				})
+			}
+		}
+		if source.Locale == locale {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Translation for this locale already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
+		translationOfID = &source.ID
+	}
+
 	// Get tenant ID from context (empty string for community edition)
 	tenantID := ""
 	if tenantIDVal := c.Locals("tenant_id"); tenantIDVal != nil {
@@ -89,13 +133,21 @@ func (h *MenuHandler) CreateMenu(c *fiber.Ctx) error {
 	}
 
 	menu := &domain.Menu{
-		TenantID:    tenantID,
-		Name:        req.Name,
-		Description: req.Description,
-		Items:       datatypes.JSON(itemsJSON),
+		TenantID:        tenantID,
+		Locale:          locale,
+		TranslationOfID: translationOfID,
+		Name:            req.Name,
+		Description:     req.Description,
+		Items:           datatypes.JSON(itemsJSON),
 	}
 
-	if err := repo.Create(c.Context(), menu); err != nil {
+	if err := repo.As(actorID(c)).Create(c.Context(), menu); err != nil {
+		if errors.Is(err, domain.ErrTranslationExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Translation for this locale already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		// Log the actual error for debugging
 		log.Printf("Error creating menu: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -104,24 +156,17 @@ func (h *MenuHandler) CreateMenu(c *fiber.Ctx) error {
 		})
 	}
 
-	// Parse items for response
-	var items []domain.MenuItem
-	if len(menu.Items) > 0 {
-		json.Unmarshal(menu.Items, &items)
-	}
-
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"id":          menu.ID,
-		"name":        menu.Name,
-		"description": menu.Description,
-		"items":       items,
-		"created_at":  menu.CreatedAt,
-		"updated_at":  menu.UpdatedAt,
-	})
+	return c.Status(fiber.StatusCreated).JSON(menuResponse(menu))
 }
 
 // ListMenus handles GET /api/v1/menus (protected endpoint)
+// Accepts the filters, sorts and paging of repository.MenuListSpec
 func (h *MenuHandler) ListMenus(c *fiber.Ctx) error {
+	q, ok, err := parseListQuery(c, c.Queries(), repoInterface.MenuListSpec)
+	if !ok {
+		return err
+	}
+
 	// Get database from context (fallback to handler's DB)
 	db, err := database.GetDBFromContext(c.Context())
 	if err != nil {
@@ -130,56 +175,34 @@ func (h *MenuHandler) ListMenus(c *fiber.Ctx) error {
 
 	repo := repository.NewMenuRepository(db)
 
-	// Parse query parameters
-	limit := 50 // default
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
-	}
-
-	offset := 0
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
-	}
-
-	menus, total, err := repo.List(c.Context(), limit, offset)
+	menus, page, err := repo.List(c.Context(), middleware.GetTenantID(c), q)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to list menus",
-			"code":  fiber.StatusInternalServerError,
-		})
+		log.Printf("Error listing menus: %v", err)
+		return listFailed(c, err, "Failed to list menus")
 	}
 
 	// Parse items for each menu
 	result := make([]fiber.Map, len(menus))
 	for i, menu := range menus {
-		var items []domain.MenuItem
-		if len(menu.Items) > 0 {
-			json.Unmarshal(menu.Items, &items)
-		}
-		result[i] = fiber.Map{
-			"id":          menu.ID,
-			"name":        menu.Name,
-			"description": menu.Description,
-			"items":       items,
-			"created_at":  menu.CreatedAt,
-			"updated_at":  menu.UpdatedAt,
-		}
+		result[i] = menuResponse(menu)
 	}
 
-	return c.JSON(fiber.Map{
-		"data":   result,
-		"total":  total,
-		"limit":  limit,
-		"offset": offset,
-	})
+	return respondList(c, result, q, page)
 }
 
-// GetMenu handles GET /api/v1/menus/:id (protected endpoint) and GET /api/public/menus/:id (public endpoint)
+// GetMenu handles GET /api/v1/menus/:id (protected endpoint)
 func (h *MenuHandler) GetMenu(c *fiber.Ctx) error {
+	return h.getMenu(c, false)
+}
+
+// GetMenuPublic handles GET /api/public/menus/:id (public endpoint)
+// Serves the menu's translation in the negotiated locale, if there is one
+func (h *MenuHandler) GetMenuPublic(c *fiber.Ctx) error {
+	return h.getMenu(c, true)
+}
+
+// getMenu looks up a menu, optionally resolving it to the negotiated locale
+func (h *MenuHandler) getMenu(c *fiber.Ctx, localize bool) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
@@ -211,20 +234,13 @@ func (h *MenuHandler) GetMenu(c *fiber.Ctx) error {
 		})
 	}
 
-	// Parse items for response
-	var items []domain.MenuItem
-	if len(menu.Items) > 0 {
-		json.Unmarshal(menu.Items, &items)
+	if localize {
+		locale := negotiateLocale(c, tenantSettings(c, db))
+		menu = localizeMenu(c, repo, menu, locale)
+		setContentLanguage(c, menu.Locale)
 	}
 
-	return c.JSON(fiber.Map{
-		"id":          menu.ID,
-		"name":        menu.Name,
-		"description": menu.Description,
-		"items":       items,
-		"created_at":  menu.CreatedAt,
-		"updated_at":  menu.UpdatedAt,
-	})
+	return c.JSON(menuResponse(menu))
 }
 
 // UpdateMenu handles PUT /api/v1/menus/:id (protected endpoint)
@@ -287,30 +303,18 @@ func (h *MenuHandler) UpdateMenu(c *fiber.Ctx) error {
 		menu.Items = datatypes.JSON(itemsBytes)
 	}
 
-	if err := repo.Update(c.Context(), menu); err != nil {
+	if err := repo.As(actorID(c)).Update(c.Context(), menu); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update menu",
 			"code":  fiber.StatusInternalServerError,
 		})
 	}
 
-	// Parse items for response
-	var items []domain.MenuItem
-	if len(menu.Items) > 0 {
-		json.Unmarshal(menu.Items, &items)
-	}
-
-	return c.JSON(fiber.Map{
-		"id":          menu.ID,
-		"name":        menu.Name,
-		"description": menu.Description,
-		"items":       items,
-		"created_at":  menu.CreatedAt,
-		"updated_at":  menu.UpdatedAt,
-	})
+	return c.JSON(menuResponse(menu))
 }
 
 // DeleteMenu handles DELETE /api/v1/menus/:id (protected endpoint)
+// The menu goes to the trash
 func (h *MenuHandler) DeleteMenu(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
@@ -344,7 +348,7 @@ func (h *MenuHandler) DeleteMenu(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := repo.Delete(c.Context(), id); err != nil {
+	if err := repo.As(actorID(c)).Delete(c.Context(), id); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to delete menu",
 			"code":  fiber.StatusInternalServerError,
@@ -353,3 +357,43 @@ func (h *MenuHandler) DeleteMenu(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
+
+// localizeMenu returns the variant of a menu in a locale, or the menu itself if there is none
+func localizeMenu(c *fiber.Ctx, repo repoInterface.MenuRepository, menu *domain.Menu, locale string) *domain.Menu {
+	if menu.Locale == locale {
+		return menu
+	}
+
+	sourceID := menu.ID
+	if menu.TranslationOfID != nil {
+		sourceID = *menu.TranslationOfID
+	}
+	if translation, err := repo.GetTranslation(c.Context(), sourceID, locale); err == nil {
+		return translation
+	}
+	if menu.TranslationOfID != nil {
+		if source, err := repo.GetByID(c.Context(), sourceID); err == nil && source.Locale == locale {
+			return source
+		}
+	}
+	return menu
+}
+
+// menuResponse builds the JSON representation of a menu with its items decoded
+func menuResponse(menu *domain.Menu) fiber.Map {
+	var items []domain.MenuItem
+	if len(menu.Items) > 0 {
+		json.Unmarshal(menu.Items, &items)
+	}
+
+	return fiber.Map{
+		"id":                menu.ID,
+		"name":              menu.Name,
+		"description":       menu.Description,
+		"items":             items,
+		"locale":            menu.Locale,
+		"translation_of_id": menu.TranslationOfID,
+		"created_at":        menu.CreatedAt,
+		"updated_at":        menu.UpdatedAt,
+	}
+}
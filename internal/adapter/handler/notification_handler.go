@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationHandler handles the current user's notifications
+type NotificationHandler struct {
+	db *gorm.DB
+}
+
+// NewNotificationHandler creates a new notification handler instance
+func NewNotificationHandler(db *gorm.DB) *NotificationHandler {
+	return &NotificationHandler{
+		db: db,
+	}
+}
+
+// ListNotifications handles GET /api/v1/notifications (protected endpoint)
+// Supports ?unread=true, ?limit= and ?offset=
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	limit, offset, ok, err := parsePaging(c, 20)
+	if !ok {
+		return err
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	repo := repository.NewNotificationRepository(db)
+	tenantID := middleware.GetTenantID(c)
+	userID, _ := c.Locals("user_id").(string)
+	notifications, total, err := repo.ListByUser(c.Context(), tenantID, userID, c.QueryBool("unread"), limit, offset)
+	if err != nil {
+		log.Printf("Error listing notifications: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list notifications",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	unread, err := repo.CountUnread(c.Context(), tenantID, userID)
+	if err != nil {
+		log.Printf("Error counting notifications: %v", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   notifications,
+		"total":  total,
+		"unread": unread,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// MarkNotificationRead handles POST /api/v1/notifications/:id/read (protected endpoint)
+func (h *NotificationHandler) MarkNotificationRead(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid notification ID",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	if err := repository.NewNotificationRepository(db).MarkRead(c.Context(), middleware.GetTenantID(c), userID, id); err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Notification not found",
+				"code":  fiber.StatusNotFound,
+			})
+		}
+		log.Printf("Error marking notification read: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to mark notification read",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// MarkAllNotificationsRead handles POST /api/v1/notifications/read (protected endpoint)
+func (h *NotificationHandler) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	updated, err := repository.NewNotificationRepository(db).MarkAllRead(c.Context(), middleware.GetTenantID(c), userID)
+	if err != nil {
+		log.Printf("Error marking notifications read: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to mark notifications read",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{"updated": updated})
+}
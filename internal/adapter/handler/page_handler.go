@@ -2,13 +2,17 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"gohac/internal/adapter/database"
 	"gohac/internal/adapter/repository"
 	"gohac/internal/core/domain"
 	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -32,9 +36,14 @@ func NewPageHandler(db *gorm.DB) *PageHandler {
 type CreatePageRequest struct {
 	Slug   string         `json:"slug" validate:"required"`
 	Title  string         `json:"title" validate:"required"`
+	Locale string         `json:"locale,omitempty"` // Defaults to the tenant's default locale
 	Blocks []domain.Block `json:"blocks,omitempty"`
 	Status string         `json:"status,omitempty"`
 	Meta   map[string]any `json:"meta,omitempty"`
+
+	ParentID   string `json:"parent_id,omitempty"`   // UUID of the parent page; the slug becomes a segment under its path
+	SortOrder  int    `json:"sort_order,omitempty"`  // Position among siblings
+	TemplateID string `json:"template_id,omitempty"` // UUID of a page template to take blocks and default meta from
 }
 
 // UpdatePageRequest represents the request body for updating a page
@@ -44,6 +53,22 @@ type UpdatePageRequest struct {
 	Blocks []domain.Block `json:"blocks,omitempty"`
 	Status string         `json:"status,omitempty"`
 	Meta   map[string]any `json:"meta,omitempty"`
+
+	ParentID  *string `json:"parent_id,omitempty"`  // UUID of the new parent page, or "" to make the page top-level
+	SortOrder *int    `json:"sort_order,omitempty"` // Position among siblings
+
+	Version *int `json:"version,omitempty"` // Version the edit is based on; an If-Match header takes precedence
+}
+
+// CreatePageTranslationRequest represents the request body for translating a page
+// Omitted fields are copied from the source page
+type CreatePageTranslationRequest struct {
+	Locale string         `json:"locale" validate:"required"`
+	Slug   string         `json:"slug,omitempty"`
+	Title  string         `json:"title,omitempty"`
+	Blocks []domain.Block `json:"blocks,omitempty"`
+	Status string         `json:"status,omitempty"`
+	Meta   map[string]any `json:"meta,omitempty"`
 }
 
 // CreatePage handles POST /api/v1/pages
@@ -73,6 +98,12 @@ func (h *PageHandler) CreatePage(c *fiber.Ctx) error {
 
 	repo := repository.NewPageRepository(db)
 
+	settings := tenantSettings(c, db)
+	locale, err := resolveContentLocale(settings, req.Locale)
+	if err != nil {
+		return localeNotEnabled(c, settings)
+	}
+
 	// Get tenant ID from context (empty string for community edition)
 	tenantID := ""
 	if tenantIDVal := c.Locals("tenant_id"); tenantIDVal != nil {
@@ -85,13 +116,19 @@ func (h *PageHandler) CreatePage(c *fiber.Ctx) error {
 	status := domain.PageStatusDraft
 	if req.Status != "" {
 		status = domain.PageStatus(req.Status)
-		if status != domain.PageStatusDraft && status != domain.PageStatusPublished && status != domain.PageStatusArchived {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid status. Must be 'draft', 'published', or 'archived'",
-				"code":  fiber.StatusBadRequest,
-			})
+		if !status.IsValid() {
+			return invalidWorkflowStatus(c)
 		}
 	}
+	// New pages start as drafts, so any other status must be reachable from draft
+	if ok, err := enforceTransition(c, settings, string(domain.PageStatusDraft), string(status)); !ok {
+		return err
+	}
+
+	// Blocks need IDs, types and data, and ref blocks must point to existing global blocks
+	if ok, err := validateBlocks(c, db, tenantID, req.Blocks); !ok {
+		return err
+	}
 
 	// Marshal blocks to JSON
 	var blocksJSON datatypes.JSON
@@ -121,21 +158,56 @@ func (h *PageHandler) CreatePage(c *fiber.Ctx) error {
 
 	// Create page
 	page := &domain.Page{
-		TenantID: tenantID,
-		Slug:     req.Slug,
-		Title:    req.Title,
-		Status:   status,
-		Blocks:   blocksJSON,
-		Meta:     metaJSON,
+		TenantID:  tenantID,
+		Locale:    locale,
+		Slug:      req.Slug,
+		Title:     req.Title,
+		Status:    status,
+		Blocks:    blocksJSON,
+		Meta:      metaJSON,
+		SortOrder: req.SortOrder,
+	}
+
+	// Pages created from a template start with its blocks and default meta
+	if req.TemplateID != "" {
+		if ok, err := applyPageTemplate(c, db, page, &req); !ok {
+			return err
+		}
 	}
 
-	if err := repo.Create(c.Context(), page); err != nil {
+	// Child pages live under their parent's path
+	if req.ParentID != "" {
+		parent, err := resolveParent(c, repo, page, req.ParentID)
+		if err != nil {
+			return invalidParent(c, err)
+		}
+		page.ParentID = &parent.ID
+		page.Slug = domain.PagePath(parent.Slug, domain.PageSegment(req.Slug))
+	}
+
+	// Reserve the page against the tenant's quota; given back if it cannot be created
+	if ok, err := reserveQuota(c, db, domain.UsageMetricPages, 1); !ok {
+		return err
+	}
+	if err := repo.As(actorID(c)).Create(c.Context(), page); err != nil {
+		releaseQuota(c, db, tenantID, domain.UsageMetricPages, 1)
+		if errors.Is(err, domain.ErrPageAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Page with this slug already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to create page",
 			"code":  fiber.StatusInternalServerError,
 		})
 	}
 
+	if settings.Workflow.IsEnabled() && status != domain.PageStatusDraft {
+		recordTransition(c, db, settings, domain.ReviewEntityPage, page.ID, page.Title, string(domain.PageStatusDraft), string(status), "")
+	}
+
+	setVersionETag(c, page.Version)
 	return c.Status(fiber.StatusCreated).JSON(page)
 }
 
@@ -151,22 +223,14 @@ func (h *PageHandler) ListPages(c *fiber.Ctx) error {
 	repo := repository.NewPageRepository(db)
 
 	// Parse query parameters
-	limit := 20 // default
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
-	}
-
-	offset := 0
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
+	limit, offset, ok, err := parsePaging(c, 20)
+	if !ok {
+		return err
 	}
 
 	status := c.Query("status")
 	search := c.Query("search")
+	locale := domain.NormalizeLocale(c.Query("locale"))
 
 	// Get tenant ID from context
 	var tenantID *uuid.UUID
@@ -182,6 +246,7 @@ func (h *PageHandler) ListPages(c *fiber.Ctx) error {
 		Limit:    limit,
 		Offset:   offset,
 		Status:   status,
+		Locale:   locale,
 		Search:   search,
 		TenantID: tenantID,
 	}
@@ -236,6 +301,7 @@ func (h *PageHandler) GetPage(c *fiber.Ctx) error {
 		})
 	}
 
+	setVersionETag(c, page.Version)
 	return c.JSON(page)
 }
 
@@ -282,24 +348,75 @@ func (h *PageHandler) UpdatePage(c *fiber.Ctx) error {
 		})
 	}
 
+	// Refuse to overwrite changes the editor has not seen
+	if ok, err := checkVersion(c, page.Version, req.Version); !ok {
+		return err
+	}
+
+	oldSlug := page.Slug
+	oldStatus := page.Status
+	settings := tenantSettings(c, db)
+
+	// Moving a page or renaming a child page recomputes its path from the parent's
+	var parent *domain.Page
+	if req.ParentID != nil && *req.ParentID != "" {
+		if parent, err = resolveParent(c, repo, page, *req.ParentID); err != nil {
+			return invalidParent(c, err)
+		}
+		page.ParentID = &parent.ID
+	} else if req.ParentID != nil {
+		page.ParentID = nil
+	} else if page.ParentID != nil && req.Slug != "" {
+		if parent, err = repo.GetByID(c.Context(), *page.ParentID); err != nil {
+			return pageLookupFailed(c, err)
+		}
+	}
+
 	// Update fields if provided
-	if req.Slug != "" {
-		page.Slug = req.Slug
+	if req.Slug != "" || req.ParentID != nil {
+		slug := page.Slug
+		if req.Slug != "" {
+			slug = req.Slug
+		}
+		switch {
+		case parent != nil:
+			page.Slug = domain.PagePath(parent.Slug, domain.PageSegment(slug))
+		case req.ParentID != nil:
+			page.Slug = domain.PageSegment(slug)
+		default:
+			page.Slug = slug
+		}
+	}
+	if req.SortOrder != nil {
+		page.SortOrder = *req.SortOrder
 	}
 	if req.Title != "" {
 		page.Title = req.Title
 	}
 	if req.Status != "" {
 		status := domain.PageStatus(req.Status)
-		if status != domain.PageStatusDraft && status != domain.PageStatusPublished && status != domain.PageStatusArchived {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid status. Must be 'draft', 'published', or 'archived'",
-				"code":  fiber.StatusBadRequest,
-			})
+		if !status.IsValid() {
+			return invalidWorkflowStatus(c)
+		}
+		if ok, err := enforceTransition(c, settings, string(page.Status), string(status)); !ok {
+			return err
 		}
 		page.Status = status
 	}
+	if req.Slug != "" || req.ParentID != nil || req.Title != "" || req.Blocks != nil || req.Meta != nil {
+		status, ok, err := enforceReviewedEdit(c, settings, string(page.Status))
+		if !ok {
+			return err
+		}
+		page.Status = domain.PageStatus(status)
+	}
 	if req.Blocks != nil {
+		if ok, err := enforceBlockLocks(c, page, req.Blocks); !ok {
+			return err
+		}
+		if ok, err := validateBlocks(c, db, page.TenantID, req.Blocks); !ok {
+			return err
+		}
 		blocksJSON, err := json.Marshal(req.Blocks)
 		if err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -320,17 +437,56 @@ func (h *PageHandler) UpdatePage(c *fiber.Ctx) error {
 		page.Meta = metaJSON
 	}
 
-	if err := repo.Update(c.Context(), page); err != nil {
+	// Editing a translation's content brings it up to date with its source
+	if page.TranslationOfID != nil && (req.Title != "" || req.Blocks != nil || req.Meta != nil) {
+		if source, err := repo.GetByID(c.Context(), *page.TranslationOfID); err == nil {
+			page.SourceHash = source.ContentHash()
+		}
+	}
+
+	// Descendants follow the page to its new path
+	moved, err := repo.As(actorID(c)).UpdateWithDescendants(c.Context(), page, oldSlug)
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			// Saved by someone else between loading and saving
+			if current, err := repo.GetByID(c.Context(), id); err == nil {
+				return versionConflict(c, current.Version)
+			}
+		}
+		if errors.Is(err, domain.ErrPageAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Page with this slug already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidParent) {
+			return invalidParent(c, err)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update page",
 			"code":  fiber.StatusInternalServerError,
 		})
 	}
 
+	if settings.Workflow.IsEnabled() && page.Status != oldStatus {
+		recordTransition(c, db, settings, domain.ReviewEntityPage, page.ID, page.Title, string(oldStatus), string(page.Status), "")
+	}
+
+	// Old URLs keep working through permanent redirects
+	if page.Slug != oldSlug {
+		addSlugRedirect(c, db, page.TenantID, domain.PagePublicPath(oldSlug), domain.PagePublicPath(page.Slug))
+		for _, descendant := range moved {
+			oldPath := oldSlug + strings.TrimPrefix(descendant.Slug, page.Slug)
+			addSlugRedirect(c, db, descendant.TenantID, domain.PagePublicPath(oldPath), domain.PagePublicPath(descendant.Slug))
+		}
+	}
+
+	setVersionETag(c, page.Version)
 	return c.JSON(page)
 }
 
 // DeletePage handles DELETE /api/v1/pages/:id
+// The page goes to the trash; pages with nested pages cannot be deleted
 func (h *PageHandler) DeletePage(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
@@ -351,7 +507,7 @@ func (h *PageHandler) DeletePage(c *fiber.Ctx) error {
 	repo := repository.NewPageRepository(db)
 
 	// Check if page exists
-	_, err = repo.GetByID(c.Context(), id)
+	page, err := repo.GetByID(c.Context(), id)
 	if err != nil {
 		if err.Error() == "page not found: record not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -365,17 +521,26 @@ func (h *PageHandler) DeletePage(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := repo.Delete(c.Context(), id); err != nil {
+	if err := repo.As(actorID(c)).Delete(c.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrPageHasChildren) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Page has child pages. Move or delete them first",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to delete page",
 			"code":  fiber.StatusInternalServerError,
 		})
 	}
 
+	trackUsage(c, db, page.TenantID, domain.UsageMetricPages, -1)
+
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
 
 // GetPageBySlugPublic handles GET /api/public/pages/* (public endpoint, no auth required)
+// Ref blocks are replaced by the global blocks they point to. Supports ?expand= to embed referenced menus, media, posts, pages, categories and authors
 func (h *PageHandler) GetPageBySlugPublic(c *fiber.Ctx) error {
 	// Get slug from wildcard parameter
 	slug := c.Params("*")
@@ -398,12 +563,22 @@ func (h *PageHandler) GetPageBySlugPublic(c *fiber.Ctx) error {
 	}
 
 	repo := repository.NewPageRepository(db)
+	tenantID := middleware.GetTenantID(c)
+	settings := tenantSettings(c, db)
+	locale := negotiateLocale(c, settings)
+	preview := c.Query("preview") == "true"
 
-	// Get page by slug
-	page, err := repo.GetBySlug(c.Context(), slug)
+	// Get page by slug, preferring the negotiated locale
+	page, err := repo.GetBySlugInLocale(c.Context(), tenantID, locale, slug)
+	if err != nil && strings.Contains(err.Error(), "page not found") {
+		page, err = repo.GetBySlug(c.Context(), tenantID, slug)
+	}
 	if err != nil {
 		// Check if error contains "page not found" (repository wraps gorm.ErrRecordNotFound)
 		if strings.Contains(err.Error(), "page not found") {
+			if redirectFor(c, db, domain.PagePublicPath(slug)) {
+				return nil
+			}
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Page not found",
 				"code":  fiber.StatusNotFound,
@@ -415,8 +590,15 @@ func (h *PageHandler) GetPageBySlugPublic(c *fiber.Ctx) error {
 		})
 	}
 
+	// Serve the variant in the negotiated locale, falling back to the default locale
+	variants, err := repo.ListTranslations(c.Context(), page.SourceID())
+	if err != nil {
+		log.Printf("Error listing translations of page %s: %v", page.ID, err)
+	} else {
+		page = selectPageVariant(page, variants, locale, settings.PrimaryLocale(), preview)
+	}
+
 	// Check if page is published (unless preview=true query param is present)
-	preview := c.Query("preview") == "true"
 	if page.Status != domain.PageStatusPublished && !preview {
 		// Return 404 if page is not published (security: don't reveal draft pages)
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -426,5 +608,455 @@ func (h *PageHandler) GetPageBySlugPublic(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(page)
+	addPageNavigation(c, repo, page, preview)
+	resolvePublicBlocks(c, db, page.TenantID, &page.Blocks)
+
+	setContentLanguage(c, page.Locale)
+	return respondExpanded(c, db, tenantID, locale, page)
+}
+
+// addPageNavigation sets a page's breadcrumbs and previous/next siblings
+// Unpublished pages are only linked in preview mode
+func addPageNavigation(c *fiber.Ctx, repo repoInterface.PageRepository, page *domain.Page, preview bool) {
+	visible := func(p *domain.Page) bool {
+		return preview || p.Status == domain.PageStatusPublished
+	}
+
+	var ancestors []domain.PageLink
+	parentID := page.ParentID
+	for depth := 0; parentID != nil && depth < domain.MaxPageDepth; depth++ {
+		parent, err := repo.GetByID(c.Context(), *parentID)
+		if err != nil {
+			log.Printf("Error loading ancestors of page %s: %v", page.ID, err)
+			break
+		}
+		if visible(parent) {
+			ancestors = append(ancestors, domain.NewPageLink(parent))
+		}
+		parentID = parent.ParentID
+	}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		page.Breadcrumbs = append(page.Breadcrumbs, ancestors[i])
+	}
+
+	siblings, err := repo.ListChildren(c.Context(), page.TenantID, page.Locale, page.ParentID)
+	if err != nil {
+		log.Printf("Error loading siblings of page %s: %v", page.ID, err)
+		return
+	}
+	var previous *domain.Page
+	found := false
+	for _, sibling := range siblings {
+		if sibling.ID == page.ID {
+			found = true
+			continue
+		}
+		if !visible(sibling) {
+			continue
+		}
+		if !found {
+			previous = sibling
+			continue
+		}
+		next := domain.NewPageLink(sibling)
+		page.Next = &next
+		break
+	}
+	if previous != nil {
+		link := domain.NewPageLink(previous)
+		page.Previous = &link
+	}
+}
+
+// GetPageTree handles GET /api/v1/pages/tree?locale=... (protected endpoint)
+// Returns the tenant's pages nested under their parents, in sort order
+func (h *PageHandler) GetPageTree(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	pages, err := repository.NewPageRepository(db).ListHierarchy(c.Context(), middleware.GetTenantID(c), domain.NormalizeLocale(c.Query("locale")))
+	if err != nil {
+		log.Printf("Error loading page tree: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to load page tree",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": domain.BuildPageTree(pages),
+	})
+}
+
+// resolveParent loads the page that will become page's parent
+// The parent must share the page's tenant and locale and must not be the page or one of its descendants,
+// and the page's subtree must still fit within MaxPageDepth below it
+func resolveParent(c *fiber.Ctx, repo repoInterface.PageRepository, page *domain.Page, parentIDStr string) (*domain.Page, error) {
+	parentID, err := uuid.Parse(parentIDStr)
+	if err != nil {
+		return nil, domain.ErrInvalidParent
+	}
+	parent, err := repo.GetByID(c.Context(), parentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "page not found") {
+			return nil, domain.ErrInvalidParent
+		}
+		return nil, err
+	}
+	if parent.TenantID != page.TenantID || parent.Locale != page.Locale {
+		return nil, domain.ErrInvalidParent
+	}
+
+	// Walk up from the parent; reaching the page itself would create a cycle
+	ancestor := parent
+	depth := 1
+	for {
+		if ancestor.ID == page.ID || depth >= domain.MaxPageDepth {
+			return nil, domain.ErrInvalidParent
+		}
+		if ancestor.ParentID == nil {
+			break
+		}
+		if ancestor, err = repo.GetByID(c.Context(), *ancestor.ParentID); err != nil {
+			return nil, err
+		}
+		depth++
+	}
+
+	// depth is the parent's level, so the page lands one level below it
+	height, err := pageSubtreeHeight(c, repo, page)
+	if err != nil {
+		return nil, err
+	}
+	if depth+1+height > domain.MaxPageDepth {
+		return nil, domain.ErrInvalidParent
+	}
+	return parent, nil
+}
+
+// pageSubtreeHeight returns how many levels of descendants a page has (0 for a new page or a leaf)
+func pageSubtreeHeight(c *fiber.Ctx, repo repoInterface.PageRepository, page *domain.Page) (int, error) {
+	if page.ID == uuid.Nil {
+		return 0, nil
+	}
+	height := 0
+	level := []*domain.Page{page}
+	for len(level) > 0 && height <= domain.MaxPageDepth {
+		var next []*domain.Page
+		for _, p := range level {
+			children, err := repo.ListChildren(c.Context(), p.TenantID, p.Locale, &p.ID)
+			if err != nil {
+				return 0, err
+			}
+			next = append(next, children...)
+		}
+		if len(next) > 0 {
+			height++
+		}
+		level = next
+	}
+	return height, nil
+}
+
+// invalidParent writes the response for a parent page that cannot be used
+func invalidParent(c *fiber.Ctx, err error) error {
+	if errors.Is(err, domain.ErrInvalidParent) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid parent page. It must exist in the same locale, cannot be the page itself or one of its descendants, and pages cannot be nested more than " + strconv.Itoa(domain.MaxPageDepth) + " levels deep",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	log.Printf("Error resolving parent page: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to get parent page",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
+
+// selectPageVariant picks the page to serve from a page's locale variants and links the others
+// Unpublished variants are only considered in preview mode
+func selectPageVariant(page *domain.Page, variants []*domain.Page, locale, fallback string, preview bool) *domain.Page {
+	byLocale := make(map[string]*domain.Page)
+	available := make(map[string]bool)
+	for _, variant := range variants {
+		if preview || variant.Status == domain.PageStatusPublished {
+			byLocale[variant.Locale] = variant
+			available[variant.Locale] = true
+		}
+	}
+
+	if page.Locale != locale {
+		if chosen := chooseVariantLocale(available, locale, fallback); chosen != "" {
+			page = byLocale[chosen]
+		}
+	}
+
+	for _, variant := range variants {
+		if variant.ID != page.ID && available[variant.Locale] {
+			page.Translations = append(page.Translations, domain.TranslationLink{
+				Locale: variant.Locale,
+				ID:     variant.ID,
+				Slug:   variant.Slug,
+			})
+		}
+	}
+	return page
+}
+
+// ListPageTranslations handles GET /api/v1/pages/:id/translations
+// Lists every locale variant of the page and whether it is outdated
+func (h *PageHandler) ListPageTranslations(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid page ID",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	repo := repository.NewPageRepository(db)
+
+	source, err := repo.GetByID(c.Context(), id)
+	if err != nil {
+		return pageLookupFailed(c, err)
+	}
+	if source.TranslationOfID != nil {
+		if source, err = repo.GetByID(c.Context(), *source.TranslationOfID); err != nil {
+			return pageLookupFailed(c, err)
+		}
+	}
+
+	variants, err := repo.ListTranslations(c.Context(), source.ID)
+	if err != nil {
+		log.Printf("Error listing translations of page %s: %v", source.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list translations",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	sourceHash := source.ContentHash()
+	statuses := make([]domain.TranslationStatus, 0, len(variants))
+	for _, variant := range variants {
+		statuses = append(statuses, domain.TranslationStatus{
+			ID:        variant.ID,
+			Locale:    variant.Locale,
+			Slug:      variant.Slug,
+			Title:     variant.Title,
+			Status:    string(variant.Status),
+			IsSource:  variant.ID == source.ID,
+			Outdated:  variant.ID != source.ID && variant.SourceHash != sourceHash,
+			UpdatedAt: variant.UpdatedAt,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"source_id": source.ID,
+		"data":      statuses,
+	})
+}
+
+// CreatePageTranslation handles POST /api/v1/pages/:id/translations
+// Creates a variant of the page in another locale, copying omitted fields from the source
+func (h *PageHandler) CreatePageTranslation(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid page ID",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var req CreatePageTranslationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	if req.Locale == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Locale is required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	repo := repository.NewPageRepository(db)
+
+	settings := tenantSettings(c, db)
+	locale, err := resolveContentLocale(settings, req.Locale)
+	if err != nil {
+		return localeNotEnabled(c, settings)
+	}
+
+	source, err := repo.GetByID(c.Context(), id)
+	if err != nil {
+		return pageLookupFailed(c, err)
+	}
+	if source.TranslationOfID != nil {
+		if source, err = repo.GetByID(c.Context(), *source.TranslationOfID); err != nil {
+			return pageLookupFailed(c, err)
+		}
+	}
+
+	variants, err := repo.ListTranslations(c.Context(), source.ID)
+	if err != nil {
+		log.Printf("Error listing translations of page %s: %v", source.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create translation",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	for _, variant := range variants {
+		if variant.Locale == locale {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Translation for this locale already exists",
+				"code":  fiber.StatusConflict,
+				"id":    variant.ID,
+			})
+		}
+	}
+
+	status := domain.PageStatusDraft
+	if req.Status != "" {
+		status = domain.PageStatus(req.Status)
+		if !status.IsValid() {
+			return invalidWorkflowStatus(c)
+		}
+	}
+	// New pages start as drafts, so any other status must be reachable from draft
+	if ok, err := enforceTransition(c, settings, string(domain.PageStatusDraft), string(status)); !ok {
+		return err
+	}
+
+	translation := &domain.Page{
+		TenantID:        source.TenantID,
+		Locale:          locale,
+		Slug:            source.Slug,
+		Title:           source.Title,
+		TranslationOfID: &source.ID,
+		SourceHash:      source.ContentHash(),
+		Status:          status,
+		Blocks:          source.Blocks,
+		Meta:            source.Meta,
+		TemplateID:      source.TemplateID,
+		LockedBlocks:    source.LockedBlocks, // Translations keep the source's block IDs
+	}
+	if req.Slug != "" {
+		translation.Slug = req.Slug
+	}
+	if req.Title != "" {
+		translation.Title = req.Title
+	}
+
+	// Nest the translation under the parent's translation in the same locale, if there is one
+	if source.ParentID != nil {
+		translation.Slug = domain.PageSegment(translation.Slug)
+		if parent, err := translatedParent(c, repo, *source.ParentID, locale); err != nil {
+			log.Printf("Error resolving translated parent of page %s: %v", source.ID, err)
+		} else if parent != nil {
+			translation.ParentID = &parent.ID
+			translation.Slug = domain.PagePath(parent.Slug, translation.Slug)
+		}
+	}
+	if req.Blocks != nil {
+		if ok, err := enforceBlockLocks(c, translation, req.Blocks); !ok {
+			return err
+		}
+		if ok, err := validateBlocks(c, db, source.TenantID, req.Blocks); !ok {
+			return err
+		}
+		blocksJSON, err := json.Marshal(req.Blocks)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid blocks format",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		translation.Blocks = blocksJSON
+	}
+	if req.Meta != nil {
+		metaJSON, err := json.Marshal(req.Meta)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid meta format",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		translation.Meta = metaJSON
+	}
+	if status == domain.PageStatusPublished {
+		now := time.Now()
+		translation.PublishedAt = &now
+	}
+
+	// Translations count towards the tenant's page quota
+	if ok, err := reserveQuota(c, db, domain.UsageMetricPages, 1); !ok {
+		return err
+	}
+
+	if err := repo.As(actorID(c)).Create(c.Context(), translation); err != nil {
+		releaseQuota(c, db, translation.TenantID, domain.UsageMetricPages, 1)
+		if errors.Is(err, domain.ErrPageAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Page with this slug already exists in this locale",
+				"code":  fiber.StatusConflict,
+			})
+		}
+		log.Printf("Error creating translation of page %s: %v", source.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create translation",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	if settings.Workflow.IsEnabled() && status != domain.PageStatusDraft {
+		recordTransition(c, db, settings, domain.ReviewEntityPage, translation.ID, translation.Title, string(domain.PageStatusDraft), string(status), "")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(translation)
+}
+
+// pageLookupFailed writes the response for a failed page lookup
+func pageLookupFailed(c *fiber.Ctx, err error) error {
+	if strings.Contains(err.Error(), "page not found") {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Page not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to get page",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
+
+// translatedParent returns the variant of a parent page in a locale, or nil if it is not translated
+func translatedParent(c *fiber.Ctx, repo repoInterface.PageRepository, parentID uuid.UUID, locale string) (*domain.Page, error) {
+	parent, err := repo.GetByID(c.Context(), parentID)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := repo.ListTranslations(c.Context(), parent.SourceID())
+	if err != nil {
+		return nil, err
+	}
+	for _, variant := range variants {
+		if variant.Locale == locale {
+			return variant, nil
+		}
+	}
+	return nil, nil
 }
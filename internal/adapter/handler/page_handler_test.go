@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"gohac/internal/adapter/database"
@@ -15,22 +17,16 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 // setupTestApp creates a Fiber app with test database and handlers
-func setupTestApp(t *testing.T) (*fiber.App, *gorm.DB) {
+func setupTestApp(t *testing.T) (*testApp, *gorm.DB) {
 	// Create in-memory test database
-	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
-	require.NoError(t, err)
-
-	// Migrate schema
-	err = db.AutoMigrate(&domain.Page{})
-	require.NoError(t, err)
+	db := setupHandlerDB(t)
 
 	// Create Fiber app
-	app := fiber.New()
+	app := newTestApp(t)
 
 	// Create page handler
 	pageHandler := NewPageHandler(db)
@@ -39,6 +35,7 @@ func setupTestApp(t *testing.T) (*fiber.App, *gorm.DB) {
 	v1 := app.Group("/api/v1")
 	v1.Post("/pages", pageHandler.CreatePage)
 	v1.Get("/pages", pageHandler.ListPages)
+	v1.Get("/pages/tree", pageHandler.GetPageTree)
 	v1.Get("/pages/:id", pageHandler.GetPage)
 	v1.Put("/pages/:id", pageHandler.UpdatePage)
 	v1.Delete("/pages/:id", pageHandler.DeletePage)
@@ -156,6 +153,13 @@ func TestPageHandler_ListPages(t *testing.T) {
 	assert.Contains(t, response, "data")
 	assert.Contains(t, response, "total")
 	assert.Equal(t, float64(2), response["total"])
+
+	// Pages are capped at the size every list allows
+	for _, query := range []string{"limit=1000", "limit=0", "offset=-1"} {
+		resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/pages?"+query, nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, query)
+	}
 }
 
 func TestPageHandler_GetPage_NotFound(t *testing.T) {
@@ -222,6 +226,52 @@ func TestPageHandler_UpdatePage(t *testing.T) {
 	assert.Equal(t, domain.PageStatusPublished, updatedPage.Status)
 }
 
+func TestPageHandler_UpdatePage_VersionConflict(t *testing.T) {
+	app, db := setupTestApp(t)
+
+	page := &domain.Page{Slug: "pricing", Title: "Pricing"}
+	require.NoError(t, repository.NewPageRepository(db).Create(context.Background(), page))
+	require.Equal(t, 1, page.Version)
+
+	update := func(body UpdatePageRequest, ifMatch string) *http.Response {
+		raw, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest("PUT", "/api/v1/pages/"+page.ID.String(), bytes.NewBuffer(raw))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// Two editors load version 1; the first save wins
+	resp := update(UpdatePageRequest{Title: "Plans"}, `"1"`)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+	resp = update(UpdatePageRequest{Title: "Prices"}, `"1"`)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+	version := 1
+	resp = update(UpdatePageRequest{Title: "Prices", Version: &version}, "")
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	stored, err := repository.NewPageRepository(db).GetByID(context.Background(), page.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Plans", stored.Title)
+
+	// Stale in-memory copies are refused by the repository too
+	page.Title = "Overwrite"
+	assert.ErrorIs(t, repository.NewPageRepository(db).Update(context.Background(), page), domain.ErrVersionConflict)
+
+	// Writes without a version are not checked
+	resp = update(UpdatePageRequest{Title: "Prices"}, "")
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"3"`, resp.Header.Get("ETag"))
+}
+
 func TestPageHandler_DeletePage(t *testing.T) {
 	app, db := setupTestApp(t)
 
@@ -471,3 +521,321 @@ func TestPageHandler_CreatePage_WithEmptyMeta(t *testing.T) {
 		assert.Empty(t, meta)
 	}
 }
+
+func TestPageHandler_CreatePage_DuplicateSlug(t *testing.T) {
+	app, db := setupTestApp(t)
+
+	repo := repository.NewPageRepository(db)
+	ctx := database.SetDBInContext(context.Background(), db)
+	err := repo.Create(ctx, &domain.Page{
+		Slug:   "about",
+		Title:  "About",
+		Status: domain.PageStatusDraft,
+	})
+	require.NoError(t, err)
+
+	reqBody := CreatePageRequest{
+		Slug:  "about",
+		Title: "Another About",
+	}
+
+	body, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/api/v1/pages", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestPageHandler_CreatePage_QuotaExceeded(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	plan := &domain.Plan{Name: "Starter", MaxPages: 1}
+	require.NoError(t, db.Create(plan).Error)
+	require.NoError(t, db.Create(&domain.Tenant{ID: "acme", Name: "Acme", PlanID: &plan.ID}).Error)
+
+	pageHandler := NewPageHandler(db)
+	app := newTestApp(t)
+	acme := map[string]string{"X-Tenant": "acme"}
+	app.Post("/api/v1/pages", pageHandler.CreatePage)
+	app.Delete("/api/v1/pages/:id", pageHandler.DeletePage)
+
+	createPage := func(slug string) *http.Response {
+		return app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: slug, Title: slug}, acme)
+	}
+
+	resp := createPage("first")
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var page domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+
+	// The plan allows a single page
+	resp = createPage("second")
+	assert.Equal(t, fiber.StatusPaymentRequired, resp.StatusCode)
+
+	usageRepo := repository.NewUsageRepository(db)
+	used, err := usageRepo.Get(context.Background(), "acme", domain.UsageMetricPages, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), used)
+
+	// Deleting releases the quota
+	resp = app.send("DELETE", "/api/v1/pages/"+page.ID.String(), nil, acme)
+	require.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+	resp = createPage("second")
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+}
+
+func TestPageHandler_Translations(t *testing.T) {
+	db := setupHandlerDB(t)
+	require.NoError(t, repository.NewSettingsRepository(db).UpdateGlobalSettings(context.Background(), "", &domain.GlobalSettings{
+		DefaultLocale: "en",
+		Locales:       []string{"de"},
+	}))
+
+	pageHandler := NewPageHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/pages", pageHandler.CreatePage)
+	app.Put("/api/v1/pages/:id", pageHandler.UpdatePage)
+	app.Get("/api/v1/pages/:id/translations", pageHandler.ListPageTranslations)
+	app.Post("/api/v1/pages/:id/translations", pageHandler.CreatePageTranslation)
+	app.Get("/api/public/pages/*", pageHandler.GetPageBySlugPublic)
+
+	resp := app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "about", Title: "About", Status: "published"})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var source domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&source))
+	assert.Equal(t, "en", source.Locale)
+
+	// Locales must be enabled for the tenant
+	resp = app.send("POST", "/api/v1/pages/"+source.ID.String()+"/translations", CreatePageTranslationRequest{Locale: "fr"})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	resp = app.send("POST", "/api/v1/pages/"+source.ID.String()+"/translations",
+		CreatePageTranslationRequest{Locale: "de", Slug: "ueber-uns", Title: "Über uns", Status: "published"})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var translation domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&translation))
+	require.NotNil(t, translation.TranslationOfID)
+	assert.Equal(t, source.ID, *translation.TranslationOfID)
+
+	resp = app.send("POST", "/api/v1/pages/"+translation.ID.String()+"/translations", CreatePageTranslationRequest{Locale: "de"})
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// The German variant is served for German readers, whichever slug they request
+	resp = app.send("GET", "/api/public/pages/about", nil, map[string]string{"Accept-Language": "de-AT, en;q=0.5"})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "de", resp.Header.Get("Content-Language"))
+	var served domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&served))
+	assert.Equal(t, translation.ID, served.ID)
+	require.Len(t, served.Translations, 1)
+	assert.Equal(t, "about", served.Translations[0].Slug)
+
+	// Unknown locales fall back to the default locale
+	resp = app.send("GET", "/api/public/pages/ueber-uns?locale=fr", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "en", resp.Header.Get("Content-Language"))
+
+	listStatuses := func() map[string]domain.TranslationStatus {
+		resp := app.send("GET", "/api/v1/pages/"+source.ID.String()+"/translations", nil)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var result struct {
+			Data []domain.TranslationStatus `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		statuses := map[string]domain.TranslationStatus{}
+		for _, status := range result.Data {
+			statuses[status.Locale] = status
+		}
+		return statuses
+	}
+
+	statuses := listStatuses()
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses["en"].IsSource)
+	assert.False(t, statuses["de"].Outdated)
+
+	// Changing the source marks the translation as outdated
+	resp = app.send("PUT", "/api/v1/pages/"+source.ID.String(), UpdatePageRequest{Title: "About us"})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.True(t, listStatuses()["de"].Outdated)
+
+	// Updating the translation brings it up to date again
+	resp = app.send("PUT", "/api/v1/pages/"+translation.ID.String(), UpdatePageRequest{Title: "Über uns!"})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.False(t, listStatuses()["de"].Outdated)
+}
+
+func TestPageHandler_Hierarchy(t *testing.T) {
+	app, db := setupTestApp(t)
+	app.Get("/api/public/pages/*", NewPageHandler(db).GetPageBySlugPublic)
+
+	create := func(req CreatePageRequest) domain.Page {
+		req.Status = "published"
+		resp := app.send("POST", "/api/v1/pages", req)
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var page domain.Page
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		return page
+	}
+
+	services := create(CreatePageRequest{Slug: "services", Title: "Services"})
+	consulting := create(CreatePageRequest{Slug: "consulting", Title: "Consulting", ParentID: services.ID.String(), SortOrder: 1})
+	assert.Equal(t, "services/consulting", consulting.Slug)
+	training := create(CreatePageRequest{Slug: "training", Title: "Training", ParentID: services.ID.String(), SortOrder: 2})
+	workshops := create(CreatePageRequest{Slug: "workshops", Title: "Workshops", ParentID: training.ID.String()})
+	assert.Equal(t, "services/training/workshops", workshops.Slug)
+
+	// A page cannot be moved under its own descendant
+	parentID := workshops.ID.String()
+	resp := app.send("PUT", "/api/v1/pages/"+services.ID.String(), UpdatePageRequest{ParentID: &parentID})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	// Renaming a page moves its descendants
+	resp = app.send("PUT", "/api/v1/pages/"+services.ID.String(), UpdatePageRequest{Slug: "what-we-do"})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp = app.send("GET", "/api/public/pages/what-we-do/training/workshops", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var page domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	require.Len(t, page.Breadcrumbs, 2)
+	assert.Equal(t, "what-we-do", page.Breadcrumbs[0].Slug)
+	assert.Equal(t, "what-we-do/training", page.Breadcrumbs[1].Slug)
+
+	resp = app.send("GET", "/api/public/pages/what-we-do/consulting", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	page = domain.Page{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	assert.Nil(t, page.Previous)
+	require.NotNil(t, page.Next)
+	assert.Equal(t, training.ID, page.Next.ID)
+
+	resp = app.send("GET", "/api/v1/pages/tree", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var tree struct {
+		Data []*domain.PageTreeNode `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&tree))
+	require.Len(t, tree.Data, 1)
+	require.Len(t, tree.Data[0].Children, 2)
+	assert.Equal(t, "what-we-do/training", tree.Data[0].Children[1].Slug)
+	require.Len(t, tree.Data[0].Children[1].Children, 1)
+
+	// Pages with children cannot be deleted
+	resp = app.send("DELETE", "/api/v1/pages/"+training.ID.String(), nil)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// Moving a page to the top level keeps only its own segment
+	root := ""
+	resp = app.send("PUT", "/api/v1/pages/"+workshops.ID.String(), UpdatePageRequest{ParentID: &root})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	page = domain.Page{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	assert.Equal(t, "workshops", page.Slug)
+	assert.Nil(t, page.ParentID)
+}
+
+func TestPageHandler_MoveKeepsMaxDepth(t *testing.T) {
+	app, db := setupTestApp(t)
+
+	create := func(slug, parentID string) domain.Page {
+		resp := app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: slug, Title: slug, ParentID: parentID})
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var page domain.Page
+		decodeJSON(t, resp, &page)
+		return page
+	}
+
+	// A chain one level short of the limit
+	deepest := create("level-1", "")
+	for level := 2; level < domain.MaxPageDepth; level++ {
+		deepest = create("level-"+strconv.Itoa(level), deepest.ID.String())
+	}
+	section := create("section", "")
+	child := create("child", section.ID.String())
+
+	// The section would fit, but its child would end up past the limit
+	parentID := deepest.ID.String()
+	resp := app.send("PUT", "/api/v1/pages/"+section.ID.String(), UpdatePageRequest{ParentID: &parentID})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	var stored domain.Page
+	require.NoError(t, db.First(&stored, "id = ?", child.ID).Error)
+	assert.Equal(t, "section/child", stored.Slug)
+
+	resp = app.send("PUT", "/api/v1/pages/"+child.ID.String(), UpdatePageRequest{ParentID: &parentID})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	decodeJSON(t, resp, &stored)
+	assert.Equal(t, deepest.Slug+"/child", stored.Slug)
+}
+
+func TestPageHandler_SlugChangeRedirects(t *testing.T) {
+	app, db := setupTestApp(t)
+	app.Get("/api/public/pages/*", NewPageHandler(db).GetPageBySlugPublic)
+	redirectHandler := NewRedirectHandler(db)
+	app.Post("/api/v1/redirects/import", redirectHandler.ImportRedirects)
+
+	redirectOf := func(resp *http.Response) string {
+		var body struct {
+			Redirect string `json:"redirect"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body.Redirect
+	}
+
+	resp := app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "about", Title: "About", Status: "published"})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var about domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&about))
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "team", Title: "Team", Status: "published", ParentID: about.ID.String()})
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	// Renaming twice redirects both old paths straight to the latest one
+	resp = app.send("PUT", "/api/v1/pages/"+about.ID.String(), UpdatePageRequest{Slug: "about-us"})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	resp = app.send("PUT", "/api/v1/pages/"+about.ID.String(), UpdatePageRequest{Slug: "company"})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp = app.send("GET", "/api/public/pages/about", nil)
+	require.Equal(t, fiber.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/company", redirectOf(resp))
+
+	resp = app.send("GET", "/api/public/pages/about-us/team", nil)
+	require.Equal(t, fiber.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/company/team", redirectOf(resp))
+
+	var redirect domain.Redirect
+	require.NoError(t, db.First(&redirect, "source = ?", "/about").Error)
+	assert.Equal(t, int64(1), redirect.Hits)
+	assert.True(t, redirect.Automatic)
+
+	// CSV import adds manual rules and reports invalid rows
+	csv := "source,target,status_code\n/legacy/*,/company/*,302\n/gone,,410\n/broken,/x,999\n"
+	req := httptest.NewRequest("POST", "/api/v1/redirects/import", bytes.NewBufferString(csv))
+	req.Header.Set("Content-Type", "text/csv")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var report struct {
+		Created int                   `json:"created"`
+		Errors  []RedirectImportError `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Equal(t, 2, report.Created)
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, 4, report.Errors[0].Line)
+
+	resp = app.send("GET", "/api/public/pages/legacy/team", nil)
+	require.Equal(t, fiber.StatusFound, resp.StatusCode)
+	assert.Equal(t, "/company/team", redirectOf(resp))
+
+	resp = app.send("GET", "/api/public/pages/gone", nil)
+	assert.Equal(t, fiber.StatusGone, resp.StatusCode)
+
+	resp = app.send("GET", "/api/public/pages/missing", nil)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
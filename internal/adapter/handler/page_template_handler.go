@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PageTemplateHandler handles page template-related HTTP requests
+type PageTemplateHandler struct {
+	db *gorm.DB
+}
+
+// NewPageTemplateHandler creates a new page template handler instance
+func NewPageTemplateHandler(db *gorm.DB) *PageTemplateHandler {
+	return &PageTemplateHandler{
+		db: db,
+	}
+}
+
+// PageTemplateRequest represents the request body for creating or updating a page template
+type PageTemplateRequest struct {
+	Name        string                 `json:"name"`
+	Description *string                `json:"description,omitempty"`
+	Blocks      []domain.TemplateBlock `json:"blocks,omitempty"` // Set "locked": true on blocks editors may not remove
+	Meta        map[string]any         `json:"meta,omitempty"`   // Default meta of pages created from the template
+}
+
+// ListPageTemplates handles GET /api/v1/page-templates (protected endpoint)
+func (h *PageTemplateHandler) ListPageTemplates(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	templates, err := repository.NewPageTemplateRepository(db).List(c.Context(), middleware.GetTenantID(c))
+	if err != nil {
+		log.Printf("Error listing page templates: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list page templates",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  templates,
+		"total": len(templates),
+	})
+}
+
+// CreatePageTemplate handles POST /api/v1/page-templates (protected endpoint, admin only)
+func (h *PageTemplateHandler) CreatePageTemplate(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Admin role required",
+			"code":  fiber.StatusForbidden,
+		})
+	}
+
+	var req PageTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	template := &domain.PageTemplate{
+		TenantID: middleware.GetTenantID(c),
+		Name:     req.Name,
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if ok, err := applyPageTemplateRequest(c, db, template, &req); !ok {
+		return err
+	}
+
+	if err := repository.NewPageTemplateRepository(db).Create(c.Context(), template); err != nil {
+		return pageTemplateSaveFailed(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(template)
+}
+
+// GetPageTemplate handles GET /api/v1/page-templates/:id (protected endpoint)
+func (h *PageTemplateHandler) GetPageTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid page template ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	template, err := getTenantPageTemplate(c, db, id)
+	if err != nil {
+		return pageTemplateLookupFailed(c, err)
+	}
+
+	return c.JSON(template)
+}
+
+// UpdatePageTemplate handles PUT /api/v1/page-templates/:id (protected endpoint, admin only)
+// Omitted fields keep their current values; existing pages are not changed
+func (h *PageTemplateHandler) UpdatePageTemplate(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Admin role required",
+			"code":  fiber.StatusForbidden,
+		})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid page template ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var req PageTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	template, err := getTenantPageTemplate(c, db, id)
+	if err != nil {
+		return pageTemplateLookupFailed(c, err)
+	}
+
+	if req.Name != "" {
+		template.Name = req.Name
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if ok, err := applyPageTemplateRequest(c, db, template, &req); !ok {
+		return err
+	}
+
+	if err := repository.NewPageTemplateRepository(db).Update(c.Context(), template); err != nil {
+		return pageTemplateSaveFailed(c, err)
+	}
+
+	return c.JSON(template)
+}
+
+// DeletePageTemplate handles DELETE /api/v1/page-templates/:id (protected endpoint, admin only)
+// Pages created from the template keep their blocks and locks
+func (h *PageTemplateHandler) DeletePageTemplate(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Admin role required",
+			"code":  fiber.StatusForbidden,
+		})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid page template ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if _, err := getTenantPageTemplate(c, db, id); err != nil {
+		return pageTemplateLookupFailed(c, err)
+	}
+
+	if err := repository.NewPageTemplateRepository(db).Delete(c.Context(), id); err != nil {
+		log.Printf("Error deleting page template: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete page template",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// applyPageTemplateRequest validates and sets a template's blocks and meta from a request
+// Writes a 400 response and returns false if they are invalid
+func applyPageTemplateRequest(c *fiber.Ctx, db *gorm.DB, template *domain.PageTemplate, req *PageTemplateRequest) (bool, error) {
+	if req.Blocks != nil {
+		if err := template.SetBlocks(req.Blocks); err != nil {
+			return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		blocks := make([]domain.Block, 0, len(req.Blocks))
+		for _, block := range req.Blocks {
+			blocks = append(blocks, block.Block)
+		}
+		if ok, err := validateBlocks(c, db, template.TenantID, blocks); !ok {
+			return false, err
+		}
+	}
+	if req.Meta != nil {
+		metaJSON, err := json.Marshal(req.Meta)
+		if err != nil {
+			return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid meta format",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		template.Meta = metaJSON
+	}
+	if err := template.Validate(); err != nil {
+		return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	return true, nil
+}
+
+// getTenantPageTemplate loads a page template, hiding templates of other tenants
+func getTenantPageTemplate(c *fiber.Ctx, db *gorm.DB, id uuid.UUID) (*domain.PageTemplate, error) {
+	template, err := repository.NewPageTemplateRepository(db).GetByID(c.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if template.TenantID != middleware.GetTenantID(c) {
+		return nil, fmt.Errorf("page template not found: %w", gorm.ErrRecordNotFound)
+	}
+	return template, nil
+}
+
+// applyPageTemplate initialises a new page from the template named by the request
+// The template's blocks become the page's blocks and its meta provides defaults
+// for keys the request does not set. Writes an error response and returns false on failure.
+func applyPageTemplate(c *fiber.Ctx, db *gorm.DB, page *domain.Page, req *CreatePageRequest) (bool, error) {
+	if len(req.Blocks) > 0 {
+		return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Blocks cannot be combined with template_id",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	id, err := uuid.Parse(req.TemplateID)
+	if err != nil {
+		return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid template ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	template, err := getTenantPageTemplate(c, db, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "page template not found") {
+			return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Page template not found",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		return false, pageTemplateLookupFailed(c, err)
+	}
+
+	blocks, locked, err := template.Instantiate()
+	if err == nil {
+		page.Blocks, err = json.Marshal(blocks)
+	}
+	if err != nil {
+		log.Printf("Error instantiating page template %s: %v", template.ID, err)
+		return false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to apply page template",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	// Global blocks referenced by the template may have been deleted since it was saved
+	if ok, err := validateBlocks(c, db, page.TenantID, blocks); !ok {
+		return false, err
+	}
+	page.TemplateID = &template.ID
+	page.SetLockedBlockIDs(locked)
+
+	if len(template.Meta) > 0 {
+		meta := map[string]any{}
+		if err := json.Unmarshal(template.Meta, &meta); err != nil {
+			log.Printf("Error decoding meta of page template %s: %v", template.ID, err)
+		}
+		for key, value := range req.Meta {
+			meta[key] = value
+		}
+		if page.Meta, err = json.Marshal(meta); err != nil {
+			return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid meta format",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+	return true, nil
+}
+
+// enforceBlockLocks checks that new blocks keep a page's locked blocks and drops locks on removed blocks
+// Admins may remove locked blocks. Writes a 403 response and returns false otherwise.
+func enforceBlockLocks(c *fiber.Ctx, page *domain.Page, blocks []domain.Block) (bool, error) {
+	if !hasAdminRole(c) {
+		if err := page.CheckLockedBlocks(blocks); err != nil {
+			if errors.Is(err, domain.ErrBlockLocked) {
+				return false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error": err.Error(),
+					"code":  fiber.StatusForbidden,
+				})
+			}
+			// Locks that cannot be checked are treated as violated
+			log.Printf("Error checking locked blocks of page %s: %v", page.ID, err)
+			return false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to check locked blocks",
+				"code":  fiber.StatusInternalServerError,
+			})
+		}
+	}
+	page.PruneLockedBlocks(blocks)
+	return true, nil
+}
+
+// pageTemplateLookupFailed writes the response for a failed page template lookup
+func pageTemplateLookupFailed(c *fiber.Ctx, err error) error {
+	if strings.Contains(err.Error(), "page template not found") {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Page template not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+	log.Printf("Error getting page template: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to get page template",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
+
+// pageTemplateSaveFailed writes the response for a failed page template create or update
+func pageTemplateSaveFailed(c *fiber.Ctx, err error) error {
+	if errors.Is(err, domain.ErrPageTemplateAlreadyExists) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Page template with this name already exists",
+			"code":  fiber.StatusConflict,
+		})
+	}
+	log.Printf("Error saving page template: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to save page template",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
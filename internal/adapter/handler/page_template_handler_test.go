@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+func TestPageTemplateHandler_CreatePageFromTemplate(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	pages := NewPageHandler(db)
+	templates := NewPageTemplateHandler(db)
+	globals := NewGlobalBlockHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/global-blocks", globals.CreateGlobalBlock)
+	app.Post("/api/v1/page-templates", templates.CreatePageTemplate)
+	app.Post("/api/v1/pages", pages.CreatePage)
+	app.Put("/api/v1/pages/:id", pages.UpdatePage)
+
+	templateReq := PageTemplateRequest{
+		Name: "Landing page",
+		Blocks: []domain.TemplateBlock{
+			{Block: domain.Block{Type: "hero", Data: json.RawMessage(`{"title":"Headline"}`)}, Locked: true},
+			{Block: domain.Block{Type: "text", Data: json.RawMessage(`{"content":"Body"}`)}},
+		},
+		Meta: map[string]any{"description": "Landing page", "robots": "index"},
+	}
+	resp := app.send("POST", "/api/v1/page-templates", templateReq, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	resp = app.send("POST", "/api/v1/page-templates", templateReq, asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var template domain.PageTemplate
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&template))
+
+	// The new page takes the template's blocks and meta, with request meta winning
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "spring-sale", Title: "Spring sale", TemplateID: template.ID.String(), Meta: map[string]any{"description": "Sale"}}, asRole(domain.UserRoleEditor))
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var page domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	require.NotNil(t, page.TemplateID)
+	assert.Equal(t, template.ID, *page.TemplateID)
+	assert.JSONEq(t, `{"description":"Sale","robots":"index"}`, string(page.Meta))
+
+	var blocks []domain.Block
+	require.NoError(t, json.Unmarshal(page.Blocks, &blocks))
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "hero", blocks[0].Type)
+	assert.Equal(t, []string{blocks[0].ID}, page.LockedBlockIDs())
+
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "both", Title: "Both", TemplateID: template.ID.String(), Blocks: blocks}, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	// Editors can fill in a locked block but not remove it; admins can
+	blocks[0].Data = json.RawMessage(`{"title":"Spring sale"}`)
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Blocks: blocks}, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Blocks: blocks[1:]}, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	resp = app.send("POST", "/api/v1/global-blocks", CreateGlobalBlockRequest{Name: "Hero", PageID: page.ID.String(), BlockID: blocks[0].ID, Replace: true}, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	var globalCount int64
+	require.NoError(t, db.Model(&domain.GlobalBlock{}).Count(&globalCount).Error)
+	assert.Zero(t, globalCount)
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Blocks: blocks[1:]}, asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var updated domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&updated))
+	assert.Empty(t, updated.LockedBlockIDs())
+	// Locks that cannot be read stop editors rather than letting every change through
+	require.NoError(t, db.Model(&domain.Page{}).Where("id = ?", page.ID).Update("locked_blocks", datatypes.JSON(`{"broken"`)).Error)
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Blocks: blocks[1:]}, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"log"
+	"strings"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// impersonationTokenHours is the lifetime of an impersonation token
+const impersonationTokenHours = 1
+
+// PlatformHandler handles platform-level HTTP requests that act across tenants
+// All routes are restricted to super-admins
+type PlatformHandler struct {
+	db *gorm.DB
+}
+
+// NewPlatformHandler creates a new platform handler instance
+func NewPlatformHandler(db *gorm.DB) *PlatformHandler {
+	return &PlatformHandler{
+		db: db,
+	}
+}
+
+// ImpersonateRequest represents the request body for starting an impersonation
+type ImpersonateRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+// Impersonate handles POST /api/v1/platform/impersonate (super-admin only)
+// Issues a short-lived token for the target user and records the impersonation
+func (h *PlatformHandler) Impersonate(c *fiber.Ctx) error {
+	var req ImpersonateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.UserID == "" || req.Reason == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "user_id and reason are required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	// Nested impersonation would hide the real actor
+	if impersonatorID, _ := c.Locals("impersonator_id").(string); impersonatorID != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Already impersonating a user",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	userRepo := repository.NewUserRepository(db)
+	target, err := userRepo.GetByID(c.Context(), req.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+
+	if target.IsSuperAdmin() {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Cannot impersonate a super-admin",
+			"code":  fiber.StatusForbidden,
+		})
+	}
+
+	actorID, _ := c.Locals("user_id").(string)
+	token, err := middleware.IssueToken(&middleware.Claims{
+		UserID:         target.ID.String(),
+		Email:          target.Email,
+		TenantID:       target.TenantID,
+		Role:           string(target.Role),
+		ImpersonatorID: actorID,
+	}, impersonationTokenHours)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to generate token",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	// The audit entry is attributed to the super-admin as the actor
+	recordAudit(c, db, target.TenantID, domain.AuditActionImpersonateStart, "user", target.ID.String(), fiber.Map{
+		"reason":       req.Reason,
+		"target_email": target.Email,
+	})
+
+	setAuthCookie(c, token, impersonationTokenHours*60*60)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Impersonation started",
+		"user": fiber.Map{
+			"id":        target.ID.String(),
+			"name":      target.Name,
+			"email":     target.Email,
+			"role":      target.Role,
+			"tenant_id": target.TenantID,
+		},
+		"impersonator_id": actorID,
+	})
+}
+
+// ListAuditLogs handles GET /api/v1/platform/audit-logs (super-admin only)
+// Supports ?tenant_id=, ?actor_id=, ?action=, ?limit= and ?offset=
+func (h *PlatformHandler) ListAuditLogs(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	limit, offset, ok, err := parsePaging(c, 50)
+	if !ok {
+		return err
+	}
+
+	opts := repoInterface.ListAuditLogOptions{
+		Limit:   limit,
+		Offset:  offset,
+		ActorID: c.Query("actor_id"),
+		Action:  c.Query("action"),
+	}
+	// An empty tenant_id is meaningful (platform-level entries), so check presence
+	if c.Context().QueryArgs().Has("tenant_id") {
+		tenantID := c.Query("tenant_id")
+		opts.TenantID = &tenantID
+	}
+
+	entries, total, err := repository.NewAuditLogRepository(db).List(c.Context(), opts)
+	if err != nil {
+		log.Printf("Error listing audit logs: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list audit logs",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   entries,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
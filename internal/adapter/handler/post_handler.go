@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
 	"log"
 	"strconv"
 	"strings"
@@ -9,9 +11,12 @@ import (
 	"gohac/internal/adapter/database"
 	"gohac/internal/adapter/repository"
 	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -29,24 +34,42 @@ func NewPostHandler(db *gorm.DB) *PostHandler {
 
 // CreatePostRequest represents the request body for creating a post
 type CreatePostRequest struct {
-	Title         string   `json:"title" validate:"required"`
-	Slug          string   `json:"slug" validate:"required"`
-	Excerpt       string   `json:"excerpt"`
-	Content       string   `json:"content"` // JSON Blocks array
-	FeaturedImage string   `json:"featured_image"`
-	Status        string   `json:"status" validate:"required,oneof=draft published archived"`
-	CategoryIDs   []string `json:"category_ids"`
+	Title         string         `json:"title" validate:"required"`
+	Slug          string         `json:"slug" validate:"required"`
+	Locale        string         `json:"locale,omitempty"` // Defaults to the tenant's default locale
+	Excerpt       string         `json:"excerpt"`
+	Blocks        []domain.Block `json:"blocks,omitempty"`
+	Content       string         `json:"content"` // Legacy Markdown/HTML; a JSON blocks array is stored as blocks
+	FeaturedImage string         `json:"featured_image"`
+	Status        string         `json:"status" validate:"required,oneof=draft in_review approved published archived"`
+	CategoryIDs   []string       `json:"category_ids"`
+	Tags          []string       `json:"tags"` // Tag names; tags that do not exist yet are created
 }
 
 // UpdatePostRequest represents the request body for updating a post
 type UpdatePostRequest struct {
-	Title         string   `json:"title,omitempty"`
-	Slug          string   `json:"slug,omitempty"`
-	Excerpt       string   `json:"excerpt,omitempty"`
-	Content       string   `json:"content,omitempty"`
-	FeaturedImage string   `json:"featured_image,omitempty"`
-	Status        string   `json:"status,omitempty"`
-	CategoryIDs   []string `json:"category_ids,omitempty"`
+	Title         string         `json:"title,omitempty"`
+	Slug          string         `json:"slug,omitempty"`
+	Excerpt       string         `json:"excerpt,omitempty"`
+	Blocks        []domain.Block `json:"blocks,omitempty"`
+	Content       string         `json:"content,omitempty"` // Legacy Markdown/HTML; a JSON blocks array is stored as blocks
+	FeaturedImage string         `json:"featured_image,omitempty"`
+	Status        string         `json:"status,omitempty"`
+	CategoryIDs   []string       `json:"category_ids,omitempty"`
+	Tags          []string       `json:"tags,omitempty"`    // Replaces the post's tags; [] removes them all
+	Version       *int           `json:"version,omitempty"` // Version the edit is based on; an If-Match header takes precedence
+}
+
+// CreatePostTranslationRequest represents the request body for translating a post
+// Omitted fields are copied from the source post
+type CreatePostTranslationRequest struct {
+	Locale  string         `json:"locale" validate:"required"`
+	Title   string         `json:"title,omitempty"`
+	Slug    string         `json:"slug,omitempty"`
+	Excerpt string         `json:"excerpt,omitempty"`
+	Blocks  []domain.Block `json:"blocks,omitempty"`
+	Content string         `json:"content,omitempty"` // Legacy Markdown/HTML; a JSON blocks array is stored as blocks
+	Status  string         `json:"status,omitempty"`
 }
 
 // CreatePost handles POST /api/v1/posts (protected endpoint)
@@ -98,22 +121,35 @@ func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 		db = h.db
 	}
 
+	settings := tenantSettings(c, db)
+	locale, err := resolveContentLocale(settings, req.Locale)
+	if err != nil {
+		return localeNotEnabled(c, settings)
+	}
+
 	// Validate status
 	status := domain.PostStatus(strings.ToLower(req.Status))
-	if status != domain.PostStatusDraft && status != domain.PostStatusPublished && status != domain.PostStatusArchived {
+	if !status.IsValid() {
 		status = domain.PostStatusDraft
 	}
+	if ok, err := enforceTransition(c, settings, string(domain.PostStatusDraft), string(status)); !ok {
+		return err
+	}
 
 	// Create post
 	post := &domain.Post{
+		TenantID:      middleware.GetTenantID(c),
+		Locale:        locale,
 		Title:         req.Title,
 		Slug:          req.Slug,
 		Excerpt:       req.Excerpt,
-		Content:       req.Content,
 		FeaturedImage: req.FeaturedImage,
 		Status:        status,
 		AuthorID:      authorUUID,
 	}
+	if ok, err := applyPostBody(c, db, post, req.Blocks, req.Content); !ok {
+		return err
+	}
 
 	// Set published_at if status is published
 	if status == domain.PostStatusPublished {
@@ -137,9 +173,27 @@ func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 		}
 		post.Categories = categories
 	}
+	if len(req.Tags) > 0 {
+		tags, ok, err := resolvePostTags(c, db, post.TenantID, req.Tags)
+		if !ok {
+			return err
+		}
+		post.Tags = tags
+	}
 
+	// Reserve the post against the tenant's quota; given back if it cannot be created
+	if ok, err := reserveQuota(c, db, domain.UsageMetricPosts, 1); !ok {
+		return err
+	}
 	postRepo := repository.NewPostRepository(db)
-	if err := postRepo.Create(c.Context(), post); err != nil {
+	if err := postRepo.As(actorID(c)).Create(c.Context(), post); err != nil {
+		releaseQuota(c, db, post.TenantID, domain.UsageMetricPosts, 1)
+		if errors.Is(err, domain.ErrPostAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Post with this slug already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		log.Printf("Error creating post: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to create post",
@@ -147,6 +201,12 @@ func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 		})
 	}
 
+	if settings.Workflow.IsEnabled() && status != domain.PostStatusDraft {
+		recordTransition(c, db, settings, domain.ReviewEntityPost, post.ID, post.Title, string(domain.PostStatusDraft), string(status), "")
+	}
+
+	setVersionETag(c, post.Version)
+
 	// Reload post with relations
 	post, err = postRepo.GetByID(c.Context(), post.ID)
 	if err != nil {
@@ -157,7 +217,19 @@ func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 }
 
 // ListPosts handles GET /api/v1/posts (protected endpoint)
+// Accepts the filters, sorts and paging of repository.PostListSpec; ?status= is kept as a shorthand for filter[status]
 func (h *PostHandler) ListPosts(c *fiber.Ctx) error {
+	params := c.Queries()
+	if status, ok := params["status"]; ok {
+		if _, filtered := params["filter[status]"]; !filtered {
+			params["filter[status]"] = strings.ToLower(status)
+		}
+	}
+	q, ok, err := parseListQuery(c, params, repoInterface.PostListSpec)
+	if !ok {
+		return err
+	}
+
 	db, err := database.GetDBFromContext(c.Context())
 	if err != nil {
 		db = h.db
@@ -165,40 +237,17 @@ func (h *PostHandler) ListPosts(c *fiber.Ctx) error {
 
 	postRepo := repository.NewPostRepository(db)
 
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
-	statusStr := c.Query("status")
-
-	var status *domain.PostStatus
-	if statusStr != "" {
-		s := domain.PostStatus(strings.ToLower(statusStr))
-		status = &s
-	}
-
-	posts, total, err := postRepo.List(c.Context(), limit, offset, status)
+	posts, page, err := postRepo.List(c.Context(), middleware.GetTenantID(c), q)
 	if err != nil {
 		log.Printf("Error listing posts: %v", err)
 		// If table doesn't exist, return empty list instead of error
 		if strings.Contains(err.Error(), "no such table") || strings.Contains(err.Error(), "doesn't exist") {
-			return c.JSON(fiber.Map{
-				"data":   []*domain.Post{},
-				"total":  0,
-				"limit":  limit,
-				"offset": offset,
-			})
+			return respondList(c, []*domain.Post{}, q, page)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to list posts",
-			"code":  fiber.StatusInternalServerError,
-		})
+		return listFailed(c, err, "Failed to list posts")
 	}
 
-	return c.JSON(fiber.Map{
-		"data":   posts,
-		"total":  total,
-		"limit":  limit,
-		"offset": offset,
-	})
+	return respondList(c, posts, q, page)
 }
 
 // GetPost handles GET /api/v1/posts/:id (protected endpoint)
@@ -233,6 +282,7 @@ func (h *PostHandler) GetPost(c *fiber.Ctx) error {
 		})
 	}
 
+	setVersionETag(c, post.Version)
 	return c.JSON(post)
 }
 
@@ -276,26 +326,39 @@ func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 		})
 	}
 
+	// Refuse to overwrite changes the editor has not seen
+	if ok, err := checkVersion(c, post.Version, req.Version); !ok {
+		return err
+	}
+
+	settings := tenantSettings(c, db)
+
 	// Update fields
 	if req.Title != "" {
 		post.Title = req.Title
 	}
+	oldSlug := post.Slug
 	if req.Slug != "" {
 		post.Slug = req.Slug
 	}
 	if req.Excerpt != "" || req.Excerpt == "" {
 		post.Excerpt = req.Excerpt
 	}
-	if req.Content != "" {
-		post.Content = req.Content
+	if req.Blocks != nil || req.Content != "" {
+		if ok, err := applyPostBody(c, db, post, req.Blocks, req.Content); !ok {
+			return err
+		}
 	}
 	if req.FeaturedImage != "" {
 		post.FeaturedImage = req.FeaturedImage
 	}
+	oldStatus := post.Status
 	if req.Status != "" {
 		status := domain.PostStatus(strings.ToLower(req.Status))
-		if status == domain.PostStatusDraft || status == domain.PostStatusPublished || status == domain.PostStatusArchived {
-			oldStatus := post.Status
+		if status.IsValid() {
+			if ok, err := enforceTransition(c, settings, string(oldStatus), string(status)); !ok {
+				return err
+			}
 			post.Status = status
 			// Set published_at if transitioning to published
 			if status == domain.PostStatusPublished && oldStatus != domain.PostStatusPublished {
@@ -304,6 +367,14 @@ func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 			}
 		}
 	}
+	if req.Title != "" || req.Slug != "" || req.Excerpt != "" || req.Blocks != nil || req.Content != "" ||
+		req.FeaturedImage != "" || req.CategoryIDs != nil || req.Tags != nil {
+		status, ok, err := enforceReviewedEdit(c, settings, string(post.Status))
+		if !ok {
+			return err
+		}
+		post.Status = domain.PostStatus(status)
+	}
 
 	// Update categories if provided
 	if req.CategoryIDs != nil {
@@ -321,8 +392,42 @@ func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 		}
 		post.Categories = categories
 	}
+	if req.Tags != nil {
+		if ok, err := checkPostTags(c, post.TenantID, req.Tags); !ok {
+			return err
+		}
+	}
 
-	if err := postRepo.Update(c.Context(), post); err != nil {
+	// Editing a translation's content brings it up to date with its source
+	if post.TranslationOfID != nil && (req.Title != "" || req.Excerpt != "" || req.Blocks != nil || req.Content != "") {
+		if source, err := postRepo.GetByID(c.Context(), *post.TranslationOfID); err == nil {
+			post.SourceHash = source.ContentHash()
+		}
+	}
+
+	// New tags are created with the update, so an update refused for its version leaves none behind
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if req.Tags != nil {
+			tags, err := repository.NewTagRepository(tx).Resolve(c.Context(), post.TenantID, req.Tags)
+			if err != nil {
+				return err
+			}
+			post.Tags = tags
+		}
+		return repository.NewPostRepository(tx).As(actorID(c)).Update(c.Context(), post)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			if current, err := postRepo.GetByID(c.Context(), id); err == nil {
+				return versionConflict(c, current.Version)
+			}
+		}
+		if errors.Is(err, domain.ErrPostAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Post with this slug already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		log.Printf("Error updating post: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update post",
@@ -330,6 +435,17 @@ func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 		})
 	}
 
+	if settings.Workflow.IsEnabled() && post.Status != oldStatus {
+		recordTransition(c, db, settings, domain.ReviewEntityPost, post.ID, post.Title, string(oldStatus), string(post.Status), "")
+	}
+
+	// Old URLs keep working through a permanent redirect
+	if post.Slug != oldSlug {
+		addSlugRedirect(c, db, post.TenantID, domain.PostPublicPath(oldSlug), domain.PostPublicPath(post.Slug))
+	}
+
+	setVersionETag(c, post.Version)
+
 	// Reload post with relations
 	post, err = postRepo.GetByID(c.Context(), post.ID)
 	if err != nil {
@@ -340,6 +456,7 @@ func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 }
 
 // DeletePost handles DELETE /api/v1/posts/:id (protected endpoint)
+// The post goes to the trash and leaves the search index until it is restored
 func (h *PostHandler) DeletePost(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := uuid.Parse(idParam)
@@ -356,7 +473,11 @@ func (h *PostHandler) DeletePost(c *fiber.Ctx) error {
 	}
 
 	postRepo := repository.NewPostRepository(db)
-	if err := postRepo.Delete(c.Context(), id); err != nil {
+
+	// Look up the post first so usage is released for the right tenant
+	post, lookupErr := postRepo.GetByID(c.Context(), id)
+
+	if err := postRepo.As(actorID(c)).Delete(c.Context(), id); err != nil {
 		log.Printf("Error deleting post: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to delete post",
@@ -364,10 +485,15 @@ func (h *PostHandler) DeletePost(c *fiber.Ctx) error {
 		})
 	}
 
+	if lookupErr == nil {
+		trackUsage(c, db, post.TenantID, domain.UsageMetricPosts, -1)
+	}
+
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
 
 // GetPostBySlugPublic handles GET /api/public/posts/:slug (public endpoint)
+// Supports ?expand= to embed referenced menus, media, posts, pages, categories and authors
 func (h *PostHandler) GetPostBySlugPublic(c *fiber.Ctx) error {
 	slug := c.Params("slug")
 	if slug == "" {
@@ -383,9 +509,20 @@ func (h *PostHandler) GetPostBySlugPublic(c *fiber.Ctx) error {
 	}
 
 	postRepo := repository.NewPostRepository(db)
-	post, err := postRepo.GetBySlug(c.Context(), slug)
+	tenantID := middleware.GetTenantID(c)
+	settings := tenantSettings(c, db)
+	locale := negotiateLocale(c, settings)
+
+	// Get post by slug, preferring the negotiated locale
+	post, err := postRepo.GetBySlugInLocale(c.Context(), tenantID, locale, slug)
+	if err != nil && strings.Contains(err.Error(), "post not found") {
+		post, err = postRepo.GetBySlug(c.Context(), tenantID, slug)
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "post not found") {
+			if redirectFor(c, db, domain.PostPublicPath(slug)) {
+				return nil
+			}
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Post not found",
 				"code":  fiber.StatusNotFound,
@@ -398,10 +535,27 @@ func (h *PostHandler) GetPostBySlugPublic(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(post)
+	// Serve the variant in the negotiated locale, falling back to the default locale
+	variants, err := postRepo.ListTranslations(c.Context(), post.SourceID())
+	if err != nil {
+		log.Printf("Error listing translations of post %s: %v", post.ID, err)
+	} else if selected := selectPostVariant(post, variants, locale, settings.PrimaryLocale()); selected.ID != post.ID {
+		// Reload the selected variant with relations
+		links := selected.Translations
+		if post, err = postRepo.GetByID(c.Context(), selected.ID); err != nil {
+			return postLookupFailed(c, err)
+		}
+		post.Translations = links
+	}
+
+	resolvePublicBlocks(c, db, tenantID, &post.Blocks)
+
+	setContentLanguage(c, post.Locale)
+	return respondExpanded(c, db, tenantID, locale, post)
 }
 
 // ListPostsPublic handles GET /api/public/posts (public endpoint)
+// Supports ?expand=; references of all listed posts are loaded together
 func (h *PostHandler) ListPostsPublic(c *fiber.Ctx) error {
 	db, err := database.GetDBFromContext(c.Context())
 	if err != nil {
@@ -410,12 +564,16 @@ func (h *PostHandler) ListPostsPublic(c *fiber.Ctx) error {
 
 	postRepo := repository.NewPostRepository(db)
 
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	limit, offset, ok, err := parsePaging(c, 10)
+	if !ok {
+		return err
+	}
 
-	// Only show published posts
-	status := domain.PostStatusPublished
-	posts, total, err := postRepo.List(c.Context(), limit, offset, &status)
+	// Only show published posts in the negotiated locale, falling back to the default locale
+	settings := tenantSettings(c, db)
+	locale := negotiateLocale(c, settings)
+	tenantID := middleware.GetTenantID(c)
+	posts, total, err := postRepo.ListPublished(c.Context(), tenantID, locale, settings.PrimaryLocale(), limit, offset)
 	if err != nil {
 		log.Printf("Error listing posts: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -424,10 +582,466 @@ func (h *PostHandler) ListPostsPublic(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(fiber.Map{
+	return respondPostList(c, db, tenantID, locale, fiber.Map{
 		"data":   posts,
 		"total":  total,
 		"limit":  limit,
 		"offset": offset,
+		"locale": locale,
+	}, posts)
+}
+
+// ListCategoryPostsPublic handles GET /api/public/categories/* (public endpoint)
+// Lists the published posts of the category at the path (e.g. "tech/go") and of its subcategories
+func (h *PostHandler) ListCategoryPostsPublic(c *fiber.Ctx) error {
+	path := strings.Trim(c.Params("*"), "/")
+	if path == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Category path is required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tenantID := middleware.GetTenantID(c)
+	categoryRepo := repository.NewCategoryRepository(db)
+	category, err := categoryRepo.GetBySlug(c.Context(), tenantID, path)
+	if err != nil {
+		if strings.Contains(err.Error(), "category not found") {
+			if redirectFor(c, db, domain.CategoryPublicPath(path)) {
+				return nil
+			}
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Category not found",
+				"code":  fiber.StatusNotFound,
+			})
+		}
+		log.Printf("Error getting category by path: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get category",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	categoryIDs, err := categoryRepo.ListSubtreeIDs(c.Context(), tenantID, category.ID)
+	if err != nil {
+		log.Printf("Error listing subcategories of %s: %v", category.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list posts",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	limit, offset, ok, err := parsePaging(c, 10)
+	if !ok {
+		return err
+	}
+	settings := tenantSettings(c, db)
+	locale := negotiateLocale(c, settings)
+	posts, total, err := repository.NewPostRepository(db).ListByCategory(c.Context(), tenantID, locale, settings.PrimaryLocale(), categoryIDs, limit, offset)
+	if err != nil {
+		log.Printf("Error listing posts of category %s: %v", category.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list posts",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return respondPostList(c, db, tenantID, locale, fiber.Map{
+		"category": category,
+		"data":     posts,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"locale":   locale,
+	}, posts)
+}
+
+// ListTagPostsPublic handles GET /api/public/tags/:slug (public endpoint)
+// Lists the published posts with the tag
+func (h *PostHandler) ListTagPostsPublic(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tenantID := middleware.GetTenantID(c)
+	tag, err := repository.NewTagRepository(db).GetBySlug(c.Context(), tenantID, c.Params("slug"))
+	if err != nil {
+		if errors.Is(err, domain.ErrTagNotFound) {
+			return tagNotFound(c)
+		}
+		log.Printf("Error getting tag by slug: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get tag",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	limit, offset, ok, err := parsePaging(c, 10)
+	if !ok {
+		return err
+	}
+	settings := tenantSettings(c, db)
+	locale := negotiateLocale(c, settings)
+	posts, total, err := repository.NewPostRepository(db).ListByTag(c.Context(), tenantID, locale, settings.PrimaryLocale(), tag.ID, limit, offset)
+	if err != nil {
+		log.Printf("Error listing posts with tag %s: %v", tag.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list posts",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	// Count what readers can see rather than every post with the tag
+	tag.PostCount = total
+	return respondPostList(c, db, tenantID, locale, fiber.Map{
+		"tag":    tag,
+		"data":   posts,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+		"locale": locale,
+	}, posts)
+}
+
+// checkPostTags checks the number of tags and their names before any is created
+// ok is false if the response has already been written
+func checkPostTags(c *fiber.Ctx, tenantID string, names []string) (bool, error) {
+	if len(names) > domain.TagMaxPerPost {
+		return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "A post can have at most " + strconv.Itoa(domain.TagMaxPerPost) + " tags",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	for _, name := range names {
+		if _, err := domain.NewTag(tenantID, name); err != nil {
+			return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+	return true, nil
+}
+
+// resolvePostTags finds or creates the tenant's tags with the given names
+// ok is false if the response has already been written
+func resolvePostTags(c *fiber.Ctx, db *gorm.DB, tenantID string, names []string) ([]domain.Tag, bool, error) {
+	if ok, err := checkPostTags(c, tenantID, names); !ok {
+		return nil, false, err
+	}
+	tags, err := repository.NewTagRepository(db).Resolve(c.Context(), tenantID, names)
+	if err != nil {
+		log.Printf("Error resolving tags: %v", err)
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to save tags",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	return tags, true, nil
+}
+
+// respondPostList writes a public listing of posts
+// Global blocks of the posts are resolved and ?expand= references of all posts are loaded together
+func respondPostList(c *fiber.Ctx, db *gorm.DB, tenantID, locale string, body fiber.Map, posts []*domain.Post) error {
+	blocks := make([]*datatypes.JSON, 0, len(posts))
+	for _, post := range posts {
+		blocks = append(blocks, &post.Blocks)
+	}
+	resolvePublicBlocks(c, db, tenantID, blocks...)
+
+	setContentLanguage(c, locale)
+	return respondExpanded(c, db, tenantID, locale, body)
+}
+
+// selectPostVariant picks the published post to serve from a post's locale variants and links the others
+func selectPostVariant(post *domain.Post, variants []*domain.Post, locale, fallback string) *domain.Post {
+	byLocale := make(map[string]*domain.Post)
+	available := make(map[string]bool)
+	for _, variant := range variants {
+		if variant.Status == domain.PostStatusPublished {
+			byLocale[variant.Locale] = variant
+			available[variant.Locale] = true
+		}
+	}
+
+	selected := post
+	if post.Locale != locale {
+		if chosen := chooseVariantLocale(available, locale, fallback); chosen != "" {
+			selected = byLocale[chosen]
+		}
+	}
+
+	var links []domain.TranslationLink
+	for _, variant := range variants {
+		if variant.ID != selected.ID && available[variant.Locale] {
+			links = append(links, domain.TranslationLink{
+				Locale: variant.Locale,
+				ID:     variant.ID,
+				Slug:   variant.Slug,
+			})
+		}
+	}
+
+	// Variants are loaded without relations, so keep the loaded post when it was selected
+	if selected.ID == post.ID {
+		selected = post
+	}
+	selected.Translations = links
+	return selected
+}
+
+// ListPostTranslations handles GET /api/v1/posts/:id/translations (protected endpoint)
+// Lists every locale variant of the post and whether it is outdated
+func (h *PostHandler) ListPostTranslations(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid post ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	postRepo := repository.NewPostRepository(db)
+
+	source, err := postRepo.GetByID(c.Context(), id)
+	if err != nil {
+		return postLookupFailed(c, err)
+	}
+	if source.TranslationOfID != nil {
+		if source, err = postRepo.GetByID(c.Context(), *source.TranslationOfID); err != nil {
+			return postLookupFailed(c, err)
+		}
+	}
+
+	variants, err := postRepo.ListTranslations(c.Context(), source.ID)
+	if err != nil {
+		log.Printf("Error listing translations of post %s: %v", source.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list translations",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	sourceHash := source.ContentHash()
+	statuses := make([]domain.TranslationStatus, 0, len(variants))
+	for _, variant := range variants {
+		statuses = append(statuses, domain.TranslationStatus{
+			ID:        variant.ID,
+			Locale:    variant.Locale,
+			Slug:      variant.Slug,
+			Title:     variant.Title,
+			Status:    string(variant.Status),
+			IsSource:  variant.ID == source.ID,
+			Outdated:  variant.ID != source.ID && variant.SourceHash != sourceHash,
+			UpdatedAt: variant.UpdatedAt,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"source_id": source.ID,
+		"data":      statuses,
+	})
+}
+
+// CreatePostTranslation handles POST /api/v1/posts/:id/translations (protected endpoint)
+// Creates a variant of the post in another locale, copying omitted fields from the source
+func (h *PostHandler) CreatePostTranslation(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid post ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var req CreatePostTranslationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	if req.Locale == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Locale is required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	postRepo := repository.NewPostRepository(db)
+
+	settings := tenantSettings(c, db)
+	locale, err := resolveContentLocale(settings, req.Locale)
+	if err != nil {
+		return localeNotEnabled(c, settings)
+	}
+
+	source, err := postRepo.GetByID(c.Context(), id)
+	if err != nil {
+		return postLookupFailed(c, err)
+	}
+	if source.TranslationOfID != nil {
+		if source, err = postRepo.GetByID(c.Context(), *source.TranslationOfID); err != nil {
+			return postLookupFailed(c, err)
+		}
+	}
+
+	variants, err := postRepo.ListTranslations(c.Context(), source.ID)
+	if err != nil {
+		log.Printf("Error listing translations of post %s: %v", source.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create translation",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	for _, variant := range variants {
+		if variant.Locale == locale {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Translation for this locale already exists",
+				"code":  fiber.StatusConflict,
+				"id":    variant.ID,
+			})
+		}
+	}
+
+	status := domain.PostStatusDraft
+	if req.Status != "" {
+		status = domain.PostStatus(strings.ToLower(req.Status))
+		if !status.IsValid() {
+			return invalidWorkflowStatus(c)
+		}
+	}
+	if ok, err := enforceTransition(c, settings, string(domain.PostStatusDraft), string(status)); !ok {
+		return err
+	}
+
+	// Translations share the source's author, image and categories
+	translation := &domain.Post{
+		TenantID:        source.TenantID,
+		Locale:          locale,
+		Title:           source.Title,
+		Slug:            source.Slug,
+		Excerpt:         source.Excerpt,
+		Blocks:          source.Blocks,
+		Content:         source.Content,
+		FeaturedImage:   source.FeaturedImage,
+		TranslationOfID: &source.ID,
+		SourceHash:      source.ContentHash(),
+		Status:          status,
+		AuthorID:        source.AuthorID,
+		Categories:      source.Categories,
+		Tags:            source.Tags,
+	}
+	if req.Title != "" {
+		translation.Title = req.Title
+	}
+	if req.Slug != "" {
+		translation.Slug = req.Slug
+	}
+	if req.Excerpt != "" {
+		translation.Excerpt = req.Excerpt
+	}
+	if req.Blocks != nil || req.Content != "" {
+		if ok, err := applyPostBody(c, db, translation, req.Blocks, req.Content); !ok {
+			return err
+		}
+	}
+	if status == domain.PostStatusPublished {
+		now := time.Now()
+		translation.PublishedAt = &now
+	}
+
+	// Translations count towards the tenant's post quota
+	if ok, err := reserveQuota(c, db, domain.UsageMetricPosts, 1); !ok {
+		return err
+	}
+
+	if err := postRepo.As(actorID(c)).Create(c.Context(), translation); err != nil {
+		releaseQuota(c, db, translation.TenantID, domain.UsageMetricPosts, 1)
+		if errors.Is(err, domain.ErrPostAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Post with this slug already exists in this locale",
+				"code":  fiber.StatusConflict,
+			})
+		}
+		log.Printf("Error creating translation of post %s: %v", source.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create translation",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	if settings.Workflow.IsEnabled() && status != domain.PostStatusDraft {
+		recordTransition(c, db, settings, domain.ReviewEntityPost, translation.ID, translation.Title, string(domain.PostStatusDraft), string(status), "")
+	}
+
+	// Reload post with relations
+	translation, err = postRepo.GetByID(c.Context(), translation.ID)
+	if err != nil {
+		log.Printf("Error reloading post: %v", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(translation)
+}
+
+// applyPostBody sets the body of a post from request blocks or legacy content
+// Content holding a JSON blocks array is stored as blocks, like blocks sent directly.
+// Writes a 400 response and returns false if the blocks are invalid.
+func applyPostBody(c *fiber.Ctx, db *gorm.DB, post *domain.Post, blocks []domain.Block, content string) (bool, error) {
+	if blocks == nil {
+		if parsed, ok := domain.ParseBlockContent(content); ok {
+			blocks = parsed
+		}
+	}
+
+	if blocks == nil {
+		post.Content = content
+		post.Blocks = nil
+		return true, nil
+	}
+
+	if ok, err := validateBlocks(c, db, post.TenantID, blocks); !ok {
+		return false, err
+	}
+	raw, err := json.Marshal(blocks)
+	if err != nil {
+		return false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid blocks",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	post.Blocks = raw
+	post.Content = ""
+	return true, nil
+}
+
+// postLookupFailed writes the response for a failed post lookup
+func postLookupFailed(c *fiber.Ctx, err error) error {
+	if strings.Contains(err.Error(), "post not found") {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Post not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+	log.Printf("Error getting post: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to get post",
+		"code":  fiber.StatusInternalServerError,
 	})
 }
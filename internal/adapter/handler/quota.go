@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// planLimits returns the limits of the tenant's plan (0 = unlimited)
+// Unprovisioned tenants and tenants without a plan are unlimited and get nil
+func planLimits(ctx context.Context, db *gorm.DB, tenantID string) (map[domain.UsageMetric]int64, error) {
+	tenant, err := repository.NewTenantRepository(db).GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if tenant.Plan == nil {
+		return nil, nil
+	}
+
+	limits := make(map[domain.UsageMetric]int64, len(domain.UsageMetrics))
+	for _, metric := range domain.UsageMetrics {
+		limits[metric] = tenant.Plan.Limit(metric)
+	}
+	return limits, nil
+}
+
+// reserveQuota adds delta units of metric to the current tenant's usage, unless that would exceed
+// its plan, in which case it writes the error response. The check and the increment are a single
+// conditional update, so concurrent requests cannot overshoot the limit together. Callers release
+// the reservation with releaseQuota if the operation it was made for fails.
+// Returns false when the request must stop. Plan limits respond with 402 Payment Required,
+// the monthly upload allowance with 429 Too Many Requests.
+// Metering failures are logged and never block the request.
+func reserveQuota(c *fiber.Ctx, db *gorm.DB, metric domain.UsageMetric, delta int64) (bool, error) {
+	tenantID := middleware.GetTenantID(c)
+	limits, err := planLimits(c.Context(), db, tenantID)
+	if err != nil {
+		log.Printf("Error checking quota %s: %v", metric, err)
+	}
+
+	err = repository.NewUsageRepository(db).Reserve(c.Context(), tenantID, metric, delta, limits[metric])
+	if err == nil {
+		return true, nil
+	}
+
+	var quotaErr *domain.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		log.Printf("Error reserving quota %s: %v", metric, err)
+		return true, nil
+	}
+
+	return false, quotaExceeded(c, quotaErr)
+}
+
+// releaseQuota gives back usage reserved with reserveQuota, logging failures
+func releaseQuota(c *fiber.Ctx, db *gorm.DB, tenantID string, metric domain.UsageMetric, delta int64) {
+	if err := repository.NewUsageRepository(db).Release(c.Context(), tenantID, metric, delta); err != nil {
+		log.Printf("Error releasing usage %s: %v", metric, err)
+	}
+}
+
+// quotaExceeded writes the response for an exceeded quota
+func quotaExceeded(c *fiber.Ctx, quotaErr *domain.QuotaExceededError) error {
+	status := fiber.StatusPaymentRequired
+	message := "Plan limit reached for " + string(quotaErr.Metric)
+	if quotaErr.Metric.IsMonthly() {
+		status = fiber.StatusTooManyRequests
+		message = "Monthly limit reached for " + string(quotaErr.Metric)
+		now := time.Now().UTC()
+		nextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(nextMonth.Sub(now).Seconds())))
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"error":  message,
+		"code":   status,
+		"metric": quotaErr.Metric,
+		"limit":  quotaErr.Limit,
+		"used":   quotaErr.Used,
+	})
+}
+
+// trackUsage adds delta to a tenant's usage counter, logging failures
+func trackUsage(c *fiber.Ctx, db *gorm.DB, tenantID string, metric domain.UsageMetric, delta int64) {
+	if err := repository.NewUsageRepository(db).Increment(c.Context(), tenantID, metric, delta); err != nil {
+		log.Printf("Error tracking usage %s: %v", metric, err)
+	}
+}
@@ -0,0 +1,434 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxRedirectImportRows caps the number of rows accepted in one CSV import
+const maxRedirectImportRows = 10000
+
+// RedirectHandler handles redirect-related HTTP requests
+type RedirectHandler struct {
+	db *gorm.DB
+}
+
+// NewRedirectHandler creates a new redirect handler instance
+func NewRedirectHandler(db *gorm.DB) *RedirectHandler {
+	return &RedirectHandler{
+		db: db,
+	}
+}
+
+// RedirectRequest represents the request body for creating or updating a redirect
+type RedirectRequest struct {
+	Source     string `json:"source"`
+	Target     string `json:"target"`
+	StatusCode int    `json:"status_code"`
+	MatchType  string `json:"match_type,omitempty"` // Inferred from the source when omitted
+}
+
+// RedirectImportError describes a CSV row that could not be imported
+type RedirectImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ListRedirects handles GET /api/v1/redirects (protected endpoint)
+func (h *RedirectHandler) ListRedirects(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	limit, offset, ok, err := parsePaging(c, 100)
+	if !ok {
+		return err
+	}
+
+	redirects, total, err := repository.NewRedirectRepository(db).List(c.Context(), middleware.GetTenantID(c), limit, offset)
+	if err != nil {
+		log.Printf("Error listing redirects: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list redirects",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   redirects,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// CreateRedirect handles POST /api/v1/redirects (protected endpoint)
+func (h *RedirectHandler) CreateRedirect(c *fiber.Ctx) error {
+	var req RedirectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	redirect := &domain.Redirect{
+		TenantID:   middleware.GetTenantID(c),
+		Source:     req.Source,
+		Target:     req.Target,
+		StatusCode: req.StatusCode,
+		MatchType:  domain.RedirectMatchType(req.MatchType),
+	}
+	if err := redirect.Normalize(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if err := repository.NewRedirectRepository(db).Create(c.Context(), redirect); err != nil {
+		return redirectSaveFailed(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(redirect)
+}
+
+// GetRedirect handles GET /api/v1/redirects/:id (protected endpoint)
+func (h *RedirectHandler) GetRedirect(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid redirect ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	redirect, err := h.getTenantRedirect(c, db, id)
+	if err != nil {
+		return redirectLookupFailed(c, err)
+	}
+
+	return c.JSON(redirect)
+}
+
+// UpdateRedirect handles PUT /api/v1/redirects/:id (protected endpoint)
+// Omitted fields keep their current values
+func (h *RedirectHandler) UpdateRedirect(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid redirect ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var req RedirectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	redirect, err := h.getTenantRedirect(c, db, id)
+	if err != nil {
+		return redirectLookupFailed(c, err)
+	}
+
+	if req.Source != "" {
+		redirect.Source = req.Source
+		// Re-infer the match type from the new source unless one was given
+		redirect.MatchType = ""
+	}
+	if req.Target != "" {
+		redirect.Target = req.Target
+	}
+	if req.StatusCode != 0 {
+		redirect.StatusCode = req.StatusCode
+	}
+	if req.MatchType != "" {
+		redirect.MatchType = domain.RedirectMatchType(req.MatchType)
+	}
+	// A manually edited redirect is no longer managed by slug changes
+	redirect.Automatic = false
+
+	if err := redirect.Normalize(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	if err := repository.NewRedirectRepository(db).Update(c.Context(), redirect); err != nil {
+		return redirectSaveFailed(c, err)
+	}
+
+	return c.JSON(redirect)
+}
+
+// DeleteRedirect handles DELETE /api/v1/redirects/:id (protected endpoint)
+func (h *RedirectHandler) DeleteRedirect(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid redirect ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if _, err := h.getTenantRedirect(c, db, id); err != nil {
+		return redirectLookupFailed(c, err)
+	}
+
+	if err := repository.NewRedirectRepository(db).Delete(c.Context(), id); err != nil {
+		log.Printf("Error deleting redirect: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete redirect",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// ImportRedirects handles POST /api/v1/redirects/import (protected endpoint)
+// Accepts CSV in the multipart "file" field or as the raw body, with the columns
+// source, target, status_code and an optional match_type. A header row is skipped.
+// Valid rows are imported even when others fail; failures are listed by line.
+func (h *RedirectHandler) ImportRedirects(c *fiber.Ctx) error {
+	var input io.Reader = bytes.NewReader(c.Body())
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to open file",
+				"code":  fiber.StatusInternalServerError,
+			})
+		}
+		defer file.Close()
+		input = file
+	}
+
+	redirects, rowErrors, err := parseRedirectCSV(input)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	created, updated, err := repository.NewRedirectRepository(db).Import(c.Context(), middleware.GetTenantID(c), redirects)
+	if err != nil {
+		log.Printf("Error importing redirects: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to import redirects",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"created": created,
+		"updated": updated,
+		"errors":  rowErrors,
+	})
+}
+
+// ResolveRedirect handles GET /api/public/redirects/resolve?path= (public endpoint)
+// Lets frontends resolve paths that are not served by pages or posts
+func (h *RedirectHandler) ResolveRedirect(c *fiber.Ctx) error {
+	path := c.Query("path")
+	if path == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Path is required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if redirectFor(c, db, path) {
+		return nil
+	}
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		"error": "No redirect for this path",
+		"code":  fiber.StatusNotFound,
+	})
+}
+
+// getTenantRedirect loads a redirect, hiding redirects of other tenants
+func (h *RedirectHandler) getTenantRedirect(c *fiber.Ctx, db *gorm.DB, id uuid.UUID) (*domain.Redirect, error) {
+	redirect, err := repository.NewRedirectRepository(db).GetByID(c.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if redirect.TenantID != middleware.GetTenantID(c) {
+		return nil, fmt.Errorf("redirect not found: %w", gorm.ErrRecordNotFound)
+	}
+	return redirect, nil
+}
+
+// parseRedirectCSV reads redirects from CSV, validating each row
+// Rows that fail validation are reported and left out of the result
+func parseRedirectCSV(input io.Reader) ([]*domain.Redirect, []RedirectImportError, error) {
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	redirects := []*domain.Redirect{}
+	rowErrors := []RedirectImportError{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "source") {
+			continue
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(redirects)+len(rowErrors) >= maxRedirectImportRows {
+			return nil, nil, fmt.Errorf("too many rows (maximum %d)", maxRedirectImportRows)
+		}
+
+		redirect := &domain.Redirect{Source: record[0]}
+		if len(record) > 1 {
+			redirect.Target = record[1]
+		}
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			code, err := strconv.Atoi(strings.TrimSpace(record[2]))
+			if err != nil {
+				rowErrors = append(rowErrors, RedirectImportError{Line: line, Error: "invalid status code: " + record[2]})
+				continue
+			}
+			redirect.StatusCode = code
+		}
+		if len(record) > 3 {
+			redirect.MatchType = domain.RedirectMatchType(strings.ToLower(strings.TrimSpace(record[3])))
+		}
+
+		if err := redirect.Normalize(); err != nil {
+			rowErrors = append(rowErrors, RedirectImportError{Line: line, Error: err.Error()})
+			continue
+		}
+		redirects = append(redirects, redirect)
+	}
+
+	return redirects, rowErrors, nil
+}
+
+// redirectFor writes a redirect response when a redirect matches path
+// Lookup failures are logged and treated as no match. The target is returned in
+// the body rather than a Location header so API clients do not follow it to the API host.
+func redirectFor(c *fiber.Ctx, db *gorm.DB, path string) bool {
+	repo := repository.NewRedirectRepository(db)
+	redirect, target, err := repo.Resolve(c.Context(), middleware.GetTenantID(c), path)
+	if err != nil {
+		if !strings.Contains(err.Error(), "redirect not found") {
+			log.Printf("Error resolving redirect for %s: %v", path, err)
+		}
+		return false
+	}
+
+	if err := repo.RecordHit(c.Context(), redirect.ID); err != nil {
+		log.Printf("Error recording redirect hit: %v", err)
+	}
+
+	if redirect.StatusCode == fiber.StatusGone {
+		_ = c.Status(fiber.StatusGone).JSON(fiber.Map{
+			"error": "This content has been removed",
+			"code":  fiber.StatusGone,
+		})
+		return true
+	}
+	_ = c.Status(redirect.StatusCode).JSON(fiber.Map{
+		"redirect":    target,
+		"status_code": redirect.StatusCode,
+		"code":        redirect.StatusCode,
+	})
+	return true
+}
+
+// addSlugRedirect records an automatic redirect after content moved, logging failures
+func addSlugRedirect(c *fiber.Ctx, db *gorm.DB, tenantID, from, to string) {
+	if err := repository.NewRedirectRepository(db).AddSlugRedirect(c.Context(), tenantID, from, to); err != nil {
+		log.Printf("Error adding redirect from %s to %s: %v", from, to, err)
+	}
+}
+
+// redirectLookupFailed writes the response for a failed redirect lookup
+func redirectLookupFailed(c *fiber.Ctx, err error) error {
+	if strings.Contains(err.Error(), "redirect not found") {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Redirect not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+	log.Printf("Error getting redirect: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to get redirect",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
+
+// redirectSaveFailed writes the response for a failed redirect create or update
+func redirectSaveFailed(c *fiber.Ctx, err error) error {
+	if errors.Is(err, domain.ErrRedirectAlreadyExists) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Redirect for this source already exists",
+			"code":  fiber.StatusConflict,
+		})
+	}
+	log.Printf("Error saving redirect: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to save redirect",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
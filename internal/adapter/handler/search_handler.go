@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// maxSearchLimit caps the page size of search results
+const maxSearchLimit = 50
+
+// SearchHandler handles full-text search across pages and posts
+type SearchHandler struct {
+	db *gorm.DB
+}
+
+// NewSearchHandler creates a new search handler instance
+func NewSearchHandler(db *gorm.DB) *SearchHandler {
+	return &SearchHandler{
+		db: db,
+	}
+}
+
+// Search handles GET /api/v1/search?q=...&type=page,post&status=draft (protected endpoint)
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	return h.search(c, c.Query("status"))
+}
+
+// SearchPublic handles GET /api/public/search?q=...&type=page,post (public endpoint)
+// Only published content is returned
+func (h *SearchHandler) SearchPublic(c *fiber.Ctx) error {
+	return h.search(c, "published")
+}
+
+// search runs a query for the current tenant
+func (h *SearchHandler) search(c *fiber.Ctx, status string) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Query parameter q is required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var types []domain.SearchEntityType
+	if typeParam := c.Query("type"); typeParam != "" {
+		for _, t := range strings.Split(typeParam, ",") {
+			entityType := domain.SearchEntityType(strings.TrimSpace(t))
+			if entityType != domain.SearchEntityPage && entityType != domain.SearchEntityPost {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid type. Must be 'page' or 'post'",
+					"code":  fiber.StatusBadRequest,
+				})
+			}
+			types = append(types, entityType)
+		}
+	}
+
+	limit, offset, ok, err := parsePaging(c, 10)
+	if !ok {
+		return err
+	}
+	if limit > maxSearchLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("limit must be between 1 and %d", maxSearchLimit),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	results, total, err := repository.NewSearchRepository(db).Search(c.Context(), repoInterface.SearchOptions{
+		Query:    query,
+		TenantID: middleware.GetTenantID(c),
+		Types:    types,
+		Status:   status,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		log.Printf("Error searching for %q: %v", query, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to search",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   results,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+		"query":  query,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"gohac/internal/adapter/database"
 	"gohac/internal/adapter/repository"
 	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -22,6 +23,7 @@ func NewSettingsHandler(db *gorm.DB) *SettingsHandler {
 }
 
 // GetSettings handles GET /api/public/settings (public endpoint)
+// Per-locale overrides for the negotiated locale are applied; ?expand=menus embeds the header and footer menus
 func (h *SettingsHandler) GetSettings(c *fiber.Ctx) error {
 	// Get database from context (fallback to handler's DB)
 	db, err := database.GetDBFromContext(c.Context())
@@ -30,7 +32,8 @@ func (h *SettingsHandler) GetSettings(c *fiber.Ctx) error {
 	}
 
 	repo := repository.NewSettingsRepository(db)
-	settings, err := repo.GetGlobalSettings(c.Context())
+	tenantID := middleware.GetTenantID(c)
+	settings, err := repo.GetGlobalSettings(c.Context(), tenantID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to get settings",
@@ -38,6 +41,31 @@ func (h *SettingsHandler) GetSettings(c *fiber.Ctx) error {
 		})
 	}
 
+	locale := negotiateLocale(c, settings)
+	setContentLanguage(c, locale)
+	localized := settings.Localize(locale)
+	localized.Workflow = nil // Editorial configuration is not public
+	return respondExpanded(c, db, tenantID, locale, localized)
+}
+
+// GetAdminSettings handles GET /api/v1/settings (protected endpoint)
+// Returns the stored settings without locale overrides applied; the workflow is only shown to admins
+func (h *SettingsHandler) GetAdminSettings(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	settings, err := repository.NewSettingsRepository(db).GetGlobalSettings(c.Context(), middleware.GetTenantID(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get settings",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	if !hasAdminRole(c) {
+		settings.Workflow = nil
+	}
 	return c.JSON(settings)
 }
 
@@ -49,6 +77,13 @@ type UpdateSettingsRequest struct {
 	ContactEmail string `json:"contact_email"`
 	HeaderMenuID string `json:"header_menu_id,omitempty"` // UUID of the menu to display in header
 	FooterMenuID string `json:"footer_menu_id,omitempty"` // UUID of the menu to display in footer
+
+	// Omit a locale field to keep its current value; an empty list or object clears it
+	DefaultLocale string                              `json:"default_locale,omitempty"`
+	Locales       []string                            `json:"locales,omitempty"`
+	Translations  map[string]domain.LocalizedSettings `json:"translations,omitempty"`
+
+	Workflow *domain.WorkflowSettings `json:"workflow,omitempty"` // Omit to keep the current workflow
 }
 
 // UpdateSettings handles PUT /api/v1/settings (protected endpoint)
@@ -68,17 +103,116 @@ func (h *SettingsHandler) UpdateSettings(c *fiber.Ctx) error {
 	}
 
 	repo := repository.NewSettingsRepository(db)
+	tenantID := middleware.GetTenantID(c)
 
+	// Locale and workflow fields left out of the request keep their current values
+	current, err := repo.GetGlobalSettings(c.Context(), tenantID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get settings",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
 	settings := &domain.GlobalSettings{
-		SiteName:     req.SiteName,
-		Logo:         req.Logo,
-		Favicon:      req.Favicon,
-		ContactEmail: req.ContactEmail,
-		HeaderMenuID: req.HeaderMenuID,
-		FooterMenuID: req.FooterMenuID,
+		SiteName:      req.SiteName,
+		Logo:          req.Logo,
+		Favicon:       req.Favicon,
+		ContactEmail:  req.ContactEmail,
+		HeaderMenuID:  req.HeaderMenuID,
+		FooterMenuID:  req.FooterMenuID,
+		DefaultLocale: current.DefaultLocale,
+		Locales:       current.Locales,
+		Workflow:      current.Workflow,
+	}
+
+	// Normalize locale tags so content and negotiation compare them consistently
+	if req.DefaultLocale != "" {
+		if settings.DefaultLocale = domain.NormalizeLocale(req.DefaultLocale); settings.DefaultLocale == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid default locale: " + req.DefaultLocale,
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+	}
+	if req.Locales != nil {
+		settings.Locales = nil
+		for _, tag := range req.Locales {
+			locale := domain.NormalizeLocale(tag)
+			if locale == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid locale: " + tag,
+					"code":  fiber.StatusBadRequest,
+				})
+			}
+			if !settings.IsLocaleEnabled(locale) {
+				settings.Locales = append(settings.Locales, locale)
+			}
+		}
+	}
+
+	// Content in a locale that is no longer enabled could not be edited or served anymore
+	for _, locale := range current.EnabledLocales() {
+		if settings.IsLocaleEnabled(locale) {
+			continue
+		}
+		inUse, err := localeInUse(c, db, tenantID, locale)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update settings",
+				"code":  fiber.StatusInternalServerError,
+			})
+		}
+		if inUse {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":  "Content exists in locale " + locale + "; keep it in locales or delete that content first",
+				"code":   fiber.StatusConflict,
+				"locale": locale,
+			})
+		}
 	}
 
-	if err := repo.UpdateGlobalSettings(c.Context(), settings); err != nil {
+	// Overrides are kept for the locales that stay enabled, unless new ones are sent
+	translations := current.Translations
+	if req.Translations != nil {
+		translations = make(map[string]domain.LocalizedSettings, len(req.Translations))
+		for tag, overrides := range req.Translations {
+			locale := domain.NormalizeLocale(tag)
+			if locale == "" || !settings.IsLocaleEnabled(locale) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Translations are only allowed for enabled locales: " + tag,
+					"code":  fiber.StatusBadRequest,
+				})
+			}
+			translations[locale] = overrides
+		}
+	}
+	for locale, overrides := range translations {
+		if settings.IsLocaleEnabled(locale) {
+			if settings.Translations == nil {
+				settings.Translations = make(map[string]domain.LocalizedSettings)
+			}
+			settings.Translations[locale] = overrides
+		}
+	}
+
+	// The workflow restricts what editors may do, so only admins change it
+	if req.Workflow != nil {
+		if !hasAdminRole(c) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Only admins can change the editorial workflow",
+				"code":  fiber.StatusForbidden,
+			})
+		}
+		if err := domain.ValidateWorkflow(req.Workflow); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		settings.Workflow = req.Workflow
+	}
+
+	if err := repo.UpdateGlobalSettings(c.Context(), tenantID, settings); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update settings",
 			"code":  fiber.StatusInternalServerError,
@@ -87,3 +221,17 @@ func (h *SettingsHandler) UpdateSettings(c *fiber.Ctx) error {
 
 	return c.JSON(settings)
 }
+
+// localeInUse reports whether the tenant has pages, posts or menus in a locale
+func localeInUse(c *fiber.Ctx, db *gorm.DB, tenantID, locale string) (bool, error) {
+	for _, model := range []interface{}{&domain.Page{}, &domain.Post{}, &domain.Menu{}} {
+		var count int64
+		if err := db.WithContext(c.Context()).Model(model).Where("tenant_id = ? AND locale = ?", tenantID, locale).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
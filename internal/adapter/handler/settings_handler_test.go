@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsHandler_UpdateSettingsKeepsLocales(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	settings := NewSettingsHandler(db)
+	menus := NewMenuHandler(db)
+	posts := NewPostHandler(db)
+	app := newTestApp(t)
+	app.Get("/api/v1/settings", settings.GetSettings)
+	app.Put("/api/v1/settings", settings.UpdateSettings)
+	app.Post("/api/v1/menus", menus.CreateMenu)
+	app.Post("/api/v1/posts/:id/translations", posts.CreatePostTranslation)
+	admin := asRole(domain.UserRoleAdmin)
+
+	current := func() domain.GlobalSettings {
+		resp := app.send("GET", "/api/v1/settings", nil, admin)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var result domain.GlobalSettings
+		decodeJSON(t, resp, &result)
+		return result
+	}
+
+	resp := app.send("PUT", "/api/v1/settings", UpdateSettingsRequest{
+		SiteName:      "Acme",
+		DefaultLocale: "en",
+		Locales:       []string{"de", "fr"},
+		Translations:  map[string]domain.LocalizedSettings{"de": {SiteName: "Acme DE"}},
+	}, admin)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// Saving the general settings leaves the locales alone
+	resp = app.send("PUT", "/api/v1/settings", UpdateSettingsRequest{SiteName: "Acme Inc"}, admin)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	saved := current()
+	assert.Equal(t, "Acme Inc", saved.SiteName)
+	assert.Equal(t, "en", saved.DefaultLocale)
+	assert.Equal(t, []string{"de", "fr"}, saved.Locales)
+	assert.Equal(t, "Acme DE", saved.Translations["de"].SiteName)
+
+	// A locale with content cannot be disabled, and neither can the default it replaces
+	menu := &domain.Menu{Name: "Main", Locale: "en"}
+	require.NoError(t, db.Create(menu).Error)
+	resp = app.send("POST", "/api/v1/menus", CreateMenuRequest{Name: "Haupt", Locale: "de", TranslationOf: menu.ID.String()}, admin)
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var german domain.Menu
+	decodeJSON(t, resp, &german)
+
+	resp = app.send("PUT", "/api/v1/settings", UpdateSettingsRequest{SiteName: "Acme Inc", Locales: []string{"fr"}}, admin)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	resp = app.send("PUT", "/api/v1/settings", UpdateSettingsRequest{SiteName: "Acme Inc", DefaultLocale: "de"}, admin)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// Unused locales can go; their overrides go with them
+	resp = app.send("PUT", "/api/v1/settings", UpdateSettingsRequest{SiteName: "Acme Inc", Locales: []string{"de"}}, admin)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"de"}, current().Locales)
+
+	// A translation of a translation is checked against the locale of the source menu
+	resp = app.send("POST", "/api/v1/menus", CreateMenuRequest{Name: "Main", Locale: "en", TranslationOf: german.ID.String()}, admin)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	post := &domain.Post{Title: "Hello", Slug: "hello", Locale: "en", Status: domain.PostStatusDraft}
+	require.NoError(t, db.Omit("Author").Create(post).Error)
+	resp = app.send("POST", "/api/v1/posts/"+post.ID.String()+"/translations", CreatePostTranslationRequest{Locale: "de", Status: "bogus"}, admin)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSettingsHandler_GetAdminSettings(t *testing.T) {
+	db := setupHandlerDB(t)
+	require.NoError(t, repository.NewSettingsRepository(db).UpdateGlobalSettings(context.Background(), "", &domain.GlobalSettings{
+		SiteName:     "Acme",
+		Locales:      []string{"de"},
+		Translations: map[string]domain.LocalizedSettings{"de": {SiteName: "Acme DE"}},
+		Workflow:     &domain.WorkflowSettings{Enabled: true},
+	}))
+
+	settings := NewSettingsHandler(db)
+	app := newTestApp(t)
+	app.Get("/api/public/settings", settings.GetSettings)
+	app.Get("/api/v1/settings", settings.GetAdminSettings)
+	get := func(path string, headers map[string]string) domain.GlobalSettings {
+		resp := app.send("GET", path, nil, headers)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var result domain.GlobalSettings
+		decodeJSON(t, resp, &result)
+		return result
+	}
+
+	// Admins see the stored settings, including the workflow, whatever their language
+	admin := asRole(domain.UserRoleAdmin)
+	admin["Accept-Language"] = "de"
+	stored := get("/api/v1/settings", admin)
+	assert.Equal(t, "Acme", stored.SiteName)
+	require.NotNil(t, stored.Workflow)
+	assert.True(t, stored.Workflow.Enabled)
+
+	assert.Nil(t, get("/api/v1/settings", asRole(domain.UserRoleEditor)).Workflow)
+	assert.Nil(t, get("/api/public/settings", nil).Workflow)
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TagHandler handles post tag HTTP requests
+type TagHandler struct {
+	db *gorm.DB
+}
+
+// NewTagHandler creates a new tag handler instance
+func NewTagHandler(db *gorm.DB) *TagHandler {
+	return &TagHandler{db: db}
+}
+
+// TagRequest represents the request body for creating or renaming a tag
+type TagRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// MergeTagsRequest represents the request body for merging tags into another
+type MergeTagsRequest struct {
+	SourceIDs []string `json:"source_ids" validate:"required"` // Tags whose posts move to the target; they are deleted
+}
+
+// ListTags handles GET /api/v1/tags (protected endpoint)
+// ?q= completes a prefix, most used tags first
+func (h *TagHandler) ListTags(c *fiber.Ctx) error {
+	return h.listTags(c, false)
+}
+
+// ListTagsPublic handles GET /api/public/tags (public endpoint)
+// Only tags of published posts are listed, with published posts counted
+func (h *TagHandler) ListTagsPublic(c *fiber.Ctx) error {
+	return h.listTags(c, true)
+}
+
+func (h *TagHandler) listTags(c *fiber.Ctx, publishedOnly bool) error {
+	opts := repoInterface.ListTagOptions{
+		Limit:         100,
+		TenantID:      middleware.GetTenantID(c),
+		Prefix:        c.Query("q"),
+		PublishedOnly: publishedOnly,
+	}
+	if opts.Prefix != "" {
+		opts.Limit = 10
+	}
+	var ok bool
+	var err error
+	if opts.Limit, opts.Offset, ok, err = parsePaging(c, opts.Limit); !ok {
+		return err
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tags, total, err := repository.NewTagRepository(db).List(c.Context(), opts)
+	if err != nil {
+		log.Printf("Error listing tags: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list tags",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   tags,
+		"total":  total,
+		"limit":  opts.Limit,
+		"offset": opts.Offset,
+	})
+}
+
+// CreateTag handles POST /api/v1/tags (protected endpoint)
+func (h *TagHandler) CreateTag(c *fiber.Ctx) error {
+	var req TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	tag, err := domain.NewTag(middleware.GetTenantID(c), req.Name)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if err := repository.NewTagRepository(db).Create(c.Context(), tag); err != nil {
+		return tagSaveFailed(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(tag)
+}
+
+// GetTag handles GET /api/v1/tags/:id (protected endpoint)
+func (h *TagHandler) GetTag(c *fiber.Ctx) error {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tag, ok, err := loadTag(c, db)
+	if !ok {
+		return err
+	}
+	return c.JSON(tag)
+}
+
+// UpdateTag handles PUT /api/v1/tags/:id (protected endpoint)
+// Renaming a tag to the name of another tag is refused; merge them instead
+func (h *TagHandler) UpdateTag(c *fiber.Ctx) error {
+	var req TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tag, ok, err := loadTag(c, db)
+	if !ok {
+		return err
+	}
+
+	renamed, err := domain.NewTag(tag.TenantID, req.Name)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	tag.Name = renamed.Name
+	tag.Slug = renamed.Slug
+
+	if err := repository.NewTagRepository(db).Update(c.Context(), tag); err != nil {
+		return tagSaveFailed(c, err)
+	}
+
+	return c.JSON(tag)
+}
+
+// DeleteTag handles DELETE /api/v1/tags/:id (protected endpoint)
+// The tag is removed from all posts; tags are not kept in the trash
+func (h *TagHandler) DeleteTag(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return invalidTagID(c)
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if err := repository.NewTagRepository(db).Delete(c.Context(), middleware.GetTenantID(c), id); err != nil {
+		if errors.Is(err, domain.ErrTagNotFound) {
+			return tagNotFound(c)
+		}
+		log.Printf("Error deleting tag %s: %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete tag",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// MergeTags handles POST /api/v1/tags/:id/merge (protected endpoint)
+// Posts tagged with any of the source tags get the target tag instead, and the source tags are deleted
+func (h *TagHandler) MergeTags(c *fiber.Ctx) error {
+	targetID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return invalidTagID(c)
+	}
+
+	var req MergeTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	if len(req.SourceIDs) == 0 || len(req.SourceIDs) > domain.TagMaxMergeIDs {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "source_ids must list between 1 and " + strconv.Itoa(domain.TagMaxMergeIDs) + " tags",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	seen := map[uuid.UUID]bool{targetID: true}
+	sourceIDs := make([]uuid.UUID, 0, len(req.SourceIDs))
+	for _, idStr := range req.SourceIDs {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return invalidTagID(c)
+		}
+		if id == targetID {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "A tag cannot be merged into itself",
+				"code":  fiber.StatusBadRequest,
+			})
+		}
+		if !seen[id] {
+			seen[id] = true
+			sourceIDs = append(sourceIDs, id)
+		}
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tag, err := repository.NewTagRepository(db).Merge(c.Context(), middleware.GetTenantID(c), targetID, sourceIDs)
+	if err != nil {
+		if errors.Is(err, domain.ErrTagNotFound) {
+			return tagNotFound(c)
+		}
+		log.Printf("Error merging tags into %s: %v", targetID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to merge tags",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(tag)
+}
+
+// loadTag loads the tag named by the :id parameter within the current tenant
+// ok is false if the response has already been written
+func loadTag(c *fiber.Ctx, db *gorm.DB) (*domain.Tag, bool, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, false, invalidTagID(c)
+	}
+
+	tag, err := repository.NewTagRepository(db).GetByID(c.Context(), middleware.GetTenantID(c), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrTagNotFound) {
+			return nil, false, tagNotFound(c)
+		}
+		log.Printf("Error getting tag %s: %v", id, err)
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get tag",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	return tag, true, nil
+}
+
+// tagSaveFailed writes the response for a failed tag create or rename
+func tagSaveFailed(c *fiber.Ctx, err error) error {
+	if errors.Is(err, domain.ErrTagAlreadyExists) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Tag with this name already exists",
+			"code":  fiber.StatusConflict,
+		})
+	}
+	log.Printf("Error saving tag: %v", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to save tag",
+		"code":  fiber.StatusInternalServerError,
+	})
+}
+
+func invalidTagID(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": "Invalid tag ID format",
+		"code":  fiber.StatusBadRequest,
+	})
+}
+
+func tagNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		"error": "Tag not found",
+		"code":  fiber.StatusNotFound,
+	})
+}
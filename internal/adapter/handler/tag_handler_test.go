@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTagHandler_TagsAutocompleteAndMerge(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	first := &domain.Post{Title: "First", Slug: "first", Status: domain.PostStatusPublished}
+	second := &domain.Post{Title: "Second", Slug: "second", Status: domain.PostStatusPublished}
+	draft := &domain.Post{Title: "Draft", Slug: "draft", Status: domain.PostStatusDraft}
+	for _, post := range []*domain.Post{first, second, draft} {
+		require.NoError(t, db.Omit("Author").Create(post).Error)
+	}
+
+	posts := NewPostHandler(db)
+	tags := NewTagHandler(db)
+	app := newTestApp(t)
+	app.Put("/api/v1/posts/:id", posts.UpdatePost)
+	app.Get("/api/v1/tags", tags.ListTags)
+	app.Post("/api/v1/tags", tags.CreateTag)
+	app.Put("/api/v1/tags/:id", tags.UpdateTag)
+	app.Delete("/api/v1/tags/:id", tags.DeleteTag)
+	app.Post("/api/v1/tags/:id/merge", tags.MergeTags)
+	app.Get("/api/public/tags", tags.ListTagsPublic)
+	app.Get("/api/public/tags/:slug", posts.ListTagPostsPublic)
+
+	list := func(path string) map[string]int64 {
+		resp := app.send("GET", path, nil)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var body struct {
+			Data []domain.Tag `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		counts := map[string]int64{}
+		for _, tag := range body.Data {
+			counts[tag.Slug] = tag.PostCount
+		}
+		return counts
+	}
+	tagPost := func(post *domain.Post, names ...string) {
+		resp := app.send("PUT", "/api/v1/posts/"+post.ID.String(), UpdatePostRequest{Tags: names})
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	}
+
+	// Tags are created on the fly; names differing only in case share a tag
+	tagPost(first, "Golang", "Go Modules")
+	tagPost(second, "golang", "go")
+	tagPost(draft, "Go", "Drafts")
+	assert.Equal(t, map[string]int64{"golang": 2, "go-modules": 1, "go": 2, "drafts": 1}, list("/api/v1/tags"))
+
+	resp := app.send("PUT", "/api/v1/posts/"+first.ID.String(), UpdatePostRequest{Tags: []string{"!!"}})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	// A post saved by someone else while the update runs refuses it, and its new tags go with it
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:concurrent_save", func(tx *gorm.DB) {
+		if tx.Statement.Table == "tags" {
+			tx.Session(&gorm.Session{NewDB: true}).Exec("UPDATE posts SET version = version + 1 WHERE id = ?", first.ID)
+		}
+	}))
+	resp = app.send("PUT", "/api/v1/posts/"+first.ID.String(), UpdatePostRequest{Tags: []string{"Racing"}})
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	require.NoError(t, db.Callback().Create().Remove("test:concurrent_save"))
+	assert.NotContains(t, list("/api/v1/tags"), "racing")
+	resp = app.send("POST", "/api/v1/tags", TagRequest{Name: "GOLANG"})
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// Autocomplete matches prefixes of names and slugs
+	assert.Equal(t, map[string]int64{"golang": 2, "go-modules": 1, "go": 2}, list("/api/v1/tags?q=go"))
+	assert.Equal(t, map[string]int64{"go-modules": 1}, list("/api/v1/tags?q=Go%20M"))
+
+	// Readers only see published posts, and tags without any are left out
+	assert.Equal(t, map[string]int64{"golang": 2, "go-modules": 1, "go": 1}, list("/api/public/tags"))
+
+	// Merging moves posts to the target once and deletes the sources
+	var golang, goTag domain.Tag
+	require.NoError(t, db.First(&golang, "slug = ?", "golang").Error)
+	require.NoError(t, db.First(&goTag, "slug = ?", "go").Error)
+	resp = app.send("POST", "/api/v1/tags/"+goTag.ID.String()+"/merge", MergeTagsRequest{SourceIDs: []string{goTag.ID.String()}})
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp = app.send("POST", "/api/v1/tags/"+goTag.ID.String()+"/merge", MergeTagsRequest{SourceIDs: []string{golang.ID.String()}})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var merged domain.Tag
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&merged))
+	assert.Equal(t, int64(3), merged.PostCount)
+	assert.Equal(t, map[string]int64{"go-modules": 1, "go": 3, "drafts": 1}, list("/api/v1/tags"))
+
+	resp = app.send("GET", "/api/public/tags/go", nil)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var page struct {
+		Tag   domain.Tag    `json:"tag"`
+		Data  []domain.Post `json:"data"`
+		Total int64         `json:"total"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	assert.Equal(t, int64(2), page.Total)
+	assert.Equal(t, int64(2), page.Tag.PostCount)
+	require.Len(t, page.Data, 2)
+	assert.NotEmpty(t, page.Data[0].Tags)
+	resp = app.send("GET", "/api/public/tags/golang", nil)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	// Renaming onto another tag's name is refused; deleting removes the tag from posts
+	var modules domain.Tag
+	require.NoError(t, db.First(&modules, "slug = ?", "go-modules").Error)
+	resp = app.send("PUT", "/api/v1/tags/"+modules.ID.String(), TagRequest{Name: "Go"})
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	resp = app.send("PUT", "/api/v1/tags/"+modules.ID.String(), TagRequest{Name: "Modules"})
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	resp = app.send("DELETE", "/api/v1/tags/"+modules.ID.String(), nil)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	var links int64
+	require.NoError(t, db.Table("post_tags").Where("tag_id = ?", modules.ID).Count(&links).Error)
+	assert.Zero(t, links)
+
+	// An empty list removes all of a post's tags
+	resp = app.send("PUT", "/api/v1/posts/"+draft.ID.String(), fiber.Map{"tags": []string{}})
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]int64{"go": 2, "drafts": 0}, list("/api/v1/tags"))
+}
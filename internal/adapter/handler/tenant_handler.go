@@ -0,0 +1,508 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"gohac/internal/adapter/bundle"
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TenantHandler handles tenant provisioning, plans and usage reporting
+// All routes are restricted to super-admins
+type TenantHandler struct {
+	db           *gorm.DB
+	mediaDir     string
+	mediaBaseURL string
+}
+
+// NewTenantHandler creates a new tenant handler instance
+func NewTenantHandler(db *gorm.DB) *TenantHandler {
+	mediaDir, mediaBaseURL := mediaStorage()
+	return &TenantHandler{
+		db:           db,
+		mediaDir:     mediaDir,
+		mediaBaseURL: mediaBaseURL,
+	}
+}
+
+// PlanRequest represents the request body for creating or updating a plan
+// A zero limit means unlimited
+type PlanRequest struct {
+	Name                   string `json:"name"`
+	MaxPages               int64  `json:"max_pages"`
+	MaxPosts               int64  `json:"max_posts"`
+	MaxUsers               int64  `json:"max_users"`
+	MaxStorageBytes        int64  `json:"max_storage_bytes"`
+	MaxUploadBytesPerMonth int64  `json:"max_upload_bytes_per_month"`
+}
+
+// TenantRequest represents the request body for creating or updating a tenant
+type TenantRequest struct {
+	ID     string `json:"id"` // Only used on create
+	Name   string `json:"name"`
+	PlanID string `json:"plan_id"` // Empty = no plan (unlimited)
+
+	// Create only: copy the site of an existing tenant
+	TemplateTenantID string `json:"template_tenant_id"`
+	IncludeUsers     bool   `json:"include_users"`
+	IncludePosts     bool   `json:"include_posts"`
+}
+
+// ListPlans handles GET /api/v1/platform/plans
+func (h *TenantHandler) ListPlans(c *fiber.Ctx) error {
+	plans, err := repository.NewPlanRepository(h.getDB(c)).List(c.Context())
+	if err != nil {
+		log.Printf("Error listing plans: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list plans",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": plans,
+	})
+}
+
+// CreatePlan handles POST /api/v1/platform/plans
+func (h *TenantHandler) CreatePlan(c *fiber.Ctx) error {
+	var req PlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	plan := &domain.Plan{}
+	if err := applyPlanRequest(plan, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	if err := repository.NewPlanRepository(h.getDB(c)).Create(c.Context(), plan); err != nil {
+		if errors.Is(err, domain.ErrPlanAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Plan with this name already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
+		log.Printf("Error creating plan: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create plan",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(plan)
+}
+
+// UpdatePlan handles PUT /api/v1/platform/plans/:id
+func (h *TenantHandler) UpdatePlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid plan ID",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var req PlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	repo := repository.NewPlanRepository(h.getDB(c))
+	plan, err := repo.GetByID(c.Context(), id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Plan not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+
+	if err := applyPlanRequest(plan, &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	if err := repo.Update(c.Context(), plan); err != nil {
+		if errors.Is(err, domain.ErrPlanAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Plan with this name already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
+		log.Printf("Error updating plan: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update plan",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(plan)
+}
+
+// DeletePlan handles DELETE /api/v1/platform/plans/:id
+// Tenants on the plan become unlimited
+func (h *TenantHandler) DeletePlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid plan ID",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	if err := repository.NewPlanRepository(h.getDB(c)).Delete(c.Context(), id); err != nil {
+		log.Printf("Error deleting plan: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete plan",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// ListTenants handles GET /api/v1/platform/tenants
+func (h *TenantHandler) ListTenants(c *fiber.Ctx) error {
+	tenants, err := repository.NewTenantRepository(h.getDB(c)).List(c.Context())
+	if err != nil {
+		log.Printf("Error listing tenants: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list tenants",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": tenants,
+	})
+}
+
+// CreateTenant handles POST /api/v1/platform/tenants
+// With template_tenant_id the new tenant starts as a deep copy of the template's site
+func (h *TenantHandler) CreateTenant(c *fiber.Ctx) error {
+	var req TenantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	req.ID = strings.TrimSpace(req.ID)
+	if req.ID == "" || req.Name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "ID and name are required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db := h.getDB(c)
+	tenant := &domain.Tenant{ID: req.ID, Name: req.Name}
+	if err := h.applyPlanID(c, db, tenant, req.PlanID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	req.TemplateTenantID = strings.TrimSpace(req.TemplateTenantID)
+	if req.TemplateTenantID == req.ID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "A tenant cannot be its own template",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var report *bundle.Report
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := repository.NewTenantRepository(tx).Create(c.Context(), tenant); err != nil {
+			return err
+		}
+		if req.TemplateTenantID == "" {
+			return nil
+		}
+
+		var err error
+		report, err = h.cloneTemplate(c, tx, tenant, &req)
+		return err
+	})
+	if err != nil {
+		// The copy's media files are written as soon as the clone finishes, so they outlive a rollback
+		if report != nil {
+			if err := bundle.RemoveMedia(report); err != nil {
+				log.Printf("Error removing media of failed clone into %s: %v", tenant.ID, err)
+			}
+		}
+		var quotaErr *domain.QuotaExceededError
+		switch {
+		case errors.Is(err, domain.ErrTenantAlreadyExists):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Tenant with this ID already exists",
+				"code":  fiber.StatusConflict,
+			})
+		case errors.Is(err, domain.ErrTenantNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Template tenant not found",
+				"code":  fiber.StatusNotFound,
+			})
+		case errors.As(err, &quotaErr):
+			return quotaExceeded(c, quotaErr)
+		}
+		log.Printf("Error creating tenant: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create tenant",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	if report == nil {
+		return c.Status(fiber.StatusCreated).JSON(tenant)
+	}
+
+	recordAudit(c, db, tenant.ID, domain.AuditActionTenantClone, "tenant", tenant.ID, fiber.Map{
+		"template_tenant_id": req.TemplateTenantID,
+		"include_users":      req.IncludeUsers,
+		"include_posts":      req.IncludePosts,
+		"created":            report.Created,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"tenant": tenant,
+		"clone":  report,
+	})
+}
+
+// cloneTemplate copies the template tenant's site into a newly created tenant
+// The copy must fit the new tenant's plan, otherwise a *domain.QuotaExceededError is returned
+func (h *TenantHandler) cloneTemplate(c *fiber.Ctx, tx *gorm.DB, tenant *domain.Tenant, req *TenantRequest) (*bundle.Report, error) {
+	// Templates need not be provisioned (e.g. "default"), but must have a site to copy
+	var pages int64
+	if err := tx.Model(&domain.Page{}).Where("tenant_id = ?", req.TemplateTenantID).Count(&pages).Error; err != nil {
+		return nil, err
+	}
+	if pages == 0 {
+		if _, err := repository.NewTenantRepository(tx).GetByID(c.Context(), req.TemplateTenantID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, domain.ErrTenantNotFound
+			}
+			return nil, err
+		}
+	}
+
+	opts := bundle.CloneOptions{
+		SourceTenantID: req.TemplateTenantID,
+		TargetTenantID: tenant.ID,
+		MediaDir:       h.mediaDir,
+		MediaBaseURL:   h.mediaBaseURL,
+		IncludeUsers:   req.IncludeUsers,
+		IncludePosts:   req.IncludePosts,
+	}
+	// The copy is reserved against the new tenant's plan as it is metered
+	limits, err := planLimits(c.Context(), tx, tenant.ID)
+	if err != nil {
+		return nil, err
+	}
+	opts.Limits = limits
+
+	return bundle.Clone(c.Context(), tx, opts)
+}
+
+// UpdateTenant handles PUT /api/v1/platform/tenants/:id
+// Also registers tenants that were only known from their header or subdomain
+func (h *TenantHandler) UpdateTenant(c *fiber.Ctx) error {
+	var req TenantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db := h.getDB(c)
+	repo := repository.NewTenantRepository(db)
+
+	tenant, err := repo.GetByID(c.Context(), c.Params("id"))
+	if err != nil {
+		tenant = &domain.Tenant{ID: c.Params("id"), Name: c.Params("id")}
+	}
+	if req.Name != "" {
+		tenant.Name = req.Name
+	}
+	if err := h.applyPlanID(c, db, tenant, req.PlanID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	if err := repo.Update(c.Context(), tenant); err != nil {
+		log.Printf("Error updating tenant: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update tenant",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(tenant)
+}
+
+// GetUsageReport handles GET /api/v1/platform/usage?period=YYYY-MM
+// Returns every tenant's running totals and the usage added during the period
+// (defaults to the current month), for the billing system to pull monthly
+func (h *TenantHandler) GetUsageReport(c *fiber.Ctx) error {
+	period := c.Query("period", domain.UsagePeriod(time.Now()))
+	if _, err := time.Parse(domain.UsagePeriodFormat, period); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid period. Must be YYYY-MM",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db := h.getDB(c)
+	usageRepo := repository.NewUsageRepository(db)
+
+	tenants, err := repository.NewTenantRepository(db).List(c.Context())
+	if err != nil {
+		log.Printf("Error listing tenants: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to build usage report",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	totals, err := usageRepo.ListByPeriod(c.Context(), "")
+	if err != nil {
+		log.Printf("Error listing usage totals: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to build usage report",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	periodUsage, err := usageRepo.ListByPeriod(c.Context(), period)
+	if err != nil {
+		log.Printf("Error listing usage for %s: %v", period, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to build usage report",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	// Tenants with usage but no tenants row (never provisioned) are reported too
+	entries := make(map[string]fiber.Map)
+	var order []string
+	entryFor := func(tenantID string) fiber.Map {
+		if entry, ok := entries[tenantID]; ok {
+			return entry
+		}
+		entry := fiber.Map{
+			"tenant_id":    tenantID,
+			"tenant_name":  tenantID,
+			"plan":         nil,
+			"totals":       emptyUsage(),
+			"period_usage": emptyUsage(),
+		}
+		entries[tenantID] = entry
+		order = append(order, tenantID)
+		return entry
+	}
+
+	for _, tenant := range tenants {
+		entry := entryFor(tenant.ID)
+		entry["tenant_name"] = tenant.Name
+		entry["plan"] = tenant.Plan
+	}
+	for _, counter := range totals {
+		entryFor(counter.TenantID)["totals"].(map[domain.UsageMetric]int64)[counter.Metric] = counter.Value
+	}
+	for _, counter := range periodUsage {
+		entryFor(counter.TenantID)["period_usage"].(map[domain.UsageMetric]int64)[counter.Metric] = counter.Value
+	}
+
+	data := make([]fiber.Map, 0, len(order))
+	for _, tenantID := range order {
+		data = append(data, entries[tenantID])
+	}
+
+	return c.JSON(fiber.Map{
+		"period": period,
+		"data":   data,
+	})
+}
+
+// getDB returns the database from context, falling back to the handler's DB
+func (h *TenantHandler) getDB(c *fiber.Ctx) *gorm.DB {
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		return h.db
+	}
+	return db
+}
+
+// applyPlanID assigns a plan to a tenant, validating that it exists
+func (h *TenantHandler) applyPlanID(c *fiber.Ctx, db *gorm.DB, tenant *domain.Tenant, planIDStr string) error {
+	if planIDStr == "" {
+		tenant.PlanID = nil
+		tenant.Plan = nil
+		return nil
+	}
+
+	planID, err := uuid.Parse(planIDStr)
+	if err != nil {
+		return errors.New("invalid plan_id")
+	}
+	plan, err := repository.NewPlanRepository(db).GetByID(c.Context(), planID)
+	if err != nil {
+		return errors.New("plan not found")
+	}
+
+	tenant.PlanID = &plan.ID
+	tenant.Plan = plan
+	return nil
+}
+
+// applyPlanRequest copies and validates plan fields from a request
+func applyPlanRequest(plan *domain.Plan, req *PlanRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+	if req.MaxPages < 0 || req.MaxPosts < 0 || req.MaxUsers < 0 || req.MaxStorageBytes < 0 || req.MaxUploadBytesPerMonth < 0 {
+		return errors.New("limits must not be negative")
+	}
+
+	plan.Name = req.Name
+	plan.MaxPages = req.MaxPages
+	plan.MaxPosts = req.MaxPosts
+	plan.MaxUsers = req.MaxUsers
+	plan.MaxStorageBytes = req.MaxStorageBytes
+	plan.MaxUploadBytesPerMonth = req.MaxUploadBytesPerMonth
+	return nil
+}
+
+// emptyUsage returns a usage map with every metric set to zero
+func emptyUsage() map[domain.UsageMetric]int64 {
+	usage := make(map[domain.UsageMetric]int64, len(domain.UsageMetrics))
+	for _, metric := range domain.UsageMetrics {
+		usage[metric] = 0
+	}
+	return usage
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	"gohac/config"
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TrashHandler handles the trash of deleted pages, posts, categories and menus
+type TrashHandler struct {
+	db *gorm.DB
+}
+
+// NewTrashHandler creates a new trash handler instance
+func NewTrashHandler(db *gorm.DB) *TrashHandler {
+	return &TrashHandler{
+		db: db,
+	}
+}
+
+// ListTrash handles GET /api/v1/trash (protected endpoint)
+// Supports ?type=page|post|category|menu
+func (h *TrashHandler) ListTrash(c *fiber.Ctx) error {
+	entityType := domain.TrashEntityType(c.Query("type"))
+	if entityType != "" && !domain.IsValidTrashEntityType(entityType) {
+		return invalidTrashType(c)
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	items, err := repository.NewTrashRepository(db).List(c.Context(), middleware.GetTenantID(c), entityType)
+	if err != nil {
+		log.Printf("Error listing trash: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list trash",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":           items,
+		"total":          len(items),
+		"retention_days": config.TrashRetentionDays(),
+	})
+}
+
+// RestoreTrashItem handles POST /api/v1/trash/:type/:id/restore (protected endpoint)
+// Restored pages and posts count towards the tenant's quota again
+func (h *TrashHandler) RestoreTrashItem(c *fiber.Ctx) error {
+	entityType, id, err := parseTrashItem(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	metric, metered := trashUsageMetrics[entityType]
+	if metered {
+		if ok, err := reserveQuota(c, db, metric, 1); !ok {
+			return err
+		}
+	}
+
+	tenantID := middleware.GetTenantID(c)
+	item, err := repository.NewTrashRepository(db).As(actorID(c)).Restore(c.Context(), tenantID, entityType, id)
+	if err != nil {
+		if metered {
+			releaseQuota(c, db, tenantID, metric, 1)
+		}
+		switch {
+		case errors.Is(err, domain.ErrNotInTrash):
+			return trashItemNotFound(c)
+		case errors.Is(err, domain.ErrParentPageInTrash):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Restore the parent page first",
+				"code":  fiber.StatusConflict,
+			})
+		case errors.Is(err, domain.ErrParentCategoryInTrash):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Restore the parent category first",
+				"code":  fiber.StatusConflict,
+			})
+		case errors.Is(err, domain.ErrPageAlreadyExists), errors.Is(err, domain.ErrPostAlreadyExists),
+			errors.Is(err, domain.ErrCategoryAlreadyExists):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Another " + string(entityType) + " now uses this slug",
+				"code":  fiber.StatusConflict,
+			})
+		case errors.Is(err, domain.ErrTranslationExists):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Translation for this locale already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
+		log.Printf("Error restoring %s %s: %v", entityType, id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to restore " + string(entityType),
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(item)
+}
+
+// PurgeTrashItem handles DELETE /api/v1/trash/:type/:id (protected endpoint)
+// Permanently deletes trashed content; requires the admin role
+func (h *TrashHandler) PurgeTrashItem(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return trashAdminRequired(c)
+	}
+
+	entityType, id, err := parseTrashItem(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if err := repository.NewTrashRepository(db).Purge(c.Context(), middleware.GetTenantID(c), entityType, id); err != nil {
+		if errors.Is(err, domain.ErrNotInTrash) {
+			return trashItemNotFound(c)
+		}
+		if errors.Is(err, domain.ErrPageHasChildren) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Cannot purge a page with nested pages; purge or restore them first",
+				"code":  fiber.StatusConflict,
+			})
+		}
+		if errors.Is(err, domain.ErrCategoryHasChildren) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Cannot purge a category with subcategories; purge or restore them first",
+				"code":  fiber.StatusConflict,
+			})
+		}
+		log.Printf("Error purging %s %s: %v", entityType, id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to purge " + string(entityType),
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// EmptyTrash handles DELETE /api/v1/trash (protected endpoint)
+// Permanently deletes all trashed content, or only one kind with ?type=; requires the admin role
+func (h *TrashHandler) EmptyTrash(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return trashAdminRequired(c)
+	}
+
+	entityType := domain.TrashEntityType(c.Query("type"))
+	if entityType != "" && !domain.IsValidTrashEntityType(entityType) {
+		return invalidTrashType(c)
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	repo := repository.NewTrashRepository(db)
+	tenantID := middleware.GetTenantID(c)
+	items, err := repo.List(c.Context(), tenantID, entityType)
+	if err != nil {
+		log.Printf("Error listing trash: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to empty trash",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	// Oldest first, so nested pages and categories (always trashed before their parent) go first
+	purged := 0
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		if err := repo.Purge(c.Context(), tenantID, item.Type, item.ID); err != nil {
+			if errors.Is(err, domain.ErrPageHasChildren) || errors.Is(err, domain.ErrCategoryHasChildren) {
+				continue
+			}
+			log.Printf("Error purging %s %s: %v", item.Type, item.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":  "Failed to empty trash",
+				"code":   fiber.StatusInternalServerError,
+				"purged": purged,
+			})
+		}
+		purged++
+	}
+
+	return c.JSON(fiber.Map{
+		"purged":  purged,
+		"skipped": len(items) - purged,
+	})
+}
+
+// trashUsageMetrics maps the kinds of trashed content that are metered to their usage metric
+var trashUsageMetrics = map[domain.TrashEntityType]domain.UsageMetric{
+	domain.TrashEntityPage: domain.UsageMetricPages,
+	domain.TrashEntityPost: domain.UsageMetricPosts,
+}
+
+// errInvalidTrashType is returned for an unknown kind of trashed content
+var errInvalidTrashType = errors.New("type must be 'page', 'post', 'category' or 'menu'")
+
+// parseTrashItem parses the :type and :id route parameters
+func parseTrashItem(c *fiber.Ctx) (domain.TrashEntityType, uuid.UUID, error) {
+	entityType := domain.TrashEntityType(c.Params("type"))
+	if !domain.IsValidTrashEntityType(entityType) {
+		return "", uuid.Nil, errInvalidTrashType
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return "", uuid.Nil, errors.New("invalid ID format")
+	}
+	return entityType, id, nil
+}
+
+// invalidTrashType writes the response for an unknown kind of trashed content
+func invalidTrashType(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": errInvalidTrashType.Error(),
+		"code":  fiber.StatusBadRequest,
+	})
+}
+
+// trashItemNotFound writes the response for content that is not in the trash
+func trashItemNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		"error": "Item not found in trash",
+		"code":  fiber.StatusNotFound,
+	})
+}
+
+// trashAdminRequired writes the response for non-admins purging content
+func trashAdminRequired(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": "Only admins can permanently delete content",
+		"code":  fiber.StatusForbidden,
+	})
+}
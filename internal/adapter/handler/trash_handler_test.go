@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTrashHandler_RestoreAndPurge(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	pages := NewPageHandler(db)
+	trash := NewTrashHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/pages", pages.CreatePage)
+	app.Delete("/api/v1/pages/:id", pages.DeletePage)
+	app.Get("/api/v1/trash", trash.ListTrash)
+	app.Delete("/api/v1/trash", trash.EmptyTrash)
+	app.Post("/api/v1/trash/:type/:id/restore", trash.RestoreTrashItem)
+	app.Delete("/api/v1/trash/:type/:id", trash.PurgeTrashItem)
+
+	createPage := func(req CreatePageRequest) domain.Page {
+		resp := app.send("POST", "/api/v1/pages", req, asRole(domain.UserRoleEditor))
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var page domain.Page
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		return page
+	}
+	listTrash := func() []domain.TrashItem {
+		resp := app.send("GET", "/api/v1/trash", nil, asRole(domain.UserRoleEditor))
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var body struct {
+			Data []domain.TrashItem `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body.Data
+	}
+
+	// Deleted pages go to the trash and free their slug
+	about := createPage(CreatePageRequest{Slug: "about", Title: "About"})
+	resp := app.send("DELETE", "/api/v1/pages/"+about.ID.String(), nil, asRole(domain.UserRoleEditor))
+	require.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	items := listTrash()
+	require.Len(t, items, 1)
+	assert.Equal(t, domain.TrashEntityPage, items[0].Type)
+	assert.Equal(t, "about", items[0].Slug)
+	assert.Equal(t, 0, countRows(t, db, &domain.Page{}))
+
+	replacement := createPage(CreatePageRequest{Slug: "about", Title: "About us"})
+	resp = app.send("POST", "/api/v1/trash/page/"+about.ID.String()+"/restore", nil, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	// Only admins purge; then the original can come back
+	require.Equal(t, fiber.StatusNoContent, app.send("DELETE", "/api/v1/pages/"+replacement.ID.String(), nil, asRole(domain.UserRoleEditor)).StatusCode)
+	resp = app.send("DELETE", "/api/v1/trash/page/"+replacement.ID.String(), nil, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	resp = app.send("DELETE", "/api/v1/trash/page/"+replacement.ID.String(), nil, asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	resp = app.send("POST", "/api/v1/trash/page/"+about.ID.String()+"/restore", nil, asRole(domain.UserRoleEditor))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, listTrash())
+	resp = app.send("POST", "/api/v1/trash/page/"+about.ID.String()+"/restore", nil, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	// Nested pages come back under their parent only, and go first when the trash is emptied
+	services := createPage(CreatePageRequest{Slug: "services", Title: "Services"})
+	web := createPage(CreatePageRequest{Slug: "web", Title: "Web", ParentID: services.ID.String()})
+	require.Equal(t, fiber.StatusNoContent, app.send("DELETE", "/api/v1/pages/"+web.ID.String(), nil, asRole(domain.UserRoleEditor)).StatusCode)
+	require.Equal(t, fiber.StatusNoContent, app.send("DELETE", "/api/v1/pages/"+services.ID.String(), nil, asRole(domain.UserRoleEditor)).StatusCode)
+	resp = app.send("POST", "/api/v1/trash/page/"+web.ID.String()+"/restore", nil, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	resp = app.send("DELETE", "/api/v1/trash/page/"+services.ID.String(), nil, asRole(domain.UserRoleAdmin))
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	resp = app.send("DELETE", "/api/v1/trash", nil, asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var emptied struct {
+		Purged  int `json:"purged"`
+		Skipped int `json:"skipped"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&emptied))
+	assert.Equal(t, 2, emptied.Purged)
+	assert.Equal(t, 0, emptied.Skipped)
+	assert.Empty(t, listTrash())
+	assert.Equal(t, 1, countRows(t, db.Unscoped(), &domain.Page{}))
+
+	resp = app.send("GET", "/api/v1/trash?type=widget", nil, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTrashHandler_TranslationsFollowTheirSource(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	pages := NewPageHandler(db)
+	trash := NewTrashHandler(db)
+	app := newTestApp(t)
+	app.Delete("/api/v1/pages/:id", pages.DeletePage)
+	app.Post("/api/v1/trash/:type/:id/restore", trash.RestoreTrashItem)
+	app.Delete("/api/v1/trash/:type/:id", trash.PurgeTrashItem)
+
+	source := &domain.Page{Slug: "about", Title: "About", Locale: "en"}
+	require.NoError(t, db.Create(source).Error)
+	translation := &domain.Page{Slug: "ueber-uns", Title: "Über uns", Locale: "de", TranslationOfID: &source.ID, SourceHash: "abc"}
+	require.NoError(t, db.Create(translation).Error)
+	linked := func() *domain.Page {
+		var page domain.Page
+		require.NoError(t, db.First(&page, "id = ?", translation.ID).Error)
+		return &page
+	}
+
+	// Trashing and restoring the source leaves its translations linked
+	require.Equal(t, fiber.StatusNoContent, app.send("DELETE", "/api/v1/pages/"+source.ID.String(), nil, asRole(domain.UserRoleEditor)).StatusCode)
+	require.NotNil(t, linked().TranslationOfID)
+	resp := app.send("POST", "/api/v1/trash/page/"+source.ID.String()+"/restore", nil, asRole(domain.UserRoleEditor))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	page := linked()
+	require.NotNil(t, page.TranslationOfID)
+	assert.Equal(t, source.ID, *page.TranslationOfID)
+	assert.Equal(t, "abc", page.SourceHash)
+
+	// Purging it makes them originals
+	require.Equal(t, fiber.StatusNoContent, app.send("DELETE", "/api/v1/pages/"+source.ID.String(), nil, asRole(domain.UserRoleEditor)).StatusCode)
+	resp = app.send("DELETE", "/api/v1/trash/page/"+source.ID.String(), nil, asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	page = linked()
+	assert.Nil(t, page.TranslationOfID)
+	assert.Empty(t, page.SourceHash)
+}
+
+func countRows(t *testing.T, db *gorm.DB, model interface{}) int {
+	var count int64
+	require.NoError(t, db.Model(model).Count(&count).Error)
+	return int(count)
+}
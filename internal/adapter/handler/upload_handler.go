@@ -1,20 +1,28 @@
 package handler
 
 import (
+	"log"
 	"os"
+	"path/filepath"
 
+	"gohac/internal/adapter/database"
 	"gohac/internal/adapter/storage"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 // UploadHandler handles file upload operations
 type UploadHandler struct {
-	storage *storage.Storage
+	db       *gorm.DB
+	storage  *storage.Storage
+	basePath string
 }
 
 // NewUploadHandler creates a new upload handler
-func NewUploadHandler() *UploadHandler {
+func NewUploadHandler(db *gorm.DB) *UploadHandler {
 	// Get storage path from environment or use default
 	basePath := os.Getenv("STORAGE_PATH")
 	if basePath == "" {
@@ -30,7 +38,9 @@ func NewUploadHandler() *UploadHandler {
 	st := storage.NewStorage(basePath, baseURL)
 
 	return &UploadHandler{
-		storage: st,
+		db:       db,
+		storage:  st,
+		basePath: basePath,
 	}
 }
 
@@ -45,9 +55,21 @@ func (h *UploadHandler) UploadFile(c *fiber.Ctx) error {
 		})
 	}
 
+	// Get database from context
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	// Reserve storage and monthly upload quota before accepting the file
+	if ok, err := h.reserveUpload(c, db, file.Size); !ok {
+		return err
+	}
+
 	// Open file
 	src, err := file.Open()
 	if err != nil {
+		h.releaseUpload(c, db, file.Size)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to open file",
 			"code":  fiber.StatusInternalServerError,
@@ -58,12 +80,15 @@ func (h *UploadHandler) UploadFile(c *fiber.Ctx) error {
 	// Save file
 	fileURL, err := h.storage.SaveFile(src, file.Filename, file.Header.Get("Content-Type"))
 	if err != nil {
+		h.releaseUpload(c, db, file.Size)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to save file",
 			"code":  fiber.StatusInternalServerError,
 		})
 	}
 
+	recordEvent(c, db, domain.MediaUploaded{URL: fileURL, Filename: file.Filename, Size: file.Size})
+
 	return c.JSON(fiber.Map{
 		"url": fileURL,
 	})
@@ -89,7 +114,13 @@ func (h *UploadHandler) DownloadFromURL(c *fiber.Ctx) error {
 		})
 	}
 
-	// Download and save
+	// Get database from context
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	// Download and save; the size is unknown until the file is on disk
 	fileURL, err := h.storage.DownloadAndSave(req.URL)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -98,7 +129,41 @@ func (h *UploadHandler) DownloadFromURL(c *fiber.Ctx) error {
 		})
 	}
 
+	// Reserve the file's actual size, and discard it if that exceeds the quota
+	path := filepath.Join(h.basePath, "uploads", filepath.Base(fileURL))
+	if info, err := os.Stat(path); err == nil {
+		if ok, err := h.reserveUpload(c, db, info.Size()); !ok {
+			if err := os.Remove(path); err != nil {
+				log.Printf("Error removing downloaded file %s: %v", path, err)
+			}
+			return err
+		}
+	} else {
+		log.Printf("Error measuring downloaded file %s: %v", fileURL, err)
+	}
+	recordEvent(c, db, domain.MediaUploaded{URL: fileURL, SourceURL: req.URL})
+
 	return c.JSON(fiber.Map{
 		"url": fileURL,
 	})
 }
+
+// reserveUpload reserves an upload's size against the tenant's storage and monthly upload quotas
+// Returns false, with the error response written, when either would be exceeded
+func (h *UploadHandler) reserveUpload(c *fiber.Ctx, db *gorm.DB, size int64) (bool, error) {
+	if ok, err := reserveQuota(c, db, domain.UsageMetricStorageBytes, size); !ok {
+		return false, err
+	}
+	if ok, err := reserveQuota(c, db, domain.UsageMetricUploadBytes, size); !ok {
+		releaseQuota(c, db, middleware.GetTenantID(c), domain.UsageMetricStorageBytes, size)
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseUpload gives back the quota reserved for an upload that was not stored
+func (h *UploadHandler) releaseUpload(c *fiber.Ctx, db *gorm.DB, size int64) {
+	tenantID := middleware.GetTenantID(c)
+	releaseQuota(c, db, tenantID, domain.UsageMetricStorageBytes, size)
+	releaseQuota(c, db, tenantID, domain.UsageMetricUploadBytes, size)
+}
@@ -1,13 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"log"
-	"strconv"
 	"strings"
 
 	"gohac/internal/adapter/database"
 	"gohac/internal/adapter/repository"
 	"gohac/internal/core/domain"
+	repoInterface "gohac/internal/core/repository"
+	"gohac/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -60,7 +63,7 @@ func (h *UserHandler) requireAdmin(c *fiber.Ctx) error {
 		})
 	}
 
-	if user.Role != domain.UserRoleAdmin {
+	if !user.IsAdmin() {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 			"error": "Admin role required",
 			"code":  fiber.StatusForbidden,
@@ -87,6 +90,7 @@ type UpdateUserRequest struct {
 }
 
 // ListUsers handles GET /api/v1/users (protected endpoint, admin only)
+// Accepts the filters, sorts and paging of repository.UserListSpec
 func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 	// Check admin role
 	if err := h.requireAdmin(c); err != nil {
@@ -99,22 +103,21 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 		db = h.db
 	}
 
-	repo := repository.NewUserRepository(db)
+	q, ok, err := parseListQuery(c, c.Queries(), repoInterface.UserListSpec)
+	if !ok {
+		return err
+	}
 
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	repo := repository.NewUserRepository(db)
 
-	users, total, err := repo.List(c.Context(), limit, offset)
+	users, page, err := repo.List(c.Context(), middleware.GetTenantID(c), q)
 	if err != nil {
 		log.Printf("Error listing users: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to list users",
-			"code":  fiber.StatusInternalServerError,
-		})
+		return listFailed(c, err, "Failed to list users")
 	}
 
 	// Prepare response (exclude passwords)
-	var responseUsers []fiber.Map
+	responseUsers := make([]fiber.Map, 0, len(users))
 	for _, user := range users {
 		responseUsers = append(responseUsers, fiber.Map{
 			"id":         user.ID.String(),
@@ -126,12 +129,7 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(fiber.Map{
-		"data":   responseUsers,
-		"total":  total,
-		"limit":  limit,
-		"offset": offset,
-	})
+	return respondList(c, responseUsers, q, page)
 }
 
 // GetUser handles GET /api/v1/users/:id (protected endpoint, admin only)
@@ -159,6 +157,10 @@ func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 
 	repo := repository.NewUserRepository(db)
 	user, err := repo.GetByID(c.Context(), id)
+	if err == nil && user.TenantID != middleware.GetTenantID(c) {
+		// Users of other tenants are invisible
+		err = fmt.Errorf("user not found: %w", gorm.ErrRecordNotFound)
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "user not found") {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -222,9 +224,10 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	}
 
 	repo := repository.NewUserRepository(db)
+	tenantID := middleware.GetTenantID(c)
 
 	// Check if email already exists
-	_, err = repo.GetByEmail(c.Context(), req.Email)
+	_, err = repo.GetByEmail(c.Context(), tenantID, req.Email)
 	if err == nil {
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 			"error": "User with this email already exists",
@@ -234,6 +237,7 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 
 	// Create user
 	user := &domain.User{
+		TenantID: tenantID,
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password, // Will be hashed
@@ -249,7 +253,18 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		})
 	}
 
+	// Reserve the user against the tenant's quota; given back if it cannot be created
+	if ok, err := reserveQuota(c, db, domain.UsageMetricUsers, 1); !ok {
+		return err
+	}
 	if err := repo.Create(c.Context(), user); err != nil {
+		releaseQuota(c, db, tenantID, domain.UsageMetricUsers, 1)
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "User with this email already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		log.Printf("Error creating user: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to create user",
@@ -299,7 +314,7 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 
 	repo := repository.NewUserRepository(db)
 	user, err := repo.GetByID(c.Context(), id)
-	if err != nil {
+	if err != nil || user.TenantID != middleware.GetTenantID(c) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "User not found",
 			"code":  fiber.StatusNotFound,
@@ -312,7 +327,7 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	}
 	if req.Email != "" {
 		// Check if email already exists (excluding current user)
-		existingUser, err := repo.GetByEmail(c.Context(), req.Email)
+		existingUser, err := repo.GetByEmail(c.Context(), user.TenantID, req.Email)
 		if err == nil && existingUser.ID != user.ID {
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 				"error": "User with this email already exists",
@@ -343,6 +358,12 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	}
 
 	if err := repo.Update(c.Context(), user); err != nil {
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "User with this email already exists",
+				"code":  fiber.StatusConflict,
+			})
+		}
 		log.Printf("Error updating user: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update user",
@@ -395,6 +416,16 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	}
 
 	repo := repository.NewUserRepository(db)
+
+	// Only users of the current tenant can be deleted
+	user, err := repo.GetByID(c.Context(), id)
+	if err != nil || user.TenantID != middleware.GetTenantID(c) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+
 	if err := repo.Delete(c.Context(), id); err != nil {
 		log.Printf("Error deleting user: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -403,5 +434,7 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 		})
 	}
 
+	trackUsage(c, db, user.TenantID, domain.UsageMetricUsers, -1)
+
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
@@ -12,24 +12,10 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
 )
 
-func setupTestDB() *gorm.DB {
-	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
-	if err != nil {
-		panic("Failed to connect to test database")
-	}
-
-	// Auto-migrate
-	db.AutoMigrate(&domain.User{})
-
-	return db
-}
-
 func TestUserHandler_CreateUser(t *testing.T) {
-	db := setupTestDB()
+	db := setupHandlerDB(t)
 	handler := NewUserHandler(db)
 
 	app := fiber.New()
@@ -84,7 +70,7 @@ func TestUserHandler_CreateUser(t *testing.T) {
 }
 
 func TestUserHandler_ListUsers(t *testing.T) {
-	db := setupTestDB()
+	db := setupHandlerDB(t)
 	handler := NewUserHandler(db)
 
 	app := fiber.New()
@@ -138,8 +124,63 @@ func TestUserHandler_ListUsers(t *testing.T) {
 	assert.NotNil(t, result["data"])
 }
 
+func TestUserHandler_ListUsers_Query(t *testing.T) {
+	db := setupHandlerDB(t)
+	handler := NewUserHandler(db)
+
+	adminUser := &domain.User{Name: "Admin", Email: "admin@test.com", Password: "admin123", Role: domain.UserRoleAdmin}
+	adminUser.HashPassword()
+	db.Create(adminUser)
+	for _, name := range []string{"Carol", "Alice", "Bob"} {
+		user := &domain.User{Name: name, Email: name + "@test.com", Password: "pass123", Role: domain.UserRoleEditor}
+		user.HashPassword()
+		db.Create(user)
+	}
+
+	app := fiber.New()
+	app.Get("/users", func(c *fiber.Ctx) error {
+		c.Locals("db", db)
+		c.Locals("user_id", adminUser.ID.String())
+		return handler.ListUsers(c)
+	})
+
+	get := func(query string) (int, map[string]interface{}) {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users?"+query, nil))
+		assert.NoError(t, err)
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result
+	}
+
+	status, result := get("filter[role]=editor&sort=name&fields=name&limit=2")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(3), result["total"])
+	data := result["data"].([]interface{})
+	if assert.Len(t, data, 2) {
+		first := data[0].(map[string]interface{})
+		assert.Equal(t, "Alice", first["name"])
+		assert.NotEmpty(t, first["id"])
+		assert.NotContains(t, first, "email")
+	}
+	cursor, _ := result["next_cursor"].(string)
+	assert.NotEmpty(t, cursor)
+
+	status, result = get("filter[role]=editor&sort=name&limit=2&cursor=" + cursor)
+	assert.Equal(t, http.StatusOK, status)
+	data = result["data"].([]interface{})
+	if assert.Len(t, data, 1) {
+		assert.Equal(t, "Carol", data[0].(map[string]interface{})["name"])
+	}
+	assert.NotContains(t, result, "next_cursor")
+
+	for _, query := range []string{"limit=500", "offset=-1", "sort=password", "filter[role]=owner", "fields=password", "filter[name]=Bob"} {
+		status, _ := get(query)
+		assert.Equal(t, http.StatusBadRequest, status, query)
+	}
+}
+
 func TestUserHandler_UpdateUser(t *testing.T) {
-	db := setupTestDB()
+	db := setupHandlerDB(t)
 	handler := NewUserHandler(db)
 
 	app := fiber.New()
@@ -194,7 +235,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 }
 
 func TestUserHandler_DeleteUser(t *testing.T) {
-	db := setupTestDB()
+	db := setupHandlerDB(t)
 	handler := NewUserHandler(db)
 
 	app := fiber.New()
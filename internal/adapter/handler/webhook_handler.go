@@ -0,0 +1,404 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/adapter/webhook"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookHandler handles webhook subscriptions and their delivery log; requires the admin role
+type WebhookHandler struct {
+	db *gorm.DB
+}
+
+// NewWebhookHandler creates a new webhook handler instance
+func NewWebhookHandler(db *gorm.DB) *WebhookHandler {
+	return &WebhookHandler{
+		db: db,
+	}
+}
+
+// WebhookRequest represents the request body for creating or updating a webhook
+// Omitted fields keep their current values on update
+type WebhookRequest struct {
+	Name         *string   `json:"name"`
+	URL          *string   `json:"url"`
+	Events       *[]string `json:"events"` // Empty for all events
+	Secret       *string   `json:"secret"` // Generated on create when omitted
+	Active       *bool     `json:"active"`
+	RotateSecret bool      `json:"rotate_secret"`
+}
+
+// WebhookWithSecret is a webhook with its signing secret, returned only when the secret is set
+type WebhookWithSecret struct {
+	*domain.Webhook
+	Secret string `json:"secret"`
+}
+
+// ListWebhooks handles GET /api/v1/webhooks (protected endpoint)
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return webhookAdminRequired(c)
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	webhooks, err := repository.NewWebhookRepository(db).List(c.Context(), middleware.GetTenantID(c))
+	if err != nil {
+		log.Printf("Error listing webhooks: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list webhooks",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   webhooks,
+		"total":  len(webhooks),
+		"events": domain.WebhookEventTypes,
+	})
+}
+
+// CreateWebhook handles POST /api/v1/webhooks (protected endpoint)
+// The response includes the signing secret; it is not shown again
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return webhookAdminRequired(c)
+	}
+
+	var req WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	webhook := &domain.Webhook{
+		TenantID: middleware.GetTenantID(c),
+		Active:   true,
+	}
+	if err := applyWebhookRequest(c.Context(), webhook, &req); err != nil {
+		return invalidWebhook(c, err)
+	}
+	if webhook.Secret == "" {
+		webhook.Secret = newWebhookSecret()
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if err := repository.NewWebhookRepository(db).Create(c.Context(), webhook); err != nil {
+		log.Printf("Error creating webhook: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create webhook",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(WebhookWithSecret{Webhook: webhook, Secret: webhook.Secret})
+}
+
+// GetWebhook handles GET /api/v1/webhooks/:id (protected endpoint)
+func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return webhookAdminRequired(c)
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	webhook, ok, err := h.loadWebhook(c, db)
+	if !ok {
+		return err
+	}
+	return c.JSON(webhook)
+}
+
+// UpdateWebhook handles PUT /api/v1/webhooks/:id (protected endpoint)
+// Set rotate_secret to generate a new signing secret, which is returned once
+func (h *WebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return webhookAdminRequired(c)
+	}
+
+	var req WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	webhook, ok, err := h.loadWebhook(c, db)
+	if !ok {
+		return err
+	}
+	if err := applyWebhookRequest(c.Context(), webhook, &req); err != nil {
+		return invalidWebhook(c, err)
+	}
+	if req.RotateSecret {
+		webhook.Secret = newWebhookSecret()
+	}
+
+	if err := repository.NewWebhookRepository(db).Update(c.Context(), webhook); err != nil {
+		log.Printf("Error updating webhook: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update webhook",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	if req.RotateSecret || req.Secret != nil {
+		return c.JSON(WebhookWithSecret{Webhook: webhook, Secret: webhook.Secret})
+	}
+	return c.JSON(webhook)
+}
+
+// DeleteWebhook handles DELETE /api/v1/webhooks/:id (protected endpoint)
+// Queued deliveries are dropped along with the delivery log
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return webhookAdminRequired(c)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	if err := repository.NewWebhookRepository(db).Delete(c.Context(), middleware.GetTenantID(c), id); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return webhookNotFound(c)
+		}
+		log.Printf("Error deleting webhook: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete webhook",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// ListWebhookDeliveries handles GET /api/v1/webhooks/:id/deliveries (protected endpoint)
+// Supports ?status=pending|succeeded|failed, ?limit= and ?offset=
+func (h *WebhookHandler) ListWebhookDeliveries(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return webhookAdminRequired(c)
+	}
+
+	status := domain.WebhookDeliveryStatus(c.Query("status"))
+	switch status {
+	case "", domain.WebhookDeliveryPending, domain.WebhookDeliverySucceeded, domain.WebhookDeliveryFailed:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "status must be 'pending', 'succeeded' or 'failed'",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	limit, offset, ok, err := parsePaging(c, 20)
+	if !ok {
+		return err
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	webhook, ok, err := h.loadWebhook(c, db)
+	if !ok {
+		return err
+	}
+
+	deliveries, total, err := repository.NewWebhookRepository(db).ListDeliveries(c.Context(), webhook.TenantID, webhook.ID, status, limit, offset)
+	if err != nil {
+		log.Printf("Error listing webhook deliveries: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list webhook deliveries",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   deliveries,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// ReplayWebhookDelivery handles POST /api/v1/webhooks/:id/deliveries/:delivery_id/replay (protected endpoint)
+// Queues the same payload again as a new delivery, so the original stays in the log
+func (h *WebhookHandler) ReplayWebhookDelivery(c *fiber.Ctx) error {
+	if !hasAdminRole(c) {
+		return webhookAdminRequired(c)
+	}
+
+	deliveryID, err := uuid.Parse(c.Params("delivery_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid delivery ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	webhook, ok, err := h.loadWebhook(c, db)
+	if !ok {
+		return err
+	}
+
+	repo := repository.NewWebhookRepository(db)
+	original, err := repo.GetDelivery(c.Context(), webhook.TenantID, webhook.ID, deliveryID)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookDeliveryNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Delivery not found",
+				"code":  fiber.StatusNotFound,
+			})
+		}
+		log.Printf("Error getting webhook delivery: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get webhook delivery",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	replay := domain.NewWebhookDelivery(webhook, original.EventID, original.EventType, original.Payload)
+	replay.ReplayOfID = &original.ID
+	if err := repo.CreateDeliveries(c.Context(), []*domain.WebhookDelivery{replay}); err != nil {
+		log.Printf("Error replaying webhook delivery: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to replay webhook delivery",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(replay)
+}
+
+// loadWebhook loads the tenant's webhook named by the :id route parameter
+// Writes the error response and returns false when it cannot be loaded
+func (h *WebhookHandler) loadWebhook(c *fiber.Ctx, db *gorm.DB) (*domain.Webhook, bool, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID format",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	webhook, err := repository.NewWebhookRepository(db).GetByID(c.Context(), middleware.GetTenantID(c), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return nil, false, webhookNotFound(c)
+		}
+		log.Printf("Error getting webhook: %v", err)
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get webhook",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+	return webhook, true, nil
+}
+
+// applyWebhookRequest copies the fields set in req to hook and validates the result
+// The URL's host is resolved so hostnames of internal addresses are refused up front
+func applyWebhookRequest(ctx context.Context, hook *domain.Webhook, req *WebhookRequest) error {
+	if req.Name != nil {
+		hook.Name = *req.Name
+	}
+	if req.URL != nil {
+		hook.URL = *req.URL
+	}
+	if req.Events != nil {
+		events, err := json.Marshal(*req.Events)
+		if err != nil {
+			return err
+		}
+		hook.Events = events
+	}
+	if req.Secret != nil {
+		hook.Secret = *req.Secret
+	}
+	if req.Active != nil {
+		hook.Active = *req.Active
+	}
+	if err := domain.ValidateWebhook(hook); err != nil {
+		return err
+	}
+	return webhook.CheckHost(ctx, hook.URL)
+}
+
+// newWebhookSecret generates a random signing secret
+func newWebhookSecret() string {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(secret)
+}
+
+// invalidWebhook writes the response for a webhook that failed validation
+func invalidWebhook(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": err.Error(),
+		"code":  fiber.StatusBadRequest,
+	})
+}
+
+// webhookNotFound writes the response for an unknown webhook
+func webhookNotFound(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		"error": "Webhook not found",
+		"code":  fiber.StatusNotFound,
+	})
+}
+
+// webhookAdminRequired writes the response for non-admins managing webhooks
+func webhookAdminRequired(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": "Only admins can manage webhooks",
+		"code":  fiber.StatusForbidden,
+	})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookHandler_QueueAndReplay(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	pages := NewPageHandler(db)
+	webhooks := NewWebhookHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/pages", pages.CreatePage)
+	app.Get("/api/v1/webhooks", webhooks.ListWebhooks)
+	app.Post("/api/v1/webhooks", webhooks.CreateWebhook)
+	app.Put("/api/v1/webhooks/:id", webhooks.UpdateWebhook)
+	app.Get("/api/v1/webhooks/:id/deliveries", webhooks.ListWebhookDeliveries)
+	app.Post("/api/v1/webhooks/:id/deliveries/:delivery_id/replay", webhooks.ReplayWebhookDelivery)
+
+	listDeliveries := func(path string) []domain.WebhookDelivery {
+		resp := app.send("GET", path, nil, asRole(domain.UserRoleAdmin))
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var body struct {
+			Data []domain.WebhookDelivery `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body.Data
+	}
+
+	str := func(s string) *string { return &s }
+	inactive := false
+	hook := WebhookRequest{
+		Name:   str("Rebuild site"),
+		URL:    str("https://example.com/build"),
+		Events: &[]string{"content.created"},
+	}
+	resp := app.send("POST", "/api/v1/webhooks", hook, asRole(domain.UserRoleEditor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	for _, url := range []string{"example.com", "http://localhost:8080/admin", "http://169.254.169.254/latest/meta-data/"} {
+		resp = app.send("POST", "/api/v1/webhooks", WebhookRequest{Name: str("Bad"), URL: str(url)}, asRole(domain.UserRoleAdmin))
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, url)
+	}
+
+	resp = app.send("POST", "/api/v1/webhooks", hook, asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var created struct {
+		ID     string `json:"id"`
+		Secret string `json:"secret"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	assert.Len(t, created.Secret, 64)
+
+	// The secret is only shown when it is set
+	resp = app.send("GET", "/api/v1/webhooks", nil, asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var listed map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listed))
+	assert.NotContains(t, listed["data"].([]interface{})[0], "secret")
+
+	// Creating a page queues a delivery of the event
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "about", Title: "About"}, asRole(domain.UserRoleEditor))
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	dispatchEvents(t, db)
+	deliveries := listDeliveries("/api/v1/webhooks/" + created.ID + "/deliveries")
+	require.Len(t, deliveries, 1)
+	original := deliveries[0]
+	assert.Equal(t, domain.EventContentCreated, original.EventType)
+	assert.Equal(t, domain.WebhookDeliveryPending, original.Status)
+	var payload domain.Event
+	require.NoError(t, json.Unmarshal(original.Payload, &payload))
+	assert.Equal(t, original.EventID, payload.ID)
+	assert.Equal(t, "page", payload.EntityType)
+
+	// Paused webhooks receive nothing
+	resp = app.send("PUT", "/api/v1/webhooks/"+created.ID, WebhookRequest{Active: &inactive}, asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "contact", Title: "Contact"}, asRole(domain.UserRoleEditor))
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	dispatchEvents(t, db)
+	assert.Len(t, listDeliveries("/api/v1/webhooks/"+created.ID+"/deliveries"), 1)
+
+	// Replays queue a copy and keep the original in the log
+	resp = app.send("POST", "/api/v1/webhooks/"+created.ID+"/deliveries/"+original.ID.String()+"/replay", nil, asRole(domain.UserRoleAdmin))
+	require.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	deliveries = listDeliveries("/api/v1/webhooks/" + created.ID + "/deliveries?status=pending")
+	require.Len(t, deliveries, 2)
+	var replay domain.WebhookDelivery
+	for _, d := range deliveries {
+		if d.ID != original.ID {
+			replay = d
+		}
+	}
+	require.NotNil(t, replay.ReplayOfID)
+	assert.Equal(t, original.ID, *replay.ReplayOfID)
+	assert.JSONEq(t, string(original.Payload), string(replay.Payload))
+
+	resp = app.send("GET", "/api/v1/webhooks/"+created.ID+"/deliveries?status=lost", nil, asRole(domain.UserRoleAdmin))
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
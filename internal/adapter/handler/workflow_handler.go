@@ -0,0 +1,454 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gohac/internal/adapter/database"
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+	"gohac/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxReviewCommentLength limits the size of a review comment
+const maxReviewCommentLength = 5000
+
+// WorkflowHandler handles the editorial review of pages and posts
+type WorkflowHandler struct {
+	db *gorm.DB
+}
+
+// NewWorkflowHandler creates a new workflow handler instance
+func NewWorkflowHandler(db *gorm.DB) *WorkflowHandler {
+	return &WorkflowHandler{
+		db: db,
+	}
+}
+
+// TransitionRequest represents the request body for moving content to another status
+type TransitionRequest struct {
+	Status  string `json:"status"`
+	Comment string `json:"comment,omitempty"` // Shown to the author, e.g. why a submission was rejected
+}
+
+// ReviewCommentRequest represents the request body for commenting on a page or post
+type ReviewCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// ListReviewQueue handles GET /api/v1/reviews (protected endpoint)
+// Lists pages and posts waiting for review; supports ?type=page|post and ?status= (default in_review)
+func (h *WorkflowHandler) ListReviewQueue(c *fiber.Ctx) error {
+	entityType := domain.ReviewEntityType(c.Query("type"))
+	if entityType != "" && entityType != domain.ReviewEntityPage && entityType != domain.ReviewEntityPost {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": errInvalidReviewType.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	status := c.Query("status", string(domain.PageStatusInReview))
+	if !domain.IsWorkflowStatus(status) {
+		return invalidWorkflowStatus(c)
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	items, err := repository.NewReviewRepository(db).ListQueue(c.Context(), middleware.GetTenantID(c), entityType, status)
+	if err != nil {
+		log.Printf("Error listing review queue: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list review queue",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  items,
+		"total": len(items),
+	})
+}
+
+// TransitionContent handles POST /api/v1/reviews/:type/:id/transition (protected endpoint)
+// Moves a page or post to another status, as far as the tenant's workflow allows the user's role
+func (h *WorkflowHandler) TransitionContent(c *fiber.Ctx) error {
+	entityType, id, err := parseReviewTarget(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var req TransitionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	to := strings.ToLower(req.Status)
+	if !domain.IsWorkflowStatus(to) {
+		return invalidWorkflowStatus(c)
+	}
+	if len(req.Comment) > maxReviewCommentLength {
+		return reviewCommentTooLong(c)
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tenantID := middleware.GetTenantID(c)
+	settings := tenantSettings(c, db)
+
+	var (
+		from   string
+		title  string
+		entity interface{}
+	)
+	switch entityType {
+	case domain.ReviewEntityPage:
+		repo := repository.NewPageRepository(db)
+		page, err := repo.GetByID(c.Context(), id)
+		if err == nil && page.TenantID != tenantID {
+			err = domain.ErrPageNotFound
+		}
+		if err != nil {
+			return pageLookupFailed(c, err)
+		}
+		if ok, err := checkVersion(c, page.Version, nil); !ok {
+			return err
+		}
+		from, title = string(page.Status), page.Title
+		if ok, err := enforceTransition(c, settings, from, to); !ok {
+			return err
+		}
+		page.Status = domain.PageStatus(to)
+		if to == string(domain.PageStatusPublished) && from != to {
+			now := time.Now()
+			page.PublishedAt = &now
+		}
+		if err := repo.As(actorID(c)).Update(c.Context(), page); err != nil {
+			if errors.Is(err, domain.ErrVersionConflict) {
+				if current, err := repo.GetByID(c.Context(), id); err == nil {
+					return versionConflict(c, current.Version)
+				}
+			}
+			log.Printf("Error updating page status: %v", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update page",
+				"code":  fiber.StatusInternalServerError,
+			})
+		}
+		setVersionETag(c, page.Version)
+		entity = page
+	case domain.ReviewEntityPost:
+		repo := repository.NewPostRepository(db)
+		post, err := repo.GetByID(c.Context(), id)
+		if err == nil && post.TenantID != tenantID {
+			err = errors.New("post not found")
+		}
+		if err != nil {
+			return postLookupFailed(c, err)
+		}
+		if ok, err := checkVersion(c, post.Version, nil); !ok {
+			return err
+		}
+		from, title = string(post.Status), post.Title
+		if ok, err := enforceTransition(c, settings, from, to); !ok {
+			return err
+		}
+		post.Status = domain.PostStatus(to)
+		if to == string(domain.PostStatusPublished) && from != to {
+			now := time.Now()
+			post.PublishedAt = &now
+		}
+		if err := repo.As(actorID(c)).Update(c.Context(), post); err != nil {
+			if errors.Is(err, domain.ErrVersionConflict) {
+				if current, err := repo.GetByID(c.Context(), id); err == nil {
+					return versionConflict(c, current.Version)
+				}
+			}
+			log.Printf("Error updating post status: %v", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to update post",
+				"code":  fiber.StatusInternalServerError,
+			})
+		}
+		setVersionETag(c, post.Version)
+		entity = post
+	}
+
+	if from == to {
+		if req.Comment != "" {
+			addReviewComment(c, db, tenantID, entityType, id, "", "", req.Comment)
+		}
+	} else {
+		recordTransition(c, db, settings, entityType, id, title, from, to, req.Comment)
+	}
+
+	return c.JSON(entity)
+}
+
+// ListReviewComments handles GET /api/v1/reviews/:type/:id/comments (protected endpoint)
+// Returns the comments and status changes of a page or post, oldest first
+func (h *WorkflowHandler) ListReviewComments(c *fiber.Ctx) error {
+	entityType, id, err := parseReviewTarget(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	comments, err := repository.NewReviewRepository(db).ListComments(c.Context(), middleware.GetTenantID(c), entityType, id)
+	if err != nil {
+		log.Printf("Error listing review comments: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list review comments",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  comments,
+		"total": len(comments),
+	})
+}
+
+// CreateReviewComment handles POST /api/v1/reviews/:type/:id/comments (protected endpoint)
+func (h *WorkflowHandler) CreateReviewComment(c *fiber.Ctx) error {
+	entityType, id, err := parseReviewTarget(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+
+	var req ReviewCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Comment body is required",
+			"code":  fiber.StatusBadRequest,
+		})
+	}
+	if len(body) > maxReviewCommentLength {
+		return reviewCommentTooLong(c)
+	}
+
+	db, err := database.GetDBFromContext(c.Context())
+	if err != nil {
+		db = h.db
+	}
+
+	tenantID := middleware.GetTenantID(c)
+	if exists, err := contentExists(db, tenantID, string(entityType), id); err != nil || !exists {
+		if err != nil {
+			log.Printf("Error checking %s %s: %v", entityType, id, err)
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Content not found",
+			"code":  fiber.StatusNotFound,
+		})
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	comment := &domain.ReviewComment{
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   id,
+		AuthorID:   userID,
+		Body:       body,
+	}
+	if err := repository.NewReviewRepository(db).CreateComment(c.Context(), comment); err != nil {
+		log.Printf("Error creating review comment: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create review comment",
+			"code":  fiber.StatusInternalServerError,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(comment)
+}
+
+// enforceTransition checks that the tenant's workflow lets the user's role move content between two statuses
+// Every move is allowed while the workflow is disabled
+func enforceTransition(c *fiber.Ctx, settings *domain.GlobalSettings, from, to string) (bool, error) {
+	role, _ := c.Locals("user_role").(string)
+	if settings.Workflow.Allows(from, to, domain.UserRole(role)) {
+		return true, nil
+	}
+	return false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": fmt.Sprintf("Your role cannot move content from '%s' to '%s'", from, to),
+		"code":  fiber.StatusForbidden,
+	})
+}
+
+// enforceReviewedEdit applies the workflow to an edit of content that ends up in status
+// Returns the status to save, which is "in_review" when a non-reviewer edits approved content
+func enforceReviewedEdit(c *fiber.Ctx, settings *domain.GlobalSettings, status string) (string, bool, error) {
+	if edited, ok := reviewedEditStatus(c, settings, status); ok {
+		return edited, true, nil
+	}
+	return "", false, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": reviewedEditRefusal(status),
+		"code":  fiber.StatusForbidden,
+	})
+}
+
+// reviewedEditStatus is enforceReviewedEdit for callers that report the refusal themselves
+func reviewedEditStatus(c *fiber.Ctx, settings *domain.GlobalSettings, status string) (string, bool) {
+	role, _ := c.Locals("user_role").(string)
+	return settings.Workflow.EditedStatus(status, domain.UserRole(role))
+}
+
+// reviewedEditRefusal explains why the user's role cannot edit content in status
+func reviewedEditRefusal(status string) string {
+	return fmt.Sprintf("Your role cannot change content that is '%s'; move it back to draft first", status)
+}
+
+// recordTransition adds a status change to the review history and notifies the people it concerns:
+// reviewers when content is submitted, the submitter when it is sent back to draft
+// Failures are logged but never fail the request
+func recordTransition(c *fiber.Ctx, db *gorm.DB, settings *domain.GlobalSettings, entityType domain.ReviewEntityType, id uuid.UUID, title, from, to, comment string) {
+	tenantID := middleware.GetTenantID(c)
+	actorID, _ := c.Locals("user_id").(string)
+	repo := repository.NewReviewRepository(db)
+
+	// Look up the submitter before this change is recorded, in case it is a new submission
+	var submitter string
+	if domain.IsRejection(from, to) {
+		var err error
+		if submitter, err = repo.LastSubmitter(c.Context(), tenantID, entityType, id); err != nil {
+			log.Printf("Error finding submitter of %s %s: %v", entityType, id, err)
+		}
+	}
+
+	addReviewComment(c, db, tenantID, entityType, id, from, to, comment)
+
+	switch {
+	case to == string(domain.PageStatusInReview):
+		users, err := repository.NewUserRepository(db).ListByRoles(c.Context(), tenantID, settings.Workflow.ReviewerRoles())
+		if err != nil {
+			log.Printf("Error listing reviewers: %v", err)
+			return
+		}
+		for _, user := range users {
+			if user.ID.String() == actorID {
+				continue
+			}
+			notify(c, db, &domain.Notification{
+				TenantID:   tenantID,
+				UserID:     user.ID.String(),
+				Type:       domain.NotificationReviewSubmitted,
+				Title:      fmt.Sprintf("%q was submitted for review", title),
+				Message:    comment,
+				EntityType: string(entityType),
+				EntityID:   id.String(),
+			})
+		}
+	case submitter != "" && submitter != actorID:
+		notify(c, db, &domain.Notification{
+			TenantID:   tenantID,
+			UserID:     submitter,
+			Type:       domain.NotificationReviewRejected,
+			Title:      fmt.Sprintf("%q was sent back to draft", title),
+			Message:    comment,
+			EntityType: string(entityType),
+			EntityID:   id.String(),
+		})
+	}
+}
+
+// addReviewComment stores a review comment or status change, logging failures
+func addReviewComment(c *fiber.Ctx, db *gorm.DB, tenantID string, entityType domain.ReviewEntityType, id uuid.UUID, from, to, body string) {
+	authorID, _ := c.Locals("user_id").(string)
+	comment := &domain.ReviewComment{
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   id,
+		AuthorID:   authorID,
+		FromStatus: from,
+		ToStatus:   to,
+		Body:       body,
+	}
+	if err := repository.NewReviewRepository(db).CreateComment(c.Context(), comment); err != nil {
+		log.Printf("Error recording review of %s %s: %v", entityType, id, err)
+	}
+}
+
+// notify stores a notification, logging failures
+func notify(c *fiber.Ctx, db *gorm.DB, notification *domain.Notification) {
+	if err := repository.NewNotificationRepository(db).Create(c.Context(), notification); err != nil {
+		log.Printf("Error notifying user %s: %v", notification.UserID, err)
+	}
+}
+
+// contentExists reports whether a tenant has the page or post ("page" or "post")
+func contentExists(db *gorm.DB, tenantID, entityType string, id uuid.UUID) (bool, error) {
+	var model interface{} = &domain.Page{}
+	if entityType == "post" {
+		model = &domain.Post{}
+	}
+	var count int64
+	if err := db.Model(model).Where("id = ? AND tenant_id = ?", id, tenantID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// errInvalidReviewType is returned for content that does not go through the workflow
+var errInvalidReviewType = errors.New("type must be 'page' or 'post'")
+
+// parseReviewTarget parses the :type and :id route parameters
+func parseReviewTarget(c *fiber.Ctx) (domain.ReviewEntityType, uuid.UUID, error) {
+	entityType := domain.ReviewEntityType(c.Params("type"))
+	if entityType != domain.ReviewEntityPage && entityType != domain.ReviewEntityPost {
+		return "", uuid.Nil, errInvalidReviewType
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return "", uuid.Nil, errors.New("invalid ID format")
+	}
+	return entityType, id, nil
+}
+
+// invalidWorkflowStatus writes the response for an unknown page or post status
+func invalidWorkflowStatus(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": "Invalid status. Must be one of: " + strings.Join(domain.WorkflowStatuses, ", "),
+		"code":  fiber.StatusBadRequest,
+	})
+}
+
+// reviewCommentTooLong writes the response for an oversized review comment
+func reviewCommentTooLong(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": fmt.Sprintf("Comment must be at most %d characters", maxReviewCommentLength),
+		"code":  fiber.StatusBadRequest,
+	})
+}
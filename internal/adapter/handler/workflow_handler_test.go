@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowHandler_ReviewCycle(t *testing.T) {
+	db := setupHandlerDB(t)
+
+	editor := &domain.User{Name: "Ed", Email: "ed@example.com", Password: "x", Role: domain.UserRoleEditor}
+	admin := &domain.User{Name: "Ada", Email: "ada@example.com", Password: "x", Role: domain.UserRoleAdmin}
+	require.NoError(t, db.Create(editor).Error)
+	require.NoError(t, db.Create(admin).Error)
+	require.NoError(t, repository.NewSettingsRepository(db).UpdateGlobalSettings(context.Background(), "",
+		&domain.GlobalSettings{Workflow: &domain.WorkflowSettings{Enabled: true}}))
+
+	pages := NewPageHandler(db)
+	workflow := NewWorkflowHandler(db)
+	notifications := NewNotificationHandler(db)
+	app := newTestApp(t)
+	app.Post("/api/v1/pages", pages.CreatePage)
+	app.Put("/api/v1/pages/:id", pages.UpdatePage)
+	app.Get("/api/v1/reviews", workflow.ListReviewQueue)
+	app.Post("/api/v1/reviews/:type/:id/transition", workflow.TransitionContent)
+	app.Get("/api/v1/reviews/:type/:id/comments", workflow.ListReviewComments)
+	app.Post("/api/v1/reviews/:type/:id/comments", workflow.CreateReviewComment)
+	app.Get("/api/v1/notifications", notifications.ListNotifications)
+	app.Post("/api/v1/notifications/read", notifications.MarkAllNotificationsRead)
+
+	listNotifications := func(user *domain.User) []domain.Notification {
+		resp := app.send("GET", "/api/v1/notifications?unread=true", nil, asUser(user))
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var body struct {
+			Data []domain.Notification `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body.Data
+	}
+	queue := func() []domain.ReviewItem {
+		resp := app.send("GET", "/api/v1/reviews", nil, asUser(admin))
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var body struct {
+			Data []domain.ReviewItem `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body.Data
+	}
+
+	// Editors cannot publish directly, neither on create nor on update
+	resp := app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "launch", Title: "Launch", Status: "published"}, asUser(editor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	resp = app.send("POST", "/api/v1/pages", CreatePageRequest{Slug: "launch", Title: "Launch"}, asUser(editor))
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	var page domain.Page
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Status: "published"}, asUser(editor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	// Submitting puts the page in the queue and notifies reviewers
+	transition := "/api/v1/reviews/page/" + page.ID.String() + "/transition"
+	resp = app.send("POST", transition, TransitionRequest{Status: "in_review"}, asUser(editor))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	items := queue()
+	require.Len(t, items, 1)
+	assert.Equal(t, page.ID, items[0].ID)
+	assert.Equal(t, editor.ID.String(), items[0].SubmittedBy)
+	assert.Empty(t, listNotifications(editor))
+	adminNotes := listNotifications(admin)
+	require.Len(t, adminNotes, 1)
+	assert.Equal(t, domain.NotificationReviewSubmitted, adminNotes[0].Type)
+
+	// Only reviewers approve; rejections notify the submitter with the reviewer's comment
+	resp = app.send("POST", transition, TransitionRequest{Status: "approved"}, asUser(editor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	comments := "/api/v1/reviews/page/" + page.ID.String() + "/comments"
+	resp = app.send("POST", comments, ReviewCommentRequest{Body: "Needs a hero image"}, asUser(admin))
+	require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	resp = app.send("POST", transition, TransitionRequest{Status: "draft", Comment: "See my comment"}, asUser(admin))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, queue())
+	editorNotes := listNotifications(editor)
+	require.Len(t, editorNotes, 1)
+	assert.Equal(t, domain.NotificationReviewRejected, editorNotes[0].Type)
+	assert.Equal(t, "See my comment", editorNotes[0].Message)
+
+	resp = app.send("GET", comments, nil, asUser(editor))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var history struct {
+		Data []domain.ReviewComment `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	require.Len(t, history.Data, 3)
+	assert.Equal(t, "in_review", history.Data[0].ToStatus)
+	assert.Equal(t, "Needs a hero image", history.Data[1].Body)
+	assert.Equal(t, "draft", history.Data[2].ToStatus)
+
+	// Resubmitted and approved; an edit by the editor needs another review
+	require.Equal(t, fiber.StatusOK, app.send("POST", transition, TransitionRequest{Status: "in_review"}, asUser(editor)).StatusCode)
+	require.Equal(t, fiber.StatusOK, app.send("POST", transition, TransitionRequest{Status: "approved"}, asUser(admin)).StatusCode)
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Title: "Launch day", Status: "published"}, asUser(editor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Title: "Launch day"}, asUser(editor))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	assert.Equal(t, domain.PageStatusInReview, page.Status)
+	require.Len(t, queue(), 1)
+
+	// Approved again and published by the editor, who can no longer change it
+	require.Equal(t, fiber.StatusOK, app.send("POST", transition, TransitionRequest{Status: "approved"}, asUser(admin)).StatusCode)
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Status: "published"}, asUser(editor))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	assert.Equal(t, domain.PageStatusPublished, page.Status)
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Title: "Launch!"}, asUser(editor))
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	resp = app.send("PUT", "/api/v1/pages/"+page.ID.String(), UpdatePageRequest{Title: "Launch!"}, asUser(admin))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	assert.Equal(t, domain.PageStatusPublished, page.Status)
+
+	resp = app.send("POST", "/api/v1/notifications/read", nil, asUser(editor))
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, listNotifications(editor))
+
+	resp = app.send("POST", transition, TransitionRequest{Status: "scheduled"}, asUser(admin))
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp = app.send("GET", "/api/v1/reviews?type=menu", nil, asUser(admin))
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
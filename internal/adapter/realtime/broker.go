@@ -0,0 +1,144 @@
+// Package realtime fans tenant events out to the admin panel's open event streams
+// Handlers publish through a Broker; the in-memory broker only reaches subscribers of
+// the same process, so deployments running several instances plug in a broker backed
+// by shared infrastructure with SetBroker.
+package realtime
+
+import (
+	"context"
+	"sync"
+
+	"gohac/internal/core/domain"
+)
+
+// subscriptionBuffer is how many events a subscriber may fall behind before events are dropped
+const subscriptionBuffer = 64
+
+// Broker delivers published events to every subscriber of the event's tenant
+type Broker interface {
+	// Publish sends the event to the tenant's subscribers, on all instances
+	Publish(ctx context.Context, event domain.Event) error
+	// Subscribe streams the tenant's events until the subscription is closed
+	Subscribe(tenantID string) Subscription
+	// Close ends all subscriptions
+	Close() error
+}
+
+// Subscription is a stream of one tenant's events
+type Subscription interface {
+	// Events is closed when the subscription or its broker is closed
+	Events() <-chan domain.Event
+	Close()
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultBroker Broker = NewMemoryBroker()
+)
+
+// Default returns the broker used by the HTTP handlers
+func Default() Broker {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultBroker
+}
+
+// SetBroker replaces the broker used by the HTTP handlers and returns the previous one
+func SetBroker(b Broker) Broker {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	previous := defaultBroker
+	defaultBroker = b
+	return previous
+}
+
+// MemoryBroker is a Broker for a single instance
+// Slow subscribers miss events rather than holding up publishers
+type MemoryBroker struct {
+	mu     sync.RWMutex
+	subs   map[string]map[*memorySubscription]struct{} // Tenant ID -> subscriptions
+	closed bool
+}
+
+// NewMemoryBroker creates an in-memory broker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{subs: make(map[string]map[*memorySubscription]struct{})}
+}
+
+// Publish sends the event to the tenant's subscribers without blocking
+func (b *MemoryBroker) Publish(ctx context.Context, event domain.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subs[event.TenantID] {
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe streams the tenant's events; a closed broker returns a closed subscription
+func (b *MemoryBroker) Subscribe(tenantID string) Subscription {
+	sub := &memorySubscription{
+		broker:   b,
+		tenantID: tenantID,
+		events:   make(chan domain.Event, subscriptionBuffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(sub.events)
+		sub.done = true
+		return sub
+	}
+	if b.subs[tenantID] == nil {
+		b.subs[tenantID] = make(map[*memorySubscription]struct{})
+	}
+	b.subs[tenantID][sub] = struct{}{}
+	return sub
+}
+
+// Close ends all subscriptions; later subscriptions are closed immediately
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for tenantID, subs := range b.subs {
+		for sub := range subs {
+			sub.done = true
+			close(sub.events)
+		}
+		delete(b.subs, tenantID)
+	}
+	return nil
+}
+
+// memorySubscription is a MemoryBroker subscriber
+// done is guarded by the broker's lock so the channel is closed exactly once
+type memorySubscription struct {
+	broker   *MemoryBroker
+	tenantID string
+	events   chan domain.Event
+	done     bool
+}
+
+func (s *memorySubscription) Events() <-chan domain.Event {
+	return s.events
+}
+
+func (s *memorySubscription) Close() {
+	b := s.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if s.done {
+		return
+	}
+	s.done = true
+	delete(b.subs[s.tenantID], s)
+	if len(b.subs[s.tenantID]) == 0 {
+		delete(b.subs, s.tenantID)
+	}
+	close(s.events)
+}
@@ -0,0 +1,56 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBroker_TenantFanOut(t *testing.T) {
+	b := NewMemoryBroker()
+	first := b.Subscribe("acme")
+	second := b.Subscribe("acme")
+	other := b.Subscribe("globex")
+
+	event := domain.NewEvent(domain.EventContentCreated, "acme", "page", "42")
+	require.NoError(t, b.Publish(context.Background(), event))
+
+	assert.Equal(t, event, <-first.Events())
+	assert.Equal(t, event, <-second.Events())
+	assert.Empty(t, other.Events())
+
+	// Closed subscriptions stop receiving; closing twice is harmless
+	first.Close()
+	first.Close()
+	_, open := <-first.Events()
+	assert.False(t, open)
+	require.NoError(t, b.Publish(context.Background(), event))
+	assert.Len(t, second.Events(), 1)
+}
+
+func TestMemoryBroker_SlowSubscriberDropsEvents(t *testing.T) {
+	b := NewMemoryBroker()
+	sub := b.Subscribe("")
+	for i := 0; i < subscriptionBuffer+10; i++ {
+		require.NoError(t, b.Publish(context.Background(), domain.NewEvent(domain.EventContentUpdated, "", "post", "1")))
+	}
+	assert.Len(t, sub.Events(), subscriptionBuffer)
+}
+
+func TestMemoryBroker_Close(t *testing.T) {
+	b := NewMemoryBroker()
+	sub := b.Subscribe("acme")
+	require.NoError(t, b.Close())
+
+	_, open := <-sub.Events()
+	assert.False(t, open)
+	sub.Close()
+
+	late := b.Subscribe("acme")
+	_, open = <-late.Events()
+	assert.False(t, open)
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"gorm.io/gorm"
+)
+
+// auditLogRepository implements the AuditLogRepository interface using GORM
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository instance
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+// Create records a new audit log entry
+func (r *auditLogRepository) Create(ctx context.Context, entry *domain.AuditLog) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+	return nil
+}
+
+// List retrieves audit log entries, newest first
+func (r *auditLogRepository) List(ctx context.Context, opts repository.ListAuditLogOptions) ([]*domain.AuditLog, int64, error) {
+	var entries []*domain.AuditLog
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.AuditLog{})
+
+	if opts.TenantID != nil {
+		query = query.Where("tenant_id = ?", *opts.TenantID)
+	}
+	if opts.ActorID != "" {
+		query = query.Where("actor_id = ? OR impersonator_id = ?", opts.ActorID, opts.ActorID)
+	}
+	if opts.Action != "" {
+		query = query.Where("action = ?", opts.Action)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		query = query.Offset(opts.Offset)
+	}
+
+	if err := query.Order("created_at DESC").Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return entries, total, nil
+}
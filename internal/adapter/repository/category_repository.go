@@ -24,6 +24,9 @@ func NewCategoryRepository(db *gorm.DB) repository.CategoryRepository {
 // Create creates a new category
 func (r *categoryRepository) Create(ctx context.Context, category *domain.Category) error {
 	if err := r.db.WithContext(ctx).Create(category).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create category: %w", domain.ErrCategoryAlreadyExists)
+		}
 		return fmt.Errorf("failed to create category: %w", err)
 	}
 	return nil
@@ -42,10 +45,24 @@ func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 	return &category, nil
 }
 
-// GetBySlug retrieves a category by its slug
-func (r *categoryRepository) GetBySlug(ctx context.Context, slug string) (*domain.Category, error) {
+// ListByIDs retrieves a tenant's categories with the given IDs, in no particular order
+func (r *categoryRepository) ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	return categories, nil
+}
+
+// GetBySlug retrieves a category by its slug within a tenant
+func (r *categoryRepository) GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Category, error) {
 	var category domain.Category
-	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&category).Error
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND slug = ?", tenantID, slug).First(&category).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("category not found: %w", err)
@@ -58,37 +75,130 @@ func (r *categoryRepository) GetBySlug(ctx context.Context, slug string) (*domai
 // Update updates an existing category
 func (r *categoryRepository) Update(ctx context.Context, category *domain.Category) error {
 	if err := r.db.WithContext(ctx).Save(category).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update category: %w", domain.ErrCategoryAlreadyExists)
+		}
 		return fmt.Errorf("failed to update category: %w", err)
 	}
 	return nil
 }
 
-// Delete deletes a category by its UUID
+// UpdateWithDescendants updates a category and moves its subcategories' paths from oldSlug to its new slug
+// Returns the subcategories whose paths changed
+func (r *categoryRepository) UpdateWithDescendants(ctx context.Context, category *domain.Category, oldSlug string) ([]*domain.Category, error) {
+	var moved []*domain.Category
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(category).Error; err != nil {
+			return err
+		}
+		if category.Slug == oldSlug {
+			return nil
+		}
+
+		// Walk the tree level by level so subcategories follow their parent's new path
+		parents := []*domain.Category{category}
+		for depth := 0; len(parents) > 0 && depth < domain.MaxCategoryDepth; depth++ {
+			var next []*domain.Category
+			for _, parent := range parents {
+				var children []*domain.Category
+				if err := tx.Where("parent_id = ?", parent.ID).Find(&children).Error; err != nil {
+					return err
+				}
+				for _, child := range children {
+					child.Slug = domain.PagePath(parent.Slug, domain.PageSegment(child.Slug))
+					if err := tx.Model(child).Update("slug", child.Slug).Error; err != nil {
+						return err
+					}
+				}
+				next = append(next, children...)
+			}
+			moved = append(moved, next...)
+			parents = next
+		}
+		return nil
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("failed to update category: %w", domain.ErrCategoryAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+	return moved, nil
+}
+
+// Delete moves a category to the trash
+// Categories with subcategories are kept, so no subcategory is left without its parent
 func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.Category{}, "id = ?", id).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var children int64
+		if err := tx.Model(&domain.Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return err
+		}
+		if children > 0 {
+			return domain.ErrCategoryHasChildren
+		}
+		return tx.Delete(&domain.Category{}, "id = ?", id).Error
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
 	return nil
 }
 
-// List retrieves a list of categories with pagination
-func (r *categoryRepository) List(ctx context.Context, limit, offset int) ([]*domain.Category, int64, error) {
-	var categories []*domain.Category
-	var total int64
+// ListAll retrieves all of a tenant's categories, ordered by path
+func (r *categoryRepository) ListAll(ctx context.Context, tenantID string) ([]*domain.Category, error) {
+	categories := []*domain.Category{}
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("slug ASC").
+		Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	return categories, nil
+}
 
-	// Count total records
-	if err := r.db.WithContext(ctx).Model(&domain.Category{}).Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count categories: %w", err)
+// ListSubtreeIDs retrieves the IDs of a category and of every category nested under it
+func (r *categoryRepository) ListSubtreeIDs(ctx context.Context, tenantID string, id uuid.UUID) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{id}
+	parents := []uuid.UUID{id}
+	for depth := 0; len(parents) > 0 && depth < domain.MaxCategoryDepth; depth++ {
+		var children []uuid.UUID
+		if err := r.db.WithContext(ctx).Model(&domain.Category{}).
+			Where("tenant_id = ? AND parent_id IN ?", tenantID, parents).
+			Pluck("id", &children).Error; err != nil {
+			return nil, fmt.Errorf("failed to list subcategories: %w", err)
+		}
+		ids = append(ids, children...)
+		parents = children
 	}
+	return ids, nil
+}
 
-	// Fetch paginated records
-	if err := r.db.WithContext(ctx).
-		Limit(limit).
-		Offset(offset).
-		Order("name ASC").
-		Find(&categories).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to list categories: %w", err)
+// SubtreeHeight returns how many levels a category and its subcategories span, 1 for a category without any
+func (r *categoryRepository) SubtreeHeight(ctx context.Context, tenantID string, id uuid.UUID) (int, error) {
+	height := 0
+	parents := []uuid.UUID{id}
+	for len(parents) > 0 && height <= domain.MaxCategoryDepth {
+		height++
+		var children []uuid.UUID
+		if err := r.db.WithContext(ctx).Model(&domain.Category{}).
+			Where("tenant_id = ? AND parent_id IN ?", tenantID, parents).
+			Pluck("id", &children).Error; err != nil {
+			return 0, fmt.Errorf("failed to measure subcategories: %w", err)
+		}
+		parents = children
 	}
+	return height, nil
+}
 
-	return categories, total, nil
+// List retrieves a page of a tenant's categories matching a list query
+func (r *categoryRepository) List(ctx context.Context, tenantID string, q repository.ListQuery) ([]*domain.Category, repository.ListPage, error) {
+	var categories []*domain.Category
+	query := r.db.WithContext(ctx).Model(&domain.Category{}).Where("tenant_id = ?", tenantID)
+
+	page, err := listPage(query, repository.CategoryListSpec, q, &categories)
+	if err != nil {
+		return nil, page, fmt.Errorf("failed to list categories: %w", err)
+	}
+	return categories, page, nil
 }
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// itemSortColumns are the built-in columns collection items can be sorted by
+var itemSortColumns = map[string]bool{
+	"created_at":   true,
+	"updated_at":   true,
+	"published_at": true,
+	"slug":         true,
+}
+
+// collectionRepository implements the CollectionRepository interface using GORM
+type collectionRepository struct {
+	db *gorm.DB
+}
+
+// NewCollectionRepository creates a new collection repository instance
+func NewCollectionRepository(db *gorm.DB) repository.CollectionRepository {
+	return &collectionRepository{db: db}
+}
+
+// Create creates a new collection
+func (r *collectionRepository) Create(ctx context.Context, collection *domain.Collection) error {
+	if err := r.db.WithContext(ctx).Create(collection).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create collection: %w", domain.ErrCollectionAlreadyExists)
+		}
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a collection by its UUID
+func (r *collectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Collection, error) {
+	var collection domain.Collection
+	if err := r.db.WithContext(ctx).First(&collection, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("collection not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return &collection, nil
+}
+
+// GetBySlug retrieves a collection by its slug within a tenant
+func (r *collectionRepository) GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Collection, error) {
+	var collection domain.Collection
+	if err := r.db.WithContext(ctx).First(&collection, "tenant_id = ? AND slug = ?", tenantID, slug).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("collection not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return &collection, nil
+}
+
+// Update updates an existing collection
+func (r *collectionRepository) Update(ctx context.Context, collection *domain.Collection) error {
+	if err := r.db.WithContext(ctx).Save(collection).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update collection: %w", domain.ErrCollectionAlreadyExists)
+		}
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a collection and all of its items
+func (r *collectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.CollectionItem{}, "collection_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Collection{}, "id = ?", id).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	return nil
+}
+
+// List retrieves all collections of a tenant, ordered by name
+func (r *collectionRepository) List(ctx context.Context, tenantID string) ([]*domain.Collection, error) {
+	var collections []*domain.Collection
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("name ASC").Find(&collections).Error; err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	return collections, nil
+}
+
+// CreateItem creates a new collection item
+func (r *collectionRepository) CreateItem(ctx context.Context, item *domain.CollectionItem) error {
+	if err := r.db.WithContext(ctx).Create(item).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create collection item: %w", domain.ErrCollectionItemAlreadyExists)
+		}
+		return fmt.Errorf("failed to create collection item: %w", err)
+	}
+	return nil
+}
+
+// GetItem retrieves a collection item by its UUID
+func (r *collectionRepository) GetItem(ctx context.Context, id uuid.UUID) (*domain.CollectionItem, error) {
+	var item domain.CollectionItem
+	if err := r.db.WithContext(ctx).First(&item, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("collection item not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get collection item: %w", err)
+	}
+	return &item, nil
+}
+
+// GetItemBySlug retrieves a collection item by its slug within a collection
+func (r *collectionRepository) GetItemBySlug(ctx context.Context, collectionID uuid.UUID, slug string) (*domain.CollectionItem, error) {
+	var item domain.CollectionItem
+	if err := r.db.WithContext(ctx).First(&item, "collection_id = ? AND slug = ?", collectionID, slug).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("collection item not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get collection item: %w", err)
+	}
+	return &item, nil
+}
+
+// UpdateItem updates an existing collection item
+func (r *collectionRepository) UpdateItem(ctx context.Context, item *domain.CollectionItem) error {
+	if err := r.db.WithContext(ctx).Save(item).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update collection item: %w", domain.ErrCollectionItemAlreadyExists)
+		}
+		return fmt.Errorf("failed to update collection item: %w", err)
+	}
+	return nil
+}
+
+// DeleteItem deletes a collection item by ID
+func (r *collectionRepository) DeleteItem(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.CollectionItem{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("failed to delete collection item: %w", err)
+	}
+	return nil
+}
+
+// ListItems retrieves the items of a collection with filtering, sorting and pagination
+func (r *collectionRepository) ListItems(ctx context.Context, opts repository.ListItemsOptions) ([]*domain.CollectionItem, int64, error) {
+	var items []*domain.CollectionItem
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.CollectionItem{}).Where("collection_id = ?", opts.CollectionID)
+	if opts.Status != "" {
+		query = query.Where("status = ?", opts.Status)
+	}
+
+	for _, filter := range opts.Filters {
+		expr := r.fieldExpr(filter.Field)
+		switch filter.Op {
+		case repository.ItemFilterEq:
+			query = query.Where(expr+" = ?", filter.Value)
+		case repository.ItemFilterNe:
+			query = query.Where("("+expr+" <> ? OR "+expr+" IS NULL)", filter.Value)
+		case repository.ItemFilterGt:
+			query = query.Where(expr+" > ?", filter.Value)
+		case repository.ItemFilterGte:
+			query = query.Where(expr+" >= ?", filter.Value)
+		case repository.ItemFilterLt:
+			query = query.Where(expr+" < ?", filter.Value)
+		case repository.ItemFilterLte:
+			query = query.Where(expr+" <= ?", filter.Value)
+		case repository.ItemFilterContains:
+			query = query.Where("LOWER("+expr+") LIKE ?", "%"+strings.ToLower(fmt.Sprint(filter.Value))+"%")
+		default:
+			return nil, 0, fmt.Errorf("unsupported filter operator %q", filter.Op)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count collection items: %w", err)
+	}
+
+	direction := " ASC"
+	if opts.SortDesc {
+		direction = " DESC"
+	}
+	switch {
+	case opts.SortField != nil:
+		query = query.Order(r.fieldExpr(*opts.SortField) + direction).Order("created_at DESC")
+	case itemSortColumns[opts.SortColumn]:
+		query = query.Order(opts.SortColumn + direction)
+	default:
+		query = query.Order("created_at DESC")
+	}
+
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		query = query.Offset(opts.Offset)
+	}
+
+	if err := query.Find(&items).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list collection items: %w", err)
+	}
+
+	return items, total, nil
+}
+
+// fieldExpr returns the SQL expression extracting a field value from item data
+// Field names are restricted by the schema validation, so they are safe to inline
+func (r *collectionRepository) fieldExpr(field domain.CollectionField) string {
+	if r.db.Dialector.Name() == "postgres" {
+		expr := fmt.Sprintf("(data->>'%s')", field.Name)
+		// Items saved before a field changed type may hold other values; those are
+		// treated as missing instead of failing the cast for the whole query
+		switch field.Type {
+		case domain.FieldTypeNumber:
+			return fmt.Sprintf("(CASE WHEN jsonb_typeof(data->'%s') = 'number' THEN %s::numeric END)", field.Name, expr)
+		case domain.FieldTypeBoolean:
+			return fmt.Sprintf("(CASE WHEN jsonb_typeof(data->'%s') = 'boolean' THEN %s::boolean END)", field.Name, expr)
+		}
+		return expr
+	}
+	// SQLite's json_extract returns numbers and booleans (as 0/1) in their native types
+	return fmt.Sprintf("json_extract(data, '$.%s')", field.Name)
+}
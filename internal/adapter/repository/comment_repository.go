@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// commentRepository implements the CommentRepository interface using GORM
+type commentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository creates a new comment repository instance
+func NewCommentRepository(db *gorm.DB) repository.CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// Create saves a submitted comment
+func (r *commentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	if err := r.db.WithContext(ctx).Create(comment).Error; err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a tenant's comment
+func (r *commentRepository) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Comment, error) {
+	var comment domain.Comment
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND id = ?", tenantID, id).First(&comment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return &comment, nil
+}
+
+// List retrieves comments for moderation, newest first
+func (r *commentRepository) List(ctx context.Context, opts repository.ListCommentOptions) ([]*domain.Comment, int64, error) {
+	comments := []*domain.Comment{}
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Comment{}).Where("tenant_id = ?", opts.TenantID)
+	if opts.Status != "" {
+		query = query.Where("status = ?", opts.Status)
+	}
+	if opts.PostID != nil {
+		query = query.Where("post_id = ?", *opts.PostID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		query = query.Offset(opts.Offset)
+	}
+
+	if err := query.Order("created_at DESC").Find(&comments).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list comments: %w", err)
+	}
+	return comments, total, nil
+}
+
+// ListApprovedThreads retrieves a page of a post's approved top-level comments with the post's approved replies
+// Threads are short enough in practice that loading every reply is cheaper than walking them level by level
+func (r *commentRepository) ListApprovedThreads(ctx context.Context, tenantID string, postID uuid.UUID, limit, offset int) ([]*domain.Comment, []*domain.Comment, int64, error) {
+	approved := r.db.WithContext(ctx).Model(&domain.Comment{}).
+		Where("tenant_id = ? AND post_id = ? AND status = ?", tenantID, postID, domain.CommentStatusApproved).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := approved.Where("parent_id IS NULL").Count(&total).Error; err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	roots := []*domain.Comment{}
+	query := approved.Where("parent_id IS NULL").Order("created_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if err := query.Find(&roots).Error; err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	var replies []*domain.Comment
+	if len(roots) > 0 {
+		if err := approved.Where("parent_id IS NOT NULL").
+			Order("created_at ASC").Find(&replies).Error; err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to list comment replies: %w", err)
+		}
+	}
+	return roots, replies, total, nil
+}
+
+// Update saves a moderated comment
+func (r *commentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	if err := r.db.WithContext(ctx).Save(comment).Error; err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// editLockRepository implements the EditLockRepository interface using GORM
+type editLockRepository struct {
+	db *gorm.DB
+}
+
+// NewEditLockRepository creates a new edit lock repository instance
+func NewEditLockRepository(db *gorm.DB) repository.EditLockRepository {
+	return &editLockRepository{db: db}
+}
+
+// Get retrieves the live lock on a page or post
+func (r *editLockRepository) Get(ctx context.Context, tenantID string, entityType domain.EditLockEntityType, entityID uuid.UUID) (*domain.EditLock, error) {
+	var lock domain.EditLock
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND entity_type = ? AND entity_id = ? AND expires_at > ?", tenantID, entityType, entityID, time.Now()).
+		First(&lock).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("failed to get edit lock: %w", domain.ErrEditLockNotFound)
+		}
+		return nil, fmt.Errorf("failed to get edit lock: %w", err)
+	}
+	return &lock, nil
+}
+
+// ListActive retrieves a tenant's live locks, optionally of one kind of content
+func (r *editLockRepository) ListActive(ctx context.Context, tenantID string, entityType domain.EditLockEntityType) ([]*domain.EditLock, error) {
+	locks := []*domain.EditLock{}
+	query := r.db.WithContext(ctx).Where("tenant_id = ? AND expires_at > ?", tenantID, time.Now())
+	if entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+	if err := query.Order("acquired_at ASC").Find(&locks).Error; err != nil {
+		return nil, fmt.Errorf("failed to list edit locks: %w", err)
+	}
+	return locks, nil
+}
+
+// Acquire takes or renews a lock
+// A lock row is kept per page or post; expired rows are reused by the next editor
+func (r *editLockRepository) Acquire(ctx context.Context, lock *domain.EditLock, takeOver bool) (*domain.EditLock, bool, error) {
+	var previous *domain.EditLock
+	acquired := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var existing []domain.EditLock
+		if err := tx.Where("tenant_id = ? AND entity_type = ? AND entity_id = ?", lock.TenantID, lock.EntityType, lock.EntityID).
+			Limit(1).Find(&existing).Error; err != nil {
+			return err
+		}
+
+		lock.ExpiresAt = now.Add(domain.EditLockTTL)
+		if len(existing) == 0 {
+			lock.AcquiredAt = now
+			acquired = true
+			return tx.Create(lock).Error
+		}
+
+		current := existing[0]
+		lock.ID = current.ID
+		lock.AcquiredAt = current.AcquiredAt
+		if current.UserID != lock.UserID || current.IsExpired(now) {
+			if current.UserID != lock.UserID && !current.IsExpired(now) {
+				previous = &current
+				if !takeOver {
+					return domain.ErrEditLocked
+				}
+			}
+			lock.AcquiredAt = now
+			acquired = true
+		}
+		return tx.Model(&domain.EditLock{}).Where("id = ?", current.ID).Updates(map[string]interface{}{
+			"user_id":     lock.UserID,
+			"user_name":   lock.UserName,
+			"acquired_at": lock.AcquiredAt,
+			"expires_at":  lock.ExpiresAt,
+		}).Error
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			// Another editor created the lock first
+			if holder, getErr := r.Get(ctx, lock.TenantID, lock.EntityType, lock.EntityID); getErr == nil {
+				return holder, false, fmt.Errorf("failed to acquire edit lock: %w", domain.ErrEditLocked)
+			}
+		}
+		return previous, false, fmt.Errorf("failed to acquire edit lock: %w", err)
+	}
+	return previous, acquired, nil
+}
+
+// Release removes a lock held by userID, or by anyone if userID is empty
+func (r *editLockRepository) Release(ctx context.Context, tenantID string, entityType domain.EditLockEntityType, entityID uuid.UUID, userID string) error {
+	lock, err := r.Get(ctx, tenantID, entityType, entityID)
+	if err != nil {
+		return err
+	}
+	if userID != "" && lock.UserID != userID {
+		return fmt.Errorf("failed to release edit lock: %w", domain.ErrEditLocked)
+	}
+	if err := r.db.WithContext(ctx).Delete(&domain.EditLock{}, "id = ?", lock.ID).Error; err != nil {
+		return fmt.Errorf("failed to release edit lock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// isUniqueViolation reports whether err was caused by a unique constraint
+// Covers GORM's translated error as well as raw SQLite and PostgreSQL messages
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") ||
+		strings.Contains(msg, "duplicate key value violates unique constraint") ||
+		strings.Contains(msg, "SQLSTATE 23505")
+}
+
+// saveVersion saves every field of a versioned row, as long as nobody saved it since it was loaded
+// The row's version is bumped on success and left alone on failure; a stale row gives ErrVersionConflict
+func saveVersion(tx *gorm.DB, model interface{}, version *int) error {
+	loaded := *version
+	*version = loaded + 1
+	result := tx.Model(model).Where("version = ?", loaded).Select("*").Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = domain.ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = loaded
+	}
+	return result.Error
+}
+
+// storedStatus reads the status column of a stored page or post
+// Used to tell whether a save publishes the content
+func storedStatus(tx *gorm.DB, model interface{}, id uuid.UUID) (string, error) {
+	var statuses []string
+	if err := tx.Model(model).Where("id = ?", id).Limit(1).Pluck("status", &statuses).Error; err != nil {
+		return "", err
+	}
+	if len(statuses) == 0 {
+		return "", nil
+	}
+	return statuses[0], nil
+}
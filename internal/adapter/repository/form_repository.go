@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// formRepository implements the FormRepository interface using GORM
+type formRepository struct {
+	db *gorm.DB
+}
+
+// NewFormRepository creates a new form repository instance
+func NewFormRepository(db *gorm.DB) repository.FormRepository {
+	return &formRepository{db: db}
+}
+
+// CreateSubmission stores a submission and records its form.submitted event in one transaction
+func (r *formRepository) CreateSubmission(ctx context.Context, submission *domain.FormSubmission, event domain.FormSubmitted) error {
+	if submission.ID == uuid.Nil {
+		submission.ID = uuid.New()
+	}
+	event.SubmissionID = submission.ID
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(submission).Error; err != nil {
+			return err
+		}
+		return recordEvents(tx, submission.TenantID, eventOrigin{}, event)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create form submission: %w", err)
+	}
+	return nil
+}
+
+// ListSubmissions retrieves the submissions of a form block, newest first
+func (r *formRepository) ListSubmissions(ctx context.Context, tenantID string, pageID uuid.UUID, blockID string, limit, offset int) ([]*domain.FormSubmission, int64, error) {
+	submissions := []*domain.FormSubmission{}
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.FormSubmission{}).
+		Where("tenant_id = ? AND page_id = ? AND block_id = ?", tenantID, pageID, blockID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count form submissions: %w", err)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if err := query.Order("created_at DESC").Find(&submissions).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list form submissions: %w", err)
+	}
+	return submissions, total, nil
+}
+
+// DeleteSubmission deletes one submission of a form block
+func (r *formRepository) DeleteSubmission(ctx context.Context, tenantID string, pageID uuid.UUID, blockID string, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND page_id = ? AND block_id = ? AND id = ?", tenantID, pageID, blockID, id).
+		Delete(&domain.FormSubmission{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete form submission: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrFormSubmissionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// globalBlockRepository implements the GlobalBlockRepository interface using GORM
+type globalBlockRepository struct {
+	db *gorm.DB
+}
+
+// NewGlobalBlockRepository creates a new global block repository instance
+func NewGlobalBlockRepository(db *gorm.DB) repository.GlobalBlockRepository {
+	return &globalBlockRepository{db: db}
+}
+
+// Create creates a new global block
+func (r *globalBlockRepository) Create(ctx context.Context, block *domain.GlobalBlock) error {
+	if err := r.db.WithContext(ctx).Create(block).Error; err != nil {
+		return fmt.Errorf("failed to create global block: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a global block by its UUID
+func (r *globalBlockRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.GlobalBlock, error) {
+	var block domain.GlobalBlock
+	err := r.db.WithContext(ctx).First(&block, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("global block not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get global block: %w", err)
+	}
+	return &block, nil
+}
+
+// ListByIDs retrieves a tenant's global blocks with the given IDs, in no particular order
+func (r *globalBlockRepository) ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.GlobalBlock, error) {
+	var blocks []*domain.GlobalBlock
+	if len(ids) == 0 {
+		return blocks, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Find(&blocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to list global blocks: %w", err)
+	}
+	return blocks, nil
+}
+
+// Update updates an existing global block
+func (r *globalBlockRepository) Update(ctx context.Context, block *domain.GlobalBlock) error {
+	if err := r.db.WithContext(ctx).Save(block).Error; err != nil {
+		return fmt.Errorf("failed to update global block: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a global block by ID
+func (r *globalBlockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.GlobalBlock{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("failed to delete global block: %w", err)
+	}
+	return nil
+}
+
+// List retrieves a tenant's global blocks ordered by name
+func (r *globalBlockRepository) List(ctx context.Context, tenantID string) ([]*domain.GlobalBlock, error) {
+	var blocks []*domain.GlobalBlock
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("name ASC").Find(&blocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to list global blocks: %w", err)
+	}
+	return blocks, nil
+}
+
+// ListUsage retrieves the pages whose blocks reference a global block
+// Candidates are found by searching the blocks text for the ID and confirmed by parsing them
+func (r *globalBlockRepository) ListUsage(ctx context.Context, tenantID string, id uuid.UUID) ([]*domain.Page, error) {
+	var candidates []*domain.Page
+	if err := r.usageCandidates(ctx, tenantID, id).Find(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to list global block usage: %w", err)
+	}
+
+	pages := []*domain.Page{}
+	for _, page := range candidates {
+		for _, ref := range domain.BlockRefIDs(page.Blocks) {
+			if ref == id {
+				pages = append(pages, page)
+				break
+			}
+		}
+	}
+	return pages, nil
+}
+
+// ListPostUsage retrieves the posts whose blocks reference a global block
+func (r *globalBlockRepository) ListPostUsage(ctx context.Context, tenantID string, id uuid.UUID) ([]*domain.Post, error) {
+	var candidates []*domain.Post
+	if err := r.usageCandidates(ctx, tenantID, id).Find(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to list global block usage: %w", err)
+	}
+
+	posts := []*domain.Post{}
+	for _, post := range candidates {
+		for _, ref := range domain.BlockRefIDs(post.Blocks) {
+			if ref == id {
+				posts = append(posts, post)
+				break
+			}
+		}
+	}
+	return posts, nil
+}
+
+// usageCandidates selects rows whose blocks mention a global block ID
+// Matches are confirmed by decoding the blocks
+func (r *globalBlockRepository) usageCandidates(ctx context.Context, tenantID string, id uuid.UUID) *gorm.DB {
+	column := "blocks"
+	if r.db.Dialector.Name() == "postgres" {
+		column = "blocks::text"
+	}
+	return r.db.WithContext(ctx).
+		Where("tenant_id = ? AND "+column+" LIKE ?", tenantID, "%"+id.String()+"%").
+		Order("slug ASC, locale ASC")
+}
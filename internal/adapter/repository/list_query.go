@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// listCursor is the position after the last row of a page
+// It is handed out base64-encoded and opaque; the sort key ties it to the order it was issued for
+type listCursor struct {
+	Sort   string    `json:"s"`
+	Values []*string `json:"v"` // Sort field values of the last row, nil where the field was empty
+	ID     string    `json:"id"`
+}
+
+// listPage loads a page of a list query into dest, a pointer to a slice of model pointers,
+// preloading the named associations. query must already be scoped to the tenant. Rows are ordered by
+// the query's sort fields with empty values last, then by ID so that pages never overlap
+func listPage(query *gorm.DB, spec repository.ListSpec, q repository.ListQuery, dest interface{}, preload ...string) (repository.ListPage, error) {
+	var page repository.ListPage
+
+	query = applyListFilters(query, spec, q.Filters)
+	if err := query.Session(&gorm.Session{}).Count(&page.Total).Error; err != nil {
+		return page, fmt.Errorf("failed to count: %w", err)
+	}
+
+	if q.Cursor != "" {
+		condition, args, err := cursorCondition(spec, q)
+		if err != nil {
+			return page, err
+		}
+		query = query.Where(condition, args...)
+	} else if q.Offset > 0 {
+		query = query.Offset(q.Offset)
+	}
+
+	for _, s := range q.Sort {
+		field := spec.Fields[s.Field]
+		if field.Nullable {
+			query = query.Order("(" + field.Column + " IS NULL)")
+		}
+		if s.Desc {
+			query = query.Order(field.Column + " DESC")
+		} else {
+			query = query.Order(field.Column + " ASC")
+		}
+	}
+
+	for _, association := range preload {
+		query = query.Preload(association)
+	}
+	result := query.Order("id ASC").Limit(q.Limit + 1).Find(dest)
+	if result.Error != nil {
+		return page, fmt.Errorf("failed to list: %w", result.Error)
+	}
+
+	// The extra row only tells whether there is a next page
+	rows := reflect.ValueOf(dest).Elem()
+	if rows.Len() <= q.Limit {
+		return page, nil
+	}
+	rows.Set(rows.Slice(0, q.Limit))
+
+	last := rows.Index(q.Limit - 1)
+	cursor := listCursor{Sort: q.SortKey()}
+	for _, s := range q.Sort {
+		value, err := columnValue(result, last, spec.Fields[s.Field].Column)
+		if err != nil {
+			return page, err
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+	id, err := columnValue(result, last, "id")
+	if err != nil || id == nil {
+		return page, fmt.Errorf("failed to read row ID: %v", err)
+	}
+	cursor.ID = *id
+
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return page, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	page.NextCursor = base64.RawURLEncoding.EncodeToString(encoded)
+	return page, nil
+}
+
+// listIDs loads the IDs of up to limit rows matching the filters, oldest first, and counts all matching rows
+func listIDs(query *gorm.DB, spec repository.ListSpec, filters []repository.Filter, limit int) ([]uuid.UUID, int64, error) {
+	var total int64
+	query = applyListFilters(query, spec, filters)
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count: %w", err)
+	}
+
+	var ids []uuid.UUID
+	if err := query.Order("created_at ASC").Order("id ASC").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list: %w", err)
+	}
+	return ids, total, nil
+}
+
+// applyListFilters restricts a query to the rows matching every filter
+func applyListFilters(query *gorm.DB, spec repository.ListSpec, filters []repository.Filter) *gorm.DB {
+	for _, filter := range filters {
+		column := spec.Fields[filter.Field].Column
+		switch {
+		case filter.Null:
+			query = query.Where(column + " IS NULL")
+		case filter.Op == repository.FilterEq && len(filter.Values) == 1:
+			query = query.Where(column+" = ?", filter.Values[0])
+		case filter.Op == repository.FilterEq:
+			query = query.Where(column+" IN ?", filter.Values)
+		default:
+			query = query.Where(column+" "+filterOperators[filter.Op]+" ?", filter.Values[0])
+		}
+	}
+	return query
+}
+
+var filterOperators = map[repository.FilterOp]string{
+	repository.FilterGt:  ">",
+	repository.FilterGte: ">=",
+	repository.FilterLt:  "<",
+	repository.FilterLte: "<=",
+}
+
+// cursorCondition decodes the query's cursor into a condition matching the rows after it:
+// those past the cursor in the first sort field, or equal in it and past it in the next, and so on
+func cursorCondition(spec repository.ListSpec, q repository.ListQuery) (string, []interface{}, error) {
+	invalid := fmt.Errorf("%w: invalid cursor", domain.ErrInvalidListQuery)
+
+	raw, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return "", nil, invalid
+	}
+	var cursor listCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || len(cursor.Values) != len(q.Sort) {
+		return "", nil, invalid
+	}
+	if cursor.Sort != q.SortKey() {
+		return "", nil, fmt.Errorf("%w: the cursor was issued for a different sort order", domain.ErrInvalidListQuery)
+	}
+	if _, err := uuid.Parse(cursor.ID); err != nil {
+		return "", nil, invalid
+	}
+
+	var alternatives []string
+	var args []interface{}
+	var equal []string // Conditions keeping every earlier sort field at the cursor's value
+	var equalArgs []interface{}
+	for i, s := range q.Sort {
+		field := spec.Fields[s.Field]
+		column := field.Column
+
+		if cursor.Values[i] == nil {
+			// Empty values sort last, so only further empty rows can follow
+			if !field.Nullable {
+				return "", nil, invalid
+			}
+			equal = append(equal, column+" IS NULL")
+			continue
+		}
+		value, err := repository.ParseListValue(repository.ListField{Type: field.Type}, *cursor.Values[i])
+		if err != nil {
+			return "", nil, invalid
+		}
+
+		op := ">"
+		if s.Desc {
+			op = "<"
+		}
+		past := column + " " + op + " ?"
+		if field.Nullable {
+			past = "(" + past + " OR " + column + " IS NULL)"
+		}
+		alternatives = append(alternatives, strings.Join(append(equal, past), " AND "))
+		args = append(append(args, equalArgs...), value)
+
+		equal = append(equal, column+" = ?")
+		equalArgs = append(equalArgs, value)
+	}
+	alternatives = append(alternatives, strings.Join(append(equal, "id > ?"), " AND "))
+	args = append(append(args, equalArgs...), cursor.ID)
+
+	return "(" + strings.Join(alternatives, ") OR (") + ")", args, nil
+}
+
+// columnValue reads a column of a loaded row as it is stored in a cursor
+func columnValue(result *gorm.DB, row reflect.Value, column string) (*string, error) {
+	field := result.Statement.Schema.LookUpField(column)
+	if field == nil {
+		return nil, fmt.Errorf("unknown column %q", column)
+	}
+	value, zero := field.ValueOf(result.Statement.Context, row)
+
+	switch v := value.(type) {
+	case *time.Time:
+		if v == nil {
+			return nil, nil
+		}
+		value = *v
+	case *uuid.UUID:
+		if v == nil {
+			return nil, nil
+		}
+		value = *v
+	}
+	if t, ok := value.(time.Time); ok {
+		s := t.Format(time.RFC3339Nano)
+		return &s, nil
+	}
+	if zero && field.FieldType.Kind() == reflect.Ptr {
+		return nil, nil
+	}
+	s := fmt.Sprint(value)
+	return &s, nil
+}
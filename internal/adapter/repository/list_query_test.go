@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPostRepository_List_FiltersSortsAndPagesWithCursors(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:list_query?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.User{}, &domain.Category{}, &domain.Post{}, &domain.OutboxEvent{}))
+
+	repo := NewPostRepository(db)
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+	day := func(d int) *time.Time {
+		t := time.Date(2024, 1, d, 12, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	create := func(slug string, author uuid.UUID, status domain.PostStatus, publishedAt *time.Time) {
+		post := &domain.Post{Title: slug, Slug: slug, Locale: "en", Status: status, AuthorID: author, PublishedAt: publishedAt}
+		require.NoError(t, db.Omit("Author").Create(post).Error)
+	}
+	create("a", alice, domain.PostStatusPublished, day(3))
+	create("b", alice, domain.PostStatusPublished, day(1))
+	create("c", bob, domain.PostStatusPublished, day(3))
+	create("d", bob, domain.PostStatusDraft, nil)
+	create("e", alice, domain.PostStatusInReview, nil)
+	create("f", bob, domain.PostStatusArchived, day(2))
+	create("other-tenant", alice, domain.PostStatusPublished, day(1))
+	require.NoError(t, db.Model(&domain.Post{}).Where("slug = ?", "other-tenant").Update("tenant_id", "acme").Error)
+
+	list := func(params map[string]string) ([]string, repository.ListPage) {
+		q, err := repository.ParseListQuery(params, repository.PostListSpec)
+		require.NoError(t, err)
+		posts, page, err := repo.List(ctx, "", q)
+		require.NoError(t, err)
+		var slugs []string
+		for _, post := range posts {
+			slugs = append(slugs, post.Slug)
+		}
+		return slugs, page
+	}
+
+	slugs, page := list(map[string]string{"filter[status]": "draft,in_review", "sort": "title"})
+	assert.Equal(t, []string{"d", "e"}, slugs)
+	assert.Equal(t, int64(2), page.Total)
+
+	slugs, _ = list(map[string]string{"filter[author_id]": bob.String(), "filter[published_at][gte]": "2024-01-02", "sort": "title"})
+	assert.Equal(t, []string{"c", "f"}, slugs)
+
+	slugs, _ = list(map[string]string{"filter[published_at]": "null", "sort": "title"})
+	assert.Equal(t, []string{"d", "e"}, slugs)
+
+	// Walk the whole list two posts at a time; unpublished posts sort last and ties keep a stable order
+	params := map[string]string{"sort": "-published_at,title", "limit": "2"}
+	var all []string
+	for {
+		slugs, page := list(params)
+		assert.Equal(t, int64(6), page.Total)
+		all = append(all, slugs...)
+		if page.NextCursor == "" {
+			break
+		}
+		params["cursor"] = page.NextCursor
+	}
+	assert.Equal(t, []string{"a", "c", "f", "b", "d", "e"}, all)
+
+	// A cursor only continues the order it was issued for
+	_, page = list(map[string]string{"sort": "title", "limit": "2"})
+	require.NotEmpty(t, page.NextCursor)
+	q, err := repository.ParseListQuery(map[string]string{"sort": "-title", "cursor": page.NextCursor}, repository.PostListSpec)
+	require.NoError(t, err)
+	_, _, err = repo.List(ctx, "", q)
+	assert.True(t, errors.Is(err, domain.ErrInvalidListQuery))
+}
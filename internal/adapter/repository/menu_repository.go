@@ -13,7 +13,8 @@ import (
 
 // menuRepository implements the MenuRepository interface using GORM
 type menuRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	origin eventOrigin // Recorded with the domain events of changes
 }
 
 // NewMenuRepository creates a new menu repository instance
@@ -21,9 +22,25 @@ func NewMenuRepository(db *gorm.DB) repository.MenuRepository {
 	return &menuRepository{db: db}
 }
 
+// As returns a menu repository recording the domain events of its changes as made by actorID
+func (r *menuRepository) As(actorID string) repository.MenuRepository {
+	origin := r.origin
+	origin.actorID = actorID
+	return &menuRepository{db: r.db, origin: origin}
+}
+
 // Create creates a new menu
 func (r *menuRepository) Create(ctx context.Context, menu *domain.Menu) error {
-	if err := r.db.WithContext(ctx).Create(menu).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(menu).Error; err != nil {
+			return err
+		}
+		return recordEvents(tx, menu.TenantID, r.origin, domain.MenuCreated{MenuEvent: domain.NewMenuEvent(menu)})
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create menu: %w", domain.ErrTranslationExists)
+		}
 		return fmt.Errorf("failed to create menu: %w", err)
 	}
 	return nil
@@ -42,40 +59,79 @@ func (r *menuRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Men
 	return &menu, nil
 }
 
+// ListByIDs retrieves a tenant's menus with the given IDs, in no particular order
+func (r *menuRepository) ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.Menu, error) {
+	var menus []*domain.Menu
+	if len(ids) == 0 {
+		return menus, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Find(&menus).Error; err != nil {
+		return nil, fmt.Errorf("failed to list menus: %w", err)
+	}
+	return menus, nil
+}
+
+// GetTranslation retrieves the translation of a source menu in a locale
+func (r *menuRepository) GetTranslation(ctx context.Context, sourceID uuid.UUID, locale string) (*domain.Menu, error) {
+	var menu domain.Menu
+	err := r.db.WithContext(ctx).First(&menu, "translation_of_id = ? AND locale = ?", sourceID, locale).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("menu not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get menu translation: %w", err)
+	}
+	return &menu, nil
+}
+
 // Update updates an existing menu
 func (r *menuRepository) Update(ctx context.Context, menu *domain.Menu) error {
-	if err := r.db.WithContext(ctx).Save(menu).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(menu).Error; err != nil {
+			return err
+		}
+		return recordEvents(tx, menu.TenantID, r.origin, domain.MenuUpdated{MenuEvent: domain.NewMenuEvent(menu)})
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update menu: %w", err)
 	}
 	return nil
 }
 
-// Delete deletes a menu by ID
+// Delete moves a menu to the trash
+// Translations of the menu stay linked to it until it is purged, so restoring it keeps them together
 func (r *menuRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.Menu{}, "id = ?", id).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var menus []*domain.Menu
+		if err := tx.Where("id = ?", id).Limit(1).Find(&menus).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.Menu{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		for _, menu := range menus {
+			if err := recordEvents(tx, menu.TenantID, r.origin, domain.MenuDeleted{MenuEvent: domain.NewMenuEvent(menu)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete menu: %w", err)
 	}
 	return nil
 }
 
-// List retrieves all menus with pagination
-func (r *menuRepository) List(ctx context.Context, limit, offset int) ([]*domain.Menu, int64, error) {
+// List retrieves a page of a tenant's menus matching a list query
+func (r *menuRepository) List(ctx context.Context, tenantID string, q repository.ListQuery) ([]*domain.Menu, repository.ListPage, error) {
 	var menus []*domain.Menu
-	var total int64
-
-	// Get total count
-	if err := r.db.WithContext(ctx).Model(&domain.Menu{}).Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count menus: %w", err)
-	}
+	query := r.db.WithContext(ctx).Model(&domain.Menu{}).Where("tenant_id = ?", tenantID)
 
-	// Get paginated results
-	if err := r.db.WithContext(ctx).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&menus).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to list menus: %w", err)
+	page, err := listPage(query, repository.MenuListSpec, q, &menus)
+	if err != nil {
+		return nil, page, fmt.Errorf("failed to list menus: %w", err)
 	}
-
-	return menus, total, nil
+	return menus, page, nil
 }
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// notificationRepository implements the NotificationRepository interface using GORM
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository instance
+func NewNotificationRepository(db *gorm.DB) repository.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Create stores a new notification
+func (r *notificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	if err := r.db.WithContext(ctx).Create(notification).Error; err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// ListByUser retrieves a user's notifications, newest first
+func (r *notificationRepository) ListByUser(ctx context.Context, tenantID, userID string, unreadOnly bool, limit, offset int) ([]*domain.Notification, int64, error) {
+	var notifications []*domain.Notification
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("tenant_id = ? AND user_id = ?", tenantID, userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if err := query.Order("created_at DESC").Find(&notifications).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, total, nil
+}
+
+// CountUnread counts a user's unread notifications
+func (r *notificationRepository) CountUnread(ctx context.Context, tenantID, userID string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("tenant_id = ? AND user_id = ? AND read_at IS NULL", tenantID, userID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks one of a user's notifications as read
+// Notifications that are already read keep their original read time
+func (r *notificationRepository) MarkRead(ctx context.Context, tenantID, userID string, id uuid.UUID) error {
+	var notification domain.Notification
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ? AND user_id = ?", id, tenantID, userID).
+		First(&notification).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to mark notification read: %w", domain.ErrNotificationNotFound)
+		}
+		return fmt.Errorf("failed to get notification: %w", err)
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Model(&notification).Update("read_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllRead marks all of a user's notifications as read and returns how many changed
+func (r *notificationRepository) MarkAllRead(ctx context.Context, tenantID, userID string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&domain.Notification{}).
+		Where("tenant_id = ? AND user_id = ? AND read_at IS NULL", tenantID, userID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"gorm.io/gorm"
+)
+
+// eventOrigin is what repositories record with the domain events of their changes
+type eventOrigin struct {
+	actorID string // User whose request made the changes
+	batchID string // Bulk action the changes are part of
+}
+
+// recordEvents appends domain events to the outbox in the caller's transaction
+func recordEvents(tx *gorm.DB, tenantID string, origin eventOrigin, events ...domain.DomainEvent) error {
+	for _, event := range events {
+		entry, err := domain.NewOutboxEvent(tenantID, origin.actorID, event)
+		if err != nil {
+			return err
+		}
+		entry.BatchID = origin.batchID
+		if err := tx.Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to record %s event: %w", event.EventType(), err)
+		}
+	}
+	return nil
+}
+
+// outboxRepository implements the OutboxRepository interface using GORM
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db *gorm.DB) repository.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// Record appends domain events made by actorID to the outbox
+func (r *outboxRepository) Record(ctx context.Context, tenantID, actorID string, events ...domain.DomainEvent) error {
+	return recordEvents(r.db.WithContext(ctx), tenantID, eventOrigin{actorID: actorID}, events...)
+}
+
+// ClaimDue takes up to limit undispatched events that are due, oldest first
+// Claims are conditional on the attempt time read, as with webhook deliveries,
+// so an event is only handed to one dispatcher at a time
+func (r *outboxRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.OutboxEvent, error) {
+	var due []*domain.OutboxEvent
+	if err := r.db.WithContext(ctx).
+		Where("dispatched_at IS NULL AND next_attempt_at <= ?", now).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&due).Error; err != nil {
+		return nil, fmt.Errorf("failed to list due outbox events: %w", err)
+	}
+
+	leaseEnd := now.Add(lease)
+	claimed := make([]*domain.OutboxEvent, 0, len(due))
+	for _, event := range due {
+		result := r.db.WithContext(ctx).Model(&domain.OutboxEvent{}).
+			Where("id = ? AND dispatched_at IS NULL AND next_attempt_at = ?", event.ID, event.NextAttemptAt).
+			Update("next_attempt_at", leaseEnd)
+		if result.Error != nil {
+			return claimed, fmt.Errorf("failed to claim outbox event: %w", result.Error)
+		}
+		if result.RowsAffected == 1 {
+			event.NextAttemptAt = &leaseEnd
+			claimed = append(claimed, event)
+		}
+	}
+	return claimed, nil
+}
+
+// Save saves the outcome of a dispatch
+func (r *outboxRepository) Save(ctx context.Context, event *domain.OutboxEvent) error {
+	if err := r.db.WithContext(ctx).Save(event).Error; err != nil {
+		return fmt.Errorf("failed to save outbox event: %w", err)
+	}
+	return nil
+}
+
+// PurgeDispatched deletes events dispatched before a cutoff and returns how many were deleted
+func (r *outboxRepository) PurgeDispatched(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("dispatched_at IS NOT NULL AND dispatched_at < ?", before).Delete(&domain.OutboxEvent{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge dispatched outbox events: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gohac/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func outboxTypes(t *testing.T, repo *outboxRepository) []domain.EventType {
+	var events []*domain.OutboxEvent
+	require.NoError(t, repo.db.Order("created_at ASC").Find(&events).Error)
+	types := make([]domain.EventType, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func TestPageRepository_RecordsOutboxEvents(t *testing.T) {
+	db := setupTestDB(t)
+	pages := NewPageRepository(db).As("user-1")
+	outbox := NewOutboxRepository(db).(*outboxRepository)
+	ctx := context.Background()
+
+	page := &domain.Page{TenantID: "acme", Slug: "about", Title: "About", Status: domain.PageStatusDraft}
+	require.NoError(t, pages.Create(ctx, page))
+	require.NoError(t, pages.Publish(ctx, page.ID))
+
+	// A create that fails on the unique slug leaves no event behind
+	err := pages.Create(ctx, &domain.Page{TenantID: "acme", Slug: "about", Title: "Again", Status: domain.PageStatusDraft})
+	require.ErrorIs(t, err, domain.ErrPageAlreadyExists)
+
+	require.NoError(t, pages.Delete(ctx, page.ID))
+
+	assert.Equal(t, []domain.EventType{
+		domain.EventContentCreated,
+		domain.EventContentUpdated,
+		domain.EventContentPublished,
+		domain.EventContentDeleted,
+	}, outboxTypes(t, outbox))
+
+	var published domain.OutboxEvent
+	require.NoError(t, db.Where("type = ?", domain.EventContentPublished).First(&published).Error)
+	assert.Equal(t, "acme", published.TenantID)
+	assert.Equal(t, "page", published.AggregateType)
+	assert.Equal(t, "user-1", published.ActorID)
+	assert.Equal(t, page.ID, published.AggregateID)
+	var payload domain.PagePublished
+	require.NoError(t, published.Decode(&payload))
+	assert.Equal(t, "about", payload.Slug)
+	assert.Equal(t, domain.PageStatusPublished, payload.Status)
+}
+
+func TestOutboxRepository_ClaimDue(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewOutboxRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, NewPageRepository(db).Create(ctx, &domain.Page{Slug: "home", Title: "Home", Status: domain.PageStatusDraft}))
+
+	now := time.Now()
+	claimed, err := repo.ClaimDue(ctx, now, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	// Claimed events stay hidden until the lease runs out
+	again, err := repo.ClaimDue(ctx, now, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	claimed[0].MarkDispatched(now)
+	require.NoError(t, repo.Save(ctx, claimed[0]))
+	after, err := repo.ClaimDue(ctx, now.Add(2*time.Minute), 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, after, "dispatched events are not claimed again")
+
+	purged, err := repo.PurgeDispatched(ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+}
@@ -15,7 +15,8 @@ import (
 
 // pageRepository implements the PageRepository interface using GORM
 type pageRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	origin eventOrigin // Recorded with the domain events of changes
 }
 
 // NewPageRepository creates a new page repository instance
@@ -23,13 +24,36 @@ func NewPageRepository(db *gorm.DB) repository.PageRepository {
 	return &pageRepository{db: db}
 }
 
+// As returns a page repository recording the domain events of its changes as made by actorID
+func (r *pageRepository) As(actorID string) repository.PageRepository {
+	origin := r.origin
+	origin.actorID = actorID
+	return &pageRepository{db: r.db, origin: origin}
+}
+
+// InBatch returns a page repository recording the domain events of its changes as part of a bulk action
+func (r *pageRepository) InBatch(batchID string) repository.PageRepository {
+	origin := r.origin
+	origin.batchID = batchID
+	return &pageRepository{db: r.db, origin: origin}
+}
+
 // Create creates a new page
 func (r *pageRepository) Create(ctx context.Context, page *domain.Page) error {
 	if page.ID == uuid.Nil {
 		page.ID = uuid.New()
 	}
 
-	if err := r.db.WithContext(ctx).Create(page).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(page).Error; err != nil {
+			return err
+		}
+		return recordEvents(tx, page.TenantID, r.origin, pageSaveEvents(page, "")...)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create page: %w", domain.ErrPageAlreadyExists)
+		}
 		return fmt.Errorf("failed to create page: %w", err)
 	}
 	return nil
@@ -47,10 +71,22 @@ func (r *pageRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Pag
 	return &page, nil
 }
 
-// GetBySlug retrieves a page by its slug
-func (r *pageRepository) GetBySlug(ctx context.Context, slug string) (*domain.Page, error) {
+// GetBySlug retrieves a page by its slug within a tenant
+func (r *pageRepository) GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Page, error) {
+	var page domain.Page
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND slug = ?", tenantID, slug).First(&page).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("page not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get page by slug: %w", err)
+	}
+	return &page, nil
+}
+
+// GetBySlugInLocale retrieves a page by its slug within a tenant and locale
+func (r *pageRepository) GetBySlugInLocale(ctx context.Context, tenantID, locale, slug string) (*domain.Page, error) {
 	var page domain.Page
-	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&page).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND locale = ? AND slug = ?", tenantID, locale, slug).First(&page).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("page not found: %w", err)
 		}
@@ -59,17 +95,166 @@ func (r *pageRepository) GetBySlug(ctx context.Context, slug string) (*domain.Pa
 	return &page, nil
 }
 
+// ListTranslations retrieves a source page and all of its translations, ordered by locale
+func (r *pageRepository) ListTranslations(ctx context.Context, sourceID uuid.UUID) ([]*domain.Page, error) {
+	var pages []*domain.Page
+	if err := r.db.WithContext(ctx).
+		Where("id = ? OR translation_of_id = ?", sourceID, sourceID).
+		Order("locale").
+		Find(&pages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list page translations: %w", err)
+	}
+	return pages, nil
+}
+
+// ListByIDs retrieves a tenant's pages with the given IDs, in no particular order
+func (r *pageRepository) ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.Page, error) {
+	var pages []*domain.Page
+	if len(ids) == 0 {
+		return pages, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Find(&pages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list pages: %w", err)
+	}
+	return pages, nil
+}
+
+// ListChildren retrieves the pages directly under a parent (top-level pages if parentID is nil), in sort order
+func (r *pageRepository) ListChildren(ctx context.Context, tenantID, locale string, parentID *uuid.UUID) ([]*domain.Page, error) {
+	var pages []*domain.Page
+	query := r.db.WithContext(ctx).Where("tenant_id = ? AND locale = ?", tenantID, locale)
+	if parentID != nil {
+		query = query.Where("parent_id = ?", *parentID)
+	} else {
+		query = query.Where("parent_id IS NULL")
+	}
+	if err := query.Order("sort_order ASC, title ASC").Find(&pages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list child pages: %w", err)
+	}
+	return pages, nil
+}
+
+// ListHierarchy retrieves all pages of a tenant in sort order, optionally in one locale
+func (r *pageRepository) ListHierarchy(ctx context.Context, tenantID, locale string) ([]*domain.Page, error) {
+	var pages []*domain.Page
+	query := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID)
+	if locale != "" {
+		query = query.Where("locale = ?", locale)
+	}
+	if err := query.Order("sort_order ASC, title ASC").Find(&pages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list pages: %w", err)
+	}
+	return pages, nil
+}
+
 // Update updates an existing page
 func (r *pageRepository) Update(ctx context.Context, page *domain.Page) error {
-	if err := r.db.WithContext(ctx).Save(page).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := storedStatus(tx, &domain.Page{}, page.ID)
+		if err != nil {
+			return err
+		}
+		if err := saveVersion(tx, page, &page.Version); err != nil {
+			return err
+		}
+		return recordEvents(tx, page.TenantID, r.origin, pageSaveEvents(page, domain.PageStatus(before))...)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update page: %w", domain.ErrPageAlreadyExists)
+		}
 		return fmt.Errorf("failed to update page: %w", err)
 	}
 	return nil
 }
 
+// UpdateWithDescendants updates a page and moves its descendants' paths from oldSlug to its new slug
+// Returns the descendants whose paths changed
+func (r *pageRepository) UpdateWithDescendants(ctx context.Context, page *domain.Page, oldSlug string) ([]*domain.Page, error) {
+	var moved []*domain.Page
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := storedStatus(tx, &domain.Page{}, page.ID)
+		if err != nil {
+			return err
+		}
+		if err := saveVersion(tx, page, &page.Version); err != nil {
+			return err
+		}
+		if err := recordEvents(tx, page.TenantID, r.origin, pageSaveEvents(page, domain.PageStatus(before))...); err != nil {
+			return err
+		}
+		if page.Slug == oldSlug {
+			return nil
+		}
+
+		// Walk the tree level by level so descendants follow their parent's new path
+		parents := []*domain.Page{page}
+		for depth := 0; len(parents) > 0 && depth < domain.MaxPageDepth; depth++ {
+			var next []*domain.Page
+			for _, parent := range parents {
+				var children []*domain.Page
+				if err := tx.Where("parent_id = ?", parent.ID).Find(&children).Error; err != nil {
+					return err
+				}
+				for _, child := range children {
+					child.Slug = domain.PagePath(parent.Slug, domain.PageSegment(child.Slug))
+					child.Version++
+					if err := tx.Model(child).Updates(map[string]interface{}{"slug": child.Slug, "version": child.Version}).Error; err != nil {
+						return err
+					}
+					if err := recordEvents(tx, child.TenantID, r.origin, domain.PageUpdated{PageEvent: domain.NewPageEvent(child)}); err != nil {
+						return err
+					}
+				}
+				next = append(next, children...)
+			}
+			moved = append(moved, next...)
+			parents = next
+		}
+		if len(parents) > 0 {
+			// Deeper pages would keep their old paths; moves that nest this deep are refused
+			return domain.ErrInvalidParent
+		}
+		return nil
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("failed to update page: %w", domain.ErrPageAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to update page: %w", err)
+	}
+	return moved, nil
+}
+
 // Delete soft-deletes a page
+// Translations of the page stay linked to it until it is purged, so restoring it keeps them together
 func (r *pageRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.Page{}, id).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var children int64
+		if err := tx.Model(&domain.Page{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return err
+		}
+		if children > 0 {
+			return domain.ErrPageHasChildren
+		}
+
+		var pages []*domain.Page
+		if err := tx.Where("id = ?", id).Limit(1).Find(&pages).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.Page{}, id).Error; err != nil {
+			return err
+		}
+		for _, page := range pages {
+			if err := recordEvents(tx, page.TenantID, r.origin, domain.PageDeleted{PageEvent: domain.NewPageEvent(page)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete page: %w", err)
 	}
 	return nil
@@ -87,6 +272,10 @@ func (r *pageRepository) List(ctx context.Context, opts repository.ListPageOptio
 		query = query.Where("status = ?", opts.Status)
 	}
 
+	if opts.Locale != "" {
+		query = query.Where("locale = ?", opts.Locale)
+	}
+
 	if opts.Search != "" {
 		searchTerm := "%" + strings.ToLower(opts.Search) + "%"
 		query = query.Where("LOWER(title) LIKE ? OR LOWER(slug) LIKE ?", searchTerm, searchTerm)
@@ -123,12 +312,7 @@ func (r *pageRepository) List(ctx context.Context, opts repository.ListPageOptio
 // Publish publishes a page
 func (r *pageRepository) Publish(ctx context.Context, id uuid.UUID) error {
 	now := time.Now()
-	if err := r.db.WithContext(ctx).Model(&domain.Page{}).
-		Where("id = ?", id).
-		Updates(map[string]interface{}{
-			"status":       domain.PageStatusPublished,
-			"published_at": &now,
-		}).Error; err != nil {
+	if err := r.setStatus(ctx, id, domain.PageStatusPublished, &now); err != nil {
 		return fmt.Errorf("failed to publish page: %w", err)
 	}
 	return nil
@@ -136,13 +320,54 @@ func (r *pageRepository) Publish(ctx context.Context, id uuid.UUID) error {
 
 // Unpublish unpublishes a page
 func (r *pageRepository) Unpublish(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Model(&domain.Page{}).
-		Where("id = ?", id).
-		Updates(map[string]interface{}{
-			"status":       domain.PageStatusDraft,
-			"published_at": nil,
-		}).Error; err != nil {
+	if err := r.setStatus(ctx, id, domain.PageStatusDraft, nil); err != nil {
 		return fmt.Errorf("failed to unpublish page: %w", err)
 	}
 	return nil
 }
+
+// setStatus changes a page's status and publication time, recording the change in the outbox
+func (r *pageRepository) setStatus(ctx context.Context, id uuid.UUID, status domain.PageStatus, publishedAt *time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var page domain.Page
+		if err := tx.Where("id = ?", id).First(&page).Error; err != nil {
+			return err
+		}
+		before := page.Status
+		page.Status = status
+		page.PublishedAt = publishedAt
+		page.Version++
+		if err := tx.Model(&page).Updates(map[string]interface{}{
+			"status":       status,
+			"published_at": publishedAt,
+			"version":      page.Version,
+		}).Error; err != nil {
+			return err
+		}
+		return recordEvents(tx, page.TenantID, r.origin, pageSaveEvents(&page, before)...)
+	})
+}
+
+// pageSaveEvents returns the events for saving a page whose stored status was before
+// before is empty for a new page
+func pageSaveEvents(page *domain.Page, before domain.PageStatus) []domain.DomainEvent {
+	state := domain.NewPageEvent(page)
+	events := []domain.DomainEvent{domain.PageUpdated{PageEvent: state}}
+	if before == "" {
+		events[0] = domain.PageCreated{PageEvent: state}
+	}
+	if page.Status == domain.PageStatusPublished && before != domain.PageStatusPublished {
+		events = append(events, domain.PagePublished{PageEvent: state})
+	}
+	return events
+}
+
+// ListIDs retrieves the IDs of up to limit of a tenant's pages matching the filters, oldest first
+func (r *pageRepository) ListIDs(ctx context.Context, tenantID string, filters []repository.Filter, limit int) ([]uuid.UUID, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Page{}).Where("tenant_id = ?", tenantID)
+	ids, total, err := listIDs(query, repository.PageListSpec, filters, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list page IDs: %w", err)
+	}
+	return ids, total, nil
+}
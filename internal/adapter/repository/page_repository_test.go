@@ -22,7 +22,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	require.NoError(t, err)
 
 	// Migrate schema
-	err = db.AutoMigrate(&domain.Page{})
+	err = db.AutoMigrate(&domain.Page{}, &domain.OutboxEvent{})
 	require.NoError(t, err)
 
 	return db
@@ -95,7 +95,7 @@ func TestPageRepository_GetBySlug(t *testing.T) {
 	require.NoError(t, err)
 
 	// Retrieve by slug
-	retrieved, err := repo.GetBySlug(ctx, "test-slug")
+	retrieved, err := repo.GetBySlug(ctx, "", "test-slug")
 	require.NoError(t, err)
 	assert.Equal(t, page.ID, retrieved.ID)
 	assert.Equal(t, page.Slug, retrieved.Slug)
@@ -268,3 +268,46 @@ func TestPageRepository_Unpublish(t *testing.T) {
 	assert.Equal(t, domain.PageStatusDraft, updated.Status)
 	assert.Nil(t, updated.PublishedAt)
 }
+
+func TestPageRepository_Create_DuplicateSlug(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPageRepository(db)
+	ctx := context.Background()
+
+	first := &domain.Page{TenantID: "acme", Slug: "about", Title: "About", Status: domain.PageStatusDraft}
+	require.NoError(t, repo.Create(ctx, first))
+
+	// Same slug in the same tenant is rejected with a typed error
+	duplicate := &domain.Page{TenantID: "acme", Slug: "about", Title: "About Again", Status: domain.PageStatusDraft}
+	err := repo.Create(ctx, duplicate)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrPageAlreadyExists)
+
+	// Same slug in another tenant is allowed
+	other := &domain.Page{TenantID: "globex", Slug: "about", Title: "About Globex", Status: domain.PageStatusDraft}
+	require.NoError(t, repo.Create(ctx, other))
+
+	// Lookups are scoped to the tenant
+	retrieved, err := repo.GetBySlug(ctx, "globex", "about")
+	require.NoError(t, err)
+	assert.Equal(t, other.ID, retrieved.ID)
+
+	_, err = repo.GetBySlug(ctx, "initech", "about")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "page not found")
+}
+
+func TestPageRepository_Update_DuplicateSlug(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPageRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, &domain.Page{Slug: "about", Title: "About", Status: domain.PageStatusDraft}))
+	page := &domain.Page{Slug: "contact", Title: "Contact", Status: domain.PageStatusDraft}
+	require.NoError(t, repo.Create(ctx, page))
+
+	page.Slug = "about"
+	err := repo.Update(ctx, page)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrPageAlreadyExists)
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// pageTemplateRepository implements the PageTemplateRepository interface using GORM
+type pageTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewPageTemplateRepository creates a new page template repository instance
+func NewPageTemplateRepository(db *gorm.DB) repository.PageTemplateRepository {
+	return &pageTemplateRepository{db: db}
+}
+
+// Create creates a new page template
+func (r *pageTemplateRepository) Create(ctx context.Context, template *domain.PageTemplate) error {
+	if err := r.db.WithContext(ctx).Create(template).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create page template: %w", domain.ErrPageTemplateAlreadyExists)
+		}
+		return fmt.Errorf("failed to create page template: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a page template by its UUID
+func (r *pageTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PageTemplate, error) {
+	var template domain.PageTemplate
+	err := r.db.WithContext(ctx).First(&template, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("page template not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get page template: %w", err)
+	}
+	return &template, nil
+}
+
+// Update updates an existing page template
+func (r *pageTemplateRepository) Update(ctx context.Context, template *domain.PageTemplate) error {
+	if err := r.db.WithContext(ctx).Save(template).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update page template: %w", domain.ErrPageTemplateAlreadyExists)
+		}
+		return fmt.Errorf("failed to update page template: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a page template by ID
+func (r *pageTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.PageTemplate{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("failed to delete page template: %w", err)
+	}
+	return nil
+}
+
+// List retrieves a tenant's page templates ordered by name
+func (r *pageTemplateRepository) List(ctx context.Context, tenantID string) ([]*domain.PageTemplate, error) {
+	var templates []*domain.PageTemplate
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to list page templates: %w", err)
+	}
+	return templates, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// planRepository implements the PlanRepository interface using GORM
+type planRepository struct {
+	db *gorm.DB
+}
+
+// NewPlanRepository creates a new plan repository instance
+func NewPlanRepository(db *gorm.DB) repository.PlanRepository {
+	return &planRepository{db: db}
+}
+
+// Create creates a new plan
+func (r *planRepository) Create(ctx context.Context, plan *domain.Plan) error {
+	if err := r.db.WithContext(ctx).Create(plan).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create plan: %w", domain.ErrPlanAlreadyExists)
+		}
+		return fmt.Errorf("failed to create plan: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a plan by its UUID
+func (r *planRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Plan, error) {
+	var plan domain.Plan
+	err := r.db.WithContext(ctx).First(&plan, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("plan not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	return &plan, nil
+}
+
+// Update updates an existing plan
+func (r *planRepository) Update(ctx context.Context, plan *domain.Plan) error {
+	if err := r.db.WithContext(ctx).Save(plan).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update plan: %w", domain.ErrPlanAlreadyExists)
+		}
+		return fmt.Errorf("failed to update plan: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a plan by its UUID
+// Tenants on the plan become unlimited
+func (r *planRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Tenant{}).Where("plan_id = ?", id).Update("plan_id", nil).Error; err != nil {
+			return fmt.Errorf("failed to detach plan from tenants: %w", err)
+		}
+		if err := tx.Delete(&domain.Plan{}, "id = ?", id).Error; err != nil {
+			return fmt.Errorf("failed to delete plan: %w", err)
+		}
+		return nil
+	})
+}
+
+// List retrieves all plans ordered by name
+func (r *planRepository) List(ctx context.Context) ([]*domain.Plan, error) {
+	var plans []*domain.Plan
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&plans).Error; err != nil {
+		return nil, fmt.Errorf("failed to list plans: %w", err)
+	}
+	return plans, nil
+}
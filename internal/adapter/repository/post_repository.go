@@ -13,7 +13,8 @@ import (
 
 // postRepository implements the PostRepository interface using GORM
 type postRepository struct {
-	db *gorm.DB
+	db     *gorm.DB
+	origin eventOrigin // Recorded with the domain events of changes
 }
 
 // NewPostRepository creates a new post repository instance
@@ -21,9 +22,32 @@ func NewPostRepository(db *gorm.DB) repository.PostRepository {
 	return &postRepository{db: db}
 }
 
+// As returns a post repository recording the domain events of its changes as made by actorID
+func (r *postRepository) As(actorID string) repository.PostRepository {
+	origin := r.origin
+	origin.actorID = actorID
+	return &postRepository{db: r.db, origin: origin}
+}
+
+// InBatch returns a post repository recording the domain events of its changes as part of a bulk action
+func (r *postRepository) InBatch(batchID string) repository.PostRepository {
+	origin := r.origin
+	origin.batchID = batchID
+	return &postRepository{db: r.db, origin: origin}
+}
+
 // Create creates a new post
 func (r *postRepository) Create(ctx context.Context, post *domain.Post) error {
-	if err := r.db.WithContext(ctx).Create(post).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		return recordEvents(tx, post.TenantID, r.origin, postSaveEvents(post, "")...)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create post: %w", domain.ErrPostAlreadyExists)
+		}
 		return fmt.Errorf("failed to create post: %w", err)
 	}
 	return nil
@@ -35,6 +59,7 @@ func (r *postRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Pos
 	err := r.db.WithContext(ctx).
 		Preload("Author").
 		Preload("Categories").
+		Preload("Tags").
 		First(&post, "id = ?", id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -45,13 +70,14 @@ func (r *postRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Pos
 	return &post, nil
 }
 
-// GetBySlug retrieves a post by its slug
-func (r *postRepository) GetBySlug(ctx context.Context, slug string) (*domain.Post, error) {
+// GetBySlug retrieves a published post by its slug within a tenant
+func (r *postRepository) GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Post, error) {
 	var post domain.Post
 	err := r.db.WithContext(ctx).
 		Preload("Author").
 		Preload("Categories").
-		Where("slug = ? AND status = ?", slug, domain.PostStatusPublished).
+		Preload("Tags").
+		Where("tenant_id = ? AND slug = ? AND status = ?", tenantID, slug, domain.PostStatusPublished).
 		First(&post).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -62,78 +88,198 @@ func (r *postRepository) GetBySlug(ctx context.Context, slug string) (*domain.Po
 	return &post, nil
 }
 
-// Update updates an existing post
-func (r *postRepository) Update(ctx context.Context, post *domain.Post) error {
-	if err := r.db.WithContext(ctx).Save(post).Error; err != nil {
-		return fmt.Errorf("failed to update post: %w", err)
+// GetBySlugInLocale retrieves a published post by its slug within a tenant and locale
+func (r *postRepository) GetBySlugInLocale(ctx context.Context, tenantID, locale, slug string) (*domain.Post, error) {
+	var post domain.Post
+	err := r.db.WithContext(ctx).
+		Preload("Author").
+		Preload("Categories").
+		Preload("Tags").
+		Where("tenant_id = ? AND locale = ? AND slug = ? AND status = ?", tenantID, locale, slug, domain.PostStatusPublished).
+		First(&post).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("post not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get post by slug: %w", err)
 	}
-	return nil
+	return &post, nil
 }
 
-// Delete deletes a post by its UUID
-func (r *postRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(&domain.Post{}, "id = ?", id).Error; err != nil {
-		return fmt.Errorf("failed to delete post: %w", err)
+// ListTranslations retrieves a source post and all of its translations, ordered by locale
+func (r *postRepository) ListTranslations(ctx context.Context, sourceID uuid.UUID) ([]*domain.Post, error) {
+	var posts []*domain.Post
+	if err := r.db.WithContext(ctx).
+		Where("id = ? OR translation_of_id = ?", sourceID, sourceID).
+		Order("locale").
+		Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list post translations: %w", err)
 	}
-	return nil
+	return posts, nil
 }
 
-// List retrieves a list of posts with pagination
-func (r *postRepository) List(ctx context.Context, limit, offset int, status *domain.PostStatus) ([]*domain.Post, int64, error) {
+// ListByIDs retrieves a tenant's posts with the given IDs, in no particular order
+func (r *postRepository) ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.Post, error) {
 	var posts []*domain.Post
-	var total int64
+	if len(ids) == 0 {
+		return posts, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Preload("Author").
+		Preload("Categories").
+		Preload("Tags").
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Find(&posts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list posts: %w", err)
+	}
+	return posts, nil
+}
+
+// ListPublished retrieves a tenant's published posts in a locale
+// Posts without a published translation in that locale are included in the fallback locale
+func (r *postRepository) ListPublished(ctx context.Context, tenantID, locale, fallbackLocale string, limit, offset int) ([]*domain.Post, int64, error) {
+	return listPublished(r.publishedIn(ctx, tenantID, locale, fallbackLocale), limit, offset)
+}
 
-	query := r.db.WithContext(ctx).Model(&domain.Post{})
+// ListByCategory retrieves a tenant's published posts in any of the given categories
+// Posts in several of the categories are listed once
+func (r *postRepository) ListByCategory(ctx context.Context, tenantID, locale, fallbackLocale string, categoryIDs []uuid.UUID, limit, offset int) ([]*domain.Post, int64, error) {
+	query := r.publishedIn(ctx, tenantID, locale, fallbackLocale).
+		Where("id IN (SELECT post_id FROM post_categories WHERE category_id IN ?)", categoryIDs)
+	return listPublished(query, limit, offset)
+}
 
-	// Filter by status if provided
-	if status != nil {
-		query = query.Where("status = ?", *status)
+// ListByTag retrieves a tenant's published posts with a tag
+func (r *postRepository) ListByTag(ctx context.Context, tenantID, locale, fallbackLocale string, tagID uuid.UUID, limit, offset int) ([]*domain.Post, int64, error) {
+	query := r.publishedIn(ctx, tenantID, locale, fallbackLocale).
+		Where("id IN (SELECT post_id FROM post_tags WHERE tag_id = ?)", tagID)
+	return listPublished(query, limit, offset)
+}
+
+// publishedIn scopes a query to a tenant's published posts in a locale
+// Posts without a published translation in that locale are included in the fallback locale
+func (r *postRepository) publishedIn(ctx context.Context, tenantID, locale, fallbackLocale string) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&domain.Post{}).
+		Where("tenant_id = ? AND status = ?", tenantID, domain.PostStatusPublished)
+
+	if fallbackLocale == "" || fallbackLocale == locale {
+		return query.Where("locale = ?", locale)
 	}
+	// A variant group is a source post and its translations, keyed by the source's ID
+	return query.Where(`locale = ? OR (locale = ? AND NOT EXISTS (
+		SELECT 1 FROM posts t
+		WHERE t.tenant_id = posts.tenant_id AND t.status = ? AND t.locale = ? AND t.deleted_at IS NULL
+		AND COALESCE(t.translation_of_id, t.id) = COALESCE(posts.translation_of_id, posts.id)))`,
+		locale, fallbackLocale, domain.PostStatusPublished, locale)
+}
+
+// listPublished counts and fetches a page of the posts a query selects, newest first
+func listPublished(query *gorm.DB, limit, offset int) ([]*domain.Post, int64, error) {
+	var posts []*domain.Post
+	var total int64
 
-	// Count total records
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count posts: %w", err)
 	}
 
-	// Fetch paginated records
-	query = query.Preload("Author").Preload("Categories").
+	if err := query.Preload("Author").Preload("Categories").Preload("Tags").
 		Limit(limit).
 		Offset(offset).
-		Order("created_at DESC")
-
-	if err := query.Find(&posts).Error; err != nil {
+		Order("created_at DESC").
+		Find(&posts).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to list posts: %w", err)
 	}
 
 	return posts, total, nil
 }
 
-// ListByCategory retrieves posts by category ID
-func (r *postRepository) ListByCategory(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*domain.Post, int64, error) {
-	var posts []*domain.Post
-	var total int64
+// Update updates an existing post
+func (r *postRepository) Update(ctx context.Context, post *domain.Post) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := storedStatus(tx, &domain.Post{}, post.ID)
+		if err != nil {
+			return err
+		}
+		if err := saveVersion(tx, post, &post.Version); err != nil {
+			return err
+		}
+		if post.Categories != nil {
+			if err := tx.Model(post).Association("Categories").Replace(post.Categories); err != nil {
+				return err
+			}
+		}
+		if post.Tags != nil {
+			if err := tx.Model(post).Association("Tags").Replace(post.Tags); err != nil {
+				return err
+			}
+		}
+		return recordEvents(tx, post.TenantID, r.origin, postSaveEvents(post, domain.PostStatus(before))...)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update post: %w", domain.ErrPostAlreadyExists)
+		}
+		return fmt.Errorf("failed to update post: %w", err)
+	}
+	return nil
+}
 
-	// Count total records
-	if err := r.db.WithContext(ctx).
-		Model(&domain.Post{}).
-		Joins("JOIN post_categories ON posts.id = post_categories.post_id").
-		Where("post_categories.category_id = ?", categoryID).
-		Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count posts by category: %w", err)
+// Delete moves a post to the trash
+// Translations of the post stay linked to it until it is purged, so restoring it keeps them together
+func (r *postRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var posts []*domain.Post
+		if err := tx.Where("id = ?", id).Limit(1).Find(&posts).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.Post{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		for _, post := range posts {
+			if err := recordEvents(tx, post.TenantID, r.origin, domain.PostDeleted{PostEvent: domain.NewPostEvent(post)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete post: %w", err)
 	}
+	return nil
+}
 
-	// Fetch paginated records
-	if err := r.db.WithContext(ctx).
-		Preload("Author").
-		Preload("Categories").
-		Joins("JOIN post_categories ON posts.id = post_categories.post_id").
-		Where("post_categories.category_id = ?", categoryID).
-		Limit(limit).
-		Offset(offset).
-		Order("created_at DESC").
-		Find(&posts).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to list posts by category: %w", err)
+// postSaveEvents returns the events for saving a post whose stored status was before
+// before is empty for a new post
+func postSaveEvents(post *domain.Post, before domain.PostStatus) []domain.DomainEvent {
+	state := domain.NewPostEvent(post)
+	events := []domain.DomainEvent{domain.PostUpdated{PostEvent: state}}
+	if before == "" {
+		events[0] = domain.PostCreated{PostEvent: state}
 	}
+	if post.Status == domain.PostStatusPublished && before != domain.PostStatusPublished {
+		events = append(events, domain.PostPublished{PostEvent: state})
+	}
+	return events
+}
 
-	return posts, total, nil
+// List retrieves a page of a tenant's posts matching a list query
+func (r *postRepository) List(ctx context.Context, tenantID string, q repository.ListQuery) ([]*domain.Post, repository.ListPage, error) {
+	var posts []*domain.Post
+	query := r.db.WithContext(ctx).Model(&domain.Post{}).Where("tenant_id = ?", tenantID)
+
+	page, err := listPage(query, repository.PostListSpec, q, &posts, "Author", "Categories", "Tags")
+	if err != nil {
+		return nil, page, fmt.Errorf("failed to list posts: %w", err)
+	}
+	return posts, page, nil
+}
+
+// ListIDs retrieves the IDs of up to limit of a tenant's posts matching the filters, oldest first
+func (r *postRepository) ListIDs(ctx context.Context, tenantID string, filters []repository.Filter, limit int) ([]uuid.UUID, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Post{}).Where("tenant_id = ?", tenantID)
+	ids, total, err := listIDs(query, repository.PostListSpec, filters, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list post IDs: %w", err)
+	}
+	return ids, total, nil
 }
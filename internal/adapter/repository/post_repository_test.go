@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPostRepository_ListPublished_FallsBackToDefaultLocale(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.User{}, &domain.Category{}, &domain.Post{}, &domain.OutboxEvent{}))
+
+	repo := NewPostRepository(db)
+	ctx := context.Background()
+	authorID := uuid.New()
+
+	create := func(slug, locale string, status domain.PostStatus, translationOf *domain.Post) *domain.Post {
+		post := &domain.Post{Title: slug, Slug: slug, Locale: locale, Status: status, AuthorID: authorID}
+		if translationOf != nil {
+			post.TranslationOfID = &translationOf.ID
+		}
+		require.NoError(t, db.Omit("Author").Create(post).Error)
+		return post
+	}
+
+	translated := create("hello", "en", domain.PostStatusPublished, nil)
+	create("hallo", "de", domain.PostStatusPublished, translated)
+	draftTranslation := create("news", "en", domain.PostStatusPublished, nil)
+	create("neuigkeiten", "de", domain.PostStatusDraft, draftTranslation)
+	create("english-only", "en", domain.PostStatusPublished, nil)
+	create("nur-deutsch", "de", domain.PostStatusPublished, nil)
+	create("other-tenant", "de", domain.PostStatusPublished, nil)
+	require.NoError(t, db.Model(&domain.Post{}).Where("slug = ?", "other-tenant").Update("tenant_id", "acme").Error)
+
+	slugs := func(posts []*domain.Post) []string {
+		var result []string
+		for _, post := range posts {
+			result = append(result, post.Slug)
+		}
+		return result
+	}
+
+	posts, total, err := repo.ListPublished(ctx, "", "de", "en", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.ElementsMatch(t, []string{"hallo", "news", "english-only", "nur-deutsch"}, slugs(posts))
+
+	posts, total, err = repo.ListPublished(ctx, "", "en", "en", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.ElementsMatch(t, []string{"hello", "news", "english-only"}, slugs(posts))
+}
+
+func TestPostRepository_UpdateCategories(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.User{}, &domain.Category{}, &domain.Tag{}, &domain.Post{}, &domain.OutboxEvent{}))
+
+	ctx := context.Background()
+	news := &domain.Category{Name: "News", Slug: "news"}
+	events := &domain.Category{Name: "Events", Slug: "events"}
+	require.NoError(t, db.Create(news).Error)
+	require.NoError(t, db.Create(events).Error)
+	post := &domain.Post{Title: "Hello", Slug: "hello", Status: domain.PostStatusDraft}
+	require.NoError(t, db.Omit("Author").Create(post).Error)
+
+	repo := NewPostRepository(db)
+	categorySlugs := func() []string {
+		stored, err := repo.GetByID(ctx, post.ID)
+		require.NoError(t, err)
+		var slugs []string
+		for _, category := range stored.Categories {
+			slugs = append(slugs, category.Slug)
+		}
+		return slugs
+	}
+
+	// Given categories replace the stored ones
+	post.Categories = []domain.Category{*news, *events}
+	require.NoError(t, repo.Update(ctx, post))
+	assert.ElementsMatch(t, []string{"news", "events"}, categorySlugs())
+	post.Categories = []domain.Category{*events}
+	require.NoError(t, repo.Update(ctx, post))
+	assert.Equal(t, []string{"events"}, categorySlugs())
+
+	// Without categories the stored ones are kept; an empty list clears them
+	post.Categories = nil
+	post.Title = "Hello again"
+	require.NoError(t, repo.InBatch("batch-1").Update(ctx, post))
+	assert.Equal(t, []string{"events"}, categorySlugs())
+	post.Categories = []domain.Category{}
+	require.NoError(t, repo.Update(ctx, post))
+	assert.Empty(t, categorySlugs())
+
+	// Events of a batch carry its ID
+	var batched int64
+	require.NoError(t, db.Model(&domain.OutboxEvent{}).Where("batch_id = ?", "batch-1").Count(&batched).Error)
+	assert.Equal(t, int64(1), batched)
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// redirectRepository implements the RedirectRepository interface using GORM
+type redirectRepository struct {
+	db *gorm.DB
+}
+
+// NewRedirectRepository creates a new redirect repository instance
+func NewRedirectRepository(db *gorm.DB) repository.RedirectRepository {
+	return &redirectRepository{db: db}
+}
+
+// Create creates a new redirect
+func (r *redirectRepository) Create(ctx context.Context, redirect *domain.Redirect) error {
+	if err := r.db.WithContext(ctx).Create(redirect).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create redirect: %w", domain.ErrRedirectAlreadyExists)
+		}
+		return fmt.Errorf("failed to create redirect: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a redirect by its UUID
+func (r *redirectRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Redirect, error) {
+	var redirect domain.Redirect
+	err := r.db.WithContext(ctx).First(&redirect, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("redirect not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get redirect: %w", err)
+	}
+	return &redirect, nil
+}
+
+// Update updates an existing redirect
+func (r *redirectRepository) Update(ctx context.Context, redirect *domain.Redirect) error {
+	if err := r.db.WithContext(ctx).Save(redirect).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update redirect: %w", domain.ErrRedirectAlreadyExists)
+		}
+		return fmt.Errorf("failed to update redirect: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a redirect by ID
+func (r *redirectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.Redirect{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("failed to delete redirect: %w", err)
+	}
+	return nil
+}
+
+// List retrieves a tenant's redirects with pagination
+func (r *redirectRepository) List(ctx context.Context, tenantID string, limit, offset int) ([]*domain.Redirect, int64, error) {
+	var redirects []*domain.Redirect
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Redirect{}).Where("tenant_id = ?", tenantID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count redirects: %w", err)
+	}
+
+	if err := query.Order("source ASC").Limit(limit).Offset(offset).Find(&redirects).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list redirects: %w", err)
+	}
+
+	return redirects, total, nil
+}
+
+// Resolve finds the redirect for a request path and returns it with the resolved target
+func (r *redirectRepository) Resolve(ctx context.Context, tenantID, path string) (*domain.Redirect, string, error) {
+	var exact domain.Redirect
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND match_type = ? AND source = ?", tenantID, domain.RedirectMatchExact, domain.NormalizePath(path)).
+		First(&exact).Error
+	if err == nil {
+		return &exact, exact.Target, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, "", fmt.Errorf("failed to resolve redirect: %w", err)
+	}
+
+	// Pattern rules are few, so they are matched in Go rather than in SQL
+	var rules []*domain.Redirect
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND match_type <> ?", tenantID, domain.RedirectMatchExact).
+		Order("created_at ASC").
+		Find(&rules).Error; err != nil {
+		return nil, "", fmt.Errorf("failed to resolve redirect: %w", err)
+	}
+	for _, rule := range rules {
+		if target, ok := rule.Match(path); ok {
+			return rule, target, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("redirect not found: %w", gorm.ErrRecordNotFound)
+}
+
+// RecordHit increments a redirect's hit count
+func (r *redirectRepository) RecordHit(ctx context.Context, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).Model(&domain.Redirect{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"hits":        gorm.Expr("hits + 1"),
+			"last_hit_at": time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record redirect hit: %w", err)
+	}
+	return nil
+}
+
+// AddSlugRedirect records a permanent redirect after content moved from one path to another
+func (r *redirectRepository) AddSlugRedirect(ctx context.Context, tenantID, from, to string) error {
+	from, to = domain.NormalizePath(from), domain.NormalizePath(to)
+	if from == to {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Content lives at the new path again, so an old redirect away from it must go
+		if err := tx.Where("tenant_id = ? AND match_type = ? AND source = ?", tenantID, domain.RedirectMatchExact, to).
+			Delete(&domain.Redirect{}).Error; err != nil {
+			return err
+		}
+
+		// Repoint redirects to the old path so visitors take a single hop
+		if err := tx.Model(&domain.Redirect{}).
+			Where("tenant_id = ? AND target = ?", tenantID, from).
+			Updates(map[string]interface{}{"target": to, "updated_at": time.Now()}).Error; err != nil {
+			return err
+		}
+
+		redirect := &domain.Redirect{
+			TenantID:   tenantID,
+			Source:     from,
+			Target:     to,
+			StatusCode: 301,
+			MatchType:  domain.RedirectMatchExact,
+			Automatic:  true,
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "tenant_id"}, {Name: "source"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"target":      to,
+				"status_code": 301,
+				"match_type":  domain.RedirectMatchExact,
+				"automatic":   true,
+				"updated_at":  time.Now(),
+			}),
+		}).Create(redirect).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add redirect from %s: %w", from, err)
+	}
+	return nil
+}
+
+// Import creates or replaces redirects by source in a single transaction
+func (r *redirectRepository) Import(ctx context.Context, tenantID string, redirects []*domain.Redirect) (int, int, error) {
+	created, updated := 0, 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		created, updated = 0, 0
+		for _, redirect := range redirects {
+			redirect.TenantID = tenantID
+
+			var existing domain.Redirect
+			err := tx.Where("tenant_id = ? AND source = ?", tenantID, redirect.Source).First(&existing).Error
+			switch {
+			case err == nil:
+				existing.Target = redirect.Target
+				existing.StatusCode = redirect.StatusCode
+				existing.MatchType = redirect.MatchType
+				existing.Automatic = false
+				if err := tx.Save(&existing).Error; err != nil {
+					return err
+				}
+				*redirect = existing
+				updated++
+			case err == gorm.ErrRecordNotFound:
+				if err := tx.Create(redirect).Error; err != nil {
+					return err
+				}
+				created++
+			default:
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to import redirects: %w", err)
+	}
+	return created, updated, nil
+}
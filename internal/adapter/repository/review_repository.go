@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// reviewModels maps each kind of reviewed content to its model
+var reviewModels = map[domain.ReviewEntityType]func() interface{}{
+	domain.ReviewEntityPage: func() interface{} { return &domain.Page{} },
+	domain.ReviewEntityPost: func() interface{} { return &domain.Post{} },
+}
+
+// reviewRepository implements the ReviewRepository interface using GORM
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository creates a new review repository instance
+func NewReviewRepository(db *gorm.DB) repository.ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+// CreateComment records a review comment or status change
+func (r *reviewRepository) CreateComment(ctx context.Context, comment *domain.ReviewComment) error {
+	if err := r.db.WithContext(ctx).Create(comment).Error; err != nil {
+		return fmt.Errorf("failed to create review comment: %w", err)
+	}
+	return nil
+}
+
+// ListComments retrieves the review history of a page or post, oldest first
+func (r *reviewRepository) ListComments(ctx context.Context, tenantID string, entityType domain.ReviewEntityType, entityID uuid.UUID) ([]*domain.ReviewComment, error) {
+	comments := []*domain.ReviewComment{}
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND entity_type = ? AND entity_id = ?", tenantID, entityType, entityID).
+		Order("created_at ASC").
+		Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list review comments: %w", err)
+	}
+	return comments, nil
+}
+
+// LastSubmitter returns the ID of the user who last submitted a page or post for review
+func (r *reviewRepository) LastSubmitter(ctx context.Context, tenantID string, entityType domain.ReviewEntityType, entityID uuid.UUID) (string, error) {
+	var submissions []domain.ReviewComment
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND entity_type = ? AND entity_id = ? AND to_status = ?", tenantID, entityType, entityID, domain.PageStatusInReview).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&submissions).Error; err != nil {
+		return "", fmt.Errorf("failed to find submitter: %w", err)
+	}
+	if len(submissions) == 0 {
+		return "", nil
+	}
+	return submissions[0].AuthorID, nil
+}
+
+// ListQueue retrieves a tenant's pages and posts in a status, longest waiting first
+// Items are dated by their latest submission, or their last update if they were never submitted
+func (r *reviewRepository) ListQueue(ctx context.Context, tenantID string, entityType domain.ReviewEntityType, status string) ([]*domain.ReviewItem, error) {
+	items := []*domain.ReviewItem{}
+	for _, t := range []domain.ReviewEntityType{domain.ReviewEntityPage, domain.ReviewEntityPost} {
+		if entityType != "" && t != entityType {
+			continue
+		}
+
+		var rows []domain.ReviewItem
+		if err := r.db.WithContext(ctx).Model(reviewModels[t]()).
+			Select("id, title, slug, locale, status, updated_at").
+			Where("tenant_id = ? AND status = ?", tenantID, status).
+			Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to list %ss in review: %w", t, err)
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		ids := make([]uuid.UUID, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		var submissions []domain.ReviewComment
+		if err := r.db.WithContext(ctx).
+			Where("tenant_id = ? AND entity_type = ? AND entity_id IN ? AND to_status = ?", tenantID, t, ids, domain.PageStatusInReview).
+			Order("created_at ASC").
+			Find(&submissions).Error; err != nil {
+			return nil, fmt.Errorf("failed to list submissions: %w", err)
+		}
+		latest := make(map[uuid.UUID]domain.ReviewComment, len(submissions))
+		for _, submission := range submissions {
+			latest[submission.EntityID] = submission
+		}
+
+		for i := range rows {
+			item := rows[i]
+			item.Type = t
+			if submission, ok := latest[item.ID]; ok {
+				submittedAt := submission.CreatedAt
+				item.SubmittedBy = submission.AuthorID
+				item.SubmittedAt = &submittedAt
+			}
+			items = append(items, &item)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return waitingSince(items[i]).Before(waitingSince(items[j]))
+	})
+	return items, nil
+}
+
+// waitingSince returns when an item entered the review queue, as far as is known
+func waitingSince(item *domain.ReviewItem) time.Time {
+	if item.SubmittedAt != nil {
+		return *item.SubmittedAt
+	}
+	return item.UpdatedAt
+}
@@ -0,0 +1,366 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"sync"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Snippet highlight markers
+// Backends wrap matches in these control characters so the snippet can be
+// HTML-escaped before they are turned into <mark> tags
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+// snippetWords is the approximate snippet length
+const snippetWords = 24
+
+// searchRepository implements the SearchRepository interface using GORM
+// The index lives in the search_documents table created by the migrations:
+// a tsvector-indexed table on PostgreSQL, an FTS5 virtual table on SQLite, or
+// a plain table searched with LIKE when SQLite was built without FTS5
+type searchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository creates a new search repository instance
+func NewSearchRepository(db *gorm.DB) repository.SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// CreateSearchSchema creates the search_documents index for the database dialect
+//   - PostgreSQL: a table with a weighted, generated tsvector column and a GIN index
+//   - SQLite: an FTS5 virtual table, or a plain table if SQLite was built without FTS5
+//     (the mattn driver needs the sqlite_fts5 build tag)
+func CreateSearchSchema(db *gorm.DB) error {
+	switch db.Dialector.Name() {
+	case "postgres":
+		if err := db.AutoMigrate(&domain.SearchDocument{}); err != nil {
+			return err
+		}
+		statements := []string{
+			`ALTER TABLE search_documents ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(excerpt, '')), 'B') ||
+				setweight(to_tsvector('simple', coalesce(body, '')), 'C')
+			) STORED`,
+			`CREATE INDEX IF NOT EXISTS idx_search_documents_vector ON search_documents USING GIN (search_vector)`,
+		}
+		for _, statement := range statements {
+			if err := db.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "sqlite":
+		err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS search_documents USING fts5(
+			title, excerpt, body,
+			entity_type UNINDEXED, entity_id UNINDEXED, tenant_id UNINDEXED, slug UNINDEXED, status UNINDEXED,
+			tokenize = 'unicode61 remove_diacritics 2'
+		)`).Error
+		if err == nil {
+			return nil
+		}
+		if !strings.Contains(err.Error(), "no such module") {
+			return err
+		}
+		log.Println("SQLite was built without FTS5; search falls back to LIKE matching")
+		return db.AutoMigrate(&domain.SearchDocument{})
+	}
+
+	return db.AutoMigrate(&domain.SearchDocument{})
+}
+
+// searchRow is a search hit as scanned from the database
+type searchRow struct {
+	EntityType domain.SearchEntityType
+	EntityID   uuid.UUID
+	Slug       string
+	Title      string
+	Status     string
+	Excerpt    string
+	Body       string
+	Snippet    string
+	Rank       float64 `gorm:"column:search_rank"` // FTS5 tables have a hidden "rank" column
+}
+
+// Index adds or replaces a document
+// FTS5 tables have no unique constraints, so the previous version is deleted first
+func (r *searchRepository) Index(ctx context.Context, doc *domain.SearchDocument) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("entity_type = ? AND entity_id = ?", doc.EntityType, doc.EntityID).
+			Delete(&domain.SearchDocument{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(doc).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to index %s: %w", doc.EntityType, err)
+	}
+	return nil
+}
+
+// Remove deletes a document from the index
+func (r *searchRepository) Remove(ctx context.Context, entityType domain.SearchEntityType, entityID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Delete(&domain.SearchDocument{}).Error; err != nil {
+		return fmt.Errorf("failed to remove %s from search index: %w", entityType, err)
+	}
+	return nil
+}
+
+// Search retrieves matching documents, best match first
+func (r *searchRepository) Search(ctx context.Context, opts repository.SearchOptions) ([]*domain.SearchResult, int64, error) {
+	terms := strings.Fields(opts.Query)
+	if len(terms) == 0 {
+		return []*domain.SearchResult{}, 0, nil
+	}
+
+	query := r.db.WithContext(ctx).Table("search_documents").Where("search_documents.tenant_id = ?", opts.TenantID)
+	if len(opts.Types) > 0 {
+		query = query.Where("search_documents.entity_type IN ?", opts.Types)
+	}
+	if opts.Status != "" {
+		query = query.Where("search_documents.status = ?", opts.Status)
+	}
+
+	var rows []searchRow
+	var total int64
+	var err error
+	switch r.db.Dialector.Name() {
+	case "postgres":
+		rows, total, err = r.searchTSVector(query, opts)
+	case "sqlite":
+		var fts bool
+		fts, err = r.hasFTS5()
+		if err != nil {
+			break
+		}
+		if fts {
+			rows, total, err = r.searchFTS5(query, terms, opts)
+		} else {
+			rows, total, err = r.searchLike(query, terms, opts)
+		}
+	default:
+		rows, total, err = r.searchLike(query, terms, opts)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search: %w", err)
+	}
+
+	results := make([]*domain.SearchResult, 0, len(rows))
+	for _, row := range rows {
+		snippet := row.Snippet
+		if snippet == "" {
+			snippet = buildSnippet(row.Excerpt+" "+row.Body, terms)
+		}
+		results = append(results, &domain.SearchResult{
+			EntityType: row.EntityType,
+			EntityID:   row.EntityID,
+			Slug:       row.Slug,
+			Title:      row.Title,
+			Status:     row.Status,
+			Snippet:    renderSnippet(snippet),
+			Rank:       row.Rank,
+		})
+	}
+	return results, total, nil
+}
+
+// searchTSVector searches the generated search_vector column on PostgreSQL
+// Title, excerpt and body are weighted A, B and C
+func (r *searchRepository) searchTSVector(query *gorm.DB, opts repository.SearchOptions) ([]searchRow, int64, error) {
+	headlineOptions := fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=%d, MinWords=%d, MaxFragments=2, FragmentDelimiter=" … "`,
+		highlightStart, highlightStop, snippetWords, snippetWords/2)
+
+	query = query.
+		Joins("CROSS JOIN websearch_to_tsquery('simple', ?) AS search_query", opts.Query).
+		Where("search_documents.search_vector @@ search_query")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []searchRow
+	err := paginate(query, opts).
+		Select("entity_type, entity_id, slug, title, status, "+
+			"ts_rank_cd(search_vector, search_query) AS search_rank, "+
+			"ts_headline('simple', excerpt || ' ' || body, search_query, ?) AS snippet", headlineOptions).
+		Order("search_rank DESC").
+		Scan(&rows).Error
+	return rows, total, err
+}
+
+// searchFTS5 searches the FTS5 virtual table on SQLite, ranked by BM25
+func (r *searchRepository) searchFTS5(query *gorm.DB, terms []string, opts repository.SearchOptions) ([]searchRow, int64, error) {
+	query = query.Where("search_documents MATCH ?", fts5Query(terms))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// bm25() is lower for better matches; weights follow the column order title, excerpt, body
+	var rows []searchRow
+	err := paginate(query, opts).
+		Select("entity_type, entity_id, slug, title, status, "+
+			"-bm25(search_documents, 10.0, 5.0, 1.0) AS search_rank, "+
+			"snippet(search_documents, -1, ?, ?, '…', ?) AS snippet", highlightStart, highlightStop, snippetWords).
+		Order("search_rank DESC").
+		Scan(&rows).Error
+	return rows, total, err
+}
+
+// searchLike matches every term with LIKE, for SQLite builds without FTS5
+// Matches in the title count more than matches in the excerpt or body
+func (r *searchRepository) searchLike(query *gorm.DB, terms []string, opts repository.SearchOptions) ([]searchRow, int64, error) {
+	var rankParts []string
+	var rankArgs []interface{}
+	for _, term := range terms {
+		pattern := "%" + escapeLike(strings.ToLower(term)) + "%"
+		query = query.Where("(LOWER(title) LIKE ? ESCAPE '\\' OR LOWER(excerpt) LIKE ? ESCAPE '\\' OR LOWER(body) LIKE ? ESCAPE '\\')",
+			pattern, pattern, pattern)
+		rankParts = append(rankParts,
+			"(CASE WHEN LOWER(title) LIKE ? ESCAPE '\\' THEN 10 ELSE 0 END)",
+			"(CASE WHEN LOWER(excerpt) LIKE ? ESCAPE '\\' THEN 5 ELSE 0 END)",
+			"(CASE WHEN LOWER(body) LIKE ? ESCAPE '\\' THEN 1 ELSE 0 END)")
+		rankArgs = append(rankArgs, pattern, pattern, pattern)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []searchRow
+	err := paginate(query, opts).
+		Select("entity_type, entity_id, slug, title, status, excerpt, body, "+strings.Join(rankParts, " + ")+" AS search_rank", rankArgs...).
+		Order("search_rank DESC").
+		Scan(&rows).Error
+	return rows, total, err
+}
+
+// fts5Tables caches hasFTS5 per database, keyed by its *gorm.Config (shared by sessions and transactions)
+// The search schema is created once by the migrations, so the answer never changes for an open database
+var fts5Tables sync.Map
+
+// hasFTS5 reports whether search_documents is an FTS5 virtual table
+func (r *searchRepository) hasFTS5() (bool, error) {
+	if fts, ok := fts5Tables.Load(r.db.Config); ok {
+		return fts.(bool), nil
+	}
+
+	var schemas []string
+	if err := r.db.Raw("SELECT sql FROM sqlite_master WHERE name = ?", "search_documents").Scan(&schemas).Error; err != nil {
+		return false, err
+	}
+	if len(schemas) == 0 {
+		// Not created yet; check again next time
+		return false, nil
+	}
+	fts := strings.Contains(strings.ToLower(schemas[0]), "using fts5")
+	fts5Tables.Store(r.db.Config, fts)
+	return fts, nil
+}
+
+// paginate applies limit and offset
+func paginate(query *gorm.DB, opts repository.SearchOptions) *gorm.DB {
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		query = query.Offset(opts.Offset)
+	}
+	return query
+}
+
+// fts5Query turns search terms into an FTS5 query
+// Terms are quoted so user input cannot use FTS5 syntax; the last term
+// matches as a prefix so results update while typing
+func fts5Query(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	quoted[len(quoted)-1] += "*"
+	return strings.Join(quoted, " ")
+}
+
+// escapeLike escapes LIKE wildcards
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// buildSnippet cuts a window of text around the first matching term and marks all matches
+func buildSnippet(text string, terms []string) string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return ""
+	}
+
+	lowerTerms := make([]string, len(terms))
+	for i, term := range terms {
+		lowerTerms[i] = strings.ToLower(term)
+	}
+	matches := func(word string) bool {
+		lower := strings.ToLower(word)
+		for _, term := range lowerTerms {
+			if strings.Contains(lower, term) {
+				return true
+			}
+		}
+		return false
+	}
+
+	start := 0
+	for i, word := range words {
+		if matches(word) {
+			start = i - snippetWords/4
+			break
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetWords
+	if end > len(words) {
+		end = len(words)
+	}
+
+	window := make([]string, 0, end-start)
+	for _, word := range words[start:end] {
+		if matches(word) {
+			word = highlightStart + word + highlightStop
+		}
+		window = append(window, word)
+	}
+
+	snippet := strings.Join(window, " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(words) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// renderSnippet escapes a snippet and turns the highlight markers into <mark> tags
+func renderSnippet(snippet string) string {
+	snippet = html.EscapeString(snippet)
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(snippet)
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupSearchTestDB creates an isolated database with the search index
+// Uses FTS5 when the SQLite driver was built with it (-tags sqlite_fts5), LIKE matching otherwise
+func setupSearchTestDB(t *testing.T) *gorm.DB {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", uuid.New().String())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, CreateSearchSchema(db))
+	return db
+}
+
+func indexPage(t *testing.T, repo repository.SearchRepository, tenantID, slug, title string, status domain.PageStatus, blocks string) *domain.Page {
+	page := &domain.Page{ID: uuid.New(), TenantID: tenantID, Slug: slug, Title: title, Status: status, Blocks: datatypes.JSON(blocks)}
+	require.NoError(t, repo.Index(context.Background(), domain.NewPageSearchDocument(page)))
+	return page
+}
+
+func TestSearchRepository_MatchesBlockText(t *testing.T) {
+	repo := NewSearchRepository(setupSearchTestDB(t))
+	ctx := context.Background()
+
+	page := indexPage(t, repo, "", "pricing", "Pricing", domain.PageStatusPublished,
+		`[{"id":"1","type":"text","data":{"content":"<p>Every plan includes unlimited <b>bandwidth</b></p>"}}]`)
+	indexPage(t, repo, "", "about", "About us", domain.PageStatusPublished,
+		`[{"id":"1","type":"text","data":{"content":"We build things"}}]`)
+
+	results, total, err := repo.Search(ctx, repository.SearchOptions{Query: "bandwidth"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Len(t, results, 1)
+	assert.Equal(t, page.ID, results[0].EntityID)
+	assert.Equal(t, domain.SearchEntityPage, results[0].EntityType)
+	assert.Contains(t, results[0].Snippet, "<mark>bandwidth</mark>")
+}
+
+func TestSearchRepository_RanksTitleMatchesFirst(t *testing.T) {
+	repo := NewSearchRepository(setupSearchTestDB(t))
+	ctx := context.Background()
+
+	indexPage(t, repo, "", "body", "Company", domain.PageStatusPublished,
+		`[{"id":"1","type":"text","data":{"content":"Our roadmap for next year"}}]`)
+	title := indexPage(t, repo, "", "roadmap", "Roadmap", domain.PageStatusPublished, `[]`)
+
+	results, _, err := repo.Search(ctx, repository.SearchOptions{Query: "roadmap"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, title.ID, results[0].EntityID)
+	assert.Greater(t, results[0].Rank, results[1].Rank)
+}
+
+func TestSearchRepository_Filters(t *testing.T) {
+	repo := NewSearchRepository(setupSearchTestDB(t))
+	ctx := context.Background()
+
+	published := indexPage(t, repo, "acme", "guide", "Setup guide", domain.PageStatusPublished, `[]`)
+	indexPage(t, repo, "acme", "draft-guide", "Draft guide", domain.PageStatusDraft, `[]`)
+	indexPage(t, repo, "other", "guide", "Other guide", domain.PageStatusPublished, `[]`)
+	post := &domain.Post{ID: uuid.New(), TenantID: "acme", Slug: "guide-post", Title: "Guide post", Status: domain.PostStatusPublished}
+	require.NoError(t, repo.Index(ctx, domain.NewPostSearchDocument(post)))
+
+	// Tenant isolation
+	_, total, err := repo.Search(ctx, repository.SearchOptions{Query: "guide", TenantID: "acme"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	// Status filter
+	_, total, err = repo.Search(ctx, repository.SearchOptions{Query: "guide", TenantID: "acme", Status: "published"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	// Type filter
+	results, total, err := repo.Search(ctx, repository.SearchOptions{
+		Query:    "guide",
+		TenantID: "acme",
+		Types:    []domain.SearchEntityType{domain.SearchEntityPage},
+		Status:   "published",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, published.ID, results[0].EntityID)
+}
+
+func TestSearchRepository_IndexReplacesAndRemove(t *testing.T) {
+	repo := NewSearchRepository(setupSearchTestDB(t))
+	ctx := context.Background()
+
+	page := indexPage(t, repo, "", "news", "Old headline", domain.PageStatusPublished, `[]`)
+	page.Title = "New headline"
+	require.NoError(t, repo.Index(ctx, domain.NewPageSearchDocument(page)))
+
+	_, total, err := repo.Search(ctx, repository.SearchOptions{Query: "old"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	_, total, err = repo.Search(ctx, repository.SearchOptions{Query: "headline"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	require.NoError(t, repo.Remove(ctx, domain.SearchEntityPage, page.ID))
+	_, total, err = repo.Search(ctx, repository.SearchOptions{Query: "headline"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
+
+func TestSearchRepository_EscapesInput(t *testing.T) {
+	repo := NewSearchRepository(setupSearchTestDB(t))
+	ctx := context.Background()
+
+	indexPage(t, repo, "", "html", "Tags", domain.PageStatusPublished,
+		`[{"id":"1","type":"code","data":{"code":"use 100% &lt;script&gt; safely"}}]`)
+
+	// Query syntax and wildcards are treated as text
+	for _, q := range []string{`"unbalanced`, "100%", "OR", "*"} {
+		_, _, err := repo.Search(ctx, repository.SearchOptions{Query: q})
+		require.NoError(t, err, q)
+	}
+
+	results, _, err := repo.Search(ctx, repository.SearchOptions{Query: "safely"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.NotContains(t, results[0].Snippet, "<script>")
+	assert.Contains(t, results[0].Snippet, "&lt;script&gt;")
+}
@@ -21,11 +21,11 @@ func NewSettingsRepository(db *gorm.DB) repository.SettingsRepository {
 	return &settingsRepository{db: db}
 }
 
-// GetGlobalSettings retrieves global site settings from system_configs table
-func (r *settingsRepository) GetGlobalSettings(ctx context.Context) (*domain.GlobalSettings, error) {
+// GetGlobalSettings retrieves a tenant's global site settings from system_configs table
+func (r *settingsRepository) GetGlobalSettings(ctx context.Context, tenantID string) (*domain.GlobalSettings, error) {
 	var config domain.SystemConfig
 	err := r.db.WithContext(ctx).
-		Where("key = ? AND tenant_id = ?", "global_settings", "").
+		Where("key = ? AND tenant_id = ?", "global_settings", tenantID).
 		First(&config).Error
 
 	if err != nil {
@@ -59,8 +59,8 @@ func (r *settingsRepository) GetGlobalSettings(ctx context.Context) (*domain.Glo
 	return &settings, nil
 }
 
-// UpdateGlobalSettings updates global site settings in system_configs table
-func (r *settingsRepository) UpdateGlobalSettings(ctx context.Context, settings *domain.GlobalSettings) error {
+// UpdateGlobalSettings updates a tenant's global site settings in system_configs table
+func (r *settingsRepository) UpdateGlobalSettings(ctx context.Context, tenantID string, settings *domain.GlobalSettings) error {
 	settingsJSON, err := json.Marshal(settings)
 	if err != nil {
 		return fmt.Errorf("failed to marshal global settings: %w", err)
@@ -69,13 +69,13 @@ func (r *settingsRepository) UpdateGlobalSettings(ctx context.Context, settings
 	// Check if config exists
 	var existingConfig domain.SystemConfig
 	err = r.db.WithContext(ctx).
-		Where("key = ? AND tenant_id = ?", "global_settings", "").
+		Where("key = ? AND tenant_id = ?", "global_settings", tenantID).
 		First(&existingConfig).Error
 
 	if err == gorm.ErrRecordNotFound {
 		// Create new config
 		config := &domain.SystemConfig{
-			TenantID: "",
+			TenantID: tenantID,
 			Key:      "global_settings",
 			Value:    settingsJSON,
 		}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// tagRepository implements the TagRepository interface using GORM
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new tag repository instance
+func NewTagRepository(db *gorm.DB) repository.TagRepository {
+	return &tagRepository{db: db}
+}
+
+// Create creates a new tag
+func (r *tagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	if err := r.db.WithContext(ctx).Create(tag).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create tag: %w", domain.ErrTagAlreadyExists)
+		}
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a tenant's tag with its post count
+func (r *tagRepository) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Tag, error) {
+	return r.get(ctx, "tenant_id = ? AND id = ?", tenantID, id)
+}
+
+// GetBySlug retrieves a tenant's tag by its slug with its post count
+func (r *tagRepository) GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Tag, error) {
+	return r.get(ctx, "tenant_id = ? AND slug = ?", tenantID, slug)
+}
+
+func (r *tagRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.Tag, error) {
+	var tag domain.Tag
+	if err := r.db.WithContext(ctx).Select("tags.*, "+tagPostCount(false)+" AS post_count").
+		Where(query, args...).First(&tag).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+	return &tag, nil
+}
+
+// List retrieves a tenant's tags with their post counts
+// Tags are ordered by name, or by use when completing a prefix
+func (r *tagRepository) List(ctx context.Context, opts repository.ListTagOptions) ([]*domain.Tag, int64, error) {
+	tags := []*domain.Tag{}
+	var total int64
+
+	count := tagPostCount(opts.PublishedOnly)
+	query := r.db.WithContext(ctx).Model(&domain.Tag{}).Where("tenant_id = ?", opts.TenantID)
+	if opts.PublishedOnly {
+		query = query.Where(count + " > 0")
+	}
+	order := "name ASC"
+	if prefix := strings.TrimSpace(opts.Prefix); prefix != "" {
+		pattern := escapeLike(strings.ToLower(prefix)) + "%"
+		query = query.Where("(LOWER(name) LIKE ? ESCAPE '\\' OR slug LIKE ? ESCAPE '\\')", pattern, escapeLike(domain.TagSlug(prefix))+"%")
+		order = "post_count DESC, name ASC"
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count tags: %w", err)
+	}
+
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		query = query.Offset(opts.Offset)
+	}
+
+	if err := query.Select("tags.*, " + count + " AS post_count").Order(order).Find(&tags).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return tags, total, nil
+}
+
+// tagPostCount is an SQL expression counting the posts of the tag in the current row
+// Posts in the trash are not counted
+func tagPostCount(publishedOnly bool) string {
+	count := `(SELECT COUNT(*) FROM post_tags JOIN posts ON posts.id = post_tags.post_id
+		WHERE post_tags.tag_id = tags.id AND posts.deleted_at IS NULL`
+	if publishedOnly {
+		count += " AND posts.status = '" + string(domain.PostStatusPublished) + "'"
+	}
+	return count + ")"
+}
+
+// Resolve returns the tenant's tags with the given names in the order given, creating missing ones
+func (r *tagRepository) Resolve(ctx context.Context, tenantID string, names []string) ([]domain.Tag, error) {
+	var wanted []*domain.Tag
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		tag, err := domain.NewTag(tenantID, name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[tag.Slug] {
+			seen[tag.Slug] = true
+			wanted = append(wanted, tag)
+		}
+	}
+	if len(wanted) == 0 {
+		return []domain.Tag{}, nil
+	}
+
+	slugs := make([]string, 0, len(wanted))
+	for _, tag := range wanted {
+		slugs = append(slugs, tag.Slug)
+	}
+	var existing []*domain.Tag
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND slug IN ?", tenantID, slugs).Find(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to resolve tags: %w", err)
+	}
+	bySlug := make(map[string]*domain.Tag, len(existing))
+	for _, tag := range existing {
+		bySlug[tag.Slug] = tag
+	}
+
+	tags := make([]domain.Tag, 0, len(wanted))
+	for _, tag := range wanted {
+		if stored, ok := bySlug[tag.Slug]; ok {
+			tags = append(tags, *stored)
+			continue
+		}
+		// In a savepoint, so losing the race does not abort a surrounding transaction
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return NewTagRepository(tx).Create(ctx, tag)
+		})
+		if err != nil {
+			if !errors.Is(err, domain.ErrTagAlreadyExists) {
+				return nil, err
+			}
+			// Created by a concurrent request since the lookup
+			stored, err := r.GetBySlug(ctx, tenantID, tag.Slug)
+			if err != nil {
+				return nil, err
+			}
+			tag = stored
+		}
+		tags = append(tags, *tag)
+	}
+	return tags, nil
+}
+
+// Update renames a tag
+func (r *tagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	if err := r.db.WithContext(ctx).Save(tag).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update tag: %w", domain.ErrTagAlreadyExists)
+		}
+		return fmt.Errorf("failed to update tag: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a tag and removes it from all posts
+func (r *tagRepository) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("tenant_id = ? AND id = ?", tenantID, id).Delete(&domain.Tag{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrTagNotFound
+		}
+		return tx.Exec("DELETE FROM post_tags WHERE tag_id = ?", id).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	return nil
+}
+
+// Merge moves the posts of the source tags to the target tag and deletes the source tags
+// Posts that already have the target tag keep a single link to it
+func (r *tagRepository) Merge(ctx context.Context, tenantID string, targetID uuid.UUID, sourceIDs []uuid.UUID) (*domain.Tag, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var found int64
+		ids := append([]uuid.UUID{targetID}, sourceIDs...)
+		if err := tx.Model(&domain.Tag{}).Where("tenant_id = ? AND id IN ?", tenantID, ids).Count(&found).Error; err != nil {
+			return err
+		}
+		if found != int64(len(ids)) {
+			return domain.ErrTagNotFound
+		}
+
+		if err := tx.Exec(`INSERT INTO post_tags (post_id, tag_id)
+			SELECT DISTINCT post_id, ? FROM post_tags
+			WHERE tag_id IN ? AND post_id NOT IN (SELECT post_id FROM post_tags WHERE tag_id = ?)`,
+			targetID, sourceIDs, targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM post_tags WHERE tag_id IN ?", sourceIDs).Error; err != nil {
+			return err
+		}
+		return tx.Where("tenant_id = ? AND id IN ?", tenantID, sourceIDs).Delete(&domain.Tag{}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+	return r.GetByID(ctx, tenantID, targetID)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"gorm.io/gorm"
+)
+
+// tenantRepository implements the TenantRepository interface using GORM
+type tenantRepository struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new tenant repository instance
+func NewTenantRepository(db *gorm.DB) repository.TenantRepository {
+	return &tenantRepository{db: db}
+}
+
+// Create provisions a new tenant
+func (r *tenantRepository) Create(ctx context.Context, tenant *domain.Tenant) error {
+	if err := r.db.WithContext(ctx).Omit("Plan").Create(tenant).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create tenant: %w", domain.ErrTenantAlreadyExists)
+		}
+		return fmt.Errorf("failed to create tenant: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a tenant by its identifier, including its plan
+func (r *tenantRepository) GetByID(ctx context.Context, id string) (*domain.Tenant, error) {
+	var tenant domain.Tenant
+	err := r.db.WithContext(ctx).Preload("Plan").First(&tenant, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("tenant not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+	return &tenant, nil
+}
+
+// Update updates an existing tenant
+func (r *tenantRepository) Update(ctx context.Context, tenant *domain.Tenant) error {
+	if err := r.db.WithContext(ctx).Omit("Plan").Save(tenant).Error; err != nil {
+		return fmt.Errorf("failed to update tenant: %w", err)
+	}
+	return nil
+}
+
+// List retrieves all tenants, including their plans
+func (r *tenantRepository) List(ctx context.Context) ([]*domain.Tenant, error) {
+	var tenants []*domain.Tenant
+	if err := r.db.WithContext(ctx).Preload("Plan").Order("id ASC").Find(&tenants).Error; err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	return tenants, nil
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// trashKinds describes how each kind of trashed content is stored
+var trashKinds = map[domain.TrashEntityType]struct {
+	model    func() interface{}
+	columns  string // Selected as the columns of trashRow
+	exists   error  // Returned when restoring would break a unique index
+	orphaned error  // Returned when restoring an item whose parent is in the trash
+}{
+	domain.TrashEntityPage:     {func() interface{} { return &domain.Page{} }, "id, title, slug, locale, parent_id, deleted_at", domain.ErrPageAlreadyExists, domain.ErrParentPageInTrash},
+	domain.TrashEntityPost:     {func() interface{} { return &domain.Post{} }, "id, title, slug, locale, deleted_at", domain.ErrPostAlreadyExists, nil},
+	domain.TrashEntityCategory: {func() interface{} { return &domain.Category{} }, "id, name AS title, slug, parent_id, deleted_at", domain.ErrCategoryAlreadyExists, domain.ErrParentCategoryInTrash},
+	domain.TrashEntityMenu:     {func() interface{} { return &domain.Menu{} }, "id, name AS title, locale, deleted_at", domain.ErrTranslationExists, nil},
+}
+
+// trashRow is a trashed entity as selected by trashKinds columns
+type trashRow struct {
+	ID        uuid.UUID
+	Title     string
+	Slug      string
+	Locale    string
+	ParentID  *uuid.UUID
+	DeletedAt time.Time
+}
+
+// item converts the row to its trash listing form
+func (row trashRow) item(entityType domain.TrashEntityType) *domain.TrashItem {
+	return &domain.TrashItem{
+		Type:      entityType,
+		ID:        row.ID,
+		Title:     row.Title,
+		Slug:      row.Slug,
+		Locale:    row.Locale,
+		DeletedAt: row.DeletedAt,
+	}
+}
+
+// trashRepository implements the TrashRepository interface using GORM
+type trashRepository struct {
+	db     *gorm.DB
+	origin eventOrigin // Recorded with the domain events of restores
+}
+
+// NewTrashRepository creates a new trash repository instance
+func NewTrashRepository(db *gorm.DB) repository.TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// As returns a trash repository recording the domain events of its restores as made by actorID
+func (r *trashRepository) As(actorID string) repository.TrashRepository {
+	return &trashRepository{db: r.db, origin: eventOrigin{actorID: actorID}}
+}
+
+// List retrieves a tenant's trashed content, most recently deleted first
+func (r *trashRepository) List(ctx context.Context, tenantID string, entityType domain.TrashEntityType) ([]*domain.TrashItem, error) {
+	items := []*domain.TrashItem{}
+	for _, t := range domain.TrashEntityTypes {
+		if entityType != "" && t != entityType {
+			continue
+		}
+		kind := trashKinds[t]
+
+		var rows []trashRow
+		if err := r.db.WithContext(ctx).Unscoped().Model(kind.model()).
+			Select(kind.columns).
+			Where("tenant_id = ? AND deleted_at IS NOT NULL", tenantID).
+			Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to list trashed %ss: %w", t, err)
+		}
+		for _, row := range rows {
+			items = append(items, row.item(t))
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+// Restore brings trashed content back
+// Restored pages and categories move to their parent's current path, which may have changed while they were trashed
+func (r *trashRepository) Restore(ctx context.Context, tenantID string, entityType domain.TrashEntityType, id uuid.UUID) (*domain.TrashItem, error) {
+	var item *domain.TrashItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row, err := trashed(tx, tenantID, entityType, id)
+		if err != nil {
+			return err
+		}
+
+		kind := trashKinds[entityType]
+		updates := map[string]interface{}{"deleted_at": nil}
+		if row.ParentID != nil {
+			var parentSlugs []string
+			if err := tx.Model(kind.model()).Where("id = ?", *row.ParentID).Limit(1).Pluck("slug", &parentSlugs).Error; err != nil {
+				return err
+			}
+			if len(parentSlugs) == 0 {
+				return kind.orphaned
+			}
+			row.Slug = parentSlugs[0] + "/" + path.Base(row.Slug)
+			updates["slug"] = row.Slug
+		}
+
+		if err := tx.Unscoped().Model(kind.model()).Where("id = ?", id).Updates(updates).Error; err != nil {
+			if isUniqueViolation(err) {
+				return kind.exists
+			}
+			return err
+		}
+		item = row.item(entityType)
+		return recordEvents(tx, tenantID, r.origin, domain.ContentRestored{TrashItem: *item})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore %s: %w", entityType, err)
+	}
+	return item, nil
+}
+
+// Purge permanently deletes trashed content
+func (r *trashRepository) Purge(ctx context.Context, tenantID string, entityType domain.TrashEntityType, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := trashed(tx, tenantID, entityType, id); err != nil {
+			return err
+		}
+		return purge(tx, entityType, id)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to purge %s: %w", entityType, err)
+	}
+	return nil
+}
+
+// PurgeExpired permanently deletes content of all tenants trashed before a cutoff
+// Content that cannot be purged yet (pages and categories with nested ones) is skipped
+func (r *trashRepository) PurgeExpired(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+	for _, t := range domain.TrashEntityTypes {
+		// Oldest first, so nested pages and categories (always trashed before their parent) go first
+		var ids []uuid.UUID
+		if err := r.db.WithContext(ctx).Unscoped().Model(trashKinds[t].model()).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Order("deleted_at ASC").
+			Pluck("id", &ids).Error; err != nil {
+			return purged, fmt.Errorf("failed to list expired %ss: %w", t, err)
+		}
+
+		for _, id := range ids {
+			err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				return purge(tx, t, id)
+			})
+			if errors.Is(err, domain.ErrPageHasChildren) || errors.Is(err, domain.ErrCategoryHasChildren) {
+				continue
+			}
+			if err != nil {
+				return purged, fmt.Errorf("failed to purge %s %s: %w", t, id, err)
+			}
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// trashed loads an entity from a tenant's trash
+func trashed(tx *gorm.DB, tenantID string, entityType domain.TrashEntityType, id uuid.UUID) (*trashRow, error) {
+	kind, ok := trashKinds[entityType]
+	if !ok {
+		return nil, domain.ErrNotInTrash
+	}
+
+	var rows []trashRow
+	if err := tx.Unscoped().Model(kind.model()).
+		Select(kind.columns).
+		Where("id = ? AND tenant_id = ? AND deleted_at IS NOT NULL", id, tenantID).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, domain.ErrNotInTrash
+	}
+	return &rows[0], nil
+}
+
+// purge permanently deletes an entity and the rows that only exist for it
+// Remaining translations become originals
+func purge(tx *gorm.DB, entityType domain.TrashEntityType, id uuid.UUID) error {
+	switch entityType {
+	case domain.TrashEntityPage:
+		var children int64
+		if err := tx.Unscoped().Model(&domain.Page{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return err
+		}
+		if children > 0 {
+			return domain.ErrPageHasChildren
+		}
+		if err := tx.Unscoped().Model(&domain.Page{}).Where("translation_of_id = ?", id).
+			Updates(map[string]interface{}{"translation_of_id": nil, "source_hash": ""}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("page_id = ?", id).Delete(&domain.FormSubmission{}).Error; err != nil {
+			return err
+		}
+		if err := purgeEditorialRows(tx, entityType, id); err != nil {
+			return err
+		}
+		if err := purgeRedirects(tx, &domain.Page{}, id, domain.PagePublicPath); err != nil {
+			return err
+		}
+	case domain.TrashEntityPost:
+		if err := tx.Exec("DELETE FROM post_categories WHERE post_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM post_tags WHERE post_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("post_id = ?", id).Delete(&domain.Comment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&domain.Post{}).Where("translation_of_id = ?", id).
+			Updates(map[string]interface{}{"translation_of_id": nil, "source_hash": ""}).Error; err != nil {
+			return err
+		}
+		if err := purgeEditorialRows(tx, entityType, id); err != nil {
+			return err
+		}
+		if err := purgeRedirects(tx, &domain.Post{}, id, domain.PostPublicPath); err != nil {
+			return err
+		}
+	case domain.TrashEntityCategory:
+		var children int64
+		if err := tx.Unscoped().Model(&domain.Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return err
+		}
+		if children > 0 {
+			return domain.ErrCategoryHasChildren
+		}
+		if err := tx.Exec("DELETE FROM post_categories WHERE category_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := purgeRedirects(tx, &domain.Category{}, id, domain.CategoryPublicPath); err != nil {
+			return err
+		}
+	case domain.TrashEntityMenu:
+		if err := tx.Unscoped().Model(&domain.Menu{}).Where("translation_of_id = ?", id).
+			Update("translation_of_id", nil).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Delete(trashKinds[entityType].model(), "id = ?", id).Error
+}
+
+// purgeEditorialRows deletes the edit lock, review history and notifications of a page or post
+func purgeEditorialRows(tx *gorm.DB, entityType domain.TrashEntityType, id uuid.UUID) error {
+	if err := tx.Where("entity_type = ? AND entity_id = ?", entityType, id).Delete(&domain.EditLock{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("entity_type = ? AND entity_id = ?", entityType, id).Delete(&domain.ReviewComment{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("entity_type = ? AND entity_id = ?", entityType, id.String()).Delete(&domain.Notification{}).Error
+}
+
+// purgeRedirects deletes the automatic redirects to the public path of an entity
+// They are kept while another locale variant of the tenant lives at the same path
+func purgeRedirects(tx *gorm.DB, model interface{}, id uuid.UUID, publicPath func(slug string) string) error {
+	var rows []struct {
+		TenantID string
+		Slug     string
+	}
+	if err := tx.Unscoped().Model(model).Select("tenant_id, slug").Where("id = ?", id).Limit(1).Scan(&rows).Error; err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	var others int64
+	if err := tx.Unscoped().Model(model).
+		Where("tenant_id = ? AND slug = ? AND id <> ?", rows[0].TenantID, rows[0].Slug, id).
+		Count(&others).Error; err != nil {
+		return err
+	}
+	if others > 0 {
+		return nil
+	}
+	return tx.Where("tenant_id = ? AND target = ? AND automatic = ?", rows[0].TenantID, publicPath(rows[0].Slug), true).
+		Delete(&domain.Redirect{}).Error
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestTrashRepository_PurgeExpired(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.User{}, &domain.Category{}, &domain.Post{}, &domain.Page{}, &domain.Menu{}, &domain.OutboxEvent{}, &domain.Comment{}, &domain.FormSubmission{},
+		&domain.EditLock{}, &domain.ReviewComment{}, &domain.Notification{}, &domain.Redirect{}))
+
+	ctx := context.Background()
+	category := &domain.Category{Name: "News", Slug: "news"}
+	require.NoError(t, db.Create(category).Error)
+	old := &domain.Post{Title: "Old", Slug: "old", AuthorID: uuid.New(), Categories: []domain.Category{*category}}
+	require.NoError(t, db.Omit("Author").Create(old).Error)
+	recent := &domain.Post{Title: "Recent", Slug: "recent", AuthorID: uuid.New()}
+	require.NoError(t, db.Omit("Author").Create(recent).Error)
+
+	posts := NewPostRepository(db)
+	require.NoError(t, db.Create(&domain.EditLock{EntityType: domain.EditLockPost, EntityID: old.ID, UserID: "editor", ExpiresAt: time.Now().Add(time.Hour)}).Error)
+	require.NoError(t, db.Create(&domain.ReviewComment{EntityType: domain.ReviewEntityPost, EntityID: old.ID, AuthorID: "editor", Body: "Looks good"}).Error)
+	require.NoError(t, db.Create(&domain.Notification{UserID: "editor", Type: "review", Title: "Old", EntityType: "post", EntityID: old.ID.String()}).Error)
+	redirects := NewRedirectRepository(db)
+	require.NoError(t, redirects.AddSlugRedirect(ctx, "", domain.PostPublicPath("older"), domain.PostPublicPath("old")))
+	require.NoError(t, redirects.AddSlugRedirect(ctx, "", domain.PostPublicPath("newer"), domain.PostPublicPath("recent")))
+	require.NoError(t, db.Create(&domain.Redirect{Source: "/promo", Target: domain.PostPublicPath("old"), StatusCode: 302}).Error)
+
+	require.NoError(t, posts.Delete(ctx, old.ID))
+	require.NoError(t, posts.Delete(ctx, recent.ID))
+	require.NoError(t, db.Unscoped().Model(old).Update("deleted_at", time.Now().AddDate(0, 0, -40)).Error)
+
+	trash := NewTrashRepository(db)
+	purged, err := trash.PurgeExpired(ctx, time.Now().AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	items, err := trash.List(ctx, "", domain.TrashEntityPost)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, recent.ID, items[0].ID)
+
+	var links int64
+	require.NoError(t, db.Table("post_categories").Where("post_id = ?", old.ID).Count(&links).Error)
+	assert.Zero(t, links)
+
+	// The lock, review history and notifications of the post go with it, as do the automatic redirects to it
+	for _, model := range []interface{}{&domain.EditLock{}, &domain.ReviewComment{}, &domain.Notification{}} {
+		var count int64
+		require.NoError(t, db.Model(model).Count(&count).Error)
+		assert.Zero(t, count, model)
+	}
+	var sources []string
+	require.NoError(t, db.Model(&domain.Redirect{}).Order("source").Pluck("source", &sources).Error)
+	assert.Equal(t, []string{domain.PostPublicPath("newer"), "/promo"}, sources)
+}
+
+func TestTrashRepository_NestedCategories(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Category{}, &domain.Tag{}, &domain.Post{}, &domain.OutboxEvent{}))
+
+	ctx := context.Background()
+	categories := NewCategoryRepository(db)
+	tech := &domain.Category{Name: "Tech", Slug: "tech"}
+	require.NoError(t, categories.Create(ctx, tech))
+	golang := &domain.Category{Name: "Go", Slug: "tech/go", ParentID: &tech.ID}
+	require.NoError(t, categories.Create(ctx, golang))
+
+	// The subcategory goes first, then its parent
+	assert.ErrorIs(t, categories.Delete(ctx, tech.ID), domain.ErrCategoryHasChildren)
+	require.NoError(t, categories.Delete(ctx, golang.ID))
+	require.NoError(t, categories.Delete(ctx, tech.ID))
+
+	trash := NewTrashRepository(db)
+	_, err = trash.Restore(ctx, "", domain.TrashEntityCategory, golang.ID)
+	assert.ErrorIs(t, err, domain.ErrParentCategoryInTrash)
+	assert.ErrorIs(t, trash.Purge(ctx, "", domain.TrashEntityCategory, tech.ID), domain.ErrCategoryHasChildren)
+
+	// A parent renamed while the subcategory was trashed takes it along on restore
+	_, err = trash.Restore(ctx, "", domain.TrashEntityCategory, tech.ID)
+	require.NoError(t, err)
+	require.NoError(t, db.Model(tech).Update("slug", "technology").Error)
+	item, err := trash.Restore(ctx, "", domain.TrashEntityCategory, golang.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "technology/go", item.Slug)
+}
+
+func TestTrashRepository_PurgeKeepsRedirectsOfLocaleVariants(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Page{}, &domain.OutboxEvent{}, &domain.FormSubmission{},
+		&domain.EditLock{}, &domain.ReviewComment{}, &domain.Notification{}, &domain.Redirect{}))
+
+	ctx := context.Background()
+	english := &domain.Page{Title: "About", Slug: "about", Locale: "en"}
+	german := &domain.Page{Title: "Über uns", Slug: "about", Locale: "de"}
+	require.NoError(t, db.Create(english).Error)
+	require.NoError(t, db.Create(german).Error)
+	require.NoError(t, NewRedirectRepository(db).AddSlugRedirect(ctx, "", "/about-us", "/about"))
+
+	// The German page still lives at the path, so the redirect stays
+	require.NoError(t, NewPageRepository(db).Delete(ctx, english.ID))
+	require.NoError(t, NewTrashRepository(db).Purge(ctx, "", domain.TrashEntityPage, english.ID))
+	var redirects int64
+	require.NoError(t, db.Model(&domain.Redirect{}).Count(&redirects).Error)
+	assert.Equal(t, int64(1), redirects)
+
+	require.NoError(t, NewPageRepository(db).Delete(ctx, german.ID))
+	require.NoError(t, NewTrashRepository(db).Purge(ctx, "", domain.TrashEntityPage, german.ID))
+	require.NoError(t, db.Model(&domain.Redirect{}).Count(&redirects).Error)
+	assert.Zero(t, redirects)
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// usageRepository implements the UsageRepository interface using GORM
+type usageRepository struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a new usage repository instance
+func NewUsageRepository(db *gorm.DB) repository.UsageRepository {
+	return &usageRepository{db: db}
+}
+
+// Increment adds delta to the tenant's running total for a metric
+// Positive deltas are also added to the current month's counter
+func (r *usageRepository) Increment(ctx context.Context, tenantID string, metric domain.UsageMetric, delta int64) error {
+	if delta == 0 {
+		return nil
+	}
+
+	periods := []string{""}
+	if delta > 0 {
+		periods = append(periods, domain.UsagePeriod(time.Now()))
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, period := range periods {
+			if err := incrementCounter(tx, tenantID, metric, period, delta, time.Now()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Reserve increments like Increment, but only if the counter the limit applies to stays within limit
+// The limit is enforced by a conditional UPDATE, so concurrent reservations never overshoot
+func (r *usageRepository) Reserve(ctx context.Context, tenantID string, metric domain.UsageMetric, delta, limit int64) error {
+	if limit <= 0 || delta <= 0 {
+		return r.Increment(ctx, tenantID, metric, delta)
+	}
+
+	now := time.Now()
+	limitPeriod := metric.LimitPeriod(now)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The row must exist for the conditional update to match it
+		counter := &domain.UsageCounter{TenantID: tenantID, Metric: metric, Period: limitPeriod}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(counter).Error; err != nil {
+			return fmt.Errorf("failed to reserve usage %s: %w", metric, err)
+		}
+		result := tx.Model(&domain.UsageCounter{}).
+			Where("tenant_id = ? AND metric = ? AND period = ? AND value + ? <= ?", tenantID, metric, limitPeriod, delta, limit).
+			Updates(map[string]interface{}{
+				"value":      gorm.Expr("value + ?", delta),
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to reserve usage %s: %w", metric, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			used, err := NewUsageRepository(tx).Get(ctx, tenantID, metric, limitPeriod)
+			if err != nil {
+				return err
+			}
+			return &domain.QuotaExceededError{Metric: metric, Limit: limit, Used: used}
+		}
+
+		for _, period := range []string{"", domain.UsagePeriod(now)} {
+			if period == limitPeriod {
+				continue
+			}
+			if err := incrementCounter(tx, tenantID, metric, period, delta, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Release undoes a reservation made this month, subtracting delta from the running total and the month's counter
+func (r *usageRepository) Release(ctx context.Context, tenantID string, metric domain.UsageMetric, delta int64) error {
+	if delta <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, period := range []string{"", domain.UsagePeriod(now)} {
+			if err := incrementCounter(tx, tenantID, metric, period, -delta, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// incrementCounter adds delta to one counter, creating it if needed
+// Upserts so concurrent increments never lose updates
+func incrementCounter(tx *gorm.DB, tenantID string, metric domain.UsageMetric, period string, delta int64, now time.Time) error {
+	counter := &domain.UsageCounter{TenantID: tenantID, Metric: metric, Period: period, Value: delta}
+	err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}, {Name: "metric"}, {Name: "period"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"value":      gorm.Expr("usage_counters.value + ?", delta),
+			"updated_at": now,
+		}),
+	}).Create(counter).Error
+	if err != nil {
+		return fmt.Errorf("failed to increment usage %s: %w", metric, err)
+	}
+	return nil
+}
+
+// Get returns a single counter value (0 if it was never incremented)
+// An empty period returns the running total
+func (r *usageRepository) Get(ctx context.Context, tenantID string, metric domain.UsageMetric, period string) (int64, error) {
+	var counter domain.UsageCounter
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND metric = ? AND period = ?", tenantID, metric, period).
+		First(&counter).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get usage %s: %w", metric, err)
+	}
+	return counter.Value, nil
+}
+
+// ListByPeriod returns all counters for a period across tenants
+// An empty period returns the running totals
+func (r *usageRepository) ListByPeriod(ctx context.Context, period string) ([]*domain.UsageCounter, error) {
+	var counters []*domain.UsageCounter
+	if err := r.db.WithContext(ctx).Where("period = ?", period).Order("tenant_id ASC, metric ASC").Find(&counters).Error; err != nil {
+		return nil, fmt.Errorf("failed to list usage: %w", err)
+	}
+	return counters, nil
+}
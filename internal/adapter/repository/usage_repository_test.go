@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gohac/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestUsageRepository_ReserveAndRelease(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.UsageCounter{}))
+
+	ctx := context.Background()
+	usage := NewUsageRepository(db)
+	month := domain.UsagePeriod(time.Now())
+
+	require.NoError(t, usage.Reserve(ctx, "acme", domain.UsageMetricStorageBytes, 600, 1000))
+
+	// A reservation past the limit changes nothing
+	err = usage.Reserve(ctx, "acme", domain.UsageMetricStorageBytes, 500, 1000)
+	var quotaErr *domain.QuotaExceededError
+	require.True(t, errors.As(err, &quotaErr))
+	assert.Equal(t, int64(600), quotaErr.Used)
+	used, err := usage.Get(ctx, "acme", domain.UsageMetricStorageBytes, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), used)
+
+	require.NoError(t, usage.Reserve(ctx, "acme", domain.UsageMetricStorageBytes, 400, 1000))
+	require.NoError(t, usage.Release(ctx, "acme", domain.UsageMetricStorageBytes, 400))
+	used, err = usage.Get(ctx, "acme", domain.UsageMetricStorageBytes, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), used)
+	used, err = usage.Get(ctx, "acme", domain.UsageMetricStorageBytes, month)
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), used)
+
+	// Monthly limits apply to the month's counter, and the running total grows as well
+	require.NoError(t, usage.Reserve(ctx, "acme", domain.UsageMetricUploadBytes, 100, 150))
+	err = usage.Reserve(ctx, "acme", domain.UsageMetricUploadBytes, 100, 150)
+	require.True(t, errors.As(err, &quotaErr))
+	used, err = usage.Get(ctx, "acme", domain.UsageMetricUploadBytes, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), used)
+
+	// Without a limit a reservation is a plain increment
+	require.NoError(t, usage.Reserve(ctx, "acme", domain.UsageMetricPages, 5, 0))
+	used, err = usage.Get(ctx, "acme", domain.UsageMetricPages, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), used)
+}
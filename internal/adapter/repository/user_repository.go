@@ -24,6 +24,9 @@ func NewUserRepository(db *gorm.DB) repository.UserRepository {
 // Create creates a new user
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to create user: %w", domain.ErrUserAlreadyExists)
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 	return nil
@@ -59,10 +62,38 @@ func (r *userRepository) GetByID(ctx context.Context, id interface{}) (*domain.U
 	return &user, nil
 }
 
-// GetByEmail retrieves a user by email
-func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+// ListByIDs retrieves a tenant's users with the given IDs, in no particular order
+func (r *userRepository) ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.User, error) {
+	var users []*domain.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id IN ?", tenantID, ids).
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
+// ListByRoles retrieves a tenant's users that have one of the roles
+func (r *userRepository) ListByRoles(ctx context.Context, tenantID string, roles []domain.UserRole) ([]*domain.User, error) {
+	var users []*domain.User
+	if len(roles) == 0 {
+		return users, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND role IN ?", tenantID, roles).
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
+// GetByEmail retrieves a user by email within a tenant
+func (r *userRepository) GetByEmail(ctx context.Context, tenantID, email string) (*domain.User, error) {
 	var user domain.User
-	err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error
+	err := r.db.WithContext(ctx).First(&user, "tenant_id = ? AND email = ?", tenantID, email).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("user not found: %w", err)
@@ -75,6 +106,9 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 // Update updates an existing user
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("failed to update user: %w", domain.ErrUserAlreadyExists)
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 	return nil
@@ -104,24 +138,14 @@ func (r *userRepository) Delete(ctx context.Context, id interface{}) error {
 	return nil
 }
 
-// List retrieves a list of users with pagination
-func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*domain.User, int64, error) {
+// List retrieves a page of a tenant's users matching a list query
+func (r *userRepository) List(ctx context.Context, tenantID string, q repository.ListQuery) ([]*domain.User, repository.ListPage, error) {
 	var users []*domain.User
-	var total int64
+	query := r.db.WithContext(ctx).Model(&domain.User{}).Where("tenant_id = ?", tenantID)
 
-	// Count total records
-	if err := r.db.WithContext(ctx).Model(&domain.User{}).Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count users: %w", err)
-	}
-
-	// Fetch paginated records
-	if err := r.db.WithContext(ctx).
-		Limit(limit).
-		Offset(offset).
-		Order("created_at DESC").
-		Find(&users).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	page, err := listPage(query, repository.UserListSpec, q, &users)
+	if err != nil {
+		return nil, page, fmt.Errorf("failed to list users: %w", err)
 	}
-
-	return users, total, nil
+	return users, page, nil
 }
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gohac/internal/core/domain"
+	"gohac/internal/core/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// webhookRepository implements the WebhookRepository interface using GORM
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository instance
+func NewWebhookRepository(db *gorm.DB) repository.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// Create stores a new webhook
+func (r *webhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	if err := r.db.WithContext(ctx).Create(webhook).Error; err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves one of a tenant's webhooks
+func (r *webhookRepository) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	if err := r.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", id, tenantID).First(&webhook).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// List retrieves a tenant's webhooks
+func (r *webhookRepository) List(ctx context.Context, tenantID string) ([]*domain.Webhook, error) {
+	webhooks := []*domain.Webhook{}
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Order("created_at ASC").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// ListActive retrieves a tenant's active webhooks
+func (r *webhookRepository) ListActive(ctx context.Context, tenantID string) ([]*domain.Webhook, error) {
+	var webhooks []*domain.Webhook
+	if err := r.db.WithContext(ctx).Where("tenant_id = ? AND active = ?", tenantID, true).Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to list active webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// Update saves changes to a webhook
+func (r *webhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	if err := r.db.WithContext(ctx).Save(webhook).Error; err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a webhook and its delivery log
+func (r *webhookRepository) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND tenant_id = ?", id, tenantID).Delete(&domain.Webhook{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete webhook: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrWebhookNotFound
+		}
+		if err := tx.Where("webhook_id = ?", id).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateDeliveries queues deliveries
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(deliveries).Error; err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveredWebhookIDs retrieves the IDs of a tenant's webhooks that already have a delivery of an event
+func (r *webhookRepository) ListDeliveredWebhookIDs(ctx context.Context, tenantID, eventID string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&domain.WebhookDelivery{}).
+		Where("tenant_id = ? AND event_id = ?", tenantID, eventID).
+		Distinct().Pluck("webhook_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list deliveries of event %s: %w", eventID, err)
+	}
+	return ids, nil
+}
+
+// GetDelivery retrieves one of a webhook's deliveries
+func (r *webhookRepository) GetDelivery(ctx context.Context, tenantID string, webhookID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND webhook_id = ? AND tenant_id = ?", id, webhookID, tenantID).
+		First(&delivery).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries retrieves a webhook's deliveries, newest first, optionally by status
+func (r *webhookRepository) ListDeliveries(ctx context.Context, tenantID string, webhookID uuid.UUID, status domain.WebhookDeliveryStatus, limit, offset int) ([]*domain.WebhookDelivery, int64, error) {
+	var deliveries []*domain.WebhookDelivery
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.WebhookDelivery{}).
+		Where("tenant_id = ? AND webhook_id = ?", tenantID, webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if err := query.Order("created_at DESC").Find(&deliveries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, total, nil
+}
+
+// ClaimDue takes up to limit pending deliveries of all tenants that are due
+// Each delivery is claimed by moving its next attempt past the lease, conditional on the
+// time read, so concurrent workers on other instances never take the same delivery.
+// A worker that dies mid-delivery leaves the delivery to be retried once the lease ends.
+func (r *webhookRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	var due []*domain.WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&due).Error; err != nil {
+		return nil, fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+
+	leaseEnd := now.Add(lease)
+	claimed := make([]*domain.WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		result := r.db.WithContext(ctx).Model(&domain.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, domain.WebhookDeliveryPending, delivery.NextAttemptAt).
+			Update("next_attempt_at", leaseEnd)
+		if result.Error != nil {
+			return claimed, fmt.Errorf("failed to claim webhook delivery: %w", result.Error)
+		}
+		if result.RowsAffected == 1 {
+			delivery.NextAttemptAt = &leaseEnd
+			claimed = append(claimed, delivery)
+		}
+	}
+	return claimed, nil
+}
+
+// SaveDelivery saves the outcome of a delivery attempt
+func (r *webhookRepository) SaveDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if err := r.db.WithContext(ctx).Save(delivery).Error; err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Package spam scores reader comments before they reach the moderation queue
+// The HTTP handlers classify through Default; deployments that use an external
+// service such as Akismet plug in their own Classifier with SetClassifier.
+package spam
+
+import (
+	"context"
+	"sync"
+
+	"gohac/internal/core/domain"
+)
+
+// Verdict is a classifier's opinion of a comment
+type Verdict struct {
+	Spam    bool
+	Score   float64  // Higher is more likely spam; the scale is up to the classifier
+	Reasons []string // Shown to moderators
+}
+
+// Classifier decides whether a submitted comment is spam
+type Classifier interface {
+	Classify(ctx context.Context, comment *domain.Comment) (Verdict, error)
+}
+
+var (
+	defaultMu         sync.RWMutex
+	defaultClassifier Classifier = NewHeuristicClassifier()
+)
+
+// Default returns the classifier used by the HTTP handlers
+func Default() Classifier {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultClassifier
+}
+
+// SetClassifier replaces the classifier used by the HTTP handlers and returns the previous one
+func SetClassifier(c Classifier) Classifier {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	previous := defaultClassifier
+	defaultClassifier = c
+	return previous
+}
@@ -0,0 +1,111 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gohac/internal/core/domain"
+)
+
+var (
+	linkPattern   = regexp.MustCompile(`(?i)https?://|www\.`)
+	markupPattern = regexp.MustCompile(`(?i)\[url[=\]]|<a\s+href`)
+)
+
+// DefaultBlockedWords are phrases that rarely appear in genuine blog comments
+var DefaultBlockedWords = []string{
+	"viagra", "cialis", "casino", "payday loan", "crypto signals", "buy followers",
+	"seo services", "work from home", "click here", "free money",
+}
+
+// HeuristicClassifier scores comments with a few cheap rules
+// Each rule adds to the score; a comment is spam once the score reaches Threshold
+type HeuristicClassifier struct {
+	Threshold    float64
+	MaxLinks     int // Links allowed before each further link counts against the comment
+	BlockedWords []string
+}
+
+// NewHeuristicClassifier creates a classifier with the default rules
+func NewHeuristicClassifier() *HeuristicClassifier {
+	return &HeuristicClassifier{
+		Threshold:    1.0,
+		MaxLinks:     2,
+		BlockedWords: DefaultBlockedWords,
+	}
+}
+
+// Classify scores the comment
+func (h *HeuristicClassifier) Classify(_ context.Context, comment *domain.Comment) (Verdict, error) {
+	var v Verdict
+	add := func(score float64, reason string) {
+		v.Score += score
+		v.Reasons = append(v.Reasons, reason)
+	}
+
+	body := comment.Body
+	lower := strings.ToLower(body)
+
+	links := len(linkPattern.FindAllStringIndex(body, -1))
+	if links > h.MaxLinks {
+		add(0.5*float64(links-h.MaxLinks), fmt.Sprintf("%d links", links))
+	}
+	if links > 0 && len([]rune(body)) < 40 {
+		add(0.5, "short comment with a link")
+	}
+	if markupPattern.MatchString(body) {
+		add(0.6, "link markup")
+	}
+	if linkPattern.MatchString(comment.AuthorName) {
+		add(1.0, "link in name")
+	}
+	for _, word := range h.BlockedWords {
+		if strings.Contains(lower, word) || strings.Contains(strings.ToLower(comment.AuthorName), word) {
+			add(0.5, fmt.Sprintf("blocked word %q", word))
+		}
+	}
+	if shouting(body) {
+		add(0.3, "mostly capitals")
+	}
+	if repeatedRun(body) >= 10 {
+		add(0.3, "repeated characters")
+	}
+
+	v.Spam = v.Score >= h.Threshold
+	return v, nil
+}
+
+// shouting reports whether a comment with a fair amount of text is mostly capital letters
+func shouting(s string) bool {
+	letters, upper := 0, 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= 20 && upper*10 > letters*7
+}
+
+// repeatedRun returns the length of the longest run of one repeated character
+func repeatedRun(s string) int {
+	longest, run := 0, 0
+	var prev rune
+	for i, r := range s {
+		if i > 0 && r == prev {
+			run++
+		} else {
+			run = 1
+		}
+		prev = r
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
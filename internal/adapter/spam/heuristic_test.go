@@ -0,0 +1,67 @@
+package spam
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gohac/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeuristicClassifier_Classify(t *testing.T) {
+	classifier := NewHeuristicClassifier()
+
+	tests := []struct {
+		name    string
+		comment domain.Comment
+		spam    bool
+		reason  string
+	}{
+		{
+			name:    "genuine comment",
+			comment: domain.Comment{AuthorName: "Ann", Body: "Thanks, the section on caching saved me a day. See also https://go.dev/blog"},
+		},
+		{
+			name:    "link in name",
+			comment: domain.Comment{AuthorName: "www.cheap-deals.example", Body: "Great article, very informative."},
+			spam:    true,
+			reason:  "link in name",
+		},
+		{
+			name:    "link farm",
+			comment: domain.Comment{AuthorName: "Sam", Body: "http://a.example http://b.example http://c.example http://d.example"},
+			spam:    true,
+			reason:  "4 links",
+		},
+		{
+			name:    "blocked words and markup",
+			comment: domain.Comment{AuthorName: "Sam", Body: "Best casino bonuses [url=http://x.example]here[/url]"},
+			spam:    true,
+			reason:  `blocked word "casino"`,
+		},
+		{
+			name:    "shouting alone is not enough",
+			comment: domain.Comment{AuthorName: "Ann", Body: "THIS IS THE BEST POST I HAVE READ ALL YEAR"},
+			reason:  "mostly capitals",
+		},
+		{
+			name:    "repeated characters",
+			comment: domain.Comment{AuthorName: "Ann", Body: "wow" + strings.Repeat("!", 12)},
+			reason:  "repeated characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, err := classifier.Classify(context.Background(), &tt.comment)
+			require.NoError(t, err)
+			assert.Equal(t, tt.spam, verdict.Spam, "score %.1f, reasons %v", verdict.Score, verdict.Reasons)
+			if tt.reason != "" {
+				assert.Contains(t, verdict.Reasons, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Package webhook delivers queued webhook deliveries to their endpoints
+// Deliveries are queued in the webhook_deliveries table by the outbox subscriber
+// (eventbus.RegisterWebhooks) and survive restarts; any number of instances can run
+// a Dispatcher against the same database because each delivery is claimed before it is sent.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// batchSize is how many due deliveries one poll claims
+	batchSize = 20
+	// claimLease is how long a claimed delivery is hidden from other workers
+	claimLease = time.Minute
+)
+
+// errInternalAddress is returned when a webhook host resolves to an internal address
+var errInternalAddress = errors.New("webhook host resolves to a loopback, private or link-local address")
+
+// Dispatcher sends due webhook deliveries and records the outcome
+type Dispatcher struct {
+	db       *gorm.DB
+	client   *http.Client
+	now      func() time.Time
+	internal func(net.IP) bool // Addresses deliveries must not connect to
+}
+
+// NewDispatcher creates a dispatcher that sends deliveries with a 10 second timeout
+// Deliveries never connect to internal addresses, go through no proxy and do not follow redirects,
+// so a webhook cannot be used to reach the server's own network
+func NewDispatcher(db *gorm.DB) *Dispatcher {
+	d := &Dispatcher{
+		db:       db,
+		now:      time.Now,
+		internal: domain.IsInternalAddress,
+	}
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: d.checkAddress}
+	d.client = &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		// A redirect is reported as the response of the attempt
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return d
+}
+
+// checkAddress refuses connections to internal addresses
+// It runs after DNS resolution, so hostnames that resolve to internal addresses,
+// now or after the webhook was saved, are refused as well
+func (d *Dispatcher) checkAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || d.internal(ip) {
+		return errInternalAddress
+	}
+	return nil
+}
+
+// CheckHost resolves the host of a webhook URL and refuses it if any of its addresses is internal
+// Hosts that do not resolve yet are accepted; the dispatcher checks every address it connects to
+func CheckHost(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", domain.ErrInvalidWebhook)
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if domain.IsInternalAddress(addr.IP) {
+			return fmt.Errorf("%w: url must not point to a loopback, private or link-local address", domain.ErrInvalidWebhook)
+		}
+	}
+	return nil
+}
+
+// Run polls for due deliveries until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DeliverDue(ctx); err != nil {
+			log.Printf("Error delivering webhooks: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue sends the deliveries that are due and returns how many were attempted
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	repo := repository.NewWebhookRepository(d.db)
+	attempted := 0
+	for {
+		deliveries, err := repo.ClaimDue(ctx, d.now(), batchSize, claimLease)
+		if err != nil {
+			return attempted, err
+		}
+		for _, delivery := range deliveries {
+			d.deliver(ctx, delivery)
+			attempted++
+		}
+		if len(deliveries) < batchSize {
+			return attempted, nil
+		}
+	}
+}
+
+// deliver sends one delivery and saves the outcome
+// Deliveries of deleted or deactivated webhooks, or of webhooks whose URL is no longer
+// allowed, fail without being sent
+func (d *Dispatcher) deliver(ctx context.Context, delivery *domain.WebhookDelivery) {
+	repo := repository.NewWebhookRepository(d.db)
+
+	hook, err := repo.GetByID(ctx, delivery.TenantID, delivery.WebhookID)
+	switch {
+	case err == domain.ErrWebhookNotFound:
+		delivery.Abandon("webhook was deleted")
+	case err != nil:
+		// Left claimed; it is tried again when the lease runs out
+		log.Printf("Error loading webhook for delivery %s: %v", delivery.ID, err)
+		return
+	case !hook.Active:
+		delivery.Abandon("webhook is inactive")
+	default:
+		if err := domain.ValidateWebhookURL(hook.URL, d.internal); err != nil {
+			delivery.Abandon(err.Error())
+			break
+		}
+		code, err := d.send(ctx, hook, delivery)
+		delivery.RecordAttempt(d.now(), code, err)
+	}
+
+	if err := repo.SaveDelivery(ctx, delivery); err != nil {
+		log.Printf("Error saving webhook delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// send posts the delivery payload, signed with the webhook's secret
+// Only the status code is kept; response bodies could expose whatever the endpoint returns
+func (d *Dispatcher) send(ctx context.Context, hook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := d.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Gohac-Webhooks/1.0")
+	req.Header.Set(domain.WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(domain.WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(domain.WebhookTimestampHeader, fmt.Sprint(timestamp))
+	req.Header.Set(domain.WebhookSignatureHeader, domain.SignWebhookPayload(hook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,187 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"gohac/internal/adapter/repository"
+	"gohac/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDispatcher_DeliverDue(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Webhook{}, &domain.WebhookDelivery{}))
+
+	// The receiver fails the first request, then accepts
+	var mu sync.Mutex
+	var requests []*http.Request
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		if len(requests) == 1 {
+			http.Error(w, "build queue full", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := repository.NewWebhookRepository(db)
+	hook := &domain.Webhook{TenantID: "acme", Name: "Rebuild", URL: server.URL, Secret: "s3cret", Active: true}
+	require.NoError(t, repo.Create(ctx, hook))
+
+	now := time.Now()
+	delivery := &domain.WebhookDelivery{
+		TenantID:      "acme",
+		WebhookID:     hook.ID,
+		EventID:       "evt-1",
+		EventType:     domain.EventContentPublished,
+		Payload:       []byte(`{"type":"content.published"}`),
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	}
+	require.NoError(t, repo.CreateDeliveries(ctx, []*domain.WebhookDelivery{delivery}))
+
+	clock := now
+	dispatcher := NewDispatcher(db)
+	dispatcher.now = func() time.Time { return clock }
+	dispatcher.internal = func(net.IP) bool { return false } // The receiver listens on loopback
+
+	attempted, err := dispatcher.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	saved, err := repo.GetDelivery(ctx, "acme", hook.ID, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliveryPending, saved.Status)
+	assert.Equal(t, 503, saved.ResponseCode)
+	assert.Equal(t, "unexpected status 503", saved.Error)
+
+	// Not due again until the backoff has passed
+	attempted, err = dispatcher.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+
+	clock = now.Add(domain.WebhookRetryDelay(1))
+	attempted, err = dispatcher.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	saved, err = repo.GetDelivery(ctx, "acme", hook.ID, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliverySucceeded, saved.Status)
+	assert.Equal(t, 2, saved.Attempts)
+	assert.Equal(t, 204, saved.ResponseCode)
+
+	// Requests are signed over the timestamp and body
+	require.Len(t, requests, 2)
+	last := requests[1]
+	assert.Equal(t, string(domain.EventContentPublished), last.Header.Get(domain.WebhookEventHeader))
+	assert.Equal(t, delivery.ID.String(), last.Header.Get(domain.WebhookDeliveryHeader))
+	timestamp, err := strconv.ParseInt(last.Header.Get(domain.WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SignWebhookPayload("s3cret", timestamp, bodies[1]), last.Header.Get(domain.WebhookSignatureHeader))
+}
+
+func TestDispatcher_InactiveWebhook(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Webhook{}, &domain.WebhookDelivery{}))
+
+	ctx := context.Background()
+	repo := repository.NewWebhookRepository(db)
+	hook := &domain.Webhook{Name: "Slack", URL: "http://127.0.0.1:1", Secret: "x", Active: true}
+	require.NoError(t, repo.Create(ctx, hook))
+	hook.Active = false
+	require.NoError(t, repo.Update(ctx, hook))
+
+	now := time.Now()
+	delivery := &domain.WebhookDelivery{WebhookID: hook.ID, EventID: "evt", EventType: domain.EventMediaUploaded, Status: domain.WebhookDeliveryPending, NextAttemptAt: &now}
+	require.NoError(t, repo.CreateDeliveries(ctx, []*domain.WebhookDelivery{delivery}))
+
+	_, err = NewDispatcher(db).DeliverDue(ctx)
+	require.NoError(t, err)
+
+	saved, err := repo.GetDelivery(ctx, "", hook.ID, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliveryFailed, saved.Status)
+	assert.Equal(t, 0, saved.Attempts)
+	assert.Equal(t, "webhook is inactive", saved.Error)
+}
+
+func TestDispatcher_RefusesInternalAddresses(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.Webhook{}, &domain.WebhookDelivery{}))
+
+	// The receiver redirects every request, which is never followed
+	var mu sync.Mutex
+	received := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		received++
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := repository.NewWebhookRepository(db)
+	queue := func(hook *domain.Webhook) *domain.WebhookDelivery {
+		now := time.Now()
+		delivery := &domain.WebhookDelivery{WebhookID: hook.ID, EventID: "evt", EventType: domain.EventMediaUploaded, Status: domain.WebhookDeliveryPending, NextAttemptAt: &now}
+		require.NoError(t, repo.CreateDeliveries(ctx, []*domain.WebhookDelivery{delivery}))
+		return delivery
+	}
+
+	// Webhooks saved before the address rules are checked again when delivered
+	hook := &domain.Webhook{Name: "Internal", URL: server.URL, Secret: "x", Active: true}
+	require.NoError(t, repo.Create(ctx, hook))
+	delivery := queue(hook)
+	_, err = NewDispatcher(db).DeliverDue(ctx)
+	require.NoError(t, err)
+	saved, err := repo.GetDelivery(ctx, "", hook.ID, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliveryFailed, saved.Status)
+	assert.Equal(t, 0, saved.Attempts)
+	assert.Contains(t, saved.Error, "loopback, private or link-local")
+	assert.Equal(t, 0, received)
+
+	// Redirects are reported instead of followed
+	delivery = queue(hook)
+	dispatcher := NewDispatcher(db)
+	dispatcher.internal = func(net.IP) bool { return false }
+	_, err = dispatcher.DeliverDue(ctx)
+	require.NoError(t, err)
+	saved, err = repo.GetDelivery(ctx, "", hook.ID, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliveryPending, saved.Status)
+	assert.Equal(t, http.StatusFound, saved.ResponseCode)
+	assert.Equal(t, 1, received)
+
+	// Hostnames are checked once resolved, on every connection
+	check := NewDispatcher(db).checkAddress
+	assert.True(t, errors.Is(check("tcp", "127.0.0.1:80", nil), errInternalAddress))
+	assert.True(t, errors.Is(check("tcp", "[::1]:443", nil), errInternalAddress))
+	assert.True(t, errors.Is(check("tcp", "[fe80::1%eth0]:443", nil), errInternalAddress))
+	assert.NoError(t, check("tcp", "93.184.216.34:443", nil))
+}
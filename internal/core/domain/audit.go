@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Audit actions
+const (
+	AuditActionImpersonateStart = "user.impersonate.start"
+	AuditActionImpersonateStop  = "user.impersonate.stop"
+	AuditActionImpersonateWrite = "user.impersonate.write" // A change made with an impersonation token
+	AuditActionSiteExport       = "site.export"
+	AuditActionSiteImport       = "site.import"
+	AuditActionTenantClone      = "tenant.clone"
+	AuditActionEditLockTakeOver = "lock.take_over"
+)
+
+// AuditLog records who did what, including actions performed while impersonating
+type AuditLog struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID       string         `gorm:"index;not null" json:"tenant_id"`                         // Tenant the action applies to
+	ActorID        string         `gorm:"type:varchar(36);index" json:"actor_id"`                  // User who performed the action
+	ImpersonatorID string         `gorm:"type:varchar(36);index" json:"impersonator_id,omitempty"` // Super-admin behind the actor, if impersonating
+	Action         string         `gorm:"type:varchar(100);not null;index" json:"action"`
+	EntityType     string         `gorm:"type:varchar(50)" json:"entity_type,omitempty"`
+	EntityID       string         `gorm:"type:varchar(36)" json:"entity_id,omitempty"`
+	Details        datatypes.JSON `gorm:"type:jsonb" json:"details,omitempty"`
+	IPAddress      string         `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	CreatedAt      time.Time      `gorm:"index" json:"created_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating an audit log entry
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
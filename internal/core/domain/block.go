@@ -1,13 +1,61 @@
 package domain
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // Block represents a single content block within a page
 // This follows the Block Protocol pattern for flexible content composition
 type Block struct {
-	ID   string          `json:"id"`   // UUID string
-	Type string          `json:"type"` // e.g., "hero", "text", "image", "gallery"
-	Data json.RawMessage `json:"data"` // Flexible JSON data specific to block type
+	ID            string          `json:"id"`                        // UUID string
+	Type          string          `json:"type"`                      // e.g., "hero", "text", "image", "gallery"
+	Data          json.RawMessage `json:"data"`                      // Flexible JSON data specific to block type
+	GlobalBlockID string          `json:"global_block_id,omitempty"` // Set on public responses when the block was resolved from a ref block
+}
+
+// ValidateBlocks checks that every block has a unique ID, a type and data, and that form blocks define valid fields
+// Pages, posts and blocks fields of collection items are validated the same way
+func ValidateBlocks(blocks []Block) error {
+	seen := make(map[string]bool, len(blocks))
+	for _, block := range blocks {
+		switch {
+		case block.ID == "":
+			return ErrBlockMissingID
+		case block.Type == "":
+			return ErrBlockMissingType
+		case len(block.Data) == 0:
+			return ErrBlockMissingData
+		case seen[block.ID]:
+			return fmt.Errorf("duplicate block id %q", block.ID)
+		}
+		seen[block.ID] = true
+		if block.Type == string(BlockTypeForm) {
+			if _, err := ParseFormBlock(block.Data); err != nil {
+				return fmt.Errorf("form block %q: %w", block.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseBlockContent decodes content that holds a JSON blocks array
+// Returns false for Markdown, HTML and other legacy content
+func ParseBlockContent(content string) ([]Block, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(content), "[") {
+		return nil, false
+	}
+	var blocks []Block
+	if err := json.Unmarshal([]byte(content), &blocks); err != nil {
+		return nil, false
+	}
+	for _, block := range blocks {
+		if block.Type == "" {
+			return nil, false
+		}
+	}
+	return blocks, true
 }
 
 // BlockType defines common block types
@@ -27,6 +75,8 @@ const (
 	BlockTypeTestimonial BlockType = "testimonial"
 	BlockTypeCTA         BlockType = "cta"
 	BlockTypeMenu        BlockType = "menu"
+	BlockTypeRef         BlockType = "ref"  // Placeholder for a GlobalBlock, resolved at read time
+	BlockTypeForm        BlockType = "form" // Fields of a form visitors submit to the site
 )
 
 // BlockData represents the structure for common block data types
@@ -151,3 +201,9 @@ type MenuBlockData struct {
 	MenuID string `json:"menu_id"`         // UUID of the menu to display
 	Style  string `json:"style,omitempty"` // horizontal, vertical, dropdown
 }
+
+// RefBlockData represents data for a ref block
+// GlobalBlockID references a GlobalBlock entity by UUID
+type RefBlockData struct {
+	GlobalBlockID string `json:"global_block_id"` // UUID of the global block to display
+}
@@ -0,0 +1,48 @@
+package domain
+
+// BulkMaxItems caps the pages or posts a single bulk call may change
+const BulkMaxItems = 500
+
+// BulkAction is a change applied to many pages or posts at once
+type BulkAction string
+
+const (
+	BulkActionPublish     BulkAction = "publish"
+	BulkActionUnpublish   BulkAction = "unpublish" // Back to draft
+	BulkActionArchive     BulkAction = "archive"
+	BulkActionDelete      BulkAction = "delete" // Moves to the trash
+	BulkActionSetCategory BulkAction = "set_category"
+	BulkActionSetAuthor   BulkAction = "set_author"
+)
+
+// IsValid checks if the bulk action is known
+func (a BulkAction) IsValid() bool {
+	switch a {
+	case BulkActionPublish, BulkActionUnpublish, BulkActionArchive, BulkActionDelete, BulkActionSetCategory, BulkActionSetAuthor:
+		return true
+	}
+	return false
+}
+
+// AppliesToPages reports whether pages support the action; categories and authors belong to posts only
+func (a BulkAction) AppliesToPages() bool {
+	return a != BulkActionSetCategory && a != BulkActionSetAuthor
+}
+
+// TargetStatus returns the workflow status the action moves content to, or "" if it keeps the status
+func (a BulkAction) TargetStatus() string {
+	switch a {
+	case BulkActionPublish:
+		return string(PageStatusPublished)
+	case BulkActionUnpublish:
+		return string(PageStatusDraft)
+	case BulkActionArchive:
+		return string(PageStatusArchived)
+	}
+	return ""
+}
+
+// AuditAction is the audit log action recorded for every item the action changed, e.g. "bulk.publish"
+func (a BulkAction) AuditAction() string {
+	return "bulk." + string(a)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxCategoryDepth limits how deeply categories can be nested
+const MaxCategoryDepth = 8
+
+// CategoryPathPrefix is the public path under which category archives are served
+const CategoryPathPrefix = "/blog/category/"
+
+// CategoryPublicPath returns the public path of a category (e.g. "tech/go" -> "/blog/category/tech/go")
+func CategoryPublicPath(slug string) string {
+	return CategoryPathPrefix + strings.Trim(slug, "/")
+}
+
+// CategoryTreeNode is a category and its subcategories
+type CategoryTreeNode struct {
+	ID          uuid.UUID           `json:"id"`
+	Name        string              `json:"name"`
+	Slug        string              `json:"slug"`
+	Path        string              `json:"path"` // Public path of the category archive
+	Description string              `json:"description,omitempty"`
+	Children    []*CategoryTreeNode `json:"children"`
+}
+
+// BuildCategoryTree nests categories under their parents, keeping the order of categories
+// Categories whose parent is not in the list are treated as top-level categories
+func BuildCategoryTree(categories []*Category) []*CategoryTreeNode {
+	nodes := make(map[uuid.UUID]*CategoryTreeNode, len(categories))
+	for _, c := range categories {
+		nodes[c.ID] = &CategoryTreeNode{
+			ID:          c.ID,
+			Name:        c.Name,
+			Slug:        c.Slug,
+			Path:        CategoryPublicPath(c.Slug),
+			Description: c.Description,
+			Children:    []*CategoryTreeNode{},
+		}
+	}
+
+	roots := []*CategoryTreeNode{}
+	for _, c := range categories {
+		node := nodes[c.ID]
+		if c.ParentID != nil {
+			if parent, ok := nodes[*c.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
@@ -0,0 +1,283 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// CollectionFieldType defines the kind of value a collection field holds
+type CollectionFieldType string
+
+const (
+	FieldTypeText      CollectionFieldType = "text"
+	FieldTypeRichText  CollectionFieldType = "richtext"  // HTML or Markdown
+	FieldTypeNumber    CollectionFieldType = "number"    // Stored as a JSON number
+	FieldTypeDate      CollectionFieldType = "date"      // Stored as an RFC 3339 UTC timestamp so it sorts as text
+	FieldTypeBoolean   CollectionFieldType = "boolean"   // Stored as a JSON boolean
+	FieldTypeMedia     CollectionFieldType = "media"     // Media URL
+	FieldTypeReference CollectionFieldType = "reference" // ID of an item in another collection
+	FieldTypeBlocks    CollectionFieldType = "blocks"    // Array of Block objects
+	FieldTypeSelect    CollectionFieldType = "select"    // One of the field's options
+)
+
+var (
+	// fieldNamePattern restricts field names so they can be used safely in JSON path expressions
+	fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+	// collectionSlugPattern restricts collection slugs to URL-safe names (e.g. "team-members")
+	collectionSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// IsValidCollectionSlug reports whether slug can name a collection in URLs
+func IsValidCollectionSlug(slug string) bool {
+	return len(slug) <= 100 && collectionSlugPattern.MatchString(slug)
+}
+
+// CollectionField describes one field of a collection's schema
+type CollectionField struct {
+	Name       string              `json:"name"` // Key in the item data, lowercase letters, digits and underscores
+	Label      string              `json:"label,omitempty"`
+	Type       CollectionFieldType `json:"type"`
+	Required   bool                `json:"required,omitempty"`
+	Options    []string            `json:"options,omitempty"`    // Allowed values of a select field
+	Collection string              `json:"collection,omitempty"` // Slug of the collection a reference field points to
+}
+
+// Filterable reports whether items can be filtered by the field
+func (f CollectionField) Filterable() bool {
+	return f.Type != FieldTypeBlocks
+}
+
+// Sortable reports whether items can be sorted by the field
+func (f CollectionField) Sortable() bool {
+	switch f.Type {
+	case FieldTypeText, FieldTypeNumber, FieldTypeDate, FieldTypeBoolean, FieldTypeSelect:
+		return true
+	}
+	return false
+}
+
+// Collection is a user-defined content type such as team members, products or events
+// Its schema lives in Fields; items store their values in a JSONB column like Page.Meta
+type Collection struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID    string         `gorm:"index;not null;uniqueIndex:idx_collections_tenant_slug,priority:1" json:"tenant_id"` // Empty string for community edition
+	Slug        string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_collections_tenant_slug,priority:2" json:"slug"`
+	Name        string         `gorm:"type:varchar(255);not null" json:"name"`
+	Description string         `gorm:"type:text" json:"description"`
+	Fields      datatypes.JSON `gorm:"type:jsonb" json:"fields"` // Array of CollectionField objects
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a collection
+func (c *Collection) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (Collection) TableName() string {
+	return "collections"
+}
+
+// Schema decodes the collection's fields
+func (c *Collection) Schema() ([]CollectionField, error) {
+	var fields []CollectionField
+	if len(c.Fields) == 0 {
+		return fields, nil
+	}
+	if err := json.Unmarshal(c.Fields, &fields); err != nil {
+		return nil, fmt.Errorf("invalid collection schema: %w", err)
+	}
+	return fields, nil
+}
+
+// Field returns the schema field with the given name
+func (c *Collection) Field(name string) (CollectionField, bool) {
+	fields, err := c.Schema()
+	if err != nil {
+		return CollectionField{}, false
+	}
+	for _, field := range fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return CollectionField{}, false
+}
+
+// ValidateCollectionFields checks a collection schema for unknown types and duplicate or unsafe names
+func ValidateCollectionFields(fields []CollectionField) error {
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		if !fieldNamePattern.MatchString(field.Name) {
+			return fmt.Errorf("%w: invalid field name %q", ErrInvalidCollectionSchema, field.Name)
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("%w: duplicate field %q", ErrInvalidCollectionSchema, field.Name)
+		}
+		seen[field.Name] = true
+
+		switch field.Type {
+		case FieldTypeText, FieldTypeRichText, FieldTypeNumber, FieldTypeDate, FieldTypeBoolean,
+			FieldTypeMedia, FieldTypeBlocks:
+		case FieldTypeSelect:
+			if len(field.Options) == 0 {
+				return fmt.Errorf("%w: select field %q needs options", ErrInvalidCollectionSchema, field.Name)
+			}
+		case FieldTypeReference:
+			if field.Collection == "" {
+				return fmt.Errorf("%w: reference field %q needs a collection", ErrInvalidCollectionSchema, field.Name)
+			}
+		default:
+			return fmt.Errorf("%w: field %q has unknown type %q", ErrInvalidCollectionSchema, field.Name, field.Type)
+		}
+	}
+	return nil
+}
+
+// ValidateItemData checks item values against the collection schema
+// Returns the values in their stored form; unknown fields are rejected
+func (c *Collection) ValidateItemData(data map[string]interface{}) (map[string]interface{}, error) {
+	fields, err := c.Schema()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.Name] = true
+	}
+	for name := range data {
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidCollectionItem, name)
+		}
+	}
+
+	normalized := make(map[string]interface{}, len(data))
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok || value == nil || value == "" {
+			if field.Required {
+				return nil, fmt.Errorf("%w: %s is required", ErrInvalidCollectionItem, field.Name)
+			}
+			continue
+		}
+		if normalized[field.Name], err = field.normalizeValue(value); err != nil {
+			return nil, fmt.Errorf("%w: %s %v", ErrInvalidCollectionItem, field.Name, err)
+		}
+	}
+	return normalized, nil
+}
+
+// normalizeValue converts a decoded JSON value to the field's stored form
+func (f CollectionField) normalizeValue(value interface{}) (interface{}, error) {
+	switch f.Type {
+	case FieldTypeText, FieldTypeRichText, FieldTypeMedia:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("must be a string")
+	case FieldTypeNumber:
+		if n, ok := value.(float64); ok {
+			return n, nil
+		}
+		return nil, fmt.Errorf("must be a number")
+	case FieldTypeBoolean:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("must be a boolean")
+	case FieldTypeDate:
+		if s, ok := value.(string); ok {
+			if t, err := ParseFieldDate(s); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("must be a date (YYYY-MM-DD or RFC 3339)")
+	case FieldTypeSelect:
+		if s, ok := value.(string); ok {
+			for _, option := range f.Options {
+				if s == option {
+					return s, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("must be one of %v", f.Options)
+	case FieldTypeReference:
+		if s, ok := value.(string); ok {
+			if id, err := uuid.Parse(s); err == nil {
+				return id.String(), nil
+			}
+		}
+		return nil, fmt.Errorf("must be an item ID")
+	case FieldTypeBlocks:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		var blocks []Block
+		if err := json.Unmarshal(raw, &blocks); err != nil {
+			return nil, fmt.Errorf("must be an array of blocks")
+		}
+		if err := ValidateBlocks(blocks); err != nil {
+			return nil, err
+		}
+		return blocks, nil
+	}
+	return nil, fmt.Errorf("has unknown type %q", f.Type)
+}
+
+// ParseFieldDate parses a date field value and returns it in its stored RFC 3339 UTC form
+func ParseFieldDate(s string) (string, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", s); err != nil {
+			return "", err
+		}
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// CollectionItemStatus represents the publication status of a collection item
+type CollectionItemStatus string
+
+const (
+	CollectionItemStatusDraft     CollectionItemStatus = "draft"
+	CollectionItemStatusPublished CollectionItemStatus = "published"
+	CollectionItemStatusArchived  CollectionItemStatus = "archived"
+)
+
+// CollectionItem is an entry of a collection
+type CollectionItem struct {
+	ID           uuid.UUID            `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID     string               `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
+	CollectionID uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex:idx_collection_items_slug,priority:1" json:"collection_id"`
+	Slug         string               `gorm:"type:varchar(255);not null;uniqueIndex:idx_collection_items_slug,priority:2" json:"slug"` // Unique within the collection
+	Status       CollectionItemStatus `gorm:"type:varchar(20);not null;default:'draft'" json:"status"`
+	Data         datatypes.JSON       `gorm:"type:jsonb" json:"data"` // Field values keyed by field name
+	PublishedAt  *time.Time           `json:"published_at,omitempty"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a collection item
+func (i *CollectionItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (CollectionItem) TableName() string {
+	return "collection_items"
+}
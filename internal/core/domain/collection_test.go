@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCollectionFields(t *testing.T) {
+	assert.NoError(t, ValidateCollectionFields([]CollectionField{
+		{Name: "name", Type: FieldTypeText, Required: true},
+		{Name: "team", Type: FieldTypeReference, Collection: "teams"},
+		{Name: "level", Type: FieldTypeSelect, Options: []string{"junior", "senior"}},
+	}))
+
+	invalid := [][]CollectionField{
+		{{Name: "Name", Type: FieldTypeText}},
+		{{Name: "name'); DROP", Type: FieldTypeText}},
+		{{Name: "name", Type: FieldTypeText}, {Name: "name", Type: FieldTypeNumber}},
+		{{Name: "level", Type: FieldTypeSelect}},
+		{{Name: "team", Type: FieldTypeReference}},
+		{{Name: "color", Type: "color"}},
+	}
+	for _, fields := range invalid {
+		err := ValidateCollectionFields(fields)
+		assert.True(t, errors.Is(err, ErrInvalidCollectionSchema), "fields %+v", fields)
+	}
+}
+
+func TestCollection_ValidateItemData(t *testing.T) {
+	fields, err := json.Marshal([]CollectionField{
+		{Name: "name", Type: FieldTypeText, Required: true},
+		{Name: "price", Type: FieldTypeNumber},
+		{Name: "in_stock", Type: FieldTypeBoolean},
+		{Name: "released", Type: FieldTypeDate},
+		{Name: "size", Type: FieldTypeSelect, Options: []string{"s", "m", "l"}},
+		{Name: "body", Type: FieldTypeBlocks},
+	})
+	require.NoError(t, err)
+	collection := &Collection{Fields: fields}
+
+	values, err := collection.ValidateItemData(map[string]interface{}{
+		"name":     "Shirt",
+		"price":    19.5,
+		"in_stock": true,
+		"released": "2024-03-01",
+		"size":     "m",
+		"body":     []interface{}{map[string]interface{}{"id": "b1", "type": "text", "data": map[string]interface{}{"content": "Hi"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-01T00:00:00Z", values["released"])
+	assert.Equal(t, 19.5, values["price"])
+
+	invalid := []map[string]interface{}{
+		{"price": 1.0},
+		{"name": "Shirt", "color": "red"},
+		{"name": "Shirt", "price": "cheap"},
+		{"name": "Shirt", "size": "xl"},
+		{"name": "Shirt", "released": "yesterday"},
+		{"name": "Shirt", "body": []interface{}{map[string]interface{}{"type": "text"}}},
+	}
+	for _, data := range invalid {
+		_, err := collection.ValidateItemData(data)
+		assert.True(t, errors.Is(err, ErrInvalidCollectionItem), "data %+v", data)
+	}
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Limits on what readers may submit
+const (
+	CommentMaxAuthorName = 100
+	CommentMaxBody       = 5000
+)
+
+// CommentStatus tracks a reader comment through moderation
+type CommentStatus string
+
+const (
+	CommentStatusPending  CommentStatus = "pending" // Waiting in the moderation queue
+	CommentStatusApproved CommentStatus = "approved"
+	CommentStatusSpam     CommentStatus = "spam"
+	CommentStatusDeleted  CommentStatus = "deleted" // Hidden by a moderator; kept so the thread keeps its shape in the queue
+)
+
+// IsValid reports whether the status is a known comment status
+func (s CommentStatus) IsValid() bool {
+	switch s {
+	case CommentStatusPending, CommentStatusApproved, CommentStatusSpam, CommentStatusDeleted:
+		return true
+	}
+	return false
+}
+
+// Comment is a reader's comment on a published post
+// Replies point at the comment they answer; only approved comments are shown publicly
+type Comment struct {
+	ID          uuid.UUID     `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID    string        `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
+	PostID      uuid.UUID     `gorm:"type:uuid;not null;index:idx_comments_post_status,priority:1" json:"post_id"`
+	ParentID    *uuid.UUID    `gorm:"type:uuid;index" json:"parent_id,omitempty"`
+	AuthorName  string        `gorm:"type:varchar(100);not null" json:"author_name"`
+	AuthorEmail string        `gorm:"type:varchar(255)" json:"author_email,omitempty"` // Never shown publicly
+	AuthorURL   string        `gorm:"type:varchar(2000)" json:"author_url,omitempty"`
+	Body        string        `gorm:"type:text;not null" json:"body"`
+	Status      CommentStatus `gorm:"type:varchar(20);not null;default:'pending';index:idx_comments_post_status,priority:2" json:"status"`
+	SpamScore   float64       `gorm:"not null;default:0" json:"spam_score"`
+	SpamReasons string        `gorm:"type:text" json:"spam_reasons,omitempty"` // Why the classifier scored it, "; "-separated
+	IPAddress   string        `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	UserAgent   string        `gorm:"type:varchar(500)" json:"user_agent,omitempty"`
+	ModeratedBy string        `gorm:"type:varchar(36)" json:"moderated_by,omitempty"`
+	ModeratedAt *time.Time    `json:"moderated_at,omitempty"`
+	CreatedAt   time.Time     `gorm:"index" json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a comment
+func (c *Comment) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// Moderate moves the comment to a status on behalf of a user
+func (c *Comment) Moderate(status CommentStatus, userID string, now time.Time) {
+	c.Status = status
+	c.ModeratedBy = userID
+	c.ModeratedAt = &now
+}
+
+// ValidateComment checks a submitted comment and trims its fields
+func ValidateComment(c *Comment) error {
+	c.AuthorName = strings.TrimSpace(c.AuthorName)
+	c.AuthorEmail = strings.TrimSpace(c.AuthorEmail)
+	c.AuthorURL = strings.TrimSpace(c.AuthorURL)
+	c.Body = strings.TrimSpace(c.Body)
+
+	if c.AuthorName == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidComment)
+	}
+	if utf8.RuneCountInString(c.AuthorName) > CommentMaxAuthorName {
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidComment, CommentMaxAuthorName)
+	}
+	if c.Body == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidComment)
+	}
+	if utf8.RuneCountInString(c.Body) > CommentMaxBody {
+		return fmt.Errorf("%w: body must be at most %d characters", ErrInvalidComment, CommentMaxBody)
+	}
+	if c.AuthorEmail != "" {
+		if _, err := mail.ParseAddress(c.AuthorEmail); err != nil {
+			return fmt.Errorf("%w: invalid email address", ErrInvalidComment)
+		}
+	}
+	if c.AuthorURL != "" {
+		u, err := url.Parse(c.AuthorURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidComment)
+		}
+	}
+	return nil
+}
+
+// PublicComment is an approved comment as shown to readers, with its approved replies
+type PublicComment struct {
+	ID         uuid.UUID        `json:"id"`
+	AuthorName string           `json:"author_name"`
+	AuthorURL  string           `json:"author_url,omitempty"`
+	Body       string           `json:"body"`
+	CreatedAt  time.Time        `json:"created_at"`
+	Replies    []*PublicComment `json:"replies"`
+}
+
+// BuildCommentThreads nests approved replies, sorted oldest first, under the given top-level comments
+// Replies whose parent is not among the comments, such as replies to a hidden comment, are left out
+func BuildCommentThreads(roots, replies []*Comment) []*PublicComment {
+	nodes := make(map[uuid.UUID]*PublicComment, len(roots)+len(replies))
+	threads := make([]*PublicComment, 0, len(roots))
+	for _, c := range roots {
+		node := newPublicComment(c)
+		nodes[c.ID] = node
+		threads = append(threads, node)
+	}
+
+	// Replies are oldest first and a reply is always newer than its parent,
+	// so each parent is placed before its own replies
+	for _, c := range replies {
+		if c.ParentID == nil {
+			continue
+		}
+		parent, ok := nodes[*c.ParentID]
+		if !ok {
+			continue
+		}
+		node := newPublicComment(c)
+		nodes[c.ID] = node
+		parent.Replies = append(parent.Replies, node)
+	}
+	return threads
+}
+
+func newPublicComment(c *Comment) *PublicComment {
+	return &PublicComment{
+		ID:         c.ID,
+		AuthorName: c.AuthorName,
+		AuthorURL:  c.AuthorURL,
+		Body:       c.Body,
+		CreatedAt:  c.CreatedAt,
+		Replies:    []*PublicComment{},
+	}
+}
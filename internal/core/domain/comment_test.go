@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateComment(t *testing.T) {
+	valid := Comment{AuthorName: " Ann ", AuthorEmail: "ann@example.com", AuthorURL: "https://ann.example", Body: " Hi "}
+	require.NoError(t, ValidateComment(&valid))
+	assert.Equal(t, "Ann", valid.AuthorName)
+	assert.Equal(t, "Hi", valid.Body)
+
+	for name, c := range map[string]Comment{
+		"missing name":  {Body: "Hi"},
+		"missing body":  {AuthorName: "Ann", Body: "  "},
+		"long body":     {AuthorName: "Ann", Body: strings.Repeat("a", CommentMaxBody+1)},
+		"bad email":     {AuthorName: "Ann", Body: "Hi", AuthorEmail: "ann"},
+		"relative url":  {AuthorName: "Ann", Body: "Hi", AuthorURL: "ann.example"},
+		"script scheme": {AuthorName: "Ann", Body: "Hi", AuthorURL: "javascript:alert(1)"},
+	} {
+		err := ValidateComment(&c)
+		assert.True(t, errors.Is(err, ErrInvalidComment), name)
+	}
+}
+
+func TestBuildCommentThreads(t *testing.T) {
+	root := &Comment{ID: uuid.New(), AuthorName: "Ann", AuthorEmail: "ann@example.com"}
+	other := &Comment{ID: uuid.New(), AuthorName: "Cat"}
+	reply := &Comment{ID: uuid.New(), ParentID: &root.ID, AuthorName: "Bob"}
+	nested := &Comment{ID: uuid.New(), ParentID: &reply.ID, AuthorName: "Ann"}
+	hiddenParent := uuid.New()
+	orphan := &Comment{ID: uuid.New(), ParentID: &hiddenParent, AuthorName: "Dan"}
+
+	threads := BuildCommentThreads([]*Comment{root, other}, []*Comment{reply, orphan, nested})
+	require.Len(t, threads, 2)
+	require.Len(t, threads[0].Replies, 1)
+	assert.Equal(t, "Bob", threads[0].Replies[0].AuthorName)
+	require.Len(t, threads[0].Replies[0].Replies, 1)
+	assert.Equal(t, "Ann", threads[0].Replies[0].Replies[0].AuthorName)
+	assert.Empty(t, threads[1].Replies)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EditLockEntityType identifies a kind of content that can be locked for editing
+type EditLockEntityType string
+
+const (
+	EditLockPage EditLockEntityType = "page"
+	EditLockPost EditLockEntityType = "post"
+)
+
+// EditLockTTL is how long an edit lock lasts without a heartbeat
+// Editors renew their lock well within it while the edit form is open
+const EditLockTTL = 2 * time.Minute
+
+// NotificationEditLockTakenOver tells an editor that an admin took over their edit lock
+const NotificationEditLockTakenOver = "lock.taken_over"
+
+// EditLock marks a page or post as being edited by one user
+// Locks are advisory: they let the admin panel warn other editors, while saves are
+// protected by the content version
+type EditLock struct {
+	ID         uuid.UUID          `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID   string             `gorm:"not null;uniqueIndex:idx_edit_locks_entity,priority:1" json:"tenant_id"` // Empty string for community edition
+	EntityType EditLockEntityType `gorm:"type:varchar(20);not null;uniqueIndex:idx_edit_locks_entity,priority:2" json:"entity_type"`
+	EntityID   uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_edit_locks_entity,priority:3" json:"entity_id"`
+	UserID     string             `gorm:"type:varchar(36);not null" json:"user_id"`
+	UserName   string             `gorm:"type:varchar(100)" json:"user_name"` // Shown as "being edited by"
+	AcquiredAt time.Time          `json:"acquired_at"`
+	ExpiresAt  time.Time          `gorm:"index" json:"expires_at"` // Pushed back by every heartbeat
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating an edit lock
+func (l *EditLock) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (EditLock) TableName() string {
+	return "edit_locks"
+}
+
+// IsExpired reports whether the lock has run out without a heartbeat
+func (l *EditLock) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
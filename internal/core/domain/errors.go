@@ -8,6 +8,62 @@ var (
 	ErrPageAlreadyExists = errors.New("page with this slug already exists")
 	ErrInvalidSlug       = errors.New("invalid slug format")
 	ErrInvalidStatus     = errors.New("invalid page status")
+	ErrInvalidParent     = errors.New("invalid parent page")
+	ErrPageHasChildren   = errors.New("page has child pages")
+
+	ErrPostAlreadyExists     = errors.New("post with this slug already exists")
+	ErrCategoryAlreadyExists = errors.New("category with this slug already exists")
+	ErrInvalidParentCategory = errors.New("invalid parent category")
+	ErrCategoryHasChildren   = errors.New("category has subcategories")
+	ErrTagAlreadyExists      = errors.New("tag with this name already exists")
+	ErrInvalidTag            = errors.New("invalid tag")
+	ErrTagNotFound           = errors.New("tag not found")
+	ErrUserAlreadyExists     = errors.New("user with this email already exists")
+
+	ErrTenantAlreadyExists = errors.New("tenant with this id already exists")
+	ErrTenantNotFound      = errors.New("tenant not found")
+	ErrPlanAlreadyExists   = errors.New("plan with this name already exists")
+
+	ErrLocaleNotEnabled  = errors.New("locale is not enabled")
+	ErrTranslationExists = errors.New("translation for this locale already exists")
+
+	ErrInvalidRedirect       = errors.New("invalid redirect")
+	ErrRedirectAlreadyExists = errors.New("redirect for this source already exists")
+
+	ErrCollectionAlreadyExists     = errors.New("collection with this slug already exists")
+	ErrCollectionItemAlreadyExists = errors.New("collection item with this slug already exists")
+	ErrInvalidCollectionSchema     = errors.New("invalid collection schema")
+	ErrInvalidCollectionItem       = errors.New("invalid collection item")
+
+	ErrInvalidGlobalBlock = errors.New("invalid global block")
+	ErrGlobalBlockInUse   = errors.New("global block is used by pages")
+
+	ErrInvalidPageTemplate       = errors.New("invalid page template")
+	ErrPageTemplateAlreadyExists = errors.New("page template with this name already exists")
+	ErrBlockLocked               = errors.New("block is locked by the page template")
+
+	ErrNotInTrash            = errors.New("item is not in the trash")
+	ErrParentPageInTrash     = errors.New("parent page is in the trash")
+	ErrParentCategoryInTrash = errors.New("parent category is in the trash")
+
+	ErrInvalidWorkflow      = errors.New("invalid workflow")
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	ErrVersionConflict  = errors.New("content was changed by someone else")
+	ErrEditLocked       = errors.New("content is being edited by someone else")
+	ErrEditLockNotFound = errors.New("edit lock not found")
+
+	ErrInvalidWebhook          = errors.New("invalid webhook")
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+	ErrInvalidComment  = errors.New("invalid comment")
+	ErrCommentNotFound = errors.New("comment not found")
+
+	ErrInvalidForm            = errors.New("invalid form")
+	ErrFormSubmissionNotFound = errors.New("form submission not found")
+
+	ErrInvalidListQuery = errors.New("invalid list query")
 
 	ErrBlockMissingID   = errors.New("block missing required id field")
 	ErrBlockMissingType = errors.New("block missing required type field")
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType identifies a kind of change
+// It is the one vocabulary of the outbox, the admin event stream and webhooks;
+// the entity type tells pages, posts and menus apart
+type EventType string
+
+const (
+	EventContentCreated   EventType = "content.created"
+	EventContentUpdated   EventType = "content.updated"
+	EventContentPublished EventType = "content.published"
+	EventContentDeleted   EventType = "content.deleted"
+	EventContentRestored  EventType = "content.restored" // Brought back from the trash
+	EventLockAcquired     EventType = "lock.acquired"
+	EventLockReleased     EventType = "lock.released"
+	EventMediaUploaded    EventType = "media.uploaded"
+	EventFormSubmitted    EventType = "form.submitted" // Never streamed or sent to webhooks; it carries the submitted values
+)
+
+// Event is a change within a tenant, fanned out to the tenant's subscribers by a broker and sent to its webhooks
+// Events are notifications only; clients reload the entity when they need its current state
+type Event struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	TenantID   string          `json:"tenant_id"` // Empty string for community edition
+	EntityType string          `json:"entity_type,omitempty"`
+	EntityID   string          `json:"entity_id,omitempty"`
+	ActorID    string          `json:"actor_id,omitempty"` // User who made the change
+	Data       json.RawMessage `json:"data,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// NewEvent creates an event with a fresh ID and timestamp
+func NewEvent(eventType EventType, tenantID, entityType, entityID string) Event {
+	return Event{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		CreatedAt:  time.Now(),
+	}
+}
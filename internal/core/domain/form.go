@@ -0,0 +1,323 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// FormMaxValueLength caps values of fields without their own max_length
+const FormMaxValueLength = 5000
+
+// FormFieldType is the kind of input a form field renders as
+type FormFieldType string
+
+const (
+	FormFieldText     FormFieldType = "text"
+	FormFieldTextarea FormFieldType = "textarea"
+	FormFieldEmail    FormFieldType = "email"
+	FormFieldPhone    FormFieldType = "tel"
+	FormFieldURL      FormFieldType = "url"
+	FormFieldNumber   FormFieldType = "number"
+	FormFieldSelect   FormFieldType = "select"
+	FormFieldCheckbox FormFieldType = "checkbox"
+)
+
+var formFieldTypes = map[FormFieldType]bool{
+	FormFieldText: true, FormFieldTextarea: true, FormFieldEmail: true, FormFieldPhone: true,
+	FormFieldURL: true, FormFieldNumber: true, FormFieldSelect: true, FormFieldCheckbox: true,
+}
+
+var (
+	formFieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+	phonePattern         = regexp.MustCompile(`^\+?[0-9 ()./-]{5,25}$`)
+)
+
+// FormField is one input of a form block and the rules its value must follow
+type FormField struct {
+	Name        string        `json:"name"` // Key of the value in submissions, e.g. "email"
+	Label       string        `json:"label"`
+	Type        FormFieldType `json:"type"`
+	Placeholder string        `json:"placeholder,omitempty"`
+	Required    bool          `json:"required,omitempty"` // A required checkbox must be ticked
+	MinLength   int           `json:"min_length,omitempty"`
+	MaxLength   int           `json:"max_length,omitempty"`
+	Pattern     string        `json:"pattern,omitempty"` // Regular expression the whole value must match
+	Min         *float64      `json:"min,omitempty"`     // Number fields only
+	Max         *float64      `json:"max,omitempty"`
+	Options     []string      `json:"options,omitempty"` // Select fields only
+}
+
+// FormBlockData represents data for a form block
+// Submissions are validated against the fields of the block as it is stored on the page
+type FormBlockData struct {
+	Title          string      `json:"title,omitempty"`
+	Description    string      `json:"description,omitempty"`
+	SubmitLabel    string      `json:"submit_label,omitempty"`
+	SuccessMessage string      `json:"success_message,omitempty"`
+	Notify         []string    `json:"notify,omitempty"` // Emailed on every submission; the site's contact email when empty
+	Fields         []FormField `json:"fields"`
+}
+
+// ParseFormBlock decodes and validates the data of a form block
+func ParseFormBlock(data json.RawMessage) (*FormBlockData, error) {
+	var form FormBlockData
+	if err := json.Unmarshal(data, &form); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidForm, err)
+	}
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+	return &form, nil
+}
+
+// StripFormNotify removes the notification addresses from the form blocks of a blocks array
+// They are for the site's staff only and never part of a public response.
+// Blocks that cannot be parsed are returned unchanged.
+func StripFormNotify(blocksJSON datatypes.JSON) datatypes.JSON {
+	var blocks []Block
+	if len(blocksJSON) == 0 || json.Unmarshal(blocksJSON, &blocks) != nil {
+		return blocksJSON
+	}
+	stripped := false
+	for i, block := range blocks {
+		if block.Type != string(BlockTypeForm) {
+			continue
+		}
+		var data map[string]json.RawMessage
+		if json.Unmarshal(block.Data, &data) != nil {
+			continue
+		}
+		if _, ok := data["notify"]; !ok {
+			continue
+		}
+		delete(data, "notify")
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			continue
+		}
+		blocks[i].Data = encoded
+		stripped = true
+	}
+	if !stripped {
+		return blocksJSON
+	}
+	encoded, err := json.Marshal(blocks)
+	if err != nil {
+		return blocksJSON
+	}
+	return encoded
+}
+
+// Validate checks the form's fields and notification addresses
+func (f *FormBlockData) Validate() error {
+	if len(f.Fields) == 0 {
+		return fmt.Errorf("%w: a form needs at least one field", ErrInvalidForm)
+	}
+	seen := make(map[string]bool, len(f.Fields))
+	for _, field := range f.Fields {
+		if !formFieldNamePattern.MatchString(field.Name) {
+			return fmt.Errorf("%w: field name %q must be lowercase letters, digits and underscores", ErrInvalidForm, field.Name)
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("%w: duplicate field %q", ErrInvalidForm, field.Name)
+		}
+		seen[field.Name] = true
+		if !formFieldTypes[field.Type] {
+			return fmt.Errorf("%w: field %q has unknown type %q", ErrInvalidForm, field.Name, field.Type)
+		}
+		if field.MinLength < 0 || field.MaxLength < 0 || (field.MaxLength > 0 && field.MinLength > field.MaxLength) {
+			return fmt.Errorf("%w: field %q has invalid length limits", ErrInvalidForm, field.Name)
+		}
+		if field.Min != nil && field.Max != nil && *field.Min > *field.Max {
+			return fmt.Errorf("%w: field %q has min above max", ErrInvalidForm, field.Name)
+		}
+		if field.Pattern != "" {
+			if _, err := regexp.Compile(field.Pattern); err != nil {
+				return fmt.Errorf("%w: field %q has an invalid pattern", ErrInvalidForm, field.Name)
+			}
+		}
+		if field.Type == FormFieldSelect && len(field.Options) == 0 {
+			return fmt.Errorf("%w: select field %q needs options", ErrInvalidForm, field.Name)
+		}
+	}
+	for _, address := range f.Notify {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return fmt.Errorf("%w: invalid notification address %q", ErrInvalidForm, address)
+		}
+	}
+	return nil
+}
+
+// ValidateSubmission checks submitted values against the form's fields
+// Returns the values of known fields as strings, or a message per invalid field;
+// values for fields the form does not have are dropped
+func (f *FormBlockData) ValidateSubmission(values map[string]interface{}) (map[string]string, map[string]string) {
+	clean := make(map[string]string, len(f.Fields))
+	problems := make(map[string]string)
+	for _, field := range f.Fields {
+		value, msg := field.check(values[field.Name])
+		if msg != "" {
+			problems[field.Name] = msg
+			continue
+		}
+		if value != "" {
+			clean[field.Name] = value
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return clean, nil
+}
+
+// check validates one submitted value and returns it as a string, or why it is invalid
+func (field FormField) check(raw interface{}) (string, string) {
+	var value string
+	switch v := raw.(type) {
+	case nil:
+	case string:
+		value = strings.TrimSpace(v)
+	case bool:
+		if v {
+			value = "true"
+		}
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return "", "must be a single value"
+	}
+
+	if field.Type == FormFieldCheckbox {
+		switch strings.ToLower(value) {
+		case "", "false", "off", "0":
+			value = ""
+		default:
+			value = "true"
+		}
+	}
+	if value == "" {
+		if field.Required {
+			return "", "is required"
+		}
+		return "", ""
+	}
+
+	length := utf8.RuneCountInString(value)
+	maxLength := field.MaxLength
+	if maxLength == 0 {
+		maxLength = FormMaxValueLength
+	}
+	switch {
+	case length > maxLength:
+		return "", fmt.Sprintf("must be at most %d characters", maxLength)
+	case length < field.MinLength:
+		return "", fmt.Sprintf("must be at least %d characters", field.MinLength)
+	}
+
+	switch field.Type {
+	case FormFieldEmail:
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+			return "", "must be an email address"
+		}
+	case FormFieldURL:
+		if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", "must be an http or https URL"
+		}
+	case FormFieldPhone:
+		if !phonePattern.MatchString(value) {
+			return "", "must be a phone number"
+		}
+	case FormFieldNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", "must be a number"
+		}
+		if field.Min != nil && n < *field.Min {
+			return "", fmt.Sprintf("must be at least %v", *field.Min)
+		}
+		if field.Max != nil && n > *field.Max {
+			return "", fmt.Sprintf("must be at most %v", *field.Max)
+		}
+	case FormFieldSelect:
+		valid := false
+		for _, option := range field.Options {
+			if value == option {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return "", "must be one of the options"
+		}
+	}
+
+	if field.Pattern != "" {
+		if re, err := regexp.Compile(`^(?:` + field.Pattern + `)$`); err == nil && !re.MatchString(value) {
+			return "", "has an invalid format"
+		}
+	}
+	return value, ""
+}
+
+// FormSubmission is a visitor's submission of a form block
+// Submissions are kept per page and block ID, so they outlive edits to the form
+type FormSubmission struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID  string         `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
+	PageID    uuid.UUID      `gorm:"type:uuid;not null;index:idx_form_submissions_form,priority:1" json:"page_id"`
+	BlockID   string         `gorm:"type:varchar(100);not null;index:idx_form_submissions_form,priority:2" json:"block_id"`
+	Values    datatypes.JSON `gorm:"type:jsonb" json:"values"` // Field name -> submitted value
+	IPAddress string         `gorm:"type:varchar(45)" json:"ip_address,omitempty"`
+	UserAgent string         `gorm:"type:varchar(500)" json:"user_agent,omitempty"`
+	CreatedAt time.Time      `gorm:"index" json:"created_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a form submission
+func (s *FormSubmission) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (FormSubmission) TableName() string {
+	return "form_submissions"
+}
+
+// DecodeValues returns the submitted values by field name
+func (s *FormSubmission) DecodeValues() map[string]string {
+	values := map[string]string{}
+	if len(s.Values) > 0 {
+		_ = json.Unmarshal(s.Values, &values)
+	}
+	return values
+}
+
+// FormSubmitted is recorded in the outbox with every stored submission
+// It carries everything the notification email needs, since the form may change before it is sent
+type FormSubmitted struct {
+	SubmissionID uuid.UUID         `json:"submission_id"`
+	PageID       uuid.UUID         `json:"page_id"`
+	PageTitle    string            `json:"page_title"`
+	BlockID      string            `json:"block_id"`
+	FormTitle    string            `json:"form_title,omitempty"`
+	Fields       []FormField       `json:"fields"`
+	Values       map[string]string `json:"values"`
+	Notify       []string          `json:"notify,omitempty"`
+}
+
+func (FormSubmitted) EventType() EventType     { return EventFormSubmitted }
+func (FormSubmitted) AggregateType() string    { return "form_submission" }
+func (e FormSubmitted) AggregateID() uuid.UUID { return e.SubmissionID }
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormBlock(t *testing.T) {
+	_, err := ParseFormBlock(json.RawMessage(`{"fields": [{"name": "email", "type": "email"}], "notify": ["ops@example.com"]}`))
+	require.NoError(t, err)
+
+	for name, data := range map[string]string{
+		"no fields":        `{"fields": []}`,
+		"bad name":         `{"fields": [{"name": "E-mail", "type": "email"}]}`,
+		"duplicate name":   `{"fields": [{"name": "a", "type": "text"}, {"name": "a", "type": "text"}]}`,
+		"unknown type":     `{"fields": [{"name": "a", "type": "color"}]}`,
+		"select no option": `{"fields": [{"name": "a", "type": "select"}]}`,
+		"bad pattern":      `{"fields": [{"name": "a", "type": "text", "pattern": "("}]}`,
+		"bad lengths":      `{"fields": [{"name": "a", "type": "text", "min_length": 5, "max_length": 2}]}`,
+		"bad notify":       `{"fields": [{"name": "a", "type": "text"}], "notify": ["nope"]}`,
+		"not an object":    `[]`,
+	} {
+		_, err := ParseFormBlock(json.RawMessage(data))
+		assert.True(t, errors.Is(err, ErrInvalidForm), name)
+	}
+}
+
+func TestStripFormNotify(t *testing.T) {
+	blocks := []byte(`[
+		{"id": "a", "type": "form", "data": {"title": "Contact", "notify": ["ops@example.com"], "fields": []}},
+		{"id": "b", "type": "text", "data": {"notify": "kept"}}
+	]`)
+	assert.JSONEq(t, `[
+		{"id": "a", "type": "form", "data": {"title": "Contact", "fields": []}},
+		{"id": "b", "type": "text", "data": {"notify": "kept"}}
+	]`, string(StripFormNotify(blocks)))
+
+	// Nothing to strip, or nothing that parses, is returned as it is
+	unchanged := []byte(`[{"id": "b", "type": "text", "data": {}}]`)
+	assert.Equal(t, string(unchanged), string(StripFormNotify(unchanged)))
+	assert.Equal(t, "not json", string(StripFormNotify([]byte("not json"))))
+}
+
+func TestFormBlockData_ValidateSubmission(t *testing.T) {
+	one, ten := 1.0, 10.0
+	form := FormBlockData{Fields: []FormField{
+		{Name: "name", Type: FormFieldText, Required: true, MinLength: 2},
+		{Name: "phone", Type: FormFieldPhone},
+		{Name: "site", Type: FormFieldURL},
+		{Name: "seats", Type: FormFieldNumber, Min: &one, Max: &ten},
+		{Name: "code", Type: FormFieldText, Pattern: `[A-Z]{3}`},
+		{Name: "terms", Type: FormFieldCheckbox, Required: true},
+	}}
+
+	values, problems := form.ValidateSubmission(map[string]interface{}{
+		"name": " Ann ", "phone": "+1 (555) 010-0000", "site": "https://ann.example",
+		"seats": float64(3), "code": "ABC", "terms": true, "unknown": "x",
+	})
+	require.Nil(t, problems)
+	assert.Equal(t, map[string]string{
+		"name": "Ann", "phone": "+1 (555) 010-0000", "site": "https://ann.example",
+		"seats": "3", "code": "ABC", "terms": "true",
+	}, values)
+
+	values, problems = form.ValidateSubmission(map[string]interface{}{
+		"name": "A", "phone": "call me", "site": "ann.example",
+		"seats": "12", "code": "ABCD", "terms": "off",
+	})
+	assert.Nil(t, values)
+	assert.Equal(t, map[string]string{
+		"name":  "must be at least 2 characters",
+		"phone": "must be a phone number",
+		"site":  "must be an http or https URL",
+		"seats": "must be at most 10",
+		"code":  "has an invalid format",
+		"terms": "is required",
+	}, problems)
+
+	_, problems = form.ValidateSubmission(map[string]interface{}{"name": []interface{}{"a", "b"}, "terms": true})
+	assert.Equal(t, "must be a single value", problems["name"])
+}
+
+func TestValidateBlocks_FormBlock(t *testing.T) {
+	err := ValidateBlocks([]Block{{ID: "f", Type: "form", Data: json.RawMessage(`{"fields": []}`)}})
+	assert.True(t, errors.Is(err, ErrInvalidForm))
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// GlobalBlock is a named block shared between pages, such as a CTA or pricing table
+// Pages embed it with a ref block, so editing it changes every page that uses it
+type GlobalBlock struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID  string         `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
+	Name      string         `gorm:"type:varchar(255);not null" json:"name"`
+	Type      string         `gorm:"type:varchar(50);not null" json:"type"` // Block type, e.g. "cta" or "pricing"
+	Data      datatypes.JSON `gorm:"type:jsonb" json:"data"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a global block
+func (g *GlobalBlock) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (GlobalBlock) TableName() string {
+	return "global_blocks"
+}
+
+// Validate checks the global block's name, type and data
+// Global blocks cannot be ref blocks themselves, so references never nest
+func (g *GlobalBlock) Validate() error {
+	g.Name = strings.TrimSpace(g.Name)
+	switch {
+	case g.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidGlobalBlock)
+	case g.Type == "":
+		return fmt.Errorf("%w: type is required", ErrInvalidGlobalBlock)
+	case g.Type == string(BlockTypeRef):
+		return fmt.Errorf("%w: a global block cannot be a ref block", ErrInvalidGlobalBlock)
+	case len(g.Data) == 0 || !json.Valid(g.Data):
+		return fmt.Errorf("%w: data must be a JSON value", ErrInvalidGlobalBlock)
+	}
+	if g.Type == string(BlockTypeForm) {
+		if _, err := ParseFormBlock(json.RawMessage(g.Data)); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidGlobalBlock, err)
+		}
+	}
+	return nil
+}
+
+// RefBlock returns a ref block with the given ID that displays the global block
+func (g *GlobalBlock) RefBlock(id string) Block {
+	data, _ := json.Marshal(RefBlockData{GlobalBlockID: g.ID.String()})
+	return Block{ID: id, Type: string(BlockTypeRef), Data: data}
+}
+
+// BlockRefID returns the global block a ref block points to
+func BlockRefID(block Block) (uuid.UUID, bool) {
+	if block.Type != string(BlockTypeRef) {
+		return uuid.Nil, false
+	}
+	var data RefBlockData
+	if err := json.Unmarshal(block.Data, &data); err != nil {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(data.GlobalBlockID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// BlockRefIDs returns the distinct global blocks referenced by ref blocks in a blocks array
+// Blocks that cannot be parsed reference nothing
+func BlockRefIDs(blocksJSON datatypes.JSON) []uuid.UUID {
+	var blocks []Block
+	if len(blocksJSON) == 0 || json.Unmarshal(blocksJSON, &blocks) != nil {
+		return nil
+	}
+
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, block := range blocks {
+		if id, ok := BlockRefID(block); ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ResolveBlockRefs replaces ref blocks with the global blocks they point to
+// Resolved blocks keep the ref block's ID and record the global block's ID.
+// Refs to global blocks that are not in globals are dropped.
+func ResolveBlockRefs(blocksJSON datatypes.JSON, globals map[uuid.UUID]*GlobalBlock) (datatypes.JSON, error) {
+	if len(blocksJSON) == 0 {
+		return blocksJSON, nil
+	}
+	var blocks []Block
+	if err := json.Unmarshal(blocksJSON, &blocks); err != nil {
+		return nil, fmt.Errorf("invalid blocks: %w", err)
+	}
+
+	resolved := make([]Block, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Type != string(BlockTypeRef) {
+			resolved = append(resolved, block)
+			continue
+		}
+		id, ok := BlockRefID(block)
+		if !ok {
+			continue
+		}
+		global, ok := globals[id]
+		if !ok {
+			continue
+		}
+		resolved = append(resolved, Block{
+			ID:            block.ID,
+			Type:          global.Type,
+			Data:          json.RawMessage(global.Data),
+			GlobalBlockID: global.ID.String(),
+		})
+	}
+	return json.Marshal(resolved)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+func TestGlobalBlock_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		block   GlobalBlock
+		wantErr bool
+	}{
+		{"valid", GlobalBlock{Name: " CTA ", Type: "cta", Data: datatypes.JSON(`{"title":"Go"}`)}, false},
+		{"missing name", GlobalBlock{Type: "cta", Data: datatypes.JSON(`{}`)}, true},
+		{"missing type", GlobalBlock{Name: "CTA", Data: datatypes.JSON(`{}`)}, true},
+		{"nested ref", GlobalBlock{Name: "CTA", Type: "ref", Data: datatypes.JSON(`{}`)}, true},
+		{"missing data", GlobalBlock{Name: "CTA", Type: "cta"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.block.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidGlobalBlock))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "CTA", tt.block.Name)
+		})
+	}
+}
+
+func TestResolveBlockRefs(t *testing.T) {
+	global := &GlobalBlock{ID: uuid.New(), Type: "pricing", Data: datatypes.JSON(`{"plans":[]}`)}
+	missing := uuid.New()
+
+	blocks, err := json.Marshal([]Block{
+		{ID: "a", Type: "text", Data: json.RawMessage(`{"content":"Hi"}`)},
+		global.RefBlock("b"),
+		{ID: "c", Type: "ref", Data: json.RawMessage(`{"global_block_id":"` + missing.String() + `"}`)},
+		global.RefBlock("d"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{global.ID, missing}, BlockRefIDs(blocks))
+
+	resolved, err := ResolveBlockRefs(blocks, map[uuid.UUID]*GlobalBlock{global.ID: global})
+	require.NoError(t, err)
+
+	var out []Block
+	require.NoError(t, json.Unmarshal(resolved, &out))
+	require.Len(t, out, 3)
+	assert.Equal(t, "text", out[0].Type)
+	assert.Empty(t, out[0].GlobalBlockID)
+	for _, block := range out[1:] {
+		assert.Equal(t, "pricing", block.Type)
+		assert.Equal(t, global.ID.String(), block.GlobalBlockID)
+		assert.JSONEq(t, `{"plans":[]}`, string(block.Data))
+	}
+	assert.Equal(t, "b", out[1].ID)
+	assert.Equal(t, "d", out[2].ID)
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLocale is the locale of tenants that have not configured one
+// Content created before localization was added is in this locale
+const DefaultLocale = "en"
+
+// localePattern matches a language with an optional script or region (e.g. "en", "pt-BR", "zh-Hant")
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?$`)
+
+// NormalizeLocale returns the canonical form of a locale tag (e.g. "pt_br" -> "pt-BR")
+// Returns an empty string if the tag is not a valid locale
+func NormalizeLocale(tag string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(part)
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		default:
+			parts[i] = strings.ToUpper(part)
+		}
+	}
+
+	locale := strings.Join(parts, "-")
+	if !localePattern.MatchString(locale) {
+		return ""
+	}
+	return locale
+}
+
+// LocaleLanguage returns the language part of a locale (e.g. "pt-BR" -> "pt")
+func LocaleLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return language
+}
+
+// LocalizedSettings holds the settings that differ per locale
+// Empty fields fall back to the default locale's value
+type LocalizedSettings struct {
+	SiteName string `json:"site_name,omitempty"`
+}
+
+// PrimaryLocale returns the tenant's default locale
+func (s *GlobalSettings) PrimaryLocale() string {
+	if s.DefaultLocale != "" {
+		return s.DefaultLocale
+	}
+	return DefaultLocale
+}
+
+// EnabledLocales returns the tenant's locales, default locale first
+func (s *GlobalSettings) EnabledLocales() []string {
+	locales := []string{s.PrimaryLocale()}
+	for _, locale := range s.Locales {
+		if locale != locales[0] {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+// IsLocaleEnabled reports whether content may be created in a locale
+func (s *GlobalSettings) IsLocaleEnabled(locale string) bool {
+	for _, enabled := range s.EnabledLocales() {
+		if enabled == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Localize returns a copy of the settings with a locale's overrides applied
+func (s *GlobalSettings) Localize(locale string) *GlobalSettings {
+	localized := *s
+	if overrides, ok := s.Translations[locale]; ok {
+		if overrides.SiteName != "" {
+			localized.SiteName = overrides.SiteName
+		}
+	}
+	return &localized
+}
+
+// TranslationLink points to a variant of a page or post in another locale
+type TranslationLink struct {
+	Locale string    `json:"locale"`
+	ID     uuid.UUID `json:"id"`
+	Slug   string    `json:"slug"`
+}
+
+// TranslationStatus describes one locale variant of a page or post
+// A translation is outdated when its source changed after it was last translated
+type TranslationStatus struct {
+	ID        uuid.UUID `json:"id"`
+	Locale    string    `json:"locale"`
+	Slug      string    `json:"slug"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	IsSource  bool      `json:"is_source"`
+	Outdated  bool      `json:"outdated"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// contentHash hashes the translatable fields of a page or post
+func contentHash(fields ...[]byte) string {
+	h := sha256.New()
+	for _, field := range fields {
+		h.Write(field)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
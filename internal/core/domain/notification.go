@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notification types
+const (
+	NotificationReviewSubmitted = "review.submitted"
+	NotificationReviewRejected  = "review.rejected"
+)
+
+// Notification is a message for one user, shown in the admin until it is read
+type Notification struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID   string     `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
+	UserID     string     `gorm:"type:varchar(36);not null;index" json:"user_id"`
+	Type       string     `gorm:"type:varchar(50);not null" json:"type"`
+	Title      string     `gorm:"type:varchar(255);not null" json:"title"`
+	Message    string     `gorm:"type:text" json:"message,omitempty"`
+	EntityType string     `gorm:"type:varchar(50)" json:"entity_type,omitempty"`
+	EntityID   string     `gorm:"type:varchar(36)" json:"entity_id,omitempty"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"index" json:"created_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a notification
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (Notification) TableName() string {
+	return "notifications"
+}
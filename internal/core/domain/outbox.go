@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// DomainEvent is a typed change recorded in the outbox
+// Repositories record the events of their changes in the same transaction as the change
+type DomainEvent interface {
+	EventType() EventType
+	AggregateType() string // Kind of entity the event is about, e.g. "page"
+	AggregateID() uuid.UUID
+}
+
+// PageEvent is the state of the page a page event is about
+type PageEvent struct {
+	PageID  uuid.UUID  `json:"page_id"`
+	Title   string     `json:"title"`
+	Slug    string     `json:"slug"`
+	Locale  string     `json:"locale"`
+	Status  PageStatus `json:"status"`
+	Version int        `json:"version"`
+}
+
+// NewPageEvent captures a page for an event
+func NewPageEvent(page *Page) PageEvent {
+	return PageEvent{PageID: page.ID, Title: page.Title, Slug: page.Slug, Locale: page.Locale, Status: page.Status, Version: page.Version}
+}
+
+func (e PageEvent) AggregateType() string  { return "page" }
+func (e PageEvent) AggregateID() uuid.UUID { return e.PageID }
+
+type (
+	PageCreated   struct{ PageEvent }
+	PageUpdated   struct{ PageEvent }
+	PagePublished struct{ PageEvent } // Recorded along with PageCreated or PageUpdated when a page goes live
+	PageDeleted   struct{ PageEvent } // Moved to the trash
+)
+
+func (PageCreated) EventType() EventType   { return EventContentCreated }
+func (PageUpdated) EventType() EventType   { return EventContentUpdated }
+func (PagePublished) EventType() EventType { return EventContentPublished }
+func (PageDeleted) EventType() EventType   { return EventContentDeleted }
+
+// PostEvent is the state of the post a post event is about
+type PostEvent struct {
+	PostID  uuid.UUID  `json:"post_id"`
+	Title   string     `json:"title"`
+	Slug    string     `json:"slug"`
+	Locale  string     `json:"locale"`
+	Status  PostStatus `json:"status"`
+	Version int        `json:"version"`
+}
+
+// NewPostEvent captures a post for an event
+func NewPostEvent(post *Post) PostEvent {
+	return PostEvent{PostID: post.ID, Title: post.Title, Slug: post.Slug, Locale: post.Locale, Status: post.Status, Version: post.Version}
+}
+
+func (e PostEvent) AggregateType() string  { return "post" }
+func (e PostEvent) AggregateID() uuid.UUID { return e.PostID }
+
+type (
+	PostCreated   struct{ PostEvent }
+	PostUpdated   struct{ PostEvent }
+	PostPublished struct{ PostEvent } // Recorded along with PostCreated or PostUpdated when a post goes live
+	PostDeleted   struct{ PostEvent } // Moved to the trash
+)
+
+func (PostCreated) EventType() EventType   { return EventContentCreated }
+func (PostUpdated) EventType() EventType   { return EventContentUpdated }
+func (PostPublished) EventType() EventType { return EventContentPublished }
+func (PostDeleted) EventType() EventType   { return EventContentDeleted }
+
+// MenuEvent is the state of the menu a menu event is about
+type MenuEvent struct {
+	MenuID uuid.UUID `json:"menu_id"`
+	Name   string    `json:"name"`
+	Locale string    `json:"locale"`
+}
+
+// NewMenuEvent captures a menu for an event
+func NewMenuEvent(menu *Menu) MenuEvent {
+	return MenuEvent{MenuID: menu.ID, Name: menu.Name, Locale: menu.Locale}
+}
+
+func (e MenuEvent) AggregateType() string  { return "menu" }
+func (e MenuEvent) AggregateID() uuid.UUID { return e.MenuID }
+
+type (
+	MenuCreated struct{ MenuEvent }
+	MenuUpdated struct{ MenuEvent }
+	MenuDeleted struct{ MenuEvent } // Moved to the trash
+)
+
+func (MenuCreated) EventType() EventType { return EventContentCreated }
+func (MenuUpdated) EventType() EventType { return EventContentUpdated }
+func (MenuDeleted) EventType() EventType { return EventContentDeleted }
+
+// ContentRestored is recorded when a page, post, category or menu is brought back from the trash
+type ContentRestored struct{ TrashItem }
+
+func (ContentRestored) EventType() EventType     { return EventContentRestored }
+func (e ContentRestored) AggregateType() string  { return string(e.TrashItem.Type) }
+func (e ContentRestored) AggregateID() uuid.UUID { return e.ID }
+
+// LockAcquired is recorded when an editor takes an edit lock, but not when a heartbeat renews it
+type LockAcquired struct{ EditLock }
+
+func (LockAcquired) EventType() EventType     { return EventLockAcquired }
+func (e LockAcquired) AggregateType() string  { return string(e.EntityType) }
+func (e LockAcquired) AggregateID() uuid.UUID { return e.EntityID }
+
+// LockReleased is recorded when an edit lock is released before it expires
+type LockReleased struct {
+	ContentType EditLockEntityType `json:"entity_type"`
+	ContentID   uuid.UUID          `json:"entity_id"`
+}
+
+func (LockReleased) EventType() EventType     { return EventLockReleased }
+func (e LockReleased) AggregateType() string  { return string(e.ContentType) }
+func (e LockReleased) AggregateID() uuid.UUID { return e.ContentID }
+
+// MediaUploaded is recorded for every file uploaded or downloaded into the media library
+type MediaUploaded struct {
+	URL       string `json:"url"`
+	Filename  string `json:"filename,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	SourceURL string `json:"source_url,omitempty"` // Where a downloaded file came from
+}
+
+func (MediaUploaded) EventType() EventType   { return EventMediaUploaded }
+func (MediaUploaded) AggregateType() string  { return "media" }
+func (MediaUploaded) AggregateID() uuid.UUID { return uuid.Nil } // Files have no ID of their own
+
+// outboxFirstRetryDelay doubles after every failed dispatch, up to outboxMaxRetryDelay
+const (
+	outboxFirstRetryDelay = 5 * time.Second
+	outboxMaxRetryDelay   = time.Hour
+)
+
+// OutboxEvent is a domain event waiting in, or dispatched from, the transactional outbox
+// Events are retried until every subscriber has handled them, so subscribers may see an event more than once
+type OutboxEvent struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID      string         `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
+	Type          EventType      `gorm:"type:varchar(50);not null" json:"type"`
+	AggregateType string         `gorm:"type:varchar(20);not null" json:"aggregate_type"`
+	AggregateID   uuid.UUID      `gorm:"type:uuid;not null" json:"aggregate_id"`
+	ActorID       string         `gorm:"type:varchar(36)" json:"actor_id,omitempty"` // User whose request recorded the event
+	BatchID       string         `gorm:"type:varchar(36)" json:"batch_id,omitempty"` // Bulk action the event is part of
+	Payload       datatypes.JSON `gorm:"type:jsonb" json:"payload"`
+	Attempts      int            `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt *time.Time     `gorm:"index" json:"next_attempt_at,omitempty"` // Nil once dispatched
+	LastError     string         `gorm:"type:text" json:"last_error,omitempty"`
+	DispatchedAt  *time.Time     `gorm:"index" json:"dispatched_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating an outbox event
+func (e *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// NewOutboxEvent wraps a domain event for the outbox, due for dispatch immediately
+func NewOutboxEvent(tenantID, actorID string, event DomainEvent) (*OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+	now := time.Now()
+	return &OutboxEvent{
+		TenantID:      tenantID,
+		Type:          event.EventType(),
+		AggregateType: event.AggregateType(),
+		AggregateID:   event.AggregateID(),
+		ActorID:       actorID,
+		Payload:       payload,
+		NextAttemptAt: &now,
+	}, nil
+}
+
+// Decode unmarshals the payload into the typed event, e.g. a *PagePublished
+func (e *OutboxEvent) Decode(event DomainEvent) error {
+	return json.Unmarshal(e.Payload, event)
+}
+
+// Event returns the outbox event as it is streamed to admin sessions and sent to webhooks
+// The outbox ID identifies the event, so every subscriber sees the same one
+func (e *OutboxEvent) Event() Event {
+	event := Event{
+		ID:         e.ID.String(),
+		Type:       e.Type,
+		TenantID:   e.TenantID,
+		EntityType: e.AggregateType,
+		ActorID:    e.ActorID,
+		Data:       json.RawMessage(e.Payload),
+		CreatedAt:  e.CreatedAt,
+	}
+	if e.AggregateID != uuid.Nil {
+		event.EntityID = e.AggregateID.String()
+	}
+	return event
+}
+
+// RecordFailure schedules the next dispatch after a subscriber failed
+func (e *OutboxEvent) RecordFailure(now time.Time, err error) {
+	e.Attempts++
+	e.LastError = err.Error()
+	delay := outboxFirstRetryDelay
+	for i := 1; i < e.Attempts && delay < outboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > outboxMaxRetryDelay {
+		delay = outboxMaxRetryDelay
+	}
+	next := now.Add(delay)
+	e.NextAttemptAt = &next
+}
+
+// MarkDispatched records that every subscriber handled the event
+func (e *OutboxEvent) MarkDispatched(now time.Time) {
+	e.Attempts++
+	e.LastError = ""
+	e.NextAttemptAt = nil
+	e.DispatchedAt = &now
+}
+
+// MatchesEventName matches an event type against "*", a "content.*"-style prefix or an exact type
+func MatchesEventName(pattern, name string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, ".*"); ok {
+		return strings.HasPrefix(name, prefix+".")
+	}
+	return pattern == name
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,16 +13,30 @@ import (
 // Page represents a content page in the CMS
 // Uses JSONB blocks for flexible, schema-less content structure
 type Page struct {
-	ID          uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
-	TenantID    string         `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
-	Slug        string         `gorm:"index;not null" json:"slug"`
-	Title       string         `gorm:"not null" json:"title"`
-	Blocks      datatypes.JSON `gorm:"type:jsonb" json:"blocks"` // Array of Block objects
-	Status      PageStatus     `gorm:"type:varchar(20);default:'draft'" json:"status"`
-	Meta        datatypes.JSON `gorm:"type:jsonb" json:"meta"` // SEO, custom fields, etc.
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	PublishedAt *time.Time     `json:"published_at,omitempty"`
+	ID              uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID        string         `gorm:"index;not null;uniqueIndex:idx_pages_tenant_slug,priority:1,where:deleted_at IS NULL" json:"tenant_id"`                                                 // Empty string for community edition
+	Locale          string         `gorm:"type:varchar(10);not null;default:'en';uniqueIndex:idx_pages_tenant_slug,priority:2;uniqueIndex:idx_pages_translation_locale,priority:2" json:"locale"` // e.g. "en", "pt-BR"
+	Slug            string         `gorm:"not null;uniqueIndex:idx_pages_tenant_slug,priority:3" json:"slug"`                                                                                     // Full path, unique per tenant and locale (e.g. "services/consulting")
+	ParentID        *uuid.UUID     `gorm:"type:uuid;index" json:"parent_id,omitempty"`                                                                                                            // Parent page, nil for top-level pages
+	SortOrder       int            `gorm:"not null;default:0" json:"sort_order"`                                                                                                                  // Position among siblings
+	TranslationOfID *uuid.UUID     `gorm:"type:uuid;index;uniqueIndex:idx_pages_translation_locale,priority:1,where:deleted_at IS NULL" json:"translation_of_id,omitempty"`                       // Source page, nil for originals
+	SourceHash      string         `gorm:"type:varchar(64)" json:"source_hash,omitempty"`                                                                                                         // Source content hash when last translated
+	Title           string         `gorm:"not null" json:"title"`
+	Blocks          datatypes.JSON `gorm:"type:jsonb" json:"blocks"` // Array of Block objects
+	Status          PageStatus     `gorm:"type:varchar(20);default:'draft'" json:"status"`
+	Meta            datatypes.JSON `gorm:"type:jsonb" json:"meta"`                       // SEO, custom fields, etc.
+	TemplateID      *uuid.UUID     `gorm:"type:uuid;index" json:"template_id,omitempty"` // Template the page was created from
+	LockedBlocks    datatypes.JSON `gorm:"type:jsonb" json:"locked_blocks,omitempty"`    // IDs of blocks editors cannot remove
+	Version         int            `gorm:"not null;default:1" json:"version"`            // Incremented on every update, served as the ETag
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	PublishedAt     *time.Time     `json:"published_at,omitempty"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"` // Set while the page is in the trash
+
+	Translations []TranslationLink `gorm:"-" json:"translations,omitempty"` // Other locale variants, set on public responses
+	Breadcrumbs  []PageLink        `gorm:"-" json:"breadcrumbs,omitempty"`  // Ancestors, top-level page first, set on public responses
+	Previous     *PageLink         `gorm:"-" json:"previous,omitempty"`     // Previous sibling, set on public responses
+	Next         *PageLink         `gorm:"-" json:"next,omitempty"`         // Next sibling, set on public responses
 }
 
 // BeforeCreate is a GORM hook that generates UUID before creating a page
@@ -29,18 +45,119 @@ func (p *Page) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == uuid.Nil {
 		p.ID = uuid.New()
 	}
+	if p.Locale == "" {
+		p.Locale = DefaultLocale
+	}
+	if p.Version == 0 {
+		p.Version = 1
+	}
 	return nil
 }
 
+// SourceID returns the ID of the page this page is a variant of (its own ID for originals)
+func (p *Page) SourceID() uuid.UUID {
+	if p.TranslationOfID != nil {
+		return *p.TranslationOfID
+	}
+	return p.ID
+}
+
+// ContentHash returns a hash of the translatable content of the page
+// Translations store the source's hash so they can be flagged as outdated when it changes
+func (p *Page) ContentHash() string {
+	return contentHash([]byte(p.Title), p.Blocks, p.Meta)
+}
+
+// LockedBlockIDs returns the IDs of the page's locked blocks
+func (p *Page) LockedBlockIDs() []string {
+	var ids []string
+	if len(p.LockedBlocks) == 0 {
+		return ids
+	}
+	_ = json.Unmarshal(p.LockedBlocks, &ids)
+	return ids
+}
+
+// SetLockedBlockIDs stores the IDs of the page's locked blocks
+func (p *Page) SetLockedBlockIDs(ids []string) {
+	if len(ids) == 0 {
+		p.LockedBlocks = nil
+		return
+	}
+	p.LockedBlocks, _ = json.Marshal(ids)
+}
+
+// CheckLockedBlocks verifies that new blocks for the page keep every locked block with its type
+// Locked blocks may still be edited and moved
+func (p *Page) CheckLockedBlocks(blocks []Block) error {
+	var locked []string
+	if len(p.LockedBlocks) > 0 {
+		if err := json.Unmarshal(p.LockedBlocks, &locked); err != nil {
+			return fmt.Errorf("invalid locked blocks: %w", err)
+		}
+	}
+	if len(locked) == 0 {
+		return nil
+	}
+
+	var current []Block
+	if len(p.Blocks) > 0 {
+		if err := json.Unmarshal(p.Blocks, &current); err != nil {
+			return fmt.Errorf("invalid page blocks: %w", err)
+		}
+	}
+	currentTypes := make(map[string]string, len(current))
+	for _, block := range current {
+		currentTypes[block.ID] = block.Type
+	}
+	newTypes := make(map[string]string, len(blocks))
+	for _, block := range blocks {
+		newTypes[block.ID] = block.Type
+	}
+
+	for _, id := range locked {
+		typ, ok := newTypes[id]
+		if !ok {
+			return fmt.Errorf("%w: block %s cannot be removed", ErrBlockLocked, id)
+		}
+		if currentType, known := currentTypes[id]; known && typ != currentType {
+			return fmt.Errorf("%w: block %s cannot change type", ErrBlockLocked, id)
+		}
+	}
+	return nil
+}
+
+// PruneLockedBlocks drops locks on blocks that are no longer on the page
+func (p *Page) PruneLockedBlocks(blocks []Block) {
+	present := make(map[string]bool, len(blocks))
+	for _, block := range blocks {
+		present[block.ID] = true
+	}
+	kept := []string{}
+	for _, id := range p.LockedBlockIDs() {
+		if present[id] {
+			kept = append(kept, id)
+		}
+	}
+	p.SetLockedBlockIDs(kept)
+}
+
 // PageStatus represents the publication status of a page
 type PageStatus string
 
 const (
 	PageStatusDraft     PageStatus = "draft"
+	PageStatusInReview  PageStatus = "in_review" // Submitted for review
+	PageStatusApproved  PageStatus = "approved"  // Approved by a reviewer, ready to publish
 	PageStatusPublished PageStatus = "published"
 	PageStatusArchived  PageStatus = "archived"
 )
 
+// IsValid reports whether s is a known page status
+func (s PageStatus) IsValid() bool {
+	return IsWorkflowStatus(string(s))
+}
+
 // TableName specifies the table name for GORM
 func (Page) TableName() string {
 	return "pages"
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// TemplateBlock is a block of a page template
+// Locked blocks cannot be removed from pages created from the template, except by admins
+type TemplateBlock struct {
+	Block
+	Locked bool `json:"locked,omitempty"`
+}
+
+// PageTemplate is a stored block layout with placeholder data and default meta
+// New pages can be initialised from it
+type PageTemplate struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID    string         `gorm:"index;not null;uniqueIndex:idx_page_templates_tenant_name,priority:1" json:"tenant_id"` // Empty string for community edition
+	Name        string         `gorm:"type:varchar(255);not null;uniqueIndex:idx_page_templates_tenant_name,priority:2" json:"name"`
+	Description string         `gorm:"type:text" json:"description"`
+	Blocks      datatypes.JSON `gorm:"type:jsonb" json:"blocks"` // Array of TemplateBlock objects
+	Meta        datatypes.JSON `gorm:"type:jsonb" json:"meta"`   // Default page meta
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a page template
+func (t *PageTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (PageTemplate) TableName() string {
+	return "page_templates"
+}
+
+// SetBlocks validates a template's blocks and stores them, generating missing block IDs
+func (t *PageTemplate) SetBlocks(blocks []TemplateBlock) error {
+	seen := make(map[string]bool, len(blocks))
+	for i := range blocks {
+		if blocks[i].ID == "" {
+			blocks[i].ID = uuid.New().String()
+		}
+		switch {
+		case seen[blocks[i].ID]:
+			return fmt.Errorf("%w: duplicate block id %q", ErrInvalidPageTemplate, blocks[i].ID)
+		case blocks[i].Type == "":
+			return fmt.Errorf("%w: %v", ErrInvalidPageTemplate, ErrBlockMissingType)
+		case len(blocks[i].Data) == 0:
+			return fmt.Errorf("%w: %v", ErrInvalidPageTemplate, ErrBlockMissingData)
+		}
+		seen[blocks[i].ID] = true
+	}
+
+	raw, err := json.Marshal(blocks)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPageTemplate, err)
+	}
+	t.Blocks = raw
+	return nil
+}
+
+// TemplateBlocks decodes the template's blocks
+func (t *PageTemplate) TemplateBlocks() ([]TemplateBlock, error) {
+	var blocks []TemplateBlock
+	if len(t.Blocks) == 0 {
+		return blocks, nil
+	}
+	if err := json.Unmarshal(t.Blocks, &blocks); err != nil {
+		return nil, fmt.Errorf("invalid template blocks: %w", err)
+	}
+	return blocks, nil
+}
+
+// Validate checks the template's name
+func (t *PageTemplate) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPageTemplate)
+	}
+	return nil
+}
+
+// Instantiate returns the blocks for a new page created from the template
+// Every block gets a fresh ID; the IDs of locked blocks are returned separately
+func (t *PageTemplate) Instantiate() ([]Block, []string, error) {
+	templateBlocks, err := t.TemplateBlocks()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blocks := make([]Block, 0, len(templateBlocks))
+	locked := []string{}
+	for _, tb := range templateBlocks {
+		block := tb.Block
+		block.ID = uuid.New().String()
+		if tb.Locked {
+			locked = append(locked, block.ID)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, locked, nil
+}
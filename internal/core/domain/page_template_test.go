@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageTemplate_Instantiate(t *testing.T) {
+	template := &PageTemplate{Name: "Landing"}
+	require.NoError(t, template.SetBlocks([]TemplateBlock{
+		{Block: Block{ID: "hero", Type: "hero", Data: json.RawMessage(`{"title":"Headline"}`)}, Locked: true},
+		{Block: Block{Type: "text", Data: json.RawMessage(`{"content":"Body"}`)}},
+	}))
+
+	first, locked, err := template.Instantiate()
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, []string{first[0].ID}, locked)
+	assert.NotEqual(t, "hero", first[0].ID)
+
+	// Every page gets its own block IDs
+	second, _, err := template.Instantiate()
+	require.NoError(t, err)
+	assert.NotEqual(t, first[0].ID, second[0].ID)
+	assert.NotEqual(t, first[1].ID, second[1].ID)
+
+	err = template.SetBlocks([]TemplateBlock{{Block: Block{ID: "a", Type: "text", Data: json.RawMessage(`{}`)}}, {Block: Block{ID: "a", Type: "text", Data: json.RawMessage(`{}`)}}})
+	assert.True(t, errors.Is(err, ErrInvalidPageTemplate))
+}
+
+func TestPage_CheckLockedBlocks(t *testing.T) {
+	page := &Page{Blocks: []byte(`[{"id":"a","type":"hero","data":{}},{"id":"b","type":"text","data":{}}]`)}
+	page.SetLockedBlockIDs([]string{"a"})
+
+	edited := []Block{{ID: "b", Type: "text"}, {ID: "a", Type: "hero", Data: json.RawMessage(`{"title":"New"}`)}}
+	assert.NoError(t, page.CheckLockedBlocks(edited))
+	assert.True(t, errors.Is(page.CheckLockedBlocks([]Block{{ID: "b", Type: "text"}}), ErrBlockLocked))
+	assert.True(t, errors.Is(page.CheckLockedBlocks([]Block{{ID: "a", Type: "text"}}), ErrBlockLocked))
+
+	page.PruneLockedBlocks([]Block{{ID: "b", Type: "text"}})
+	assert.Empty(t, page.LockedBlockIDs())
+	assert.Nil(t, page.LockedBlocks)
+}
@@ -114,3 +114,48 @@ func TestPage_Blocks_JSONMarshaling(t *testing.T) {
 	assert.Equal(t, "<p>Hello World</p>", textData.Content)
 	assert.Equal(t, "left", textData.Align)
 }
+
+func TestNormalizeLocale(t *testing.T) {
+	assert.Equal(t, "en", NormalizeLocale("EN"))
+	assert.Equal(t, "pt-BR", NormalizeLocale("pt_br"))
+	assert.Equal(t, "zh-Hant-TW", NormalizeLocale("zh-hant-tw"))
+	assert.Equal(t, "es-419", NormalizeLocale("es-419"))
+	assert.Equal(t, "", NormalizeLocale("english"))
+	assert.Equal(t, "", NormalizeLocale(""))
+}
+
+func TestPage_ContentHash(t *testing.T) {
+	page := &Page{Title: "About", Blocks: []byte(`[]`)}
+	hash := page.ContentHash()
+
+	// Status and slug are not translatable content
+	page.Status = PageStatusPublished
+	page.Slug = "about-us"
+	assert.Equal(t, hash, page.ContentHash())
+
+	page.Title = "About us"
+	assert.NotEqual(t, hash, page.ContentHash())
+}
+
+func TestBuildPageTree(t *testing.T) {
+	services := &Page{ID: uuid.New(), Slug: "services"}
+	consulting := &Page{ID: uuid.New(), Slug: "services/consulting", ParentID: &services.ID}
+	orphanParent := uuid.New()
+	orphan := &Page{ID: uuid.New(), Slug: "orphan", ParentID: &orphanParent}
+	about := &Page{ID: uuid.New(), Slug: "about"}
+
+	tree := BuildPageTree([]*Page{services, consulting, orphan, about})
+	require.Len(t, tree, 3)
+	assert.Equal(t, "services", tree[0].Slug)
+	require.Len(t, tree[0].Children, 1)
+	assert.Equal(t, "services/consulting", tree[0].Children[0].Slug)
+	assert.Equal(t, "orphan", tree[1].Slug)
+	assert.Equal(t, "about", tree[2].Slug)
+}
+
+func TestPagePath(t *testing.T) {
+	assert.Equal(t, "consulting", PageSegment("services/consulting"))
+	assert.Equal(t, "about", PageSegment("/about/"))
+	assert.Equal(t, "services/consulting", PagePath("services", "consulting"))
+	assert.Equal(t, "about", PagePath("", "about"))
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxPageDepth limits how deeply pages can be nested
+const MaxPageDepth = 16
+
+// PageLink is a reference to a page used for navigation
+type PageLink struct {
+	ID    uuid.UUID `json:"id"`
+	Slug  string    `json:"slug"`
+	Title string    `json:"title"`
+}
+
+// NewPageLink returns a navigation link to a page
+func NewPageLink(p *Page) PageLink {
+	return PageLink{ID: p.ID, Slug: p.Slug, Title: p.Title}
+}
+
+// PageTreeNode is a page and its children in the page hierarchy
+type PageTreeNode struct {
+	ID        uuid.UUID       `json:"id"`
+	Slug      string          `json:"slug"`
+	Title     string          `json:"title"`
+	Status    PageStatus      `json:"status"`
+	Locale    string          `json:"locale"`
+	SortOrder int             `json:"sort_order"`
+	Children  []*PageTreeNode `json:"children"`
+}
+
+// BuildPageTree nests pages under their parents, keeping the order of pages
+// Pages whose parent is not in the list are treated as top-level pages
+func BuildPageTree(pages []*Page) []*PageTreeNode {
+	nodes := make(map[uuid.UUID]*PageTreeNode, len(pages))
+	for _, p := range pages {
+		nodes[p.ID] = &PageTreeNode{
+			ID:        p.ID,
+			Slug:      p.Slug,
+			Title:     p.Title,
+			Status:    p.Status,
+			Locale:    p.Locale,
+			SortOrder: p.SortOrder,
+			Children:  []*PageTreeNode{},
+		}
+	}
+
+	roots := []*PageTreeNode{}
+	for _, p := range pages {
+		node := nodes[p.ID]
+		if p.ParentID != nil {
+			if parent, ok := nodes[*p.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+// PageSegment returns the last segment of a page path (e.g. "services/consulting" -> "consulting")
+func PageSegment(slug string) string {
+	slug = strings.Trim(slug, "/")
+	if i := strings.LastIndex(slug, "/"); i >= 0 {
+		return slug[i+1:]
+	}
+	return slug
+}
+
+// PagePath returns the path of a page with the given segment under a parent path
+// An empty parent path means a top-level page
+func PagePath(parentSlug, segment string) string {
+	if parentSlug == "" {
+		return segment
+	}
+	return parentSlug + "/" + segment
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -12,26 +13,68 @@ type PostStatus string
 
 const (
 	PostStatusDraft     PostStatus = "draft"
+	PostStatusInReview  PostStatus = "in_review"
+	PostStatusApproved  PostStatus = "approved"
 	PostStatusPublished PostStatus = "published"
 	PostStatusArchived  PostStatus = "archived"
 )
 
+// IsValid reports whether s is a known post status
+func (s PostStatus) IsValid() bool {
+	return IsWorkflowStatus(string(s))
+}
+
 // Post represents a blog post
 type Post struct {
-	ID            uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
-	TenantID      string     `gorm:"index" json:"tenant_id"` // Empty string for community edition
-	Title         string     `gorm:"type:varchar(255);not null" json:"title"`
-	Slug          string     `gorm:"type:varchar(255);not null;uniqueIndex" json:"slug"`
-	Excerpt       string     `gorm:"type:text" json:"excerpt"`
-	Content       string     `gorm:"type:text" json:"content"` // JSON Blocks array
-	FeaturedImage string     `gorm:"type:varchar(500)" json:"featured_image"`
-	Status        PostStatus `gorm:"type:varchar(20);not null;default:'draft'" json:"status"`
-	PublishedAt   *time.Time `json:"published_at"`
-	AuthorID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"author_id"`
-	Author        User       `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
-	Categories    []Category `gorm:"many2many:post_categories;" json:"categories,omitempty"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
+	ID              uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID        string         `gorm:"index;uniqueIndex:idx_posts_tenant_slug,priority:1,where:deleted_at IS NULL" json:"tenant_id"` // Empty string for community edition
+	Locale          string         `gorm:"type:varchar(10);not null;default:'en';uniqueIndex:idx_posts_tenant_slug,priority:2;uniqueIndex:idx_posts_translation_locale,priority:2" json:"locale"`
+	Title           string         `gorm:"type:varchar(255);not null" json:"title"`
+	Slug            string         `gorm:"type:varchar(255);not null;uniqueIndex:idx_posts_tenant_slug,priority:3" json:"slug"`                                             // Unique per tenant and locale
+	TranslationOfID *uuid.UUID     `gorm:"type:uuid;index;uniqueIndex:idx_posts_translation_locale,priority:1,where:deleted_at IS NULL" json:"translation_of_id,omitempty"` // Source post, nil for originals
+	SourceHash      string         `gorm:"type:varchar(64)" json:"source_hash,omitempty"`                                                                                   // Source content hash when last translated
+	Excerpt         string         `gorm:"type:text" json:"excerpt"`
+	Blocks          datatypes.JSON `gorm:"type:jsonb" json:"blocks"` // Array of Block objects, like Page.Blocks
+	Content         string         `gorm:"type:text" json:"content"` // Legacy Markdown/HTML body, served when the post has no blocks
+	FeaturedImage   string         `gorm:"type:varchar(500)" json:"featured_image"`
+	Status          PostStatus     `gorm:"type:varchar(20);not null;default:'draft'" json:"status"`
+	PublishedAt     *time.Time     `json:"published_at"`
+	Version         int            `gorm:"not null;default:1" json:"version"` // Incremented on every update, like Page.Version
+	AuthorID        uuid.UUID      `gorm:"type:uuid;not null;index" json:"author_id"`
+	Author          User           `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Categories      []Category     `gorm:"many2many:post_categories;" json:"categories,omitempty"`
+	Tags            []Tag          `gorm:"many2many:post_tags;" json:"tags,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"` // Set while the post is in the trash
+
+	Translations []TranslationLink `gorm:"-" json:"translations,omitempty"` // Other locale variants, set on public responses
+}
+
+// SourceID returns the ID of the post this post is a variant of (its own ID for originals)
+func (p *Post) SourceID() uuid.UUID {
+	if p.TranslationOfID != nil {
+		return *p.TranslationOfID
+	}
+	return p.ID
+}
+
+// ContentHash returns a hash of the translatable content of the post
+func (p *Post) ContentHash() string {
+	return contentHash([]byte(p.Title), []byte(p.Excerpt), p.Body())
+}
+
+// Body returns the post's blocks, or its legacy content if it has none
+func (p *Post) Body() []byte {
+	if p.HasBlocks() {
+		return p.Blocks
+	}
+	return []byte(p.Content)
+}
+
+// HasBlocks reports whether the post's body is stored as blocks
+func (p *Post) HasBlocks() bool {
+	return len(p.Blocks) > 0 && string(p.Blocks) != "null"
 }
 
 // BeforeCreate is a GORM hook that generates UUID before creating a post
@@ -39,6 +82,12 @@ func (p *Post) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == uuid.Nil {
 		p.ID = uuid.New()
 	}
+	if p.Locale == "" {
+		p.Locale = DefaultLocale
+	}
+	if p.Version == 0 {
+		p.Version = 1
+	}
 	return nil
 }
 
@@ -49,14 +98,16 @@ func (Post) TableName() string {
 
 // Category represents a blog category/taxonomy
 type Category struct {
-	ID          uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
-	TenantID    string    `gorm:"index" json:"tenant_id"` // Empty string for community edition
-	Name        string    `gorm:"type:varchar(100);not null" json:"name"`
-	Slug        string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"slug"`
-	Description string    `gorm:"type:text" json:"description"`
-	Posts       []Post    `gorm:"many2many:post_categories;" json:"posts,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID    string         `gorm:"index;uniqueIndex:idx_categories_tenant_slug,priority:1,where:deleted_at IS NULL" json:"tenant_id"` // Empty string for community edition
+	ParentID    *uuid.UUID     `gorm:"type:uuid;index" json:"parent_id,omitempty"`                                                        // Parent category, nil for top-level categories
+	Name        string         `gorm:"type:varchar(100);not null" json:"name"`
+	Slug        string         `gorm:"type:varchar(255);not null;uniqueIndex:idx_categories_tenant_slug,priority:2" json:"slug"` // Full path (e.g. "tech/go"), unique per tenant
+	Description string         `gorm:"type:text" json:"description"`
+	Posts       []Post         `gorm:"many2many:post_categories;" json:"posts,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"` // Set while the category is in the trash
 }
 
 // BeforeCreate is a GORM hook that generates UUID before creating a category
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlockContent(t *testing.T) {
+	blocks, ok := ParseBlockContent(` [{"id":"1","type":"text","data":{"content":"Hi"}}]`)
+	require.True(t, ok)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "text", blocks[0].Type)
+
+	for _, content := range []string{"", "# Title", "<p>[1]</p>", "[1, 2]", `[{"id":"1"}]`} {
+		_, ok := ParseBlockContent(content)
+		assert.False(t, ok, content)
+	}
+}
+
+func TestValidateBlocks(t *testing.T) {
+	data := json.RawMessage(`{}`)
+	assert.NoError(t, ValidateBlocks([]Block{{ID: "a", Type: "text", Data: data}, {ID: "b", Type: "cta", Data: data}}))
+	assert.Error(t, ValidateBlocks([]Block{{Type: "text", Data: data}}))
+	assert.Error(t, ValidateBlocks([]Block{{ID: "a", Data: data}}))
+	assert.Error(t, ValidateBlocks([]Block{{ID: "a", Type: "text"}}))
+	assert.Error(t, ValidateBlocks([]Block{{ID: "a", Type: "text", Data: data}, {ID: "a", Type: "cta", Data: data}}))
+}
+
+func TestPost_ContentHashUnchangedByBlockConversion(t *testing.T) {
+	content := `[{"id":"1","type":"text","data":{"content":"Hi"}}]`
+	legacy := &Post{Title: "T", Content: content}
+	converted := &Post{Title: "T", Blocks: []byte(content)}
+
+	assert.False(t, legacy.HasBlocks())
+	assert.True(t, converted.HasBlocks())
+	assert.Equal(t, legacy.ContentHash(), converted.ContentHash())
+	assert.False(t, (&Post{Blocks: []byte("null")}).HasBlocks())
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	tech := &Category{ID: uuid.New(), Name: "Tech", Slug: "tech"}
+	golang := &Category{ID: uuid.New(), Name: "Go", Slug: "tech/go", ParentID: &tech.ID}
+	travel := &Category{ID: uuid.New(), Name: "Travel", Slug: "travel"}
+
+	tree := BuildCategoryTree([]*Category{tech, golang, travel})
+	require.Len(t, tree, 2)
+	require.Len(t, tree[0].Children, 1)
+	assert.Equal(t, "tech/go", tree[0].Children[0].Slug)
+	assert.Equal(t, "/blog/category/tech/go", tree[0].Children[0].Path)
+	assert.Empty(t, tree[1].Children)
+}
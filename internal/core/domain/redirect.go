@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RedirectMatchType defines how a redirect's source is matched against a request path
+type RedirectMatchType string
+
+const (
+	RedirectMatchExact  RedirectMatchType = "exact"  // Source is a path (e.g. "/old-page")
+	RedirectMatchPrefix RedirectMatchType = "prefix" // Source ends in "*" (e.g. "/blog/*"); a "*" in the target is replaced by the rest of the path
+	RedirectMatchRegex  RedirectMatchType = "regex"  // Source is a regular expression; the target may use $1-style references
+)
+
+// PostPathPrefix is the public path under which posts are served
+// Pages are served at their slug directly under "/"
+const PostPathPrefix = "/posts/"
+
+// redirectPatterns caches the compiled source of regex rules by rule ID
+// An entry is used while the rule's source and updated_at are unchanged
+var redirectPatterns sync.Map
+
+// compiledPattern is a cached regex rule source
+type compiledPattern struct {
+	source    string
+	updatedAt time.Time
+	re        *regexp.Regexp
+}
+
+// Redirect sends requests for an old path to a new one, or marks it as gone
+type Redirect struct {
+	ID         uuid.UUID         `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID   string            `gorm:"index;not null;uniqueIndex:idx_redirects_tenant_source,priority:1" json:"tenant_id"` // Empty string for community edition
+	Source     string            `gorm:"type:varchar(500);not null;uniqueIndex:idx_redirects_tenant_source,priority:2" json:"source"`
+	Target     string            `gorm:"type:varchar(500)" json:"target"` // Empty for 410 Gone
+	StatusCode int               `gorm:"not null;default:301" json:"status_code"`
+	MatchType  RedirectMatchType `gorm:"type:varchar(10);not null;default:'exact'" json:"match_type"`
+	Automatic  bool              `gorm:"not null;default:false" json:"automatic"` // Created when a slug changed
+	Hits       int64             `gorm:"not null;default:0" json:"hits"`
+	LastHitAt  *time.Time        `json:"last_hit_at,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a redirect
+func (r *Redirect) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (Redirect) TableName() string {
+	return "redirects"
+}
+
+// Normalize fills in defaults and validates the redirect
+// The match type is inferred from the source when not set
+func (r *Redirect) Normalize() error {
+	r.Source = strings.TrimSpace(r.Source)
+	r.Target = strings.TrimSpace(r.Target)
+	if r.Source == "" {
+		return fmt.Errorf("%w: source is required", ErrInvalidRedirect)
+	}
+
+	if r.MatchType == "" {
+		switch {
+		case strings.HasPrefix(r.Source, "^"):
+			r.MatchType = RedirectMatchRegex
+		case strings.HasSuffix(r.Source, "*"):
+			r.MatchType = RedirectMatchPrefix
+		default:
+			r.MatchType = RedirectMatchExact
+		}
+	}
+
+	switch r.MatchType {
+	case RedirectMatchExact:
+		r.Source = NormalizePath(r.Source)
+	case RedirectMatchPrefix:
+		prefix := NormalizePath(strings.TrimSuffix(r.Source, "*"))
+		if prefix != "/" {
+			prefix += "/"
+		}
+		r.Source = prefix + "*"
+	case RedirectMatchRegex:
+		if _, err := regexp.Compile(r.Source); err != nil {
+			return fmt.Errorf("%w: invalid regular expression: %v", ErrInvalidRedirect, err)
+		}
+	default:
+		return fmt.Errorf("%w: match type must be 'exact', 'prefix' or 'regex'", ErrInvalidRedirect)
+	}
+
+	if r.StatusCode == 0 {
+		r.StatusCode = 301
+	}
+	switch r.StatusCode {
+	case 301, 302:
+		if r.Target == "" {
+			return fmt.Errorf("%w: target is required", ErrInvalidRedirect)
+		}
+	case 410:
+		r.Target = ""
+	default:
+		return fmt.Errorf("%w: status code must be 301, 302 or 410", ErrInvalidRedirect)
+	}
+
+	if r.MatchType == RedirectMatchExact && r.Target == r.Source {
+		return fmt.Errorf("%w: source and target are the same", ErrInvalidRedirect)
+	}
+	return nil
+}
+
+// Match reports whether the redirect applies to path and returns the resolved target
+func (r *Redirect) Match(path string) (string, bool) {
+	switch r.MatchType {
+	case RedirectMatchExact:
+		return r.Target, NormalizePath(path) == r.Source
+	case RedirectMatchPrefix:
+		prefix := strings.TrimSuffix(r.Source, "*")
+		if !strings.HasPrefix(path, prefix) {
+			return "", false
+		}
+		return strings.Replace(r.Target, "*", strings.TrimPrefix(path, prefix), 1), true
+	case RedirectMatchRegex:
+		re, err := r.pattern()
+		if err != nil {
+			return "", false
+		}
+		match := re.FindStringSubmatchIndex(path)
+		if match == nil {
+			return "", false
+		}
+		return string(re.ExpandString(nil, r.Target, path, match)), true
+	}
+	return "", false
+}
+
+// pattern returns the compiled source of a regex rule, compiling it once per change of the rule
+func (r *Redirect) pattern() (*regexp.Regexp, error) {
+	if cached, ok := redirectPatterns.Load(r.ID); ok {
+		if p := cached.(compiledPattern); p.source == r.Source && p.updatedAt.Equal(r.UpdatedAt) {
+			return p.re, nil
+		}
+	}
+	re, err := regexp.Compile(r.Source)
+	if err != nil {
+		return nil, err
+	}
+	if r.ID != uuid.Nil {
+		redirectPatterns.Store(r.ID, compiledPattern{source: r.Source, updatedAt: r.UpdatedAt, re: re})
+	}
+	return re, nil
+}
+
+// NormalizePath returns a path with a leading slash and without a trailing slash
+func NormalizePath(path string) string {
+	return "/" + strings.Trim(strings.TrimSpace(path), "/")
+}
+
+// PagePublicPath returns the public path of a page
+func PagePublicPath(slug string) string {
+	return NormalizePath(slug)
+}
+
+// PostPublicPath returns the public path of a post
+func PostPublicPath(slug string) string {
+	return PostPathPrefix + strings.Trim(slug, "/")
+}
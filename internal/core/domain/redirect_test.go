@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedirect_Normalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		redirect  Redirect
+		source    string
+		matchType RedirectMatchType
+		target    string
+		wantErr   bool
+	}{
+		{"exact path", Redirect{Source: "old-page/", Target: "/new-page"}, "/old-page", RedirectMatchExact, "/new-page", false},
+		{"prefix inferred", Redirect{Source: "/blog/*", Target: "/posts/*"}, "/blog/*", RedirectMatchPrefix, "/posts/*", false},
+		{"prefix without slash", Redirect{Source: "/blog*", Target: "/posts"}, "/blog/*", RedirectMatchPrefix, "/posts", false},
+		{"regex inferred", Redirect{Source: `^/news/(\d+)$`, Target: "/posts/$1"}, `^/news/(\d+)$`, RedirectMatchRegex, "/posts/$1", false},
+		{"gone clears target", Redirect{Source: "/removed", Target: "/x", StatusCode: 410}, "/removed", RedirectMatchExact, "", false},
+		{"missing target", Redirect{Source: "/old"}, "", "", "", true},
+		{"invalid status", Redirect{Source: "/old", Target: "/new", StatusCode: 307}, "", "", "", true},
+		{"invalid regex", Redirect{Source: "^/(", Target: "/new"}, "", "", "", true},
+		{"self redirect", Redirect{Source: "/same", Target: "/same"}, "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redirect := tt.redirect
+			err := redirect.Normalize()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidRedirect))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.source, redirect.Source)
+			assert.Equal(t, tt.matchType, redirect.MatchType)
+			assert.Equal(t, tt.target, redirect.Target)
+		})
+	}
+}
+
+func TestRedirect_Match(t *testing.T) {
+	exact := Redirect{Source: "/old", Target: "/new"}
+	prefix := Redirect{Source: "/blog/*", Target: "/posts/*"}
+	regex := Redirect{Source: `^/news/(\d+)$`, Target: "/posts/news-$1"}
+	for _, r := range []*Redirect{&exact, &prefix, &regex} {
+		require.NoError(t, r.Normalize())
+	}
+
+	target, ok := exact.Match("/old/")
+	assert.True(t, ok)
+	assert.Equal(t, "/new", target)
+
+	target, ok = prefix.Match("/blog/2024/hello")
+	assert.True(t, ok)
+	assert.Equal(t, "/posts/2024/hello", target)
+
+	_, ok = prefix.Match("/blogroll")
+	assert.False(t, ok)
+
+	target, ok = regex.Match("/news/42")
+	assert.True(t, ok)
+	assert.Equal(t, "/posts/news-42", target)
+
+	_, ok = regex.Match("/news/latest")
+	assert.False(t, ok)
+}
+
+func TestRedirect_MatchRecompilesChangedRules(t *testing.T) {
+	rule := Redirect{ID: uuid.New(), Source: `^/news/(\d+)$`, Target: "/posts/$1", UpdatedAt: time.Now()}
+	require.NoError(t, rule.Normalize())
+	target, ok := rule.Match("/news/42")
+	assert.True(t, ok)
+	assert.Equal(t, "/posts/42", target)
+
+	// A saved change to the source replaces the cached pattern
+	rule.Source = `^/articles/(\d+)$`
+	rule.UpdatedAt = rule.UpdatedAt.Add(time.Second)
+	_, ok = rule.Match("/news/42")
+	assert.False(t, ok)
+	target, ok = rule.Match("/articles/7")
+	assert.True(t, ok)
+	assert.Equal(t, "/posts/7", target)
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SearchEntityType identifies the kind of content a search document was built from
+type SearchEntityType string
+
+const (
+	SearchEntityPage SearchEntityType = "page"
+	SearchEntityPost SearchEntityType = "post"
+)
+
+// SearchDocument is the searchable text of a page or post
+// Documents are derived from content and rebuilt whenever it is saved
+type SearchDocument struct {
+	EntityType SearchEntityType `gorm:"type:varchar(20);primaryKey" json:"entity_type"`
+	EntityID   uuid.UUID        `gorm:"type:uuid;primaryKey" json:"entity_id"`
+	TenantID   string           `gorm:"type:varchar(100);index;not null" json:"tenant_id"` // Empty string for community edition
+	Slug       string           `gorm:"not null" json:"slug"`
+	Status     string           `gorm:"type:varchar(20);not null" json:"status"`
+	Title      string           `gorm:"not null" json:"title"`
+	Excerpt    string           `gorm:"type:text" json:"excerpt"`
+	Body       string           `gorm:"type:text" json:"body"` // Plain text extracted from blocks
+}
+
+// TableName specifies the table name for GORM
+func (SearchDocument) TableName() string {
+	return "search_documents"
+}
+
+// SearchResult is a ranked search hit
+// Snippet is HTML-escaped text with matches wrapped in <mark> tags
+type SearchResult struct {
+	EntityType SearchEntityType `json:"type"`
+	EntityID   uuid.UUID        `json:"id"`
+	Slug       string           `json:"slug"`
+	Title      string           `json:"title"`
+	Status     string           `json:"status"`
+	Snippet    string           `json:"snippet"`
+	Rank       float64          `json:"rank"`
+}
+
+// NewPageSearchDocument builds the search document of a page
+// The excerpt is the page's meta description, if any
+func NewPageSearchDocument(p *Page) *SearchDocument {
+	var meta struct {
+		Description string `json:"description"`
+	}
+	if len(p.Meta) > 0 {
+		_ = json.Unmarshal(p.Meta, &meta)
+	}
+
+	return &SearchDocument{
+		EntityType: SearchEntityPage,
+		EntityID:   p.ID,
+		TenantID:   p.TenantID,
+		Slug:       p.Slug,
+		Status:     string(p.Status),
+		Title:      p.Title,
+		Excerpt:    meta.Description,
+		Body:       ExtractBlockText(p.Blocks),
+	}
+}
+
+// NewPostSearchDocument builds the search document of a post
+// Text is extracted from the post's blocks like a page's, or from its legacy HTML/Markdown content
+func NewPostSearchDocument(p *Post) *SearchDocument {
+	body := ExtractBlockText(p.Blocks)
+	if !p.HasBlocks() {
+		body = stripHTML(p.Content)
+	}
+
+	return &SearchDocument{
+		EntityType: SearchEntityPost,
+		EntityID:   p.ID,
+		TenantID:   p.TenantID,
+		Slug:       p.Slug,
+		Status:     string(p.Status),
+		Title:      p.Title,
+		Excerpt:    p.Excerpt,
+		Body:       body,
+	}
+}
+
+// nonTextBlockKeys are block data fields holding identifiers, links or styling rather than prose
+var nonTextBlockKeys = map[string]bool{
+	"id":           true,
+	"type":         true,
+	"url":          true,
+	"src":          true,
+	"href":         true,
+	"image_url":    true,
+	"avatar_url":   true,
+	"button_url":   true,
+	"icon":         true,
+	"menu_id":      true,
+	"style":        true,
+	"button_style": true,
+	"background":   true,
+	"align":        true,
+	"language":     true,
+}
+
+// ExtractBlockText returns the human-readable text of a blocks array, one block per line
+// Returns an empty string if blocksJSON is not a blocks array
+func ExtractBlockText(blocksJSON []byte) string {
+	if len(blocksJSON) == 0 {
+		return ""
+	}
+
+	var blocks []Block
+	if err := json.Unmarshal(blocksJSON, &blocks); err != nil {
+		return ""
+	}
+
+	var lines []string
+	for _, block := range blocks {
+		if len(block.Data) == 0 {
+			continue
+		}
+		var data interface{}
+		if err := json.Unmarshal(block.Data, &data); err != nil {
+			continue
+		}
+
+		var parts []string
+		collectText(data, &parts)
+		if len(parts) > 0 {
+			lines = append(lines, strings.Join(parts, " "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// collectText appends the text values found in decoded block data
+func collectText(v interface{}, parts *[]string) {
+	switch value := v.(type) {
+	case string:
+		if text := stripHTML(value); text != "" {
+			*parts = append(*parts, text)
+		}
+	case []interface{}:
+		for _, item := range value {
+			collectText(item, parts)
+		}
+	case map[string]interface{}:
+		// Sorted so the extracted text is stable
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			if !nonTextBlockKeys[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			collectText(value[key], parts)
+		}
+	}
+}
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// stripHTML removes tags and entities and collapses whitespace
+func stripHTML(s string) string {
+	s = htmlTagPattern.ReplaceAllString(s, " ")
+	s = html.UnescapeString(s)
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(s, " "))
+}
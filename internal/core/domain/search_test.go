@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestExtractBlockText(t *testing.T) {
+	blocks := datatypes.JSON(`[
+		{"id":"1","type":"hero","data":{"title":"Welcome","subtitle":"Fast &amp; simple","image_url":"/uploads/hero.png","cta":{"text":"Start","url":"/start"}}},
+		{"id":"2","type":"text","data":{"content":"<p>Hello <strong>world</strong></p>","align":"left"}},
+		{"id":"3","type":"menu","data":{"menu_id":"0b8c7a3e-0000-0000-0000-000000000000"}},
+		{"id":"4","type":"faq","data":{"items":[{"question":"Why?","answer":"Because."}]}}
+	]`)
+
+	text := ExtractBlockText(blocks)
+
+	assert.Equal(t, "Start Fast & simple Welcome\nHello world\nBecause. Why?", text)
+	assert.NotContains(t, text, "/uploads/hero.png")
+	assert.NotContains(t, text, "0b8c7a3e")
+}
+
+func TestExtractBlockText_NotBlocks(t *testing.T) {
+	assert.Equal(t, "", ExtractBlockText(nil))
+	assert.Equal(t, "", ExtractBlockText([]byte("plain text")))
+}
+
+func TestNewPostSearchDocument_PlainContent(t *testing.T) {
+	post := &Post{
+		ID:      uuid.New(),
+		Title:   "Release notes",
+		Slug:    "release-notes",
+		Excerpt: "What changed",
+		Content: "<h2>New</h2><p>Search is here</p>",
+		Status:  PostStatusPublished,
+	}
+
+	doc := NewPostSearchDocument(post)
+
+	assert.Equal(t, SearchEntityPost, doc.EntityType)
+	assert.Equal(t, post.ID, doc.EntityID)
+	assert.Equal(t, "published", doc.Status)
+	assert.Equal(t, "What changed", doc.Excerpt)
+	assert.Equal(t, "New Search is here", doc.Body)
+}
+
+func TestNewPostSearchDocument_Blocks(t *testing.T) {
+	post := &Post{
+		ID:      uuid.New(),
+		Title:   "Launch",
+		Content: "ignored",
+		Blocks:  datatypes.JSON(`[{"id":"1","type":"text","data":{"content":"<p>Now live</p>"}}]`),
+	}
+
+	assert.Equal(t, "Now live", NewPostSearchDocument(post).Body)
+}
+
+func TestNewPageSearchDocument_MetaDescription(t *testing.T) {
+	page := &Page{
+		ID:     uuid.New(),
+		Title:  "About",
+		Slug:   "about",
+		Status: PageStatusDraft,
+		Meta:   datatypes.JSON(`{"description":"Who we are"}`),
+	}
+
+	doc := NewPageSearchDocument(page)
+
+	assert.Equal(t, SearchEntityPage, doc.EntityType)
+	assert.Equal(t, "Who we are", doc.Excerpt)
+	assert.Equal(t, "", doc.Body)
+}
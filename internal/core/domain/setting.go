@@ -16,6 +16,12 @@ type GlobalSettings struct {
 	ContactEmail string `json:"contact_email"`
 	HeaderMenuID string `json:"header_menu_id,omitempty"` // UUID of the menu to display in header
 	FooterMenuID string `json:"footer_menu_id,omitempty"` // UUID of the menu to display in footer
+
+	DefaultLocale string                       `json:"default_locale,omitempty"` // Locale served when no enabled locale matches the request
+	Locales       []string                     `json:"locales,omitempty"`        // Enabled locales; the default locale is always enabled
+	Translations  map[string]LocalizedSettings `json:"translations,omitempty"`   // Per-locale overrides keyed by locale
+
+	Workflow *WorkflowSettings `json:"workflow,omitempty"` // Editorial workflow; nil leaves status changes unrestricted
 }
 
 // MenuItem represents a single menu item (can be nested)
@@ -28,13 +34,16 @@ type MenuItem struct {
 
 // Menu represents a navigation menu (reusable, can be used anywhere)
 type Menu struct {
-	ID          uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
-	TenantID    string         `gorm:"index" json:"tenant_id"`                 // Empty string for community edition
-	Name        string         `gorm:"type:varchar(100);not null" json:"name"` // Menu name (e.g., "Main Navigation", "Footer Links")
-	Description string         `gorm:"type:text" json:"description,omitempty"` // Optional description
-	Items       datatypes.JSON `gorm:"type:jsonb" json:"items"`                // Array of MenuItem objects
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID              uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID        string         `gorm:"index" json:"tenant_id"` // Empty string for community edition
+	Locale          string         `gorm:"type:varchar(10);not null;default:'en';uniqueIndex:idx_menus_translation_locale,priority:2" json:"locale"`
+	TranslationOfID *uuid.UUID     `gorm:"type:uuid;index;uniqueIndex:idx_menus_translation_locale,priority:1,where:deleted_at IS NULL" json:"translation_of_id,omitempty"` // Source menu, nil for originals
+	Name            string         `gorm:"type:varchar(100);not null" json:"name"`                                                                                          // Menu name (e.g., "Main Navigation", "Footer Links")
+	Description     string         `gorm:"type:text" json:"description,omitempty"`                                                                                          // Optional description
+	Items           datatypes.JSON `gorm:"type:jsonb" json:"items"`                                                                                                         // Array of MenuItem objects
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"` // Set while the menu is in the trash
 }
 
 // BeforeCreate is a GORM hook that generates UUID before creating a menu
@@ -42,6 +51,9 @@ func (m *Menu) BeforeCreate(tx *gorm.DB) error {
 	if m.ID == uuid.Nil {
 		m.ID = uuid.New()
 	}
+	if m.Locale == "" {
+		m.Locale = DefaultLocale
+	}
 	return nil
 }
 
@@ -54,8 +66,8 @@ func (Menu) TableName() string {
 // Used for storing GlobalSettings as a single JSON blob
 type SystemConfig struct {
 	ID        uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
-	TenantID  string         `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
-	Key       string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_tenant_key" json:"key"`
+	TenantID  string         `gorm:"index;not null;uniqueIndex:idx_tenant_key,priority:1" json:"tenant_id"` // Empty string for community edition
+	Key       string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_tenant_key,priority:2" json:"key"`
 	Value     datatypes.JSON `gorm:"type:jsonb" json:"value"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Limits on free-form tags
+const (
+	TagMaxName     = 50
+	TagMaxPerPost  = 20
+	TagMaxMergeIDs = 50
+)
+
+// Tag is a free-form label on posts
+// Unlike categories, tags are flat and created on the fly when a post uses a new name
+type Tag struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID  string    `gorm:"index;uniqueIndex:idx_tags_tenant_slug,priority:1" json:"tenant_id"` // Empty string for community edition
+	Name      string    `gorm:"type:varchar(50);not null" json:"name"`
+	Slug      string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_tags_tenant_slug,priority:2" json:"slug"` // Derived from the name, unique per tenant
+	PostCount int64     `gorm:"->;-:migration" json:"post_count"`                                                   // Set by listings
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a tag
+func (t *Tag) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// NewTag returns a tag for a name entered by an editor, with its whitespace collapsed
+func NewTag(tenantID, name string) (*Tag, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidTag)
+	}
+	if utf8.RuneCountInString(name) > TagMaxName {
+		return nil, fmt.Errorf("%w: name must be at most %d characters", ErrInvalidTag, TagMaxName)
+	}
+	slug := TagSlug(name)
+	if slug == "" {
+		return nil, fmt.Errorf("%w: name %q needs at least one letter or digit", ErrInvalidTag, name)
+	}
+	return &Tag{TenantID: tenantID, Name: name, Slug: slug}, nil
+}
+
+// TagSlug derives the URL slug of a tag name (e.g. "Go Modules" -> "go-modules")
+// Names that only differ in case or punctuation share a slug, and so a tag
+func TagSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagSlug(t *testing.T) {
+	assert.Equal(t, "go-modules", TagSlug("Go Modules"))
+	assert.Equal(t, "c-tips", TagSlug("  C++ -- tips! "))
+	assert.Equal(t, "café", TagSlug("Café"))
+	assert.Equal(t, "", TagSlug("!!!"))
+}
+
+func TestNewTag(t *testing.T) {
+	tag, err := NewTag("acme", "  Go   Modules ")
+	require.NoError(t, err)
+	assert.Equal(t, "Go Modules", tag.Name)
+	assert.Equal(t, "go-modules", tag.Slug)
+	assert.Equal(t, "acme", tag.TenantID)
+
+	for _, name := range []string{"", "   ", "?!", strings.Repeat("a", TagMaxName+1)} {
+		_, err := NewTag("", name)
+		assert.ErrorIs(t, err, ErrInvalidTag, name)
+	}
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UsageMetric identifies a metered resource
+type UsageMetric string
+
+const (
+	UsageMetricPages        UsageMetric = "pages"
+	UsageMetricPosts        UsageMetric = "posts"
+	UsageMetricUsers        UsageMetric = "users"
+	UsageMetricStorageBytes UsageMetric = "storage_bytes"
+	UsageMetricUploadBytes  UsageMetric = "upload_bytes" // Bytes uploaded, metered per month
+)
+
+// UsageMetrics lists every metered resource in report order
+var UsageMetrics = []UsageMetric{
+	UsageMetricPages,
+	UsageMetricPosts,
+	UsageMetricUsers,
+	UsageMetricStorageBytes,
+	UsageMetricUploadBytes,
+}
+
+// UsagePeriodFormat is the time layout of monthly usage periods (e.g. "2024-01")
+const UsagePeriodFormat = "2006-01"
+
+// UsagePeriod returns the monthly usage period containing t
+func UsagePeriod(t time.Time) string {
+	return t.UTC().Format(UsagePeriodFormat)
+}
+
+// IsMonthly reports whether the metric's limit resets at the start of each month
+func (m UsageMetric) IsMonthly() bool {
+	return m == UsageMetricUploadBytes
+}
+
+// LimitPeriod returns the usage period the metric's limit applies to at time t
+// Monthly metrics use the month, all others the running total ("")
+func (m UsageMetric) LimitPeriod(t time.Time) string {
+	if m.IsMonthly() {
+		return UsagePeriod(t)
+	}
+	return ""
+}
+
+// Plan defines the resource limits for tenants subscribed to it
+// A zero limit means unlimited
+type Plan struct {
+	ID                     uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	Name                   string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	MaxPages               int64     `gorm:"not null;default:0" json:"max_pages"`
+	MaxPosts               int64     `gorm:"not null;default:0" json:"max_posts"`
+	MaxUsers               int64     `gorm:"not null;default:0" json:"max_users"`
+	MaxStorageBytes        int64     `gorm:"not null;default:0" json:"max_storage_bytes"`
+	MaxUploadBytesPerMonth int64     `gorm:"not null;default:0" json:"max_upload_bytes_per_month"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a plan
+func (p *Plan) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (Plan) TableName() string {
+	return "plans"
+}
+
+// Limit returns the plan's limit for a metric (0 = unlimited)
+func (p *Plan) Limit(metric UsageMetric) int64 {
+	switch metric {
+	case UsageMetricPages:
+		return p.MaxPages
+	case UsageMetricPosts:
+		return p.MaxPosts
+	case UsageMetricUsers:
+		return p.MaxUsers
+	case UsageMetricStorageBytes:
+		return p.MaxStorageBytes
+	case UsageMetricUploadBytes:
+		return p.MaxUploadBytesPerMonth
+	}
+	return 0
+}
+
+// Tenant represents a provisioned enterprise tenant
+// The ID is the identifier resolved by TenantMiddleware (header or subdomain)
+type Tenant struct {
+	ID        string     `gorm:"type:varchar(100);primary_key" json:"id"`
+	Name      string     `gorm:"type:varchar(255);not null" json:"name"`
+	PlanID    *uuid.UUID `gorm:"type:uuid;index" json:"plan_id,omitempty"` // No plan = unlimited
+	Plan      *Plan      `gorm:"foreignKey:PlanID" json:"plan,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Tenant) TableName() string {
+	return "tenants"
+}
+
+// UsageCounter is an incrementally maintained usage total for a tenant
+// Period is empty for the running total, or a month (UsagePeriodFormat)
+// for the amount added during that month
+type UsageCounter struct {
+	TenantID  string      `gorm:"type:varchar(100);primaryKey" json:"tenant_id"`
+	Metric    UsageMetric `gorm:"type:varchar(50);primaryKey" json:"metric"`
+	Period    string      `gorm:"type:varchar(7);primaryKey" json:"period"`
+	Value     int64       `gorm:"not null;default:0" json:"value"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UsageCounter) TableName() string {
+	return "usage_counters"
+}
+
+// QuotaExceededError is returned when an operation would exceed a plan limit
+type QuotaExceededError struct {
+	Metric UsageMetric
+	Limit  int64
+	Used   int64
+}
+
+// Error implements the error interface
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota exceeded for %s: %d of %d used", e.Metric, e.Used, e.Limit)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashEntityType identifies a kind of content that can be moved to the trash
+type TrashEntityType string
+
+const (
+	TrashEntityPage     TrashEntityType = "page"
+	TrashEntityPost     TrashEntityType = "post"
+	TrashEntityCategory TrashEntityType = "category"
+	TrashEntityMenu     TrashEntityType = "menu"
+)
+
+// TrashEntityTypes lists the kinds of content with a trash, in listing order
+var TrashEntityTypes = []TrashEntityType{TrashEntityPage, TrashEntityPost, TrashEntityCategory, TrashEntityMenu}
+
+// IsValidTrashEntityType reports whether t names a kind of content with a trash
+func IsValidTrashEntityType(t TrashEntityType) bool {
+	for _, known := range TrashEntityTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultTrashRetentionDays is how long deleted content stays in the trash before it is purged
+const DefaultTrashRetentionDays = 30
+
+// TrashItem summarises a deleted entity in the trash listing
+type TrashItem struct {
+	Type      TrashEntityType `json:"type"`
+	ID        uuid.UUID       `json:"id"`
+	Title     string          `json:"title"` // Title of pages and posts, name of categories and menus
+	Slug      string          `json:"slug,omitempty"`
+	Locale    string          `json:"locale,omitempty"`
+	DeletedAt time.Time       `json:"deleted_at"`
+}
@@ -14,14 +14,19 @@ type UserRole string
 const (
 	UserRoleAdmin  UserRole = "admin"
 	UserRoleEditor UserRole = "editor"
+
+	// UserRoleSuperAdmin is a platform role that can act across all tenants
+	// Super-admins belong to the platform (empty TenantID), not to a customer tenant
+	UserRoleSuperAdmin UserRole = "super_admin"
 )
 
 // User represents a system user
 type User struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID  string    `gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_users_tenant_email,priority:1" json:"tenant_id"` // Empty string for community edition
 	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
-	Email     string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"email"`
-	Password  string    `gorm:"type:varchar(255);not null" json:"-"` // Never serialize password
+	Email     string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_users_tenant_email,priority:2" json:"email"` // Unique per tenant
+	Password  string    `gorm:"type:varchar(255);not null" json:"-"`                                                   // Never serialize password
 	Role      UserRole  `gorm:"type:varchar(20);not null;default:'editor'" json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -40,6 +45,17 @@ func (User) TableName() string {
 	return "users"
 }
 
+// IsSuperAdmin reports whether the user is a platform super-admin
+func (u *User) IsSuperAdmin() bool {
+	return u.Role == UserRoleSuperAdmin
+}
+
+// IsAdmin reports whether the user can administer their tenant
+// Super-admins are admins of every tenant
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin || u.Role == UserRoleSuperAdmin
+}
+
 // HashPassword hashes the user's password using bcrypt
 func (u *User) HashPassword() error {
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
@@ -0,0 +1,267 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Webhook delivery headers sent with every request
+const (
+	WebhookSignatureHeader = "X-Gohac-Signature" // "sha256=" + hex HMAC of "<timestamp>.<body>"
+	WebhookTimestampHeader = "X-Gohac-Timestamp" // Unix seconds; receivers should reject stale deliveries
+	WebhookEventHeader     = "X-Gohac-Event"
+	WebhookDeliveryHeader  = "X-Gohac-Delivery" // Delivery ID, stable across retries
+)
+
+const (
+	// WebhookMaxAttempts is how many times a delivery is tried before it fails for good
+	WebhookMaxAttempts = 8
+	// webhookFirstRetryDelay doubles after every failed attempt
+	webhookFirstRetryDelay = 30 * time.Second
+)
+
+// WebhookEventTypes are the events webhooks can subscribe to, and that are streamed to admin sessions
+var WebhookEventTypes = []EventType{
+	EventContentCreated,
+	EventContentUpdated,
+	EventContentPublished,
+	EventContentDeleted,
+	EventContentRestored,
+	EventLockAcquired,
+	EventLockReleased,
+	EventMediaUploaded,
+}
+
+// IsWebhookEventType reports whether webhooks can subscribe to an event type
+func IsWebhookEventType(eventType EventType) bool {
+	for _, known := range WebhookEventTypes {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook posts a tenant's events to an external URL, such as a static site build hook
+type Webhook struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID  string         `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
+	Name      string         `gorm:"type:varchar(255);not null" json:"name"`
+	URL       string         `gorm:"type:varchar(2000);not null" json:"url"`
+	Events    datatypes.JSON `gorm:"type:jsonb" json:"events"` // Event types or "content.*"-style patterns; empty for all events
+	Secret    string         `gorm:"type:varchar(255);not null" json:"-"`
+	Active    bool           `gorm:"not null;default:true" json:"active"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a webhook
+func (w *Webhook) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// EventFilter decodes the event types and patterns the webhook subscribes to
+func (w *Webhook) EventFilter() []string {
+	var events []string
+	if len(w.Events) > 0 {
+		_ = json.Unmarshal(w.Events, &events)
+	}
+	return events
+}
+
+// Matches reports whether the webhook subscribes to an event type
+func (w *Webhook) Matches(eventType EventType) bool {
+	filter := w.EventFilter()
+	if len(filter) == 0 {
+		return true
+	}
+	for _, pattern := range filter {
+		if MatchesEventName(pattern, string(eventType)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWebhook checks the URL and event filter of a webhook
+func ValidateWebhook(w *Webhook) error {
+	if strings.TrimSpace(w.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWebhook)
+	}
+	if err := ValidateWebhookURL(w.URL, IsInternalAddress); err != nil {
+		return err
+	}
+	for _, pattern := range w.EventFilter() {
+		known := false
+		for _, eventType := range WebhookEventTypes {
+			if MatchesEventName(pattern, string(eventType)) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, pattern)
+		}
+	}
+	return nil
+}
+
+// ValidateWebhookURL checks that a webhook URL is an absolute http or https URL whose host is not internal
+// internal decides which addresses are refused; hostnames are only checked once they are resolved,
+// when the webhook is saved and again by the dispatcher on every connection
+func ValidateWebhookURL(rawURL string, internal func(net.IP) bool) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	ip := net.ParseIP(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		ip = net.IPv4(127, 0, 0, 1)
+	}
+	if ip != nil && internal(ip) {
+		return fmt.Errorf("%w: url must not point to a loopback, private or link-local address", ErrInvalidWebhook)
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, as internal as the private ranges
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsInternalAddress reports whether ip is a loopback, private, link-local or other non-public address
+// Webhooks are never sent to such addresses so they cannot be used to reach the server's own network
+func IsInternalAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// SignWebhookPayload returns the signature header value for a delivery body
+// Signing the timestamp with the body keeps captured deliveries from being replayed later
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookDeliveryStatus tracks a delivery through the queue
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending" // Waiting for its next attempt
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // Gave up after WebhookMaxAttempts
+)
+
+// WebhookDelivery is one event queued for, and logged after, delivery to a webhook
+// The queue is the table itself: pending rows are picked up once NextAttemptAt passes
+type WebhookDelivery struct {
+	ID            uuid.UUID             `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID      string                `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
+	WebhookID     uuid.UUID             `gorm:"type:uuid;not null;index" json:"webhook_id"`
+	EventID       string                `gorm:"type:varchar(36);not null" json:"event_id"`
+	EventType     EventType             `gorm:"type:varchar(50);not null" json:"event_type"`
+	Payload       datatypes.JSON        `gorm:"type:jsonb" json:"payload"` // The event, sent as the request body
+	Status        WebhookDeliveryStatus `gorm:"type:varchar(20);not null;default:'pending';index:idx_webhook_deliveries_due,priority:1" json:"status"`
+	Attempts      int                   `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt *time.Time            `gorm:"index:idx_webhook_deliveries_due,priority:2" json:"next_attempt_at,omitempty"`
+	ResponseCode  int                   `json:"response_code,omitempty"` // Of the last attempt; 0 when no response was received. Response bodies are not kept
+	Error         string                `gorm:"type:text" json:"error,omitempty"`
+	DeliveredAt   *time.Time            `json:"delivered_at,omitempty"`
+	ReplayOfID    *uuid.UUID            `gorm:"type:uuid" json:"replay_of_id,omitempty"` // Delivery this one was replayed from
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a webhook delivery
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// NewWebhookDelivery creates a delivery of an event to a webhook, due immediately
+func NewWebhookDelivery(webhook *Webhook, eventID string, eventType EventType, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		TenantID:      webhook.TenantID,
+		WebhookID:     webhook.ID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	}
+}
+
+// RecordAttempt updates the delivery after an attempt and schedules the next one
+// A nil err with a 2xx code is a success; anything else is retried with exponential backoff
+func (d *WebhookDelivery) RecordAttempt(now time.Time, code int, err error) {
+	d.Attempts++
+	d.ResponseCode = code
+	d.Error = ""
+	if err != nil {
+		d.Error = err.Error()
+	} else if code < 200 || code > 299 {
+		d.Error = fmt.Sprintf("unexpected status %d", code)
+	}
+
+	if d.Error == "" {
+		d.Status = WebhookDeliverySucceeded
+		d.DeliveredAt = &now
+		d.NextAttemptAt = nil
+		return
+	}
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(WebhookRetryDelay(d.Attempts))
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = &next
+}
+
+// Abandon fails the delivery without sending it
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = WebhookDeliveryFailed
+	d.NextAttemptAt = nil
+	d.Error = reason
+}
+
+// WebhookRetryDelay returns how long to wait after a delivery's nth failed attempt
+// 30s, 1m, 2m, ... so the last retry happens about an hour after the event
+func WebhookRetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return webhookFirstRetryDelay << (attempts - 1)
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestWebhook_Matches(t *testing.T) {
+	all := &Webhook{}
+	assert.True(t, all.Matches(EventMediaUploaded))
+
+	content := &Webhook{Events: datatypes.JSON(`["content.*","lock.released"]`)}
+	assert.True(t, content.Matches(EventContentPublished))
+	assert.True(t, content.Matches(EventLockReleased))
+	assert.False(t, content.Matches(EventLockAcquired))
+	assert.False(t, content.Matches(EventMediaUploaded))
+}
+
+func TestValidateWebhook(t *testing.T) {
+	valid := &Webhook{Name: "Rebuild", URL: "https://api.netlify.com/build_hooks/abc", Events: datatypes.JSON(`["content.published"]`)}
+	assert.NoError(t, ValidateWebhook(valid))
+
+	for name, webhook := range map[string]*Webhook{
+		"missing name":  {URL: "https://example.com"},
+		"relative url":  {Name: "x", URL: "/hooks"},
+		"ftp url":       {Name: "x", URL: "ftp://example.com"},
+		"unknown event": {Name: "x", URL: "https://example.com", Events: datatypes.JSON(`["page.saved"]`)},
+		"unknown group": {Name: "x", URL: "https://example.com", Events: datatypes.JSON(`["comment.*"]`)},
+		"loopback":      {Name: "x", URL: "http://127.0.0.1:8080/admin"},
+		"localhost":     {Name: "x", URL: "http://LOCALHOST/"},
+		"rooted name":   {Name: "x", URL: "http://api.localhost./"},
+		"private":       {Name: "x", URL: "http://10.0.0.5/"},
+		"metadata":      {Name: "x", URL: "http://169.254.169.254/latest/meta-data/"},
+		"ipv6 loopback": {Name: "x", URL: "http://[::1]/"},
+		"mapped ipv4":   {Name: "x", URL: "http://[::ffff:192.168.1.1]/"},
+		"unspecified":   {Name: "x", URL: "http://0.0.0.0/"},
+	} {
+		assert.True(t, errors.Is(ValidateWebhook(webhook), ErrInvalidWebhook), name)
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"type":"content.published"}`)
+	signature := SignWebhookPayload("secret", 1700000000, body)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.Equal(t, signature, SignWebhookPayload("secret", 1700000000, body))
+	assert.NotEqual(t, signature, SignWebhookPayload("other", 1700000000, body))
+	assert.NotEqual(t, signature, SignWebhookPayload("secret", 1700000001, body))
+}
+
+func TestWebhookDelivery_RecordAttempt(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	delivery := &WebhookDelivery{Status: WebhookDeliveryPending}
+
+	delivery.RecordAttempt(now, 503, nil)
+	assert.Equal(t, WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, "unexpected status 503", delivery.Error)
+	assert.Equal(t, now.Add(30*time.Second), *delivery.NextAttemptAt)
+
+	delivery.RecordAttempt(now, 0, errors.New("connection refused"))
+	assert.Equal(t, now.Add(time.Minute), *delivery.NextAttemptAt)
+
+	delivery.RecordAttempt(now, 204, nil)
+	assert.Equal(t, WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Empty(t, delivery.Error)
+	assert.Nil(t, delivery.NextAttemptAt)
+	assert.Equal(t, now, *delivery.DeliveredAt)
+
+	failing := &WebhookDelivery{Attempts: WebhookMaxAttempts - 1}
+	failing.RecordAttempt(now, 500, nil)
+	assert.Equal(t, WebhookDeliveryFailed, failing.Status)
+	assert.Nil(t, failing.NextAttemptAt)
+}
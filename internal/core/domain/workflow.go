@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WorkflowStatuses lists the statuses pages and posts move through, in editorial order
+var WorkflowStatuses = []string{
+	string(PageStatusDraft),
+	string(PageStatusInReview),
+	string(PageStatusApproved),
+	string(PageStatusPublished),
+	string(PageStatusArchived),
+}
+
+// IsWorkflowStatus reports whether status is a known page and post status
+func IsWorkflowStatus(status string) bool {
+	for _, known := range WorkflowStatuses {
+		if status == known {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkflowTransition allows users with one of Roles to move content from one status to another
+type WorkflowTransition struct {
+	From  string     `json:"from"`
+	To    string     `json:"to"`
+	Roles []UserRole `json:"roles"` // Super-admins may do whatever admins may
+}
+
+// DefaultWorkflowTransitions is the workflow of tenants that enable it without listing transitions
+// Editors submit drafts and publish approved content; admins review
+var DefaultWorkflowTransitions = []WorkflowTransition{
+	{From: "draft", To: "in_review", Roles: []UserRole{UserRoleEditor, UserRoleAdmin}},
+	{From: "draft", To: "archived", Roles: []UserRole{UserRoleEditor, UserRoleAdmin}},
+	{From: "in_review", To: "draft", Roles: []UserRole{UserRoleEditor, UserRoleAdmin}}, // Withdrawn, or rejected by a reviewer
+	{From: "in_review", To: "approved", Roles: []UserRole{UserRoleAdmin}},
+	{From: "in_review", To: "published", Roles: []UserRole{UserRoleAdmin}},
+	{From: "approved", To: "draft", Roles: []UserRole{UserRoleAdmin}},
+	{From: "approved", To: "published", Roles: []UserRole{UserRoleEditor, UserRoleAdmin}},
+	{From: "published", To: "draft", Roles: []UserRole{UserRoleAdmin}},
+	{From: "published", To: "archived", Roles: []UserRole{UserRoleAdmin}},
+	{From: "archived", To: "draft", Roles: []UserRole{UserRoleEditor, UserRoleAdmin}},
+}
+
+// WorkflowSettings configures the editorial workflow of a tenant
+// While it is disabled any user may set any status, as before workflows existed
+type WorkflowSettings struct {
+	Enabled     bool                 `json:"enabled"`
+	Transitions []WorkflowTransition `json:"transitions,omitempty"` // Empty uses DefaultWorkflowTransitions
+}
+
+// IsEnabled reports whether status changes are restricted by the workflow
+func (w *WorkflowSettings) IsEnabled() bool {
+	return w != nil && w.Enabled
+}
+
+// EffectiveTransitions returns the transitions in force when the workflow is enabled
+func (w *WorkflowSettings) EffectiveTransitions() []WorkflowTransition {
+	if w == nil || len(w.Transitions) == 0 {
+		return DefaultWorkflowTransitions
+	}
+	return w.Transitions
+}
+
+// Allows reports whether a user with role may move content from one status to another
+func (w *WorkflowSettings) Allows(from, to string, role UserRole) bool {
+	if !w.IsEnabled() || from == to {
+		return true
+	}
+	for _, transition := range w.EffectiveTransitions() {
+		if transition.From == from && transition.To == to && transition.permits(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewerRoles returns the roles that may approve or publish content that is in review
+func (w *WorkflowSettings) ReviewerRoles() []UserRole {
+	var roles []UserRole
+	seen := make(map[UserRole]bool)
+	for _, transition := range w.EffectiveTransitions() {
+		if transition.From != string(PageStatusInReview) || transition.To == string(PageStatusDraft) {
+			continue
+		}
+		for _, role := range transition.Roles {
+			if !seen[role] {
+				seen[role] = true
+				roles = append(roles, role)
+			}
+		}
+	}
+	if seen[UserRoleAdmin] && !seen[UserRoleSuperAdmin] {
+		roles = append(roles, UserRoleSuperAdmin)
+	}
+	return roles
+}
+
+// IsReviewer reports whether role may approve or publish content that is in review
+func (w *WorkflowSettings) IsReviewer(role UserRole) bool {
+	for _, reviewer := range w.ReviewerRoles() {
+		if role == reviewer {
+			return true
+		}
+	}
+	return false
+}
+
+// EditedStatus returns the status content in status has after a user with role changes it
+// Reviewers edit freely; other users send approved content back to review and may not change
+// published content, which would bypass the review
+func (w *WorkflowSettings) EditedStatus(status string, role UserRole) (string, bool) {
+	if !w.IsEnabled() || w.IsReviewer(role) {
+		return status, true
+	}
+	switch status {
+	case string(PageStatusApproved):
+		return string(PageStatusInReview), true
+	case string(PageStatusPublished):
+		return status, false
+	}
+	return status, true
+}
+
+// permits reports whether role may make the transition
+func (t WorkflowTransition) permits(role UserRole) bool {
+	for _, allowed := range t.Roles {
+		if role == allowed || (role == UserRoleSuperAdmin && allowed == UserRoleAdmin) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWorkflow checks that transitions only use known statuses and roles
+func ValidateWorkflow(w *WorkflowSettings) error {
+	if w == nil {
+		return nil
+	}
+	for _, transition := range w.Transitions {
+		if !IsWorkflowStatus(transition.From) || !IsWorkflowStatus(transition.To) || transition.From == transition.To {
+			return fmt.Errorf("%w: invalid transition from %q to %q", ErrInvalidWorkflow, transition.From, transition.To)
+		}
+		if len(transition.Roles) == 0 {
+			return fmt.Errorf("%w: transition from %q to %q needs roles", ErrInvalidWorkflow, transition.From, transition.To)
+		}
+		for _, role := range transition.Roles {
+			if role != UserRoleEditor && role != UserRoleAdmin && role != UserRoleSuperAdmin {
+				return fmt.Errorf("%w: unknown role %q", ErrInvalidWorkflow, role)
+			}
+		}
+	}
+	return nil
+}
+
+// IsRejection reports whether moving content from one status to another sends it back to its author
+func IsRejection(from, to string) bool {
+	return to == string(PageStatusDraft) && (from == string(PageStatusInReview) || from == string(PageStatusApproved))
+}
+
+// ReviewEntityType identifies a kind of content that goes through the workflow
+type ReviewEntityType string
+
+const (
+	ReviewEntityPage ReviewEntityType = "page"
+	ReviewEntityPost ReviewEntityType = "post"
+)
+
+// ReviewComment is a reviewer's note on a page or post, or a record of a status change
+// Status changes made through the workflow keep FromStatus and ToStatus; plain comments leave them empty
+type ReviewComment struct {
+	ID         uuid.UUID        `gorm:"type:uuid;primary_key" json:"id"`
+	TenantID   string           `gorm:"index;not null" json:"tenant_id"` // Empty string for community edition
+	EntityType ReviewEntityType `gorm:"type:varchar(20);not null;index:idx_review_comments_entity,priority:1" json:"entity_type"`
+	EntityID   uuid.UUID        `gorm:"type:uuid;not null;index:idx_review_comments_entity,priority:2" json:"entity_id"`
+	AuthorID   string           `gorm:"type:varchar(36)" json:"author_id,omitempty"`
+	FromStatus string           `gorm:"type:varchar(20)" json:"from_status,omitempty"`
+	ToStatus   string           `gorm:"type:varchar(20)" json:"to_status,omitempty"`
+	Body       string           `gorm:"type:text" json:"body,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// BeforeCreate is a GORM hook that generates UUID before creating a review comment
+func (r *ReviewComment) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (ReviewComment) TableName() string {
+	return "review_comments"
+}
+
+// ReviewItem summarises a page or post in the review queue
+type ReviewItem struct {
+	Type        ReviewEntityType `json:"type"`
+	ID          uuid.UUID        `json:"id"`
+	Title       string           `json:"title"`
+	Slug        string           `json:"slug"`
+	Locale      string           `json:"locale"`
+	Status      string           `json:"status"`
+	SubmittedBy string           `json:"submitted_by,omitempty"` // User who last submitted it for review
+	SubmittedAt *time.Time       `json:"submitted_at,omitempty"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowSettings_Allows(t *testing.T) {
+	// Without a workflow anything goes
+	var disabled *WorkflowSettings
+	assert.True(t, disabled.Allows("draft", "published", UserRoleEditor))
+	assert.True(t, (&WorkflowSettings{}).Allows("draft", "published", UserRoleEditor))
+
+	workflow := &WorkflowSettings{Enabled: true}
+	assert.False(t, workflow.Allows("draft", "published", UserRoleEditor))
+	assert.True(t, workflow.Allows("draft", "in_review", UserRoleEditor))
+	assert.False(t, workflow.Allows("in_review", "approved", UserRoleEditor))
+	assert.True(t, workflow.Allows("in_review", "approved", UserRoleAdmin))
+	assert.True(t, workflow.Allows("in_review", "approved", UserRoleSuperAdmin))
+	assert.True(t, workflow.Allows("approved", "published", UserRoleEditor))
+	assert.True(t, workflow.Allows("published", "published", UserRoleEditor))
+	assert.False(t, workflow.Allows("draft", "in_review", ""))
+
+	// Custom transitions replace the defaults
+	custom := &WorkflowSettings{Enabled: true, Transitions: []WorkflowTransition{
+		{From: "draft", To: "published", Roles: []UserRole{UserRoleEditor}},
+	}}
+	assert.True(t, custom.Allows("draft", "published", UserRoleEditor))
+	assert.False(t, custom.Allows("draft", "in_review", UserRoleEditor))
+	assert.False(t, custom.Allows("draft", "published", UserRoleAdmin))
+}
+
+func TestWorkflowSettings_ReviewerRoles(t *testing.T) {
+	assert.ElementsMatch(t, []UserRole{UserRoleAdmin, UserRoleSuperAdmin}, (&WorkflowSettings{Enabled: true}).ReviewerRoles())
+
+	// Sending content back to draft does not make a role a reviewer
+	custom := &WorkflowSettings{Enabled: true, Transitions: []WorkflowTransition{
+		{From: "in_review", To: "draft", Roles: []UserRole{UserRoleEditor}},
+		{From: "in_review", To: "published", Roles: []UserRole{UserRoleEditor}},
+	}}
+	assert.Equal(t, []UserRole{UserRoleEditor}, custom.ReviewerRoles())
+}
+
+func TestValidateWorkflow(t *testing.T) {
+	assert.NoError(t, ValidateWorkflow(nil))
+	assert.NoError(t, ValidateWorkflow(&WorkflowSettings{Enabled: true, Transitions: DefaultWorkflowTransitions}))
+
+	for _, transition := range []WorkflowTransition{
+		{From: "draft", To: "scheduled", Roles: []UserRole{UserRoleEditor}},
+		{From: "draft", To: "draft", Roles: []UserRole{UserRoleEditor}},
+		{From: "draft", To: "in_review"},
+		{From: "draft", To: "in_review", Roles: []UserRole{"viewer"}},
+	} {
+		err := ValidateWorkflow(&WorkflowSettings{Transitions: []WorkflowTransition{transition}})
+		assert.True(t, errors.Is(err, ErrInvalidWorkflow), "%+v", transition)
+	}
+}
+
+func TestIsRejection(t *testing.T) {
+	assert.True(t, IsRejection("in_review", "draft"))
+	assert.True(t, IsRejection("approved", "draft"))
+	assert.False(t, IsRejection("published", "draft"))
+	assert.False(t, IsRejection("in_review", "approved"))
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+)
+
+// AuditLogRepository defines the interface for audit log data access
+// Audit logs are append-only
+type AuditLogRepository interface {
+	// Create records a new audit log entry
+	Create(ctx context.Context, entry *domain.AuditLog) error
+
+	// List retrieves audit log entries, newest first
+	List(ctx context.Context, opts ListAuditLogOptions) ([]*domain.AuditLog, int64, error)
+}
+
+// ListAuditLogOptions defines options for listing audit logs
+type ListAuditLogOptions struct {
+	Limit    int
+	Offset   int
+	TenantID *string // Filter by tenant (nil = all tenants)
+	ActorID  string  // Filter by actor or impersonator
+	Action   string  // Filter by action
+}
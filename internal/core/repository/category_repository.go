@@ -16,15 +16,46 @@ type CategoryRepository interface {
 	// GetByID retrieves a category by its UUID
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Category, error)
 
-	// GetBySlug retrieves a category by its slug
-	GetBySlug(ctx context.Context, slug string) (*domain.Category, error)
+	// ListByIDs retrieves a tenant's categories with the given IDs, in no particular order
+	ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.Category, error)
+
+	// GetBySlug retrieves a category by its slug within a tenant
+	GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Category, error)
 
 	// Update updates an existing category
 	Update(ctx context.Context, category *domain.Category) error
 
-	// Delete deletes a category by its UUID
+	// UpdateWithDescendants updates a category and moves its subcategories' paths from oldSlug to its new slug
+	// Returns the subcategories whose paths changed
+	UpdateWithDescendants(ctx context.Context, category *domain.Category, oldSlug string) ([]*domain.Category, error)
+
+	// Delete soft-deletes a category (moves it to the trash)
+	// Returns domain.ErrCategoryHasChildren if other categories are nested under it
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	// List retrieves a list of categories with pagination
-	List(ctx context.Context, limit, offset int) ([]*domain.Category, int64, error)
+	// ListAll retrieves all of a tenant's categories, ordered by path so parents come before their children
+	ListAll(ctx context.Context, tenantID string) ([]*domain.Category, error)
+
+	// ListSubtreeIDs retrieves the IDs of a category and of every category nested under it
+	ListSubtreeIDs(ctx context.Context, tenantID string, id uuid.UUID) ([]uuid.UUID, error)
+
+	// SubtreeHeight returns how many levels a category and its subcategories span, 1 for a category without any
+	SubtreeHeight(ctx context.Context, tenantID string, id uuid.UUID) (int, error)
+
+	// List retrieves a page of a tenant's categories matching a CategoryListSpec query
+	List(ctx context.Context, tenantID string, q ListQuery) ([]*domain.Category, ListPage, error)
+}
+
+// CategoryListSpec describes the filters and sort orders of the category list
+var CategoryListSpec = ListSpec{
+	Fields: map[string]ListField{
+		"parent_id":  {Column: "parent_id", Type: ListFieldUUID, Nullable: true, Filter: true}, // null lists top-level categories
+		"name":       {Column: "name", Sort: true},
+		"slug":       {Column: "slug", Filter: true, Sort: true},
+		"created_at": {Column: "created_at", Type: ListFieldTime, Filter: true, Sort: true},
+		"updated_at": {Column: "updated_at", Type: ListFieldTime, Filter: true, Sort: true},
+	},
+	Select:       []string{"tenant_id", "parent_id", "name", "slug", "description", "created_at", "updated_at"},
+	DefaultSort:  []SortField{{Field: "name"}},
+	DefaultLimit: 100,
 }
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// CollectionRepository defines the interface for collection and collection item data access
+type CollectionRepository interface {
+	// Create creates a new collection
+	Create(ctx context.Context, collection *domain.Collection) error
+
+	// GetByID retrieves a collection by its UUID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Collection, error)
+
+	// GetBySlug retrieves a collection by its slug within a tenant
+	GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Collection, error)
+
+	// Update updates an existing collection
+	Update(ctx context.Context, collection *domain.Collection) error
+
+	// Delete deletes a collection and all of its items
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves all collections of a tenant, ordered by name
+	List(ctx context.Context, tenantID string) ([]*domain.Collection, error)
+
+	// CreateItem creates a new collection item
+	CreateItem(ctx context.Context, item *domain.CollectionItem) error
+
+	// GetItem retrieves a collection item by its UUID
+	GetItem(ctx context.Context, id uuid.UUID) (*domain.CollectionItem, error)
+
+	// GetItemBySlug retrieves a collection item by its slug within a collection
+	GetItemBySlug(ctx context.Context, collectionID uuid.UUID, slug string) (*domain.CollectionItem, error)
+
+	// UpdateItem updates an existing collection item
+	UpdateItem(ctx context.Context, item *domain.CollectionItem) error
+
+	// DeleteItem deletes a collection item by ID
+	DeleteItem(ctx context.Context, id uuid.UUID) error
+
+	// ListItems retrieves the items of a collection with filtering, sorting and pagination
+	ListItems(ctx context.Context, opts ListItemsOptions) ([]*domain.CollectionItem, int64, error)
+}
+
+// ItemFilterOp is a comparison applied to a field value
+type ItemFilterOp string
+
+const (
+	ItemFilterEq       ItemFilterOp = "eq"
+	ItemFilterNe       ItemFilterOp = "ne"
+	ItemFilterGt       ItemFilterOp = "gt"
+	ItemFilterGte      ItemFilterOp = "gte"
+	ItemFilterLt       ItemFilterOp = "lt"
+	ItemFilterLte      ItemFilterOp = "lte"
+	ItemFilterContains ItemFilterOp = "contains" // Case-insensitive substring match
+)
+
+// ItemFilter restricts listed items by a field value
+// Value must already be converted to the field type (string, float64 or bool)
+type ItemFilter struct {
+	Field domain.CollectionField
+	Op    ItemFilterOp
+	Value interface{}
+}
+
+// ListItemsOptions defines options for listing collection items
+type ListItemsOptions struct {
+	CollectionID uuid.UUID
+	Status       string // Filter by status (draft, published, archived)
+	Filters      []ItemFilter
+	SortField    *domain.CollectionField // Schema field to sort by; nil sorts by SortColumn
+	SortColumn   string                  // Built-in column to sort by when SortField is nil (defaults to created_at)
+	SortDesc     bool
+	Limit        int
+	Offset       int
+}
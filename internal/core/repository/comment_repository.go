@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// CommentRepository defines the interface for reader comment data access
+type CommentRepository interface {
+	// Create saves a submitted comment
+	Create(ctx context.Context, comment *domain.Comment) error
+
+	// GetByID retrieves a tenant's comment
+	// Returns domain.ErrCommentNotFound if there is none
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Comment, error)
+
+	// List retrieves comments for moderation, newest first
+	List(ctx context.Context, opts ListCommentOptions) ([]*domain.Comment, int64, error)
+
+	// ListApprovedThreads retrieves a page of a post's approved top-level comments, oldest first,
+	// along with all approved replies on the post
+	ListApprovedThreads(ctx context.Context, tenantID string, postID uuid.UUID, limit, offset int) (roots, replies []*domain.Comment, total int64, err error)
+
+	// Update saves a moderated comment
+	Update(ctx context.Context, comment *domain.Comment) error
+}
+
+// ListCommentOptions defines options for listing comments
+type ListCommentOptions struct {
+	Limit    int
+	Offset   int
+	TenantID string
+	Status   domain.CommentStatus // Filter by status (empty = all)
+	PostID   *uuid.UUID           // Filter by post
+}
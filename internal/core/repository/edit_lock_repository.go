@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// EditLockRepository defines the interface for edit lock data access
+// Expired locks are treated as if they did not exist
+type EditLockRepository interface {
+	// Get retrieves the live lock on a page or post (ErrEditLockNotFound if there is none)
+	Get(ctx context.Context, tenantID string, entityType domain.EditLockEntityType, entityID uuid.UUID) (*domain.EditLock, error)
+
+	// ListActive retrieves a tenant's live locks, optionally of one kind of content
+	ListActive(ctx context.Context, tenantID string, entityType domain.EditLockEntityType) ([]*domain.EditLock, error)
+
+	// Acquire takes the lock for lock.UserID, or renews it if the user already holds it
+	// If another user holds a live lock, it is returned with ErrEditLocked unless takeOver is set,
+	// in which case it is replaced and still returned so its holder can be told
+	// acquired is true when lock.UserID did not hold a live lock before, false for a renewal
+	Acquire(ctx context.Context, lock *domain.EditLock, takeOver bool) (previous *domain.EditLock, acquired bool, err error)
+
+	// Release removes a lock held by userID, or by anyone if userID is empty
+	// Returns ErrEditLocked if another user holds it and ErrEditLockNotFound if nobody does
+	Release(ctx context.Context, tenantID string, entityType domain.EditLockEntityType, entityID uuid.UUID, userID string) error
+}
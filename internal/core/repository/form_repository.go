@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// FormRepository defines the interface for form submission data access
+type FormRepository interface {
+	// CreateSubmission stores a submission and records the event that notifies the form's recipients
+	CreateSubmission(ctx context.Context, submission *domain.FormSubmission, event domain.FormSubmitted) error
+
+	// ListSubmissions retrieves the submissions of a form block, newest first
+	// A limit of 0 returns all of them
+	ListSubmissions(ctx context.Context, tenantID string, pageID uuid.UUID, blockID string, limit, offset int) ([]*domain.FormSubmission, int64, error)
+
+	// DeleteSubmission deletes one submission of a form block
+	// Returns domain.ErrFormSubmissionNotFound if there is none
+	DeleteSubmission(ctx context.Context, tenantID string, pageID uuid.UUID, blockID string, id uuid.UUID) error
+}
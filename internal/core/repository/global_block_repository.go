@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// GlobalBlockRepository defines the interface for global block data access
+type GlobalBlockRepository interface {
+	// Create creates a new global block
+	Create(ctx context.Context, block *domain.GlobalBlock) error
+
+	// GetByID retrieves a global block by its UUID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.GlobalBlock, error)
+
+	// ListByIDs retrieves a tenant's global blocks with the given IDs, in no particular order
+	ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.GlobalBlock, error)
+
+	// Update updates an existing global block
+	Update(ctx context.Context, block *domain.GlobalBlock) error
+
+	// Delete deletes a global block by ID
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves a tenant's global blocks ordered by name
+	List(ctx context.Context, tenantID string) ([]*domain.GlobalBlock, error)
+
+	// ListUsage retrieves the pages (including drafts and translations) whose blocks reference a global block
+	ListUsage(ctx context.Context, tenantID string, id uuid.UUID) ([]*domain.Page, error)
+
+	// ListPostUsage retrieves the posts (including drafts and translations) whose blocks reference a global block
+	ListPostUsage(ctx context.Context, tenantID string, id uuid.UUID) ([]*domain.Post, error)
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// Limits shared by all list endpoints
+const (
+	MaxPageSize   = 100
+	MaxSortFields = 3
+)
+
+// ListFieldType is the kind of value a listed field holds
+type ListFieldType int
+
+const (
+	ListFieldString ListFieldType = iota
+	ListFieldUUID
+	ListFieldTime // Filtered with date ranges
+)
+
+// ListField describes a field of a listed entity that can be filtered or sorted on
+type ListField struct {
+	Column   string // Column of the entity's table
+	Type     ListFieldType
+	Values   []string // Allowed filter values of an enumerated field; empty allows any
+	Nullable bool     // The field may be empty: filter[x]=null matches those rows, and they sort last
+	Filter   bool
+	Sort     bool
+}
+
+// ListSpec describes how a list endpoint can be queried
+type ListSpec struct {
+	Fields       map[string]ListField // By name in the API
+	Select       []string             // Response fields ?fields= may pick; "id" is always included
+	DefaultSort  []SortField
+	DefaultLimit int
+}
+
+// FilterOp compares a field with filter values
+type FilterOp string
+
+const (
+	FilterEq  FilterOp = "eq" // Any of the values
+	FilterGt  FilterOp = "gt"
+	FilterGte FilterOp = "gte"
+	FilterLt  FilterOp = "lt"
+	FilterLte FilterOp = "lte"
+)
+
+// Filter restricts a list to rows whose field matches
+type Filter struct {
+	Field  string
+	Op     FilterOp
+	Values []interface{} // Parsed values: strings, or time.Time for time fields
+	Null   bool          // Match rows without a value (filter[x]=null)
+}
+
+// SortField orders a list by a field
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListQuery is a parsed request for a page of a list
+type ListQuery struct {
+	Filters []Filter
+	Sort    []SortField
+	Fields  []string // Response fields to return; empty returns all
+	Limit   int
+	Offset  int
+	Cursor  string // Opaque position from a previous page's NextCursor; excludes Offset
+}
+
+// ListPage describes the page a list query returned
+type ListPage struct {
+	Total      int64  // Rows matching the filters, on all pages
+	NextCursor string // Continues after the page; empty on the last page
+}
+
+// SortKey is the canonical form of the query's sort, e.g. "-published_at,title"
+// Cursors remember it, as they are only valid for the order they were issued in
+func (q ListQuery) SortKey() string {
+	keys := make([]string, 0, len(q.Sort))
+	for _, s := range q.Sort {
+		if s.Desc {
+			keys = append(keys, "-"+s.Field)
+		} else {
+			keys = append(keys, s.Field)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// ParseListQuery reads a list query from request query parameters:
+//
+//	filter[status]=draft,published   any of the values
+//	filter[author_id]=null           rows without an author
+//	filter[published_at][gte]=2024-01-01&filter[published_at][lt]=2024-02-01
+//	sort=-published_at,title         "-" sorts descending
+//	fields=id,title,slug
+//	limit=20&offset=40, or limit=20&cursor=...
+//
+// Errors wrap domain.ErrInvalidListQuery
+func ParseListQuery(params map[string]string, spec ListSpec) (ListQuery, error) {
+	q := ListQuery{Limit: spec.DefaultLimit, Sort: spec.DefaultSort}
+	if q.Limit == 0 {
+		q.Limit = 20
+	}
+
+	for key, raw := range params {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+		filter, err := parseFilter(key, raw, spec)
+		if err != nil {
+			return ListQuery{}, err
+		}
+		q.Filters = append(q.Filters, filter)
+	}
+
+	if raw := params["sort"]; raw != "" {
+		q.Sort = nil
+		seen := map[string]bool{}
+		for _, key := range strings.Split(raw, ",") {
+			key = strings.TrimSpace(key)
+			s := SortField{Field: strings.TrimPrefix(key, "-"), Desc: strings.HasPrefix(key, "-")}
+			field, ok := spec.Fields[s.Field]
+			if !ok || !field.Sort {
+				return ListQuery{}, fmt.Errorf("%w: cannot sort by %q", domain.ErrInvalidListQuery, s.Field)
+			}
+			if seen[s.Field] {
+				return ListQuery{}, fmt.Errorf("%w: %q is sorted by twice", domain.ErrInvalidListQuery, s.Field)
+			}
+			seen[s.Field] = true
+			q.Sort = append(q.Sort, s)
+		}
+		if len(q.Sort) > MaxSortFields {
+			return ListQuery{}, fmt.Errorf("%w: at most %d sort fields", domain.ErrInvalidListQuery, MaxSortFields)
+		}
+	}
+
+	if raw := params["fields"]; raw != "" {
+		selectable := make(map[string]bool, len(spec.Select))
+		for _, name := range spec.Select {
+			selectable[name] = true
+		}
+		q.Fields = []string{"id"}
+		for _, name := range strings.Split(raw, ",") {
+			name = strings.TrimSpace(name)
+			if name == "id" {
+				continue
+			}
+			if !selectable[name] {
+				return ListQuery{}, fmt.Errorf("%w: unknown field %q", domain.ErrInvalidListQuery, name)
+			}
+			q.Fields = append(q.Fields, name)
+		}
+	}
+
+	if raw, ok := params["limit"]; ok {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxPageSize {
+			return ListQuery{}, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidListQuery, MaxPageSize)
+		}
+		q.Limit = limit
+	}
+	if raw, ok := params["offset"]; ok {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return ListQuery{}, fmt.Errorf("%w: offset must not be negative", domain.ErrInvalidListQuery)
+		}
+		q.Offset = offset
+	}
+	q.Cursor = params["cursor"]
+	if q.Cursor != "" && q.Offset > 0 {
+		return ListQuery{}, fmt.Errorf("%w: use either cursor or offset", domain.ErrInvalidListQuery)
+	}
+	return q, nil
+}
+
+// parseFilter parses a filter[field] or filter[field][op] parameter
+func parseFilter(key, raw string, spec ListSpec) (Filter, error) {
+	rest := strings.TrimPrefix(key, "filter[")
+	name, rest, ok := strings.Cut(rest, "]")
+	if !ok {
+		return Filter{}, fmt.Errorf("%w: malformed parameter %q", domain.ErrInvalidListQuery, key)
+	}
+	filter := Filter{Field: name, Op: FilterEq}
+	if rest != "" {
+		op, ok := strings.CutPrefix(rest, "[")
+		if !ok || !strings.HasSuffix(op, "]") {
+			return Filter{}, fmt.Errorf("%w: malformed parameter %q", domain.ErrInvalidListQuery, key)
+		}
+		filter.Op = FilterOp(strings.TrimSuffix(op, "]"))
+	}
+
+	field, ok := spec.Fields[name]
+	if !ok || !field.Filter {
+		return Filter{}, fmt.Errorf("%w: cannot filter by %q", domain.ErrInvalidListQuery, name)
+	}
+	switch filter.Op {
+	case FilterEq:
+	case FilterGt, FilterGte, FilterLt, FilterLte:
+		if field.Type != ListFieldTime {
+			return Filter{}, fmt.Errorf("%w: %q only supports exact matches", domain.ErrInvalidListQuery, name)
+		}
+	default:
+		return Filter{}, fmt.Errorf("%w: unknown operator %q", domain.ErrInvalidListQuery, filter.Op)
+	}
+
+	if raw == "null" && filter.Op == FilterEq {
+		if !field.Nullable {
+			return Filter{}, fmt.Errorf("%w: %q is never empty", domain.ErrInvalidListQuery, name)
+		}
+		filter.Null = true
+		return filter, nil
+	}
+
+	values := []string{raw}
+	if filter.Op == FilterEq {
+		values = strings.Split(raw, ",")
+	}
+	for _, value := range values {
+		parsed, err := ParseListValue(field, strings.TrimSpace(value))
+		if err != nil {
+			return Filter{}, fmt.Errorf("%w: filter[%s]: %v", domain.ErrInvalidListQuery, name, err)
+		}
+		filter.Values = append(filter.Values, parsed)
+	}
+	return filter, nil
+}
+
+// ParseListValue parses a filter or cursor value of a field
+// Times are RFC 3339 timestamps or dates, which mean midnight UTC
+func ParseListValue(field ListField, value string) (interface{}, error) {
+	switch field.Type {
+	case ListFieldTime:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse("2006-01-02", value); err == nil {
+			return t, nil
+		}
+		return nil, fmt.Errorf("%q is not a date or RFC 3339 time", value)
+	case ListFieldUUID:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a UUID", value)
+		}
+		return id.String(), nil
+	}
+	if len(field.Values) > 0 {
+		for _, allowed := range field.Values {
+			if value == allowed {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("must be one of %s", strings.Join(field.Values, ", "))
+	}
+	return value, nil
+}
@@ -10,18 +10,40 @@ import (
 
 // MenuRepository defines the interface for menu data access
 type MenuRepository interface {
+	// As returns a repository recording the domain events of its changes as made by actorID
+	As(actorID string) MenuRepository
+
 	// Create creates a new menu
 	Create(ctx context.Context, menu *domain.Menu) error
 
 	// GetByID retrieves a menu by its UUID
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Menu, error)
 
+	// ListByIDs retrieves a tenant's menus with the given IDs, in no particular order
+	ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.Menu, error)
+
+	// GetTranslation retrieves the translation of a source menu in a locale
+	GetTranslation(ctx context.Context, sourceID uuid.UUID, locale string) (*domain.Menu, error)
+
 	// Update updates an existing menu
 	Update(ctx context.Context, menu *domain.Menu) error
 
-	// Delete deletes a menu by ID
+	// Delete soft-deletes a menu (moves it to the trash)
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	// List retrieves all menus with pagination
-	List(ctx context.Context, limit, offset int) ([]*domain.Menu, int64, error)
+	// List retrieves a page of a tenant's menus matching a MenuListSpec query
+	List(ctx context.Context, tenantID string, q ListQuery) ([]*domain.Menu, ListPage, error)
+}
+
+// MenuListSpec describes the filters and sort orders of the menu list
+var MenuListSpec = ListSpec{
+	Fields: map[string]ListField{
+		"locale":     {Column: "locale", Filter: true},
+		"name":       {Column: "name", Sort: true},
+		"created_at": {Column: "created_at", Type: ListFieldTime, Filter: true, Sort: true},
+		"updated_at": {Column: "updated_at", Type: ListFieldTime, Filter: true, Sort: true},
+	},
+	Select:       []string{"locale", "translation_of_id", "name", "description", "items", "created_at", "updated_at"},
+	DefaultSort:  []SortField{{Field: "created_at", Desc: true}},
+	DefaultLimit: 50,
 }
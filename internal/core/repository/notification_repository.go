@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	// Create stores a new notification
+	Create(ctx context.Context, notification *domain.Notification) error
+
+	// ListByUser retrieves a user's notifications, newest first
+	ListByUser(ctx context.Context, tenantID, userID string, unreadOnly bool, limit, offset int) ([]*domain.Notification, int64, error)
+
+	// CountUnread counts a user's unread notifications
+	CountUnread(ctx context.Context, tenantID, userID string) (int64, error)
+
+	// MarkRead marks one of a user's notifications as read
+	MarkRead(ctx context.Context, tenantID, userID string, id uuid.UUID) error
+
+	// MarkAllRead marks all of a user's notifications as read and returns how many changed
+	MarkAllRead(ctx context.Context, tenantID, userID string) (int64, error)
+}
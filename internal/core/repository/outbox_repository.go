@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gohac/internal/core/domain"
+)
+
+// OutboxRepository defines the interface for dispatching events from the transactional outbox
+// Events are written by the other repositories, in the transaction of the change they describe
+type OutboxRepository interface {
+	// Record appends events made by actorID to the outbox, for changes no repository records events for
+	Record(ctx context.Context, tenantID, actorID string, events ...domain.DomainEvent) error
+
+	// ClaimDue takes up to limit undispatched events that are due, oldest first
+	// Claimed events are hidden from other dispatchers until lease has passed
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.OutboxEvent, error)
+
+	// Save saves the outcome of a dispatch
+	Save(ctx context.Context, event *domain.OutboxEvent) error
+
+	// PurgeDispatched deletes events dispatched before a cutoff and returns how many were deleted
+	PurgeDispatched(ctx context.Context, before time.Time) (int64, error)
+}
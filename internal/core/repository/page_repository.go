@@ -12,19 +12,46 @@ import (
 // This follows the Repository pattern from Clean Architecture
 // Implementations will be in internal/adapter/repository
 type PageRepository interface {
+	// As returns a repository recording the domain events of its changes as made by actorID
+	As(actorID string) PageRepository
+
+	// InBatch returns a repository recording the domain events of its changes as part of the bulk action batchID
+	InBatch(batchID string) PageRepository
+
 	// Create creates a new page
 	Create(ctx context.Context, page *domain.Page) error
 
 	// GetByID retrieves a page by its UUID
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Page, error)
 
-	// GetBySlug retrieves a page by its slug
-	GetBySlug(ctx context.Context, slug string) (*domain.Page, error)
+	// GetBySlug retrieves a page by its slug within a tenant
+	GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Page, error)
+
+	// GetBySlugInLocale retrieves a page by its slug within a tenant and locale
+	GetBySlugInLocale(ctx context.Context, tenantID, locale, slug string) (*domain.Page, error)
+
+	// ListTranslations retrieves a source page and all of its translations, ordered by locale
+	ListTranslations(ctx context.Context, sourceID uuid.UUID) ([]*domain.Page, error)
+
+	// ListByIDs retrieves a tenant's pages with the given IDs, in no particular order
+	ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.Page, error)
+
+	// ListChildren retrieves the pages directly under a parent (top-level pages if parentID is nil), in sort order
+	ListChildren(ctx context.Context, tenantID, locale string, parentID *uuid.UUID) ([]*domain.Page, error)
 
-	// Update updates an existing page
+	// ListHierarchy retrieves all pages of a tenant in sort order, optionally in one locale
+	ListHierarchy(ctx context.Context, tenantID, locale string) ([]*domain.Page, error)
+
+	// Update updates an existing page and bumps its version
+	// Returns ErrVersionConflict if the page was updated since it was loaded
 	Update(ctx context.Context, page *domain.Page) error
 
-	// Delete soft-deletes a page (sets DeletedAt)
+	// UpdateWithDescendants updates a page and moves its descendants' paths from oldSlug to its new slug
+	// Returns the descendants whose paths changed; fails with ErrVersionConflict like Update
+	UpdateWithDescendants(ctx context.Context, page *domain.Page, oldSlug string) ([]*domain.Page, error)
+
+	// Delete soft-deletes a page (sets DeletedAt, moving it to the trash)
+	// Returns domain.ErrPageHasChildren if other pages are nested under it
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// List retrieves pages with pagination and filtering
@@ -35,6 +62,26 @@ type PageRepository interface {
 
 	// Unpublish unpublishes a page (sets status to draft)
 	Unpublish(ctx context.Context, id uuid.UUID) error
+
+	// ListIDs retrieves the IDs of up to limit of a tenant's pages matching PageListSpec filters,
+	// and how many pages match in all
+	ListIDs(ctx context.Context, tenantID string, filters []Filter, limit int) ([]uuid.UUID, int64, error)
+}
+
+// PageListSpec describes the filters bulk page operations select pages with
+var PageListSpec = ListSpec{
+	Fields: map[string]ListField{
+		"status": {Column: "status", Filter: true, Values: []string{
+			string(domain.PageStatusDraft), string(domain.PageStatusInReview), string(domain.PageStatusApproved),
+			string(domain.PageStatusPublished), string(domain.PageStatusArchived),
+		}},
+		"locale":       {Column: "locale", Filter: true},
+		"parent_id":    {Column: "parent_id", Type: ListFieldUUID, Nullable: true, Filter: true},
+		"template_id":  {Column: "template_id", Type: ListFieldUUID, Nullable: true, Filter: true},
+		"published_at": {Column: "published_at", Type: ListFieldTime, Nullable: true, Filter: true},
+		"created_at":   {Column: "created_at", Type: ListFieldTime, Filter: true},
+		"updated_at":   {Column: "updated_at", Type: ListFieldTime, Filter: true},
+	},
 }
 
 // ListPageOptions defines options for listing pages
@@ -42,6 +89,7 @@ type ListPageOptions struct {
 	Limit    int
 	Offset   int
 	Status   string // Filter by status (draft, published, archived)
+	Locale   string // Filter by locale
 	Search   string // Search in title and description
 	TenantID *uuid.UUID
 }
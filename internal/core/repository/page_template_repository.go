@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// PageTemplateRepository defines the interface for page template data access
+type PageTemplateRepository interface {
+	// Create creates a new page template
+	// Returns domain.ErrPageTemplateAlreadyExists if the tenant already has a template with the name
+	Create(ctx context.Context, template *domain.PageTemplate) error
+
+	// GetByID retrieves a page template by its UUID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.PageTemplate, error)
+
+	// Update updates an existing page template
+	Update(ctx context.Context, template *domain.PageTemplate) error
+
+	// Delete deletes a page template by ID
+	// Pages created from it keep their blocks and locks
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves a tenant's page templates ordered by name
+	List(ctx context.Context, tenantID string) ([]*domain.PageTemplate, error)
+}
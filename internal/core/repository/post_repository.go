@@ -10,24 +10,75 @@ import (
 
 // PostRepository defines the interface for post data access
 type PostRepository interface {
+	// As returns a repository recording the domain events of its changes as made by actorID
+	As(actorID string) PostRepository
+
+	// InBatch returns a repository recording the domain events of its changes as part of the bulk action batchID
+	InBatch(batchID string) PostRepository
+
 	// Create creates a new post
 	Create(ctx context.Context, post *domain.Post) error
 
 	// GetByID retrieves a post by its UUID
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Post, error)
 
-	// GetBySlug retrieves a post by its slug
-	GetBySlug(ctx context.Context, slug string) (*domain.Post, error)
+	// GetBySlug retrieves a published post by its slug within a tenant
+	GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Post, error)
+
+	// GetBySlugInLocale retrieves a published post by its slug within a tenant and locale
+	GetBySlugInLocale(ctx context.Context, tenantID, locale, slug string) (*domain.Post, error)
+
+	// ListTranslations retrieves a source post and all of its translations, ordered by locale
+	ListTranslations(ctx context.Context, sourceID uuid.UUID) ([]*domain.Post, error)
+
+	// ListByIDs retrieves a tenant's posts with the given IDs, in no particular order
+	ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.Post, error)
 
-	// Update updates an existing post
+	// ListPublished retrieves a tenant's published posts in a locale
+	// Posts without a published translation in that locale are included in the fallback locale
+	ListPublished(ctx context.Context, tenantID, locale, fallbackLocale string, limit, offset int) ([]*domain.Post, int64, error)
+
+	// Update updates an existing post and bumps its version
+	// The post's categories and tags are replaced unless nil
+	// A post updated by someone else since it was loaded is not overwritten (ErrVersionConflict)
 	Update(ctx context.Context, post *domain.Post) error
 
-	// Delete deletes a post by its UUID
+	// Delete soft-deletes a post (moves it to the trash)
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	// List retrieves a list of posts with pagination
-	List(ctx context.Context, limit, offset int, status *domain.PostStatus) ([]*domain.Post, int64, error)
+	// List retrieves a page of a tenant's posts, in any status, matching a PostListSpec query
+	List(ctx context.Context, tenantID string, q ListQuery) ([]*domain.Post, ListPage, error)
+
+	// ListByCategory retrieves a tenant's published posts in any of the given categories, like ListPublished
+	ListByCategory(ctx context.Context, tenantID, locale, fallbackLocale string, categoryIDs []uuid.UUID, limit, offset int) ([]*domain.Post, int64, error)
+
+	// ListByTag retrieves a tenant's published posts with a tag, like ListPublished
+	ListByTag(ctx context.Context, tenantID, locale, fallbackLocale string, tagID uuid.UUID, limit, offset int) ([]*domain.Post, int64, error)
+
+	// ListIDs retrieves the IDs of up to limit of a tenant's posts matching PostListSpec filters,
+	// and how many posts match in all
+	ListIDs(ctx context.Context, tenantID string, filters []Filter, limit int) ([]uuid.UUID, int64, error)
+}
 
-	// ListByCategory retrieves posts by category ID
-	ListByCategory(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*domain.Post, int64, error)
+// PostListSpec describes the filters and sort orders of the post list
+var PostListSpec = ListSpec{
+	Fields: map[string]ListField{
+		"status": {Column: "status", Filter: true, Values: []string{
+			string(domain.PostStatusDraft), string(domain.PostStatusInReview), string(domain.PostStatusApproved),
+			string(domain.PostStatusPublished), string(domain.PostStatusArchived),
+		}},
+		"author_id":    {Column: "author_id", Type: ListFieldUUID, Filter: true},
+		"locale":       {Column: "locale", Filter: true},
+		"title":        {Column: "title", Sort: true},
+		"slug":         {Column: "slug", Filter: true, Sort: true},
+		"published_at": {Column: "published_at", Type: ListFieldTime, Nullable: true, Filter: true, Sort: true},
+		"created_at":   {Column: "created_at", Type: ListFieldTime, Filter: true, Sort: true},
+		"updated_at":   {Column: "updated_at", Type: ListFieldTime, Filter: true, Sort: true},
+	},
+	Select: []string{
+		"tenant_id", "locale", "title", "slug", "translation_of_id", "excerpt", "blocks", "content", "featured_image",
+		"status", "published_at", "version", "author_id", "author", "categories", "tags", "created_at", "updated_at",
+	},
+	DefaultSort:  []SortField{{Field: "created_at", Desc: true}},
+	DefaultLimit: 10,
 }
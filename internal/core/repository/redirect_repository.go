@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// RedirectRepository defines the interface for redirect data access
+type RedirectRepository interface {
+	// Create creates a new redirect
+	Create(ctx context.Context, redirect *domain.Redirect) error
+
+	// GetByID retrieves a redirect by its UUID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Redirect, error)
+
+	// Update updates an existing redirect
+	Update(ctx context.Context, redirect *domain.Redirect) error
+
+	// Delete deletes a redirect by ID
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves a tenant's redirects with pagination
+	List(ctx context.Context, tenantID string, limit, offset int) ([]*domain.Redirect, int64, error)
+
+	// Resolve finds the redirect for a request path and returns it with the resolved target
+	// Exact sources win over prefix and regex rules, which are tried in creation order
+	Resolve(ctx context.Context, tenantID, path string) (*domain.Redirect, string, error)
+
+	// RecordHit increments a redirect's hit count
+	RecordHit(ctx context.Context, id uuid.UUID) error
+
+	// AddSlugRedirect records a permanent redirect after content moved from one path to another
+	// Existing redirects pointing at the old path are repointed so chains never form
+	AddSlugRedirect(ctx context.Context, tenantID, from, to string) error
+
+	// Import creates or replaces redirects by source in a single transaction
+	Import(ctx context.Context, tenantID string, redirects []*domain.Redirect) (created, updated int, err error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ReviewRepository defines the interface for editorial review data access
+type ReviewRepository interface {
+	// CreateComment records a review comment or status change
+	CreateComment(ctx context.Context, comment *domain.ReviewComment) error
+
+	// ListComments retrieves the review history of a page or post, oldest first
+	ListComments(ctx context.Context, tenantID string, entityType domain.ReviewEntityType, entityID uuid.UUID) ([]*domain.ReviewComment, error)
+
+	// LastSubmitter returns the ID of the user who last submitted a page or post for review
+	// Returns an empty string if it was never submitted
+	LastSubmitter(ctx context.Context, tenantID string, entityType domain.ReviewEntityType, entityID uuid.UUID) (string, error)
+
+	// ListQueue retrieves a tenant's pages and posts in a status, longest waiting first
+	// An empty entityType lists both
+	ListQueue(ctx context.Context, tenantID string, entityType domain.ReviewEntityType, status string) ([]*domain.ReviewItem, error)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// SearchRepository defines the interface for the full-text search index
+// Implementations use the database's native full-text search (FTS5 on SQLite,
+// tsvector on PostgreSQL)
+type SearchRepository interface {
+	// Index adds or replaces a document
+	Index(ctx context.Context, doc *domain.SearchDocument) error
+
+	// Remove deletes a document from the index
+	Remove(ctx context.Context, entityType domain.SearchEntityType, entityID uuid.UUID) error
+
+	// Search retrieves matching documents, best match first
+	Search(ctx context.Context, opts SearchOptions) ([]*domain.SearchResult, int64, error)
+}
+
+// SearchOptions defines options for searching
+type SearchOptions struct {
+	Query    string
+	TenantID string
+	Types    []domain.SearchEntityType // Empty = all types
+	Status   string                    // Filter by status (empty = any)
+	Limit    int
+	Offset   int
+}
@@ -8,9 +8,9 @@ import (
 
 // SettingsRepository defines the interface for settings data access
 type SettingsRepository interface {
-	// GetGlobalSettings retrieves global site settings
-	GetGlobalSettings(ctx context.Context) (*domain.GlobalSettings, error)
+	// GetGlobalSettings retrieves a tenant's global site settings
+	GetGlobalSettings(ctx context.Context, tenantID string) (*domain.GlobalSettings, error)
 
-	// UpdateGlobalSettings updates global site settings
-	UpdateGlobalSettings(ctx context.Context, settings *domain.GlobalSettings) error
+	// UpdateGlobalSettings updates a tenant's global site settings
+	UpdateGlobalSettings(ctx context.Context, tenantID string, settings *domain.GlobalSettings) error
 }
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// TagRepository defines the interface for post tag data access
+type TagRepository interface {
+	// Create creates a new tag
+	// Returns domain.ErrTagAlreadyExists if the tenant has a tag with the same slug
+	Create(ctx context.Context, tag *domain.Tag) error
+
+	// GetByID retrieves a tenant's tag
+	// Returns domain.ErrTagNotFound if there is none
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Tag, error)
+
+	// GetBySlug retrieves a tenant's tag by its slug
+	// Returns domain.ErrTagNotFound if there is none
+	GetBySlug(ctx context.Context, tenantID, slug string) (*domain.Tag, error)
+
+	// List retrieves a tenant's tags with their post counts
+	List(ctx context.Context, opts ListTagOptions) ([]*domain.Tag, int64, error)
+
+	// Resolve returns the tenant's tags with the given names, creating those that do not exist yet
+	// Names sharing a slug resolve to a single tag
+	Resolve(ctx context.Context, tenantID string, names []string) ([]domain.Tag, error)
+
+	// Update renames a tag
+	Update(ctx context.Context, tag *domain.Tag) error
+
+	// Delete deletes a tag and removes it from all posts
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
+
+	// Merge moves the posts of the source tags to the target tag and deletes the source tags
+	// Returns the target tag with its new post count
+	Merge(ctx context.Context, tenantID string, targetID uuid.UUID, sourceIDs []uuid.UUID) (*domain.Tag, error)
+}
+
+// ListTagOptions defines options for listing tags
+type ListTagOptions struct {
+	Limit         int
+	Offset        int
+	TenantID      string
+	Prefix        string // Only tags whose name or slug starts with it, most used first (autocomplete)
+	PublishedOnly bool   // Count only published posts and leave out tags without any
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// TenantRepository defines the interface for tenant data access
+type TenantRepository interface {
+	// Create provisions a new tenant
+	Create(ctx context.Context, tenant *domain.Tenant) error
+
+	// GetByID retrieves a tenant by its identifier, including its plan
+	GetByID(ctx context.Context, id string) (*domain.Tenant, error)
+
+	// Update updates an existing tenant
+	Update(ctx context.Context, tenant *domain.Tenant) error
+
+	// List retrieves all tenants, including their plans
+	List(ctx context.Context) ([]*domain.Tenant, error)
+}
+
+// PlanRepository defines the interface for plan data access
+type PlanRepository interface {
+	// Create creates a new plan
+	Create(ctx context.Context, plan *domain.Plan) error
+
+	// GetByID retrieves a plan by its UUID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Plan, error)
+
+	// Update updates an existing plan
+	Update(ctx context.Context, plan *domain.Plan) error
+
+	// Delete deletes a plan by its UUID
+	// Tenants on the plan become unlimited
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves all plans ordered by name
+	List(ctx context.Context) ([]*domain.Plan, error)
+}
+
+// UsageRepository defines the interface for usage counter data access
+type UsageRepository interface {
+	// Increment adds delta to the tenant's running total for a metric
+	// Positive deltas are also added to the current month's counter
+	Increment(ctx context.Context, tenantID string, metric domain.UsageMetric, delta int64) error
+
+	// Reserve increments like Increment, but only if the counter the limit applies to stays within limit
+	// The check and the increment are one conditional update, so concurrent reservations never overshoot.
+	// Returns a *domain.QuotaExceededError when the limit would be exceeded; a limit of 0 is unlimited
+	Reserve(ctx context.Context, tenantID string, metric domain.UsageMetric, delta, limit int64) error
+
+	// Release undoes a reservation made this month, subtracting delta from the running total and the month's counter
+	Release(ctx context.Context, tenantID string, metric domain.UsageMetric, delta int64) error
+
+	// Get returns a single counter value (0 if it was never incremented)
+	// An empty period returns the running total
+	Get(ctx context.Context, tenantID string, metric domain.UsageMetric, period string) (int64, error)
+
+	// ListByPeriod returns all counters for a period across tenants
+	// An empty period returns the running totals
+	ListByPeriod(ctx context.Context, period string) ([]*domain.UsageCounter, error)
+}
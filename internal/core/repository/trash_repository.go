@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// TrashRepository defines the interface for deleted content
+// Pages, posts, categories and menus are soft-deleted into the trash by their own repositories
+type TrashRepository interface {
+	// As returns a repository recording the domain events of its restores as made by actorID
+	As(actorID string) TrashRepository
+
+	// List retrieves a tenant's trashed content, most recently deleted first
+	// An empty entity type lists every kind of content
+	List(ctx context.Context, tenantID string, entityType domain.TrashEntityType) ([]*domain.TrashItem, error)
+
+	// Restore brings trashed content back and records a ContentRestored event
+	// Returns domain.ErrNotInTrash if the entity is not in the tenant's trash, the entity's
+	// already-exists error if its slug has been taken meanwhile, and domain.ErrParentPageInTrash
+	// or domain.ErrParentCategoryInTrash for pages and categories whose parent is still in the trash
+	Restore(ctx context.Context, tenantID string, entityType domain.TrashEntityType, id uuid.UUID) (*domain.TrashItem, error)
+
+	// Purge permanently deletes trashed content
+	// Returns domain.ErrNotInTrash if the entity is not in the tenant's trash, and
+	// domain.ErrPageHasChildren or domain.ErrCategoryHasChildren for pages and categories
+	// that others are still nested under
+	Purge(ctx context.Context, tenantID string, entityType domain.TrashEntityType, id uuid.UUID) error
+
+	// PurgeExpired permanently deletes content of all tenants trashed before a cutoff
+	// Returns the number of purged entities
+	PurgeExpired(ctx context.Context, before time.Time) (int, error)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
 )
 
 // UserRepository defines the interface for user data access
@@ -14,8 +16,14 @@ type UserRepository interface {
 	// GetByID retrieves a user by its UUID (accepts string or uuid.UUID)
 	GetByID(ctx context.Context, id interface{}) (*domain.User, error)
 
-	// GetByEmail retrieves a user by email
-	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	// ListByIDs retrieves a tenant's users with the given IDs, in no particular order
+	ListByIDs(ctx context.Context, tenantID string, ids []uuid.UUID) ([]*domain.User, error)
+
+	// ListByRoles retrieves a tenant's users that have one of the roles
+	ListByRoles(ctx context.Context, tenantID string, roles []domain.UserRole) ([]*domain.User, error)
+
+	// GetByEmail retrieves a user by email within a tenant
+	GetByEmail(ctx context.Context, tenantID, email string) (*domain.User, error)
 
 	// Update updates an existing user
 	Update(ctx context.Context, user *domain.User) error
@@ -23,6 +31,21 @@ type UserRepository interface {
 	// Delete deletes a user by its UUID (accepts string or uuid.UUID)
 	Delete(ctx context.Context, id interface{}) error
 
-	// List retrieves a list of users with pagination
-	List(ctx context.Context, limit, offset int) ([]*domain.User, int64, error)
+	// List retrieves a page of a tenant's users matching a UserListSpec query
+	List(ctx context.Context, tenantID string, q ListQuery) ([]*domain.User, ListPage, error)
+}
+
+// UserListSpec describes the filters and sort orders of the user list
+var UserListSpec = ListSpec{
+	Fields: map[string]ListField{
+		"role": {Column: "role", Filter: true, Values: []string{
+			string(domain.UserRoleAdmin), string(domain.UserRoleEditor), string(domain.UserRoleSuperAdmin),
+		}},
+		"name":       {Column: "name", Sort: true},
+		"email":      {Column: "email", Filter: true, Sort: true},
+		"created_at": {Column: "created_at", Type: ListFieldTime, Filter: true, Sort: true},
+	},
+	Select:       []string{"name", "email", "role", "created_at", "updated_at"},
+	DefaultSort:  []SortField{{Field: "created_at", Desc: true}},
+	DefaultLimit: 10,
 }
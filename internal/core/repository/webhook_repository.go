@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gohac/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// WebhookRepository defines the interface for webhook and delivery data access
+type WebhookRepository interface {
+	// Create stores a new webhook
+	Create(ctx context.Context, webhook *domain.Webhook) error
+
+	// GetByID retrieves one of a tenant's webhooks
+	GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*domain.Webhook, error)
+
+	// List retrieves a tenant's webhooks
+	List(ctx context.Context, tenantID string) ([]*domain.Webhook, error)
+
+	// ListActive retrieves a tenant's active webhooks
+	ListActive(ctx context.Context, tenantID string) ([]*domain.Webhook, error)
+
+	// Update saves changes to a webhook
+	Update(ctx context.Context, webhook *domain.Webhook) error
+
+	// Delete removes a webhook and its delivery log
+	Delete(ctx context.Context, tenantID string, id uuid.UUID) error
+
+	// CreateDeliveries queues deliveries
+	CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error
+
+	// ListDeliveredWebhookIDs retrieves the IDs of a tenant's webhooks that already have a delivery of an event
+	ListDeliveredWebhookIDs(ctx context.Context, tenantID, eventID string) ([]uuid.UUID, error)
+
+	// GetDelivery retrieves one of a webhook's deliveries
+	GetDelivery(ctx context.Context, tenantID string, webhookID, id uuid.UUID) (*domain.WebhookDelivery, error)
+
+	// ListDeliveries retrieves a webhook's deliveries, newest first, optionally by status
+	ListDeliveries(ctx context.Context, tenantID string, webhookID uuid.UUID, status domain.WebhookDeliveryStatus, limit, offset int) ([]*domain.WebhookDelivery, int64, error)
+
+	// ClaimDue takes up to limit pending deliveries of all tenants that are due
+	// Claimed deliveries are hidden from other workers until lease has passed
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error)
+
+	// SaveDelivery saves the outcome of a delivery attempt
+	SaveDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
@@ -4,6 +4,9 @@ import (
 	"strings"
 	"time"
 
+	"gohac/internal/adapter/database"
+	"gohac/internal/core/domain"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -19,8 +22,11 @@ const (
 
 // Claims represents JWT claims structure
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID         string `json:"user_id"`
+	Email          string `json:"email"`
+	TenantID       string `json:"tenant_id"`                 // Tenant the user belongs to (empty for community edition and platform users)
+	Role           string `json:"role,omitempty"`            // User role at the time the token was issued
+	ImpersonatorID string `json:"impersonator_id,omitempty"` // Super-admin acting as this user, if any
 	jwt.RegisteredClaims
 }
 
@@ -85,25 +91,69 @@ func Protected() fiber.Handler {
 			})
 		}
 
+		// Tokens are bound to the tenant they were issued for
+		// Platform super-admins may act on any tenant, as long as the database still says they are one
+		if claims.TenantID != GetTenantID(c) && (claims.Role != string(domain.UserRoleSuperAdmin) || !isSuperAdmin(c, claims.UserID)) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Token is not valid for this tenant",
+				"code":  fiber.StatusForbidden,
+			})
+		}
+
 		// Set user information in locals for use in handlers
 		c.Locals("user_id", claims.UserID)
 		c.Locals("user_email", claims.Email)
+		c.Locals("user_role", claims.Role)
+		c.Locals("user_tenant_id", claims.TenantID)
+		if claims.ImpersonatorID != "" {
+			c.Locals("impersonator_id", claims.ImpersonatorID)
+		}
 
 		return c.Next()
 	}
 }
 
-// GenerateToken generates a JWT token for a user
-func GenerateToken(userID, email string, expirationHours int) (string, error) {
+// RequireSuperAdmin is a Fiber middleware that only lets platform super-admins through
+// Must be registered after Protected; the role is checked against the database, so a demoted
+// super-admin is refused even while their token is still valid
+func RequireSuperAdmin() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("user_role").(string)
+		userID, _ := c.Locals("user_id").(string)
+		if role != string(domain.UserRoleSuperAdmin) || !isSuperAdmin(c, userID) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Super-admin role required",
+				"code":  fiber.StatusForbidden,
+			})
+		}
+		return c.Next()
+	}
+}
+
+// isSuperAdmin checks the tenant database for a platform super-admin with the given ID
+// The role in a token is only what it was when the token was issued, so it is not trusted
+// on its own to cross tenants; without a database the answer is no
+func isSuperAdmin(c *fiber.Ctx, userID string) bool {
+	db, err := database.GetDBFromContext(c.UserContext())
+	if err != nil {
+		return false
+	}
+	var count int64
+	if err := db.WithContext(c.UserContext()).Model(&domain.User{}).
+		Where("id = ? AND tenant_id = ? AND role = ?", userID, "", domain.UserRoleSuperAdmin).
+		Count(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// IssueToken signs the given claims, setting expiry and issue time
+func IssueToken(claims *Claims, expirationHours int) (string, error) {
 	expirationTime := time.Now().Add(time.Duration(expirationHours) * time.Hour)
 
-	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(expirationTime),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
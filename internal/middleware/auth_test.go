@@ -5,10 +5,15 @@ import (
 	"testing"
 	"time"
 
+	"gohac/internal/adapter/database"
+	"gohac/internal/core/domain"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 )
 
 func setupTestApp() *fiber.App {
@@ -81,7 +86,7 @@ func TestProtected_ValidToken(t *testing.T) {
 	app := setupTestApp()
 
 	// Generate a valid token
-	tokenString, err := GenerateToken("test-user-123", "test@example.com", 24)
+	tokenString, err := IssueToken(&Claims{UserID: "test-user-123", Email: "test@example.com"}, 24)
 	require.NoError(t, err)
 
 	req := httptest.NewRequest("GET", "/test", nil)
@@ -97,7 +102,7 @@ func TestProtected_ValidToken_AuthorizationHeader(t *testing.T) {
 	app := setupTestApp()
 
 	// Generate a valid token
-	tokenString, err := GenerateToken("test-user-456", "test2@example.com", 24)
+	tokenString, err := IssueToken(&Claims{UserID: "test-user-456", Email: "test2@example.com"}, 24)
 	require.NoError(t, err)
 
 	req := httptest.NewRequest("GET", "/test", nil)
@@ -108,12 +113,12 @@ func TestProtected_ValidToken_AuthorizationHeader(t *testing.T) {
 	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
 }
 
-func TestGenerateToken(t *testing.T) {
+func TestIssueToken(t *testing.T) {
 	userID := "test-user"
 	email := "test@example.com"
 	expirationHours := 24
 
-	tokenString, err := GenerateToken(userID, email, expirationHours)
+	tokenString, err := IssueToken(&Claims{UserID: userID, Email: email}, expirationHours)
 	require.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
 
@@ -146,3 +151,102 @@ func TestProtected_InvalidSigningMethod(t *testing.T) {
 
 	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
 }
+
+func setupTenantTestApp(db *gorm.DB) *fiber.App {
+	app := fiber.New()
+
+	// Simulate TenantMiddleware resolving the tenant from a header and its database
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("tenant_id", c.Get("X-Tenant-ID"))
+		if db != nil {
+			c.SetUserContext(database.SetDBInContext(c.UserContext(), db))
+		}
+		return c.Next()
+	})
+	app.Get("/test", Protected(), func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"success": true})
+	})
+
+	return app
+}
+
+func TestProtected_TenantMismatch(t *testing.T) {
+	app := setupTenantTestApp(nil)
+
+	tokenString, err := IssueToken(&Claims{UserID: "test-user", Email: "test@example.com", TenantID: "acme", Role: "admin"}, 24)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	req.Header.Set("X-Tenant-ID", "globex")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	// Same token against its own tenant is accepted
+	req = httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	req.Header.Set("X-Tenant-ID", "acme")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestProtected_SuperAdminCrossTenant(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.User{}))
+	root := &domain.User{Name: "Root", Email: "root@example.com", Password: "x", Role: domain.UserRoleSuperAdmin}
+	demoted := &domain.User{Name: "Former", Email: "former@example.com", Password: "x", Role: domain.UserRoleAdmin}
+	require.NoError(t, db.Create(root).Error)
+	require.NoError(t, db.Create(demoted).Error)
+
+	app := setupTenantTestApp(db)
+	get := func(claims *Claims) int {
+		tokenString, err := IssueToken(claims, 24)
+		require.NoError(t, err)
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		req.Header.Set("X-Tenant-ID", "globex")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusOK, get(&Claims{UserID: root.ID.String(), Email: root.Email, Role: "super_admin"}))
+
+	// A token still claiming the role of a demoted or unknown user does not cross tenants
+	assert.Equal(t, fiber.StatusForbidden, get(&Claims{UserID: demoted.ID.String(), Email: demoted.Email, Role: "super_admin"}))
+	assert.Equal(t, fiber.StatusForbidden, get(&Claims{UserID: "ghost", Email: "ghost@example.com", Role: "super_admin"}))
+}
+
+func TestRequireSuperAdmin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&domain.User{}))
+	root := &domain.User{Name: "Root", Email: "root@example.com", Password: "x", Role: domain.UserRoleSuperAdmin}
+	demoted := &domain.User{Name: "Former", Email: "former@example.com", Password: "x", Role: domain.UserRoleAdmin}
+	require.NoError(t, db.Create(root).Error)
+	require.NoError(t, db.Create(demoted).Error)
+
+	app := setupTenantTestApp(db)
+	app.Get("/platform", Protected(), RequireSuperAdmin(), func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"success": true})
+	})
+	get := func(claims *Claims) int {
+		tokenString, err := IssueToken(claims, 24)
+		require.NoError(t, err)
+		req := httptest.NewRequest("GET", "/platform", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusForbidden, get(&Claims{UserID: demoted.ID.String(), Email: demoted.Email, Role: "admin"}))
+	assert.Equal(t, fiber.StatusOK, get(&Claims{UserID: root.ID.String(), Email: root.Email, Role: "super_admin"}))
+
+	// A token still claiming the role of a demoted or unknown user is refused
+	assert.Equal(t, fiber.StatusForbidden, get(&Claims{UserID: demoted.ID.String(), Email: demoted.Email, Role: "super_admin"}))
+	assert.Equal(t, fiber.StatusForbidden, get(&Claims{UserID: "ghost", Email: "ghost@example.com", Role: "super_admin"}))
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimit allows each client IP at most max requests per window, per tenant
+// Counters are kept in memory, so every instance enforces its own limit
+func RateLimit(max int, window time.Duration) fiber.Handler {
+	limiter := newFixedWindowLimiter(max, window)
+	return func(c *fiber.Ctx) error {
+		allowed, retryAfter := limiter.allow(GetTenantID(c)+"|"+c.IP(), time.Now())
+		if !allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(retryAfter.Round(time.Second).Seconds())))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Too many requests, please try again later",
+				"code":  fiber.StatusTooManyRequests,
+			})
+		}
+		return c.Next()
+	}
+}
+
+// fixedWindowLimiter counts requests per key in windows starting at each key's first request
+type fixedWindowLimiter struct {
+	mu        sync.Mutex
+	max       int
+	window    time.Duration
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func newFixedWindowLimiter(max int, window time.Duration) *fixedWindowLimiter {
+	return &fixedWindowLimiter{max: max, window: window, windows: make(map[string]*rateWindow)}
+}
+
+// allow counts a request and reports whether it is within the limit, or else how long until it will be
+func (l *fixedWindowLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop expired windows now and then so idle clients do not pile up
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.window {
+				delete(l.windows, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = &rateWindow{start: now}
+		l.windows[key] = w
+	}
+	if w.count >= l.max {
+		return false, w.start.Add(l.window).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
@@ -67,3 +67,12 @@ func extractTenantID(c *fiber.Ctx) string {
 
 	return ""
 }
+
+// GetTenantID returns the tenant ID resolved by TenantMiddleware
+// Returns an empty string for community edition
+func GetTenantID(c *fiber.Ctx) string {
+	if tenantID, ok := c.Locals("tenant_id").(string); ok {
+		return tenantID
+	}
+	return ""
+}
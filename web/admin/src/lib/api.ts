@@ -46,8 +46,34 @@ export const pagesAPI = {
   delete: (id: string) => api.delete(`/v1/pages/${id}`),
 }
 
+// Advisory edit locks; acquire again every 30 seconds as a heartbeat
+export const locksAPI = {
+  get: (type: string, id: string) => api.get(`/v1/locks/${type}/${id}`),
+  acquire: (type: string, id: string, takeOver = false) =>
+    api.post(`/v1/locks/${type}/${id}`, { take_over: takeOver }),
+  release: (type: string, id: string) => api.delete(`/v1/locks/${type}/${id}`),
+}
+
+export const contentEvents = [
+  'content.created',
+  'content.updated',
+  'content.published',
+  'content.deleted',
+  'content.restored',
+]
+
+// Live changes in the current tenant, streamed as server-sent events
+// The auth cookie authenticates the stream; returns a function that closes it
+export const subscribeToEvents = (types: string[], onEvent: (event: any) => void) => {
+  const source = new EventSource(`/api/v1/events?types=${types.join(',')}`, { withCredentials: true })
+  types.forEach((type) =>
+    source.addEventListener(type, (e) => onEvent(JSON.parse((e as MessageEvent).data)))
+  )
+  return () => source.close()
+}
+
 export const settingsAPI = {
-  get: () => api.get('/public/settings'),
+  get: () => api.get('/v1/settings'),
   update: (data: any) => api.put('/v1/settings', data),
 }
 
@@ -2,7 +2,7 @@ import { useState, useEffect } from 'react'
 import { useNavigate, useParams } from 'react-router-dom'
 import { ArrowLeft, Save, FileText, Search } from 'lucide-react'
 import toast from 'react-hot-toast'
-import { pagesAPI } from '../../lib/api'
+import { locksAPI, pagesAPI } from '../../lib/api'
 import BlockEditor from '../../components/editor/BlockEditor'
 import ImageUpload from '../../components/editor/ImageUpload'
 import { Block } from '../../types/block'
@@ -22,11 +22,14 @@ interface Page {
   id: string
   slug: string
   title: string
-  status: 'draft' | 'published' | 'archived'
+  status: 'draft' | 'in_review' | 'approved' | 'published' | 'archived'
   blocks?: Block[]
   meta?: PageMeta | string
+  version: number
 }
 
+const LOCK_HEARTBEAT_MS = 30000
+
 export default function PageEdit() {
   const { id } = useParams<{ id: string }>()
   const navigate = useNavigate()
@@ -34,7 +37,7 @@ export default function PageEdit() {
   const [formData, setFormData] = useState({
     slug: '',
     title: '',
-    status: 'draft' as 'draft' | 'published' | 'archived',
+    status: 'draft' as 'draft' | 'in_review' | 'approved' | 'published' | 'archived',
   })
   const [blocks, setBlocks] = useState<Block[]>([])
   const [meta, setMeta] = useState<PageMeta>({
@@ -46,6 +49,8 @@ export default function PageEdit() {
   const [loading, setLoading] = useState(false)
   const [fetching, setFetching] = useState(true)
   const [error, setError] = useState<string | null>(null)
+  const [version, setVersion] = useState<number | undefined>()
+  const [lockedBy, setLockedBy] = useState<string | null>(null)
 
   useEffect(() => {
     if (id) {
@@ -53,6 +58,31 @@ export default function PageEdit() {
     }
   }, [id])
 
+  // Hold the edit lock while the form is open so other editors see who is editing
+  useEffect(() => {
+    if (!id) return
+    const heartbeat = () => acquireLock(false)
+    heartbeat()
+    const timer = setInterval(heartbeat, LOCK_HEARTBEAT_MS)
+    return () => {
+      clearInterval(timer)
+      locksAPI.release('page', id).catch(() => {})
+    }
+  }, [id])
+
+  const acquireLock = async (takeOver: boolean) => {
+    try {
+      await locksAPI.acquire('page', id!, takeOver)
+      setLockedBy(null)
+    } catch (err: any) {
+      if (err.response?.status === 409) {
+        setLockedBy(err.response.data.lock?.user_name || 'another user')
+      } else if (takeOver) {
+        toast.error(err.response?.data?.error || 'Failed to take over the page')
+      }
+    }
+  }
+
   const fetchPage = async () => {
     try {
       setFetching(true)
@@ -63,6 +93,7 @@ export default function PageEdit() {
         title: page.title,
         status: page.status,
       })
+      setVersion(page.version)
 
       // Parse blocks from JSON
       if (page.blocks) {
@@ -141,6 +172,7 @@ export default function PageEdit() {
       ...formData,
       blocks,
       meta: Object.keys(metaData).length > 0 ? metaData : null,
+      version,
     }
 
     const updatePromise = pagesAPI.update(id!, updateData)
@@ -202,6 +234,14 @@ export default function PageEdit() {
 
       <form onSubmit={handleSubmit} className="form">
         {error && <div className="error-message">{error}</div>}
+        {lockedBy && (
+          <div className="lock-warning">
+            <span>Being edited by {lockedBy}</span>
+            <button type="button" onClick={() => acquireLock(true)}>
+              Take over
+            </button>
+          </div>
+        )}
 
         {/* Basic Fields - Always Visible */}
         <div className="form-group">
@@ -239,12 +279,14 @@ export default function PageEdit() {
             onChange={(e) =>
               setFormData({
                 ...formData,
-                status: e.target.value as 'draft' | 'published' | 'archived',
+                status: e.target.value as 'draft' | 'in_review' | 'approved' | 'published' | 'archived',
               })
             }
             disabled={loading}
           >
             <option value="draft">Draft</option>
+            <option value="in_review">In review</option>
+            <option value="approved">Approved</option>
             <option value="published">Published</option>
             <option value="archived">Archived</option>
           </select>
@@ -23,7 +23,7 @@ export default function PageForm() {
   const [formData, setFormData] = useState({
     slug: '',
     title: '',
-    status: 'draft' as 'draft' | 'published' | 'archived',
+    status: 'draft' as 'draft' | 'in_review' | 'approved' | 'published' | 'archived',
   })
   const [blocks, setBlocks] = useState<Block[]>([])
   const [meta, setMeta] = useState<PageMeta>({
@@ -141,12 +141,14 @@ export default function PageForm() {
             onChange={(e) =>
               setFormData({
                 ...formData,
-                status: e.target.value as 'draft' | 'published' | 'archived',
+                status: e.target.value as 'draft' | 'in_review' | 'approved' | 'published' | 'archived',
               })
             }
             disabled={loading}
           >
             <option value="draft">Draft</option>
+            <option value="in_review">In review</option>
+            <option value="approved">Approved</option>
             <option value="published">Published</option>
             <option value="archived">Archived</option>
           </select>
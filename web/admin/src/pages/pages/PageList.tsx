@@ -2,7 +2,7 @@ import { useEffect, useState } from 'react'
 import { Link } from 'react-router-dom'
 import { Plus, Edit, Trash2, FileText } from 'lucide-react'
 import toast from 'react-hot-toast'
-import { pagesAPI } from '../../lib/api'
+import { pagesAPI, contentEvents, subscribeToEvents } from '../../lib/api'
 import ConfirmDialog from '../../components/ConfirmDialog'
 import './PageList.css'
 
@@ -10,7 +10,7 @@ interface Page {
   id: string
   slug: string
   title: string
-  status: 'draft' | 'published' | 'archived'
+  status: 'draft' | 'in_review' | 'approved' | 'published' | 'archived'
   created_at: string
   updated_at: string
 }
@@ -33,6 +33,13 @@ export default function PageList() {
     fetchPages()
   }, [])
 
+  // Refresh when anyone changes a page
+  useEffect(() => {
+    return subscribeToEvents(contentEvents, (event) => {
+      if (event.entity_type === 'page') fetchPages()
+    })
+  }, [])
+
   const fetchPages = async () => {
     try {
       setLoading(true)
@@ -13,11 +13,13 @@ interface Post {
   slug: string
   title: string
   excerpt: string
-  content: string
+  blocks: Block[] | null
+  content: string // Legacy Markdown/HTML body of posts without blocks
   featured_image: string
-  status: 'draft' | 'published' | 'archived'
+  status: 'draft' | 'in_review' | 'approved' | 'published' | 'archived'
   category_ids?: string[]
   categories?: Array<{ id: string; name: string }>
+  version: number
 }
 
 interface Category {
@@ -36,7 +38,7 @@ export default function PostForm() {
     title: '',
     excerpt: '',
     featured_image: '',
-    status: 'draft' as 'draft' | 'published' | 'archived',
+    status: 'draft' as 'draft' | 'in_review' | 'approved' | 'published' | 'archived',
   })
   const [blocks, setBlocks] = useState<Block[]>([])
   const [selectedCategories, setSelectedCategories] = useState<string[]>([])
@@ -44,6 +46,7 @@ export default function PostForm() {
   const [loading, setLoading] = useState(false)
   const [fetching, setFetching] = useState(isEdit)
   const [error, setError] = useState<string | null>(null)
+  const [version, setVersion] = useState<number | undefined>()
 
   useEffect(() => {
     fetchCategories()
@@ -78,26 +81,17 @@ export default function PostForm() {
         featured_image: post.featured_image || '',
         status: post.status,
       })
+      setVersion(post.version)
 
-      // Parse content blocks from JSON
-      if (post.content) {
-        try {
-          let parsedBlocks: Block[] = []
-          if (typeof post.content === 'string') {
-            parsedBlocks = JSON.parse(post.content)
-          } else if (Array.isArray(post.content)) {
-            parsedBlocks = post.content
-          }
-          parsedBlocks = parsedBlocks.map((block) => ({
+      // Posts store their body as blocks; legacy Markdown/HTML content is kept as is
+      if (Array.isArray(post.blocks)) {
+        setBlocks(
+          post.blocks.map((block: Block) => ({
             id: block.id || `block-${Date.now()}-${Math.random().toString(36).substr(2, 9)}`,
             type: block.type,
             data: block.data || {},
           }))
-          setBlocks(parsedBlocks)
-        } catch (e) {
-          console.error('Failed to parse content blocks:', e)
-          setBlocks([])
-        }
+        )
       } else {
         setBlocks([])
       }
@@ -133,13 +127,14 @@ export default function PostForm() {
 
     const postData: any = {
       ...formData,
-      content: blocks.length > 0 ? JSON.stringify(blocks) : '', // Convert blocks to JSON string, empty string if no blocks
+      // Without blocks the post keeps its legacy content
+      ...(blocks.length > 0 ? { blocks } : {}),
       category_ids: selectedCategories.length > 0 ? selectedCategories : [], // Ensure it's always an array
     }
 
     try {
       if (isEdit && id) {
-        const updatePromise = postsAPI.update(id, postData)
+        const updatePromise = postsAPI.update(id, { ...postData, version })
 
         toast.promise(updatePromise, {
           loading: 'Updating post...',
@@ -254,12 +249,14 @@ export default function PostForm() {
             onChange={(e) =>
               setFormData({
                 ...formData,
-                status: e.target.value as 'draft' | 'published' | 'archived',
+                status: e.target.value as 'draft' | 'in_review' | 'approved' | 'published' | 'archived',
               })
             }
             disabled={loading}
           >
             <option value="draft">Draft</option>
+            <option value="in_review">In review</option>
+            <option value="approved">Approved</option>
             <option value="published">Published</option>
             <option value="archived">Archived</option>
           </select>
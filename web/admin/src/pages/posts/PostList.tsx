@@ -2,7 +2,7 @@ import { useEffect, useState } from 'react'
 import { Link, useNavigate } from 'react-router-dom'
 import { Plus, Edit, Trash2, FileText } from 'lucide-react'
 import toast from 'react-hot-toast'
-import { postsAPI } from '../../lib/api'
+import { postsAPI, contentEvents, subscribeToEvents } from '../../lib/api'
 import ConfirmDialog from '../../components/ConfirmDialog'
 import '../pages/PageList.css'
 
@@ -11,7 +11,7 @@ interface Post {
   slug: string
   title: string
   excerpt: string
-  status: 'draft' | 'published' | 'archived'
+  status: 'draft' | 'in_review' | 'approved' | 'published' | 'archived'
   published_at: string | null
   created_at: string
   updated_at: string
@@ -31,6 +31,13 @@ export default function PostList() {
     fetchPosts()
   }, [])
 
+  // Refresh when anyone changes a post
+  useEffect(() => {
+    return subscribeToEvents(contentEvents, (event) => {
+      if (event.entity_type === 'post') fetchPosts()
+    })
+  }, [])
+
   const fetchPosts = async () => {
     try {
       setLoading(true)